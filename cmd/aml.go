@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/codec"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/presentation"
+	"github.com/urfave/cli/v2"
+)
+
+// amlCommand screens the bets against the anti-money laundering rules, exporting the findings or raising their alerts
+func amlCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "aml",
+		Usage: "Screen bets against the anti-money laundering rules",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "report",
+				Usage:     "Export the large transactions and structuring found in the bets, with the evidence bets",
+				ArgsUsage: "[output file, defaults to stdout]",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "user-id",
+						Usage: "Only screen the bets of this user",
+					},
+					&cli.StringFlag{
+						Name:  "from",
+						Usage: "Only screen bets placed at or after this RFC3339 timestamp",
+					},
+					&cli.StringFlag{
+						Name:  "to",
+						Usage: "Only screen bets placed before this RFC3339 timestamp",
+					},
+					&cli.StringFlag{
+						Name:  "format",
+						Usage: "Report format (json or csv). Guessed from the file extension when omitted, json by default",
+					},
+				},
+				Action: func(c *cli.Context) (err error) {
+					filename := c.Args().First()
+
+					format := enums.ReportFormat(c.String("format"))
+					if !c.IsSet("format") {
+						format = enums.JSONReport
+						if strings.EqualFold(filepath.Ext(filename), ".csv") {
+							format = enums.CSVReport
+						}
+					}
+
+					if !format.IsValid() {
+						return fmt.Errorf("invalid format %q: must be one of %v", format, enums.ReportFormats)
+					}
+
+					filter := domain.BetFilter{UserID: c.String("user-id")}
+
+					if filter.From, err = parseTimeFlag(c, "from"); err != nil {
+						return err
+					}
+
+					if filter.To, err = parseTimeFlag(c, "to"); err != nil {
+						return err
+					}
+
+					usecases, err := presentation.ConfigureStartUpDependencies(cfg)
+					if err != nil {
+						return fmt.Errorf("failed to configure start up dependencies: %w", err)
+					}
+
+					report, err := usecases.GetAMLReport(c.Context, filter)
+					if err != nil {
+						return err
+					}
+
+					out := os.Stdout
+
+					if filename != "" {
+						out, err = os.Create(filename)
+						if err != nil {
+							return fmt.Errorf("failed to create output file: %w", err)
+						}
+
+						defer func() {
+							err = errors.Join(err, out.Close())
+						}()
+					}
+
+					if err := codec.WriteAMLReport(out, report, format); err != nil {
+						return err
+					}
+
+					if filename != "" {
+						fmt.Printf("Exported %d findings to %s\n", len(report.Findings), filename)
+					}
+
+					return nil
+				},
+			},
+			{
+				Name:  "screen",
+				Usage: "Screen the bets placed over aml.lookback now, raising an alert for every new finding",
+				Action: func(c *cli.Context) error {
+					usecases, err := presentation.ConfigureStartUpDependencies(cfg)
+					if err != nil {
+						return fmt.Errorf("failed to configure start up dependencies: %w", err)
+					}
+
+					raised, err := usecases.ScreenAML(c.Context)
+					if err != nil {
+						return err
+					}
+
+					fmt.Printf("Raised %d anti-money laundering alerts\n", raised)
+
+					return nil
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,343 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+	"unicode/utf8"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/codec"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/ingest"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/presentation"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/usecases"
+	"github.com/urfave/cli/v2"
+)
+
+// formatFlags select the file format and describe the CSV layout
+func formatFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "format",
+			Usage: "File format (ndjson or csv). Guessed from the file extension when omitted",
+		},
+		&cli.StringFlag{
+			Name:  "csv-columns",
+			Usage: "CSV header mapping as field=header pairs, e.g bet_id=BetRef,user_id=Customer",
+		},
+		&cli.StringSliceFlag{
+			Name:  "csv-timestamp-layout",
+			Usage: "Go time layout of CSV timestamps, repeat to try several layouts in order",
+		},
+		&cli.StringFlag{
+			Name:  "csv-decimal-separator",
+			Value: ".",
+			Usage: "Decimal separator of CSV amounts and odds",
+		},
+		&cli.StringFlag{
+			Name:  "csv-delimiter",
+			Value: ",",
+			Usage: "CSV field delimiter",
+		},
+		&cli.StringFlag{
+			Name: "odds-format",
+			Usage: "Odds format (decimal, fractional, american, hongkong or malay). CSV odds are read in it, or " +
+				"guessed from each value when omitted, and exported odds are written in it, decimal when omitted",
+		},
+	}
+}
+
+// singleRune parses a flag value holding exactly one character
+func singleRune(name, value string) (rune, error) {
+	if utf8.RuneCountInString(value) != 1 {
+		return 0, fmt.Errorf("--%s must be a single character, got %q", name, value)
+	}
+
+	r, _ := utf8.DecodeRuneInString(value)
+
+	return r, nil
+}
+
+// fileFormat returns the format chosen with --format or guessed from filename
+func fileFormat(c *cli.Context, filename string) (enums.FileFormat, error) {
+	if !c.IsSet("format") {
+		return codec.FormatFromFilename(filename), nil
+	}
+
+	format := enums.FileFormat(c.String("format"))
+	if !format.IsValid() {
+		return "", fmt.Errorf("invalid format %q: must be %s or %s", format, enums.NDJSON, enums.CSV)
+	}
+
+	return format, nil
+}
+
+// csvOptions builds the CSV layout from the format flags
+func csvOptions(c *cli.Context) (codec.CSVOptions, error) {
+	columns, err := codec.ParseCSVColumns(c.String("csv-columns"))
+	if err != nil {
+		return codec.CSVOptions{}, err
+	}
+
+	decimalSeparator, err := singleRune("csv-decimal-separator", c.String("csv-decimal-separator"))
+	if err != nil {
+		return codec.CSVOptions{}, err
+	}
+
+	delimiter, err := singleRune("csv-delimiter", c.String("csv-delimiter"))
+	if err != nil {
+		return codec.CSVOptions{}, err
+	}
+
+	oddsFormat := enums.OddsFormat(c.String("odds-format"))
+	if oddsFormat != "" && !oddsFormat.IsValid() {
+		return codec.CSVOptions{}, fmt.Errorf("invalid --odds-format %q: must be one of %v", oddsFormat, enums.OddsFormats)
+	}
+
+	return codec.CSVOptions{
+		Columns:          columns,
+		TimestampLayouts: c.StringSlice("csv-timestamp-layout"),
+		DecimalSeparator: decimalSeparator,
+		Delimiter:        delimiter,
+		OddsFormat:       oddsFormat,
+	}, nil
+}
+
+// parseTimeFlag parses an optional RFC3339 timestamp flag
+func parseTimeFlag(c *cli.Context, name string) (*time.Time, error) {
+	if !c.IsSet(name) {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, c.String(name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s timestamp: %w", name, err)
+	}
+
+	return &parsed, nil
+}
+
+// processCommand stores the bets of one or more files, directories, glob patterns or stdin
+func processCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "process",
+		Usage:     "Process betting data from NDJSON or CSV files, optionally gzip or zstd compressed",
+		ArgsUsage: "<file, directory, glob or - for stdin>...",
+		Flags: append([]cli.Flag{
+			&cli.BoolFlag{
+				Name:  "resume",
+				Usage: "Continue interrupted imports from their last checkpoint and skip files that were fully imported",
+			},
+			&cli.BoolFlag{
+				Name:  "fail-fast",
+				Usage: "Stop processing the remaining inputs after the first failure",
+			},
+			&cli.IntFlag{
+				Name:  "concurrency",
+				Value: 4,
+				Usage: "Number of inputs processed at the same time",
+			},
+		}, formatFlags()...),
+		Action: func(c *cli.Context) (err error) {
+			ctx, span := tracer.Start(c.Context, "process")
+			defer span.End()
+
+			inputs, err := ingest.ExpandInputs(c.Args().Slice())
+			if err != nil {
+				return err
+			}
+
+			usecases, err := presentation.ConfigureStartUpDependencies(cfg)
+			if err != nil {
+				return fmt.Errorf("failed to configure start up dependencies: %w", err)
+			}
+
+			process, err := importer(c, usecases, c.Bool("resume"))
+			if err != nil {
+				return err
+			}
+
+			results, err := ingest.Run(ctx, inputs, ingest.Options{
+				Concurrency: c.Int("concurrency"),
+				FailFast:    c.Bool("fail-fast"),
+			}, process)
+
+			var total int64
+
+			for _, result := range results {
+				total += result.Bets
+
+				switch {
+				case errors.Is(result.Err, ingest.ErrSkipped):
+					fmt.Printf("%s: %v\n", result.Input, result.Err)
+				case result.Err != nil:
+					fmt.Printf("%s: failed after %d bets: %v\n", result.Input, result.Bets, result.Err)
+				default:
+					fmt.Printf("%s: %d bets\n", result.Input, result.Bets)
+				}
+			}
+
+			fmt.Printf("Processed %d bets from %d inputs\n", total, len(results))
+
+			if err != nil {
+				return fmt.Errorf("failed to process bets: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// importer returns the function importing a single input with the format flags of c.
+// Files are imported as ingest jobs, resuming earlier jobs when resume is set. Stdin is imported without a job.
+func importer(c *cli.Context, usecases *usecases.UsecaseMayBets, resume bool) (ingest.ProcessFunc, error) {
+	options, err := csvOptions(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, input string) (stored int64, err error) {
+		format, err := fileFormat(c, input)
+		if err != nil {
+			return 0, err
+		}
+
+		// stdin cannot be identified or re-read, so it is imported without a job
+		if input == ingest.Stdin {
+			file, err := ingest.Open(input)
+			if err != nil {
+				return 0, err
+			}
+
+			defer file.Close()
+
+			reader, err := codec.NewReader(file, format, options)
+			if err != nil {
+				return 0, err
+			}
+
+			return usecases.IngestBets(ctx, reader)
+		}
+
+		size, hash, err := ingest.Identify(input)
+		if err != nil {
+			return 0, err
+		}
+
+		job, err := usecases.StartIngestJob(ctx, domain.IngestJob{
+			Path:   input,
+			Size:   size,
+			Hash:   hash,
+			Format: format,
+		}, resume)
+		if err != nil {
+			return 0, err
+		}
+
+		if job.Status == enums.IngestCompleted {
+			return 0, fmt.Errorf("%w: already imported by job %s", ingest.ErrSkipped, job.ID)
+		}
+
+		file, err := ingest.Open(input)
+		if err != nil {
+			return 0, err
+		}
+
+		defer func() {
+			err = errors.Join(err, file.Close())
+		}()
+
+		reader, err := codec.NewReaderAt(file, format, options, codec.Position{
+			Offset:  job.Offset,
+			Records: job.Records,
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		return usecases.RunIngestJob(ctx, job, reader)
+	}, nil
+}
+
+// exportCommand writes bets from the database to a file or stdout
+func exportCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "export",
+		Usage:     "Export bets as CSV or NDJSON",
+		ArgsUsage: "[output file, defaults to stdout]",
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:  "user-id",
+				Usage: "Only export the bets of this user",
+			},
+			&cli.StringFlag{
+				Name:  "from",
+				Usage: "Only export bets placed at or after this RFC3339 timestamp",
+			},
+			&cli.StringFlag{
+				Name:  "to",
+				Usage: "Only export bets placed before this RFC3339 timestamp",
+			},
+		}, formatFlags()...),
+		Action: func(c *cli.Context) (err error) {
+			filename := c.Args().First()
+
+			format, err := fileFormat(c, filename)
+			if err != nil {
+				return err
+			}
+
+			options, err := csvOptions(c)
+			if err != nil {
+				return err
+			}
+
+			filter := domain.BetFilter{UserID: c.String("user-id")}
+
+			if filter.From, err = parseTimeFlag(c, "from"); err != nil {
+				return err
+			}
+
+			if filter.To, err = parseTimeFlag(c, "to"); err != nil {
+				return err
+			}
+
+			usecases, err := presentation.ConfigureStartUpDependencies(cfg)
+			if err != nil {
+				return fmt.Errorf("failed to configure start up dependencies: %w", err)
+			}
+
+			out := os.Stdout
+
+			if filename != "" {
+				out, err = os.Create(filename)
+				if err != nil {
+					return fmt.Errorf("failed to create output file: %w", err)
+				}
+
+				defer func() {
+					err = errors.Join(err, out.Close())
+				}()
+			}
+
+			writer, err := codec.NewWriter(out, format, options, options.OddsFormat)
+			if err != nil {
+				return err
+			}
+
+			written, err := usecases.ExportBets(c.Context, filter, writer)
+			if err != nil {
+				return fmt.Errorf("failed to export bets: %w", err)
+			}
+
+			if filename != "" {
+				fmt.Printf("Exported %d bets to %s\n", written, filename)
+			}
+
+			return nil
+		},
+	}
+}
@@ -3,59 +3,65 @@ package main
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/config"
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/helpers"
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/presentation"
 	"github.com/urfave/cli/v2"
+	"go.opentelemetry.io/otel"
 )
 
-func main() {
-	ctx := context.Background()
+var tracer = otel.Tracer("github.com/KathurimaKimathi/maybets/cmd")
 
-	usecases, err := presentation.ConfigureStartUpDependencies()
-	if err != nil {
-		log.Fatalf("Failed to configure start up dependencies: %v", err)
-	}
+// cfg is the effective configuration, loaded before any command runs
+var cfg *config.Config
+
+// shutdownTracing flushes the trace pipeline set up before the command ran
+var shutdownTracing = func(context.Context) error { return nil }
+
+func main() {
+	// interrupting a command cancels its context so that imports can record their checkpoint before exiting
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	app := &cli.App{
 		Name:  "betting-analytics",
 		Usage: "Analyze betting data",
-		Commands: []*cli.Command{
-			{
-				Name:  "process",
-				Usage: "Process betting data from a file",
-				Action: func(c *cli.Context) error {
-					filename := c.Args().First()
-					bets, err := helpers.LoadBetsFromFile(filename)
-					if err != nil {
-						return err
-					}
+		Flags: configFlags(),
+		Before: func(c *cli.Context) error {
+			loaded, err := loadConfig(c)
+			if err != nil {
+				return err
+			}
 
-					if err = usecases.ProcessBets(ctx, bets); err != nil {
-						return fmt.Errorf("failed to process bets: %w", err)
-					}
+			cfg = loaded
 
-					fmt.Println("Processing complete!")
-					return nil
-				},
-			},
+			helpers.SetupLogger(cfg.Level())
+
+			// every command, the server included, traces through the same pipeline
+			shutdown, err := helpers.SetupOTelSDK(c.Context, cfg.TracingConfig())
+			if err != nil {
+				return fmt.Errorf("failed to set up tracing: %w", err)
+			}
+
+			shutdownTracing = shutdown
+
+			return nil
+		},
+		After: func(c *cli.Context) error {
+			return shutdownTracing(context.WithoutCancel(c.Context))
+		},
+		Commands: []*cli.Command{
+			processCommand(),
 			{
 				Name:  "runserver",
 				Usage: "Start the analytics API server",
 				Action: func(_ *cli.Context) error {
-					port, err := helpers.ConvertPortToInt()
-					if err != nil {
-						return err
-					}
-
-					err = presentation.StartServer(ctx, port)
-					if err != nil {
-						return err
-					}
-
-					return nil
+					return presentation.StartServer(ctx, cfg)
 				},
 			},
 			{
@@ -80,10 +86,39 @@ func main() {
 					},
 				},
 			},
+			exportCommand(),
+			migrateCommand(),
+			jobsCommand(),
+			settleCommand(),
+			amlCommand(),
+			linkedCommand(),
+			watchCommand(),
+			consumeCommand(),
+			{
+				Name:  "config",
+				Usage: "Inspect the service configuration",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "print",
+						Usage: "Print the effective configuration with secrets redacted",
+						Action: func(_ *cli.Context) error {
+							out, err := cfg.Redacted().YAML()
+							if err != nil {
+								return err
+							}
+
+							fmt.Print(out)
+							return nil
+						},
+					},
+				},
+			},
 		},
 	}
 
-	if err := app.Run(os.Args); err != nil {
-		log.Fatal(err)
+	if err := app.RunContext(ctx, os.Args); err != nil {
+		slog.Error("command failed", "error", err)
+		stop()
+		os.Exit(1)
 	}
 }
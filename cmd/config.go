@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/config"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/urfave/cli/v2"
+)
+
+// configFlags are the global flags that override the file and environment configuration
+func configFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "config",
+			Usage:   "Path to a YAML config file",
+			EnvVars: []string{"CONFIG_FILE"},
+		},
+		&cli.StringFlag{
+			Name:  "environment",
+			Usage: "Deployment environment (PROD, STAGING, TEST or LOCAL)",
+		},
+		&cli.IntFlag{
+			Name:  "port",
+			Usage: "Port the API server listens on",
+		},
+		&cli.IntFlag{
+			Name:  "grpc-port",
+			Usage: "Port the gRPC server listens on",
+		},
+		&cli.StringFlag{
+			Name:  "log-level",
+			Usage: "Minimum log level (DEBUG, INFO, WARN or ERROR)",
+		},
+		&cli.StringFlag{
+			Name:  "sqlite-path",
+			Usage: "Path to the SQLite database file",
+		},
+		&cli.StringFlag{
+			Name:  "redis-url",
+			Usage: "Redis connection URL",
+		},
+		&cli.StringFlag{
+			Name:  "tracing-exporter",
+			Usage: "Trace exporter (otlp-http, otlp-grpc, stdout or none)",
+		},
+		&cli.StringFlag{
+			Name:  "tracing-endpoint",
+			Usage: "host:port of the OTLP collector",
+		},
+		&cli.BoolFlag{
+			Name:  "tracing-insecure",
+			Usage: "Disable TLS when talking to the OTLP collector",
+		},
+		&cli.Float64Flag{
+			Name:  "tracing-sample-ratio",
+			Usage: "Fraction of root traces to sample",
+		},
+	}
+}
+
+// loadConfig loads the configuration file and environment, applies the CLI flags and validates the result
+func loadConfig(c *cli.Context) (*config.Config, error) {
+	cfg, err := config.Load(c.String("config"))
+	if err != nil {
+		return nil, err
+	}
+
+	if c.IsSet("environment") {
+		cfg.Environment = enums.Environment(c.String("environment"))
+	}
+
+	if c.IsSet("port") {
+		cfg.Port = c.Int("port")
+	}
+
+	if c.IsSet("grpc-port") {
+		cfg.GRPCPort = c.Int("grpc-port")
+	}
+
+	if c.IsSet("log-level") {
+		cfg.LogLevel = c.String("log-level")
+	}
+
+	if c.IsSet("sqlite-path") {
+		cfg.SQLite.Path = c.String("sqlite-path")
+	}
+
+	if c.IsSet("redis-url") {
+		cfg.Redis.URL = c.String("redis-url")
+	}
+
+	if c.IsSet("tracing-exporter") {
+		cfg.Tracing.Exporter = enums.TraceExporter(c.String("tracing-exporter"))
+	}
+
+	if c.IsSet("tracing-endpoint") {
+		cfg.Tracing.Endpoint = c.String("tracing-endpoint")
+	}
+
+	if c.IsSet("tracing-insecure") {
+		cfg.Tracing.Insecure = c.Bool("tracing-insecure")
+	}
+
+	if c.IsSet("tracing-sample-ratio") {
+		cfg.Tracing.SampleRatio = c.Float64("tracing-sample-ratio")
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+
+	return cfg, nil
+}
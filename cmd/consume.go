@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/stream"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/presentation"
+	"github.com/go-redis/redis"
+	"github.com/urfave/cli/v2"
+)
+
+// consumeCommand stores the bets published on message transports as they arrive
+func consumeCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "consume",
+		Usage: "Store bets published on a message transport in real time",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "redis",
+				Usage: "Consume bets from a Redis stream through a consumer group",
+				Action: func(c *cli.Context) error {
+					return runConsumer(c, func(ctx context.Context, handler stream.Handler) error {
+						options, err := redis.ParseURL(cfg.Redis.URL)
+						if err != nil {
+							return err
+						}
+
+						client := redis.NewClient(options)
+						defer client.Close()
+
+						settings := cfg.Redis.Stream
+
+						consumer, err := stream.NewRedisConsumer(client, stream.RedisOptions{
+							Stream:        settings.Name,
+							Group:         settings.Group,
+							Consumer:      settings.ConsumerName(),
+							DeadLetter:    settings.DeadLetter,
+							BatchSize:     settings.BatchSize,
+							BatchWait:     settings.BatchWait,
+							MaxDeliveries: settings.MaxDeliveries,
+							RetryIdle:     settings.RetryIdle,
+						}, handler)
+						if err != nil {
+							return err
+						}
+
+						return consumer.Run(ctx)
+					})
+				},
+			},
+			{
+				Name:  "kafka",
+				Usage: "Consume bets from a Kafka topic through a consumer group",
+				Action: func(c *cli.Context) error {
+					return runConsumer(c, func(ctx context.Context, handler stream.Handler) error {
+						settings := cfg.Kafka
+
+						consumer := stream.NewKafkaConsumer(stream.KafkaOptions{
+							Brokers:       settings.Brokers,
+							Topic:         settings.Topic,
+							Group:         settings.Group,
+							DeadLetter:    settings.DeadLetter,
+							BatchSize:     settings.BatchSize,
+							BatchWait:     settings.BatchWait,
+							MaxAttempts:   settings.MaxAttempts,
+							RetryBackoff:  settings.RetryBackoff,
+							StatsInterval: settings.StatsInterval,
+						}, handler)
+
+						return consumer.Run(ctx)
+					})
+				},
+			},
+		},
+	}
+}
+
+// runConsumer sets up storage, then runs consume with a handler storing the bets it receives
+func runConsumer(c *cli.Context, consume func(ctx context.Context, handler stream.Handler) error) error {
+	usecases, err := presentation.ConfigureStartUpDependencies(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to configure start up dependencies: %w", err)
+	}
+
+	return consume(c.Context, usecases.StoreBets)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/presentation"
+	"github.com/urfave/cli/v2"
+)
+
+// jobsCommand inspects the progress of file imports
+func jobsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "jobs",
+		Usage: "Inspect file import jobs",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "List the most recent import jobs",
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:  "limit",
+						Value: 20,
+						Usage: "Maximum number of jobs to list",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					usecases, err := presentation.ConfigureStartUpDependencies(cfg)
+					if err != nil {
+						return fmt.Errorf("failed to configure start up dependencies: %w", err)
+					}
+
+					jobs, err := usecases.ListIngestJobs(c.Context, c.Int("limit"))
+					if err != nil {
+						return err
+					}
+
+					writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+					fmt.Fprintln(writer, "ID\tSTATUS\tRECORDS\tOFFSET\tUPDATED\tPATH")
+
+					for _, job := range jobs {
+						fmt.Fprintf(writer, "%s\t%s\t%d\t%d\t%s\t%s\n",
+							job.ID, job.Status, job.Records, job.Offset, job.UpdatedAt.Format(time.RFC3339), job.Path)
+					}
+
+					return writer.Flush()
+				},
+			},
+			{
+				Name:      "show",
+				Usage:     "Show the details of an import job",
+				ArgsUsage: "<job id>",
+				Action: func(c *cli.Context) error {
+					if c.NArg() != 1 {
+						return fmt.Errorf("expected a job id")
+					}
+
+					usecases, err := presentation.ConfigureStartUpDependencies(cfg)
+					if err != nil {
+						return fmt.Errorf("failed to configure start up dependencies: %w", err)
+					}
+
+					job, err := usecases.GetIngestJob(c.Context, c.Args().First())
+					if err != nil {
+						return err
+					}
+
+					writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+					fmt.Fprintf(writer, "ID:\t%s\n", job.ID)
+					fmt.Fprintf(writer, "Path:\t%s\n", job.Path)
+					fmt.Fprintf(writer, "Format:\t%s\n", job.Format)
+					fmt.Fprintf(writer, "Size:\t%d bytes\n", job.Size)
+					fmt.Fprintf(writer, "SHA-256:\t%s\n", job.Hash)
+					fmt.Fprintf(writer, "Status:\t%s\n", job.Status)
+					fmt.Fprintf(writer, "Committed records:\t%d\n", job.Records)
+					fmt.Fprintf(writer, "Committed offset:\t%d bytes (uncompressed)\n", job.Offset)
+					fmt.Fprintf(writer, "Created:\t%s\n", job.CreatedAt.Format(time.RFC3339))
+					fmt.Fprintf(writer, "Updated:\t%s\n", job.UpdatedAt.Format(time.RFC3339))
+
+					if job.CompletedAt != nil {
+						fmt.Fprintf(writer, "Completed:\t%s\n", job.CompletedAt.Format(time.RFC3339))
+					}
+
+					if job.Error != "" {
+						fmt.Fprintf(writer, "Error:\t%s\n", job.Error)
+					}
+
+					return writer.Flush()
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/presentation"
+	"github.com/urfave/cli/v2"
+)
+
+// linkedCommand looks for linked accounts offline, printing a summary of the groups and writing them as JSON when asked
+func linkedCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "linked",
+		Usage:     "Find the groups of users who bet the same selections at the same odds at the same moments",
+		ArgsUsage: "[JSON output file holding the groups with the bets they shared]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "user-id",
+				Usage: "Only report the groups of this user",
+			},
+			&cli.StringFlag{
+				Name:  "from",
+				Usage: "Only compare bets placed at or after this RFC3339 timestamp",
+			},
+			&cli.StringFlag{
+				Name:  "to",
+				Usage: "Only compare bets placed before this RFC3339 timestamp",
+			},
+			&cli.DurationFlag{
+				Name:  "window",
+				Usage: "Longest time between two bets for them to be shared, linked_accounts.window when omitted",
+			},
+			&cli.IntFlag{
+				Name:  "min-events",
+				Usage: "Number of events two users must share bets on to be linked, linked_accounts.min_events when omitted",
+			},
+		},
+		Action: func(c *cli.Context) (err error) {
+			filter := domain.BetFilter{UserID: c.String("user-id")}
+
+			if filter.From, err = parseTimeFlag(c, "from"); err != nil {
+				return err
+			}
+
+			if filter.To, err = parseTimeFlag(c, "to"); err != nil {
+				return err
+			}
+
+			rules := domain.LinkRules{Window: c.Duration("window"), MinEvents: c.Int("min-events")}
+			if rules.Window < 0 || rules.MinEvents < 0 {
+				return fmt.Errorf("--window and --min-events must not be negative")
+			}
+
+			usecases, err := presentation.ConfigureStartUpDependencies(cfg)
+			if err != nil {
+				return fmt.Errorf("failed to configure start up dependencies: %w", err)
+			}
+
+			report, err := usecases.ScanLinkedAccounts(c.Context, filter, rules)
+			if err != nil {
+				return err
+			}
+
+			writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+			fmt.Fprintf(writer, "Users\tScore\tEvents\tShared selections\n")
+
+			for _, group := range report.Groups {
+				fmt.Fprintf(writer, "%s\t%.4f\t%d\t%d\n", strings.Join(group.Users, ","), group.Score, group.Events, len(group.Shared))
+			}
+
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+
+			filename := c.Args().First()
+			if filename == "" {
+				return nil
+			}
+
+			out, err := os.Create(filename)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+
+			defer func() {
+				err = errors.Join(err, out.Close())
+			}()
+
+			encoder := json.NewEncoder(out)
+			encoder.SetIndent("", "  ")
+
+			if err := encoder.Encode(report); err != nil {
+				return fmt.Errorf("failed to encode JSON: %w", err)
+			}
+
+			fmt.Printf("Wrote %d groups to %s\n", len(report.Groups), filename)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/database/postgres"
+	"github.com/urfave/cli/v2"
+)
+
+// withMigrator opens a migrator on the configured database, runs fn and closes the migrator
+func withMigrator(fn func(m *postgres.Migrator) error) (err error) {
+	migrator, err := postgres.NewMigrator(cfg.SQLite.Path)
+	if err != nil {
+		return fmt.Errorf("failed to open migrations: %w", err)
+	}
+
+	defer func() {
+		err = errors.Join(err, migrator.Close())
+	}()
+
+	return fn(migrator)
+}
+
+// printVersion prints the currently applied migration version
+func printVersion(m *postgres.Migrator) error {
+	version, dirty, err := m.Version()
+	if err != nil {
+		return err
+	}
+
+	if dirty {
+		fmt.Printf("Version: %d (dirty)\n", version)
+		return nil
+	}
+
+	fmt.Printf("Version: %d\n", version)
+
+	return nil
+}
+
+// migrateCommand manages the database schema
+func migrateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "migrate",
+		Usage: "Manage database migrations",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "up",
+				Usage: "Apply all pending migrations",
+				Action: func(_ *cli.Context) error {
+					return withMigrator(func(m *postgres.Migrator) error {
+						if err := m.Up(); err != nil {
+							return err
+						}
+
+						return printVersion(m)
+					})
+				},
+			},
+			{
+				Name:      "down",
+				Usage:     "Roll back the last N migrations",
+				ArgsUsage: "N",
+				Action: func(c *cli.Context) error {
+					steps, err := strconv.Atoi(c.Args().First())
+					if err != nil {
+						return fmt.Errorf("invalid number of steps %q: %w", c.Args().First(), err)
+					}
+
+					return withMigrator(func(m *postgres.Migrator) error {
+						if err := m.Down(steps); err != nil {
+							return err
+						}
+
+						return printVersion(m)
+					})
+				},
+			},
+			{
+				Name:      "goto",
+				Usage:     "Migrate up or down to version V",
+				ArgsUsage: "V",
+				Action: func(c *cli.Context) error {
+					version, err := strconv.ParseUint(c.Args().First(), 10, 32)
+					if err != nil {
+						return fmt.Errorf("invalid version %q: %w", c.Args().First(), err)
+					}
+
+					return withMigrator(func(m *postgres.Migrator) error {
+						if err := m.Goto(uint(version)); err != nil {
+							return err
+						}
+
+						return printVersion(m)
+					})
+				},
+			},
+			{
+				Name:  "version",
+				Usage: "Print the current migration version",
+				Action: func(_ *cli.Context) error {
+					return withMigrator(printVersion)
+				},
+			},
+			{
+				Name:      "force",
+				Usage:     "Set version V without running migrations and clear the dirty flag",
+				ArgsUsage: "V",
+				Action: func(c *cli.Context) error {
+					version, err := strconv.Atoi(c.Args().First())
+					if err != nil {
+						return fmt.Errorf("invalid version %q: %w", c.Args().First(), err)
+					}
+
+					return withMigrator(func(m *postgres.Migrator) error {
+						if err := m.Force(version); err != nil {
+							return err
+						}
+
+						return printVersion(m)
+					})
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/presentation"
+	"github.com/urfave/cli/v2"
+)
+
+// settleCommand submits the result of a selection and prints the summary of the settlement
+func settleCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "settle",
+		Usage:     "Submit the result of a selection, settling every bet on it",
+		ArgsUsage: "<selection id> <win|lose|void|push>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "submitted-by",
+				Required: true,
+				Usage:    "Who submits the result, recorded for auditing",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() != 2 {
+				return fmt.Errorf("expected a selection id and a result")
+			}
+
+			usecases, err := presentation.ConfigureStartUpDependencies(cfg)
+			if err != nil {
+				return fmt.Errorf("failed to configure start up dependencies: %w", err)
+			}
+
+			result, err := usecases.SettleSelection(c.Context, domain.SelectionResult{
+				SelectionID: c.Args().Get(0),
+				Result:      enums.Outcome(c.Args().Get(1)),
+				SubmittedBy: c.String("submitted-by"),
+			})
+			if err != nil {
+				return err
+			}
+
+			writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+			fmt.Fprintf(writer, "Selection:\t%s\n", result.SelectionID)
+			fmt.Fprintf(writer, "Result:\t%s\n", result.Result)
+
+			if result.Resettlement() {
+				fmt.Fprintf(writer, "Previous result:\t%s\n", result.PreviousResult)
+			}
+
+			fmt.Fprintf(writer, "Submitted by:\t%s\n", result.SubmittedBy)
+			fmt.Fprintf(writer, "Submitted:\t%s\n", result.SubmittedAt.Format(time.RFC3339))
+			fmt.Fprintf(writer, "Bets settled:\t%d\n", result.BetsSettled)
+			fmt.Fprintf(writer, "Stake:\t%s %s\n", result.Stake, result.Currency)
+			fmt.Fprintf(writer, "Payout:\t%s %s\n", result.Payout, result.Currency)
+
+			return writer.Flush()
+		},
+	}
+}
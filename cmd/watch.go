@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/ingest"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/presentation"
+	"github.com/urfave/cli/v2"
+)
+
+// watchCommand continuously imports the bet files dropped in an inbox directory
+func watchCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "watch",
+		Usage:     "Import bet files as they appear in an inbox directory",
+		ArgsUsage: "<inbox directory>",
+		Flags: append([]cli.Flag{
+			&cli.DurationFlag{
+				Name:  "interval",
+				Value: 10 * time.Second,
+				Usage: "Time between two scans of the inbox",
+			},
+			&cli.DurationFlag{
+				Name:  "settle",
+				Value: 30 * time.Second,
+				Usage: "How long a file must remain unchanged before it is imported",
+			},
+			&cli.StringFlag{
+				Name:  "processed-dir",
+				Usage: "Directory imported files are moved to, defaults to <inbox>/processed",
+			},
+			&cli.StringFlag{
+				Name:  "failed-dir",
+				Usage: "Directory failed files and their error reports are moved to, defaults to <inbox>/failed",
+			},
+		}, formatFlags()...),
+		Action: func(c *cli.Context) error {
+			if c.NArg() != 1 {
+				return fmt.Errorf("expected an inbox directory")
+			}
+
+			usecases, err := presentation.ConfigureStartUpDependencies(cfg)
+			if err != nil {
+				return fmt.Errorf("failed to configure start up dependencies: %w", err)
+			}
+
+			// files are always resumed so that a restart never imports a file twice
+			process, err := importer(c, usecases, true)
+			if err != nil {
+				return err
+			}
+
+			watcher, err := ingest.NewWatcher(ingest.WatchOptions{
+				Inbox:        c.Args().First(),
+				ProcessedDir: c.String("processed-dir"),
+				FailedDir:    c.String("failed-dir"),
+				Interval:     c.Duration("interval"),
+				Settle:       c.Duration("settle"),
+			}, process)
+			if err != nil {
+				return err
+			}
+
+			return watcher.Run(c.Context)
+		},
+	}
+}
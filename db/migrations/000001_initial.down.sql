@@ -1 +1,2 @@
-DROP TABLE IF EXISTS bets;
\ No newline at end of file
+DROP INDEX IF EXISTS idx_user_id;
+DROP TABLE IF EXISTS bets;
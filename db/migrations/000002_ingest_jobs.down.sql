@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_ingest_jobs_identity;
+DROP TABLE IF EXISTS ingest_jobs;
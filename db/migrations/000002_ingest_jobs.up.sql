@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS ingest_jobs (
+    id TEXT PRIMARY KEY,
+    path TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    hash TEXT NOT NULL,
+    format TEXT NOT NULL,
+    status TEXT CHECK(status IN ('running', 'completed', 'failed')) NOT NULL,
+    committed_offset INTEGER NOT NULL DEFAULT 0,
+    committed_records INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    completed_at TIMESTAMP,
+    created TIMESTAMP NOT NULL,
+    updated TIMESTAMP NOT NULL,
+    created_by TEXT,
+    updated_by TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_ingest_jobs_identity ON ingest_jobs(hash, size);
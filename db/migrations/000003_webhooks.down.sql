@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS webhook_dead_letters;
+DROP INDEX IF EXISTS idx_webhook_deliveries_subscription;
+DROP INDEX IF EXISTS idx_webhook_deliveries_due;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS alerts;
+DROP TABLE IF EXISTS webhook_subscriptions;
@@ -0,0 +1,60 @@
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id TEXT PRIMARY KEY,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    events TEXT NOT NULL,
+    created TIMESTAMP NOT NULL,
+    updated TIMESTAMP NOT NULL,
+    created_by TEXT,
+    updated_by TEXT
+);
+
+CREATE TABLE IF NOT EXISTS alerts (
+    id TEXT PRIMARY KEY,
+    event TEXT CHECK(event IN ('anomaly.detected', 'bet.large', 'user.loss_limit_crossed')) NOT NULL,
+    dedupe_key TEXT UNIQUE NOT NULL,
+    user_id TEXT NOT NULL,
+    bet_id TEXT,
+    value REAL NOT NULL,
+    threshold REAL NOT NULL DEFAULT 0,
+    created TIMESTAMP NOT NULL,
+    updated TIMESTAMP NOT NULL,
+    created_by TEXT,
+    updated_by TEXT
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id TEXT PRIMARY KEY,
+    subscription_id TEXT NOT NULL,
+    alert_id TEXT NOT NULL,
+    event TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    last_status INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created TIMESTAMP NOT NULL,
+    updated TIMESTAMP NOT NULL,
+    created_by TEXT,
+    updated_by TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id);
+
+CREATE TABLE IF NOT EXISTS webhook_dead_letters (
+    id TEXT PRIMARY KEY,
+    delivery_id TEXT NOT NULL,
+    subscription_id TEXT NOT NULL,
+    url TEXT NOT NULL,
+    alert_id TEXT NOT NULL,
+    event TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    attempts INTEGER NOT NULL,
+    last_status INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created TIMESTAMP NOT NULL,
+    updated TIMESTAMP NOT NULL,
+    created_by TEXT,
+    updated_by TEXT
+);
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_alerts_user ON alerts(user_id, created);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS bet_legs;
+
+ALTER TABLE bets DROP COLUMN payout;
@@ -0,0 +1,17 @@
+ALTER TABLE bets ADD COLUMN payout REAL NOT NULL DEFAULT 0;
+
+UPDATE bets SET payout = amount * odds WHERE outcome = 'win';
+
+CREATE TABLE IF NOT EXISTS bet_legs (
+    id TEXT PRIMARY KEY,
+    bet_id TEXT NOT NULL REFERENCES bets(bet_id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    selection TEXT NOT NULL,
+    odds REAL NOT NULL,
+    outcome TEXT CHECK(outcome IN ('win', 'lose')) NOT NULL,
+    created TEXT NOT NULL,
+    updated TEXT NOT NULL,
+    created_by TEXT,
+    updated_by TEXT,
+    UNIQUE(bet_id, position)
+);
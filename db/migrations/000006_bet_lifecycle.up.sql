@@ -0,0 +1,44 @@
+-- SQLite cannot alter a CHECK constraint, so both tables are rebuilt with the lifecycle states.
+-- The rows are copied aside first so that dropping the tables cannot cascade to the legs.
+CREATE TABLE bets_copy AS SELECT * FROM bets;
+CREATE TABLE bet_legs_copy AS SELECT * FROM bet_legs;
+
+DROP TABLE bet_legs;
+DROP TABLE bets;
+
+CREATE TABLE bets (
+    id TEXT PRIMARY KEY,
+    bet_id TEXT UNIQUE NOT NULL,
+    user_id TEXT NOT NULL,
+    amount REAL NOT NULL,
+    odds REAL NOT NULL,
+    outcome TEXT CHECK(outcome IN ('pending', 'win', 'lose', 'void', 'push', 'cashout', 'half_win', 'half_lose')) NOT NULL,
+    timestamp TEXT NOT NULL,
+    created TEXT NOT NULL,
+    updated TEXT NOT NULL,
+    created_by TEXT,
+    updated_by TEXT,
+    payout REAL NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_id ON bets(user_id);
+
+CREATE TABLE bet_legs (
+    id TEXT PRIMARY KEY,
+    bet_id TEXT NOT NULL REFERENCES bets(bet_id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    selection TEXT NOT NULL,
+    odds REAL NOT NULL,
+    outcome TEXT CHECK(outcome IN ('pending', 'win', 'lose', 'void', 'push')) NOT NULL,
+    created TEXT NOT NULL,
+    updated TEXT NOT NULL,
+    created_by TEXT,
+    updated_by TEXT,
+    UNIQUE(bet_id, position)
+);
+
+INSERT INTO bets SELECT * FROM bets_copy;
+INSERT INTO bet_legs SELECT * FROM bet_legs_copy;
+
+DROP TABLE bets_copy;
+DROP TABLE bet_legs_copy;
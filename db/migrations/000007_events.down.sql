@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_bet_legs_selection;
+DROP INDEX IF EXISTS idx_bets_selection;
+
+ALTER TABLE bets DROP COLUMN selection;
+
+DROP TABLE IF EXISTS selection_results;
+DROP TABLE IF EXISTS selections;
+DROP TABLE IF EXISTS markets;
+DROP TABLE IF EXISTS events;
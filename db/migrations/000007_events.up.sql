@@ -0,0 +1,56 @@
+CREATE TABLE IF NOT EXISTS events (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    starts_at TIMESTAMP NOT NULL,
+    created TIMESTAMP NOT NULL,
+    updated TIMESTAMP NOT NULL,
+    created_by TEXT,
+    updated_by TEXT
+);
+
+CREATE TABLE IF NOT EXISTS markets (
+    id TEXT PRIMARY KEY,
+    event_id TEXT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    created TIMESTAMP NOT NULL,
+    updated TIMESTAMP NOT NULL,
+    created_by TEXT,
+    updated_by TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_markets_event ON markets(event_id);
+
+CREATE TABLE IF NOT EXISTS selections (
+    id TEXT PRIMARY KEY,
+    market_id TEXT NOT NULL REFERENCES markets(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    result TEXT CHECK(result IN ('win', 'lose', 'void', 'push')),
+    created TIMESTAMP NOT NULL,
+    updated TIMESTAMP NOT NULL,
+    created_by TEXT,
+    updated_by TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_selections_market ON selections(market_id);
+
+-- every result submitted for a selection is kept to audit resettlements
+CREATE TABLE IF NOT EXISTS selection_results (
+    id TEXT PRIMARY KEY,
+    selection_id TEXT NOT NULL REFERENCES selections(id) ON DELETE CASCADE,
+    result TEXT CHECK(result IN ('win', 'lose', 'void', 'push')) NOT NULL,
+    previous_result TEXT,
+    bets_settled INTEGER NOT NULL,
+    stake REAL NOT NULL,
+    payout REAL NOT NULL,
+    created TIMESTAMP NOT NULL,
+    updated TIMESTAMP NOT NULL,
+    created_by TEXT NOT NULL,
+    updated_by TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_selection_results_selection ON selection_results(selection_id, created);
+
+ALTER TABLE bets ADD COLUMN selection TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_bets_selection ON bets(selection);
+CREATE INDEX IF NOT EXISTS idx_bet_legs_selection ON bet_legs(selection);
@@ -0,0 +1,78 @@
+-- Amounts go back to floating point numbers of units and the currencies of the bets are dropped,
+-- so bets staked in different currencies can no longer be told apart.
+CREATE TABLE bets_copy AS SELECT * FROM bets;
+CREATE TABLE bet_legs_copy AS SELECT * FROM bet_legs;
+
+DROP TABLE bet_legs;
+DROP TABLE bets;
+
+CREATE TABLE bets (
+    id TEXT PRIMARY KEY,
+    bet_id TEXT UNIQUE NOT NULL,
+    user_id TEXT NOT NULL,
+    amount REAL NOT NULL,
+    odds REAL NOT NULL,
+    outcome TEXT CHECK(outcome IN ('pending', 'win', 'lose', 'void', 'push', 'cashout', 'half_win', 'half_lose')) NOT NULL,
+    timestamp TEXT NOT NULL,
+    created TEXT NOT NULL,
+    updated TEXT NOT NULL,
+    created_by TEXT,
+    updated_by TEXT,
+    payout REAL NOT NULL DEFAULT 0,
+    selection TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_id ON bets(user_id);
+CREATE INDEX IF NOT EXISTS idx_bets_selection ON bets(selection);
+
+CREATE TABLE bet_legs (
+    id TEXT PRIMARY KEY,
+    bet_id TEXT NOT NULL REFERENCES bets(bet_id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    selection TEXT NOT NULL,
+    odds REAL NOT NULL,
+    outcome TEXT CHECK(outcome IN ('pending', 'win', 'lose', 'void', 'push')) NOT NULL,
+    created TEXT NOT NULL,
+    updated TEXT NOT NULL,
+    created_by TEXT,
+    updated_by TEXT,
+    UNIQUE(bet_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS idx_bet_legs_selection ON bet_legs(selection);
+
+INSERT INTO bets (id, bet_id, user_id, amount, odds, outcome, timestamp, created, updated, created_by, updated_by, payout, selection)
+SELECT id, bet_id, user_id, amount / 10000.0, odds, outcome, timestamp, created, updated,
+    created_by, updated_by, payout / 10000.0, selection
+FROM bets_copy;
+
+INSERT INTO bet_legs SELECT * FROM bet_legs_copy;
+
+DROP TABLE bets_copy;
+DROP TABLE bet_legs_copy;
+
+CREATE TABLE selection_results_copy AS SELECT * FROM selection_results;
+
+DROP TABLE selection_results;
+
+CREATE TABLE selection_results (
+    id TEXT PRIMARY KEY,
+    selection_id TEXT NOT NULL REFERENCES selections(id) ON DELETE CASCADE,
+    result TEXT CHECK(result IN ('win', 'lose', 'void', 'push')) NOT NULL,
+    previous_result TEXT,
+    bets_settled INTEGER NOT NULL,
+    stake REAL NOT NULL,
+    payout REAL NOT NULL,
+    created TIMESTAMP NOT NULL,
+    updated TIMESTAMP NOT NULL,
+    created_by TEXT NOT NULL,
+    updated_by TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_selection_results_selection ON selection_results(selection_id, created);
+
+INSERT INTO selection_results (id, selection_id, result, previous_result, bets_settled, stake, payout, created, updated, created_by, updated_by)
+SELECT id, selection_id, result, previous_result, bets_settled, stake / 10000.0, payout / 10000.0, created, updated, created_by, updated_by
+FROM selection_results_copy;
+
+DROP TABLE selection_results_copy;
@@ -0,0 +1,2 @@
+ALTER TABLE bets DROP COLUMN quoted_odds;
+ALTER TABLE bets DROP COLUMN odds_format;
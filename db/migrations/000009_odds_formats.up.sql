@@ -0,0 +1,5 @@
+ALTER TABLE bets ADD COLUMN odds_format TEXT NOT NULL DEFAULT 'decimal'
+    CHECK(odds_format IN ('decimal', 'fractional', 'american', 'hongkong', 'malay'));
+
+-- the odds as they were quoted, empty when they are decimal
+ALTER TABLE bets ADD COLUMN quoted_odds TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_bets_event;
+DROP INDEX IF EXISTS idx_bets_sport;
+
+ALTER TABLE bets DROP COLUMN market;
+ALTER TABLE bets DROP COLUMN event_id;
+ALTER TABLE bets DROP COLUMN competition;
+ALTER TABLE bets DROP COLUMN sport;
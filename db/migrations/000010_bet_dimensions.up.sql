@@ -0,0 +1,8 @@
+-- what a bet was placed on, empty when the bet does not say
+ALTER TABLE bets ADD COLUMN sport TEXT NOT NULL DEFAULT '';
+ALTER TABLE bets ADD COLUMN competition TEXT NOT NULL DEFAULT '';
+ALTER TABLE bets ADD COLUMN event_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE bets ADD COLUMN market TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_bets_sport ON bets(sport);
+CREATE INDEX IF NOT EXISTS idx_bets_event ON bets(event_id);
@@ -0,0 +1,30 @@
+-- Limit breaches do not fit the previous CHECK constraint: they are dropped along with their deliveries.
+DELETE FROM webhook_deliveries WHERE event = 'user.limit_breached';
+DELETE FROM webhook_dead_letters WHERE event = 'user.limit_breached';
+
+CREATE TABLE alerts_copy AS SELECT * FROM alerts WHERE event <> 'user.limit_breached';
+
+DROP TABLE alerts;
+
+CREATE TABLE alerts (
+    id TEXT PRIMARY KEY,
+    event TEXT CHECK(event IN ('anomaly.detected', 'bet.large', 'user.loss_limit_crossed')) NOT NULL,
+    dedupe_key TEXT UNIQUE NOT NULL,
+    user_id TEXT NOT NULL,
+    bet_id TEXT,
+    value REAL NOT NULL,
+    threshold REAL NOT NULL DEFAULT 0,
+    created TIMESTAMP NOT NULL,
+    updated TIMESTAMP NOT NULL,
+    created_by TEXT,
+    updated_by TEXT
+);
+
+INSERT INTO alerts (id, event, dedupe_key, user_id, bet_id, value, threshold, created, updated, created_by, updated_by)
+SELECT id, event, dedupe_key, user_id, bet_id, value, threshold, created, updated, created_by, updated_by FROM alerts_copy;
+
+DROP TABLE alerts_copy;
+
+CREATE INDEX IF NOT EXISTS idx_alerts_user ON alerts(user_id, created);
+
+DROP TABLE IF EXISTS user_limits;
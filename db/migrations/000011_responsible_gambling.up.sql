@@ -0,0 +1,44 @@
+-- The caps a user is held to over a rolling period, on top of the defaults every user is held to.
+-- Amounts are whole numbers of ten-thousandths of a unit of the reporting currency; zero leaves a cap unset.
+CREATE TABLE IF NOT EXISTS user_limits (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    period TEXT CHECK(period IN ('day', 'week', 'month')) NOT NULL,
+    loss_cap INTEGER NOT NULL DEFAULT 0,
+    stake_cap INTEGER NOT NULL DEFAULT 0,
+    bet_cap INTEGER NOT NULL DEFAULT 0,
+    created TIMESTAMP NOT NULL,
+    updated TIMESTAMP NOT NULL,
+    created_by TEXT,
+    updated_by TEXT,
+    UNIQUE(user_id, period)
+);
+
+-- SQLite cannot alter a CHECK constraint, so the alerts are rebuilt to take limit breaches,
+-- which name the limit and the period they breached.
+CREATE TABLE alerts_copy AS SELECT * FROM alerts;
+
+DROP TABLE alerts;
+
+CREATE TABLE alerts (
+    id TEXT PRIMARY KEY,
+    event TEXT CHECK(event IN ('anomaly.detected', 'bet.large', 'user.loss_limit_crossed', 'user.limit_breached')) NOT NULL,
+    dedupe_key TEXT UNIQUE NOT NULL,
+    user_id TEXT NOT NULL,
+    bet_id TEXT,
+    value REAL NOT NULL,
+    threshold REAL NOT NULL DEFAULT 0,
+    created TIMESTAMP NOT NULL,
+    updated TIMESTAMP NOT NULL,
+    created_by TEXT,
+    updated_by TEXT,
+    limit_kind TEXT NOT NULL DEFAULT '',
+    period TEXT NOT NULL DEFAULT ''
+);
+
+INSERT INTO alerts (id, event, dedupe_key, user_id, bet_id, value, threshold, created, updated, created_by, updated_by)
+SELECT id, event, dedupe_key, user_id, bet_id, value, threshold, created, updated, created_by, updated_by FROM alerts_copy;
+
+DROP TABLE alerts_copy;
+
+CREATE INDEX IF NOT EXISTS idx_alerts_user ON alerts(user_id, created);
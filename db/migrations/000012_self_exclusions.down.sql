@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_exclusion_violations_created;
+DROP TABLE IF EXISTS exclusion_violations;
+DROP INDEX IF EXISTS idx_self_exclusions_user;
+DROP TABLE IF EXISTS self_exclusions;
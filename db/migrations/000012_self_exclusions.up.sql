@@ -0,0 +1,35 @@
+-- The periods users excluded themselves from betting over. An exclusion without an end lasts until it is lifted.
+CREATE TABLE IF NOT EXISTS self_exclusions (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP,
+    reason TEXT NOT NULL,
+    lifted_at TIMESTAMP,
+    lifted_by TEXT,
+    created TIMESTAMP NOT NULL,
+    updated TIMESTAMP NOT NULL,
+    created_by TEXT,
+    updated_by TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_self_exclusions_user ON self_exclusions(user_id);
+
+-- Every bet received from a user during one of their exclusions, whether it was stored or turned down.
+-- Amounts are whole numbers of ten-thousandths of a unit of the currency.
+CREATE TABLE IF NOT EXISTS exclusion_violations (
+    id TEXT PRIMARY KEY,
+    exclusion_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    bet_id TEXT NOT NULL,
+    amount INTEGER NOT NULL,
+    currency TEXT NOT NULL,
+    placed_at TIMESTAMP NOT NULL,
+    action TEXT CHECK(action IN ('flag', 'reject')) NOT NULL,
+    created TIMESTAMP NOT NULL,
+    updated TIMESTAMP NOT NULL,
+    created_by TEXT,
+    updated_by TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_exclusion_violations_created ON exclusion_violations(created);
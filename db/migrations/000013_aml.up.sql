@@ -0,0 +1,36 @@
+-- SQLite cannot alter a CHECK constraint, so the alerts are rebuilt to take the findings of the anti-money laundering
+-- rules, which hold the comma separated IDs of the bets they report as evidence.
+CREATE TABLE alerts_copy AS SELECT * FROM alerts;
+
+DROP TABLE alerts;
+
+CREATE TABLE alerts (
+    id TEXT PRIMARY KEY,
+    event TEXT CHECK(event IN (
+        'anomaly.detected', 'bet.large', 'user.loss_limit_crossed', 'user.limit_breached',
+        'aml.large_transaction', 'aml.structuring'
+    )) NOT NULL,
+    dedupe_key TEXT UNIQUE NOT NULL,
+    user_id TEXT NOT NULL,
+    bet_id TEXT,
+    value REAL NOT NULL,
+    threshold REAL NOT NULL DEFAULT 0,
+    created TIMESTAMP NOT NULL,
+    updated TIMESTAMP NOT NULL,
+    created_by TEXT,
+    updated_by TEXT,
+    limit_kind TEXT NOT NULL DEFAULT '',
+    period TEXT NOT NULL DEFAULT '',
+    evidence TEXT NOT NULL DEFAULT ''
+);
+
+INSERT INTO alerts (
+    id, event, dedupe_key, user_id, bet_id, value, threshold, created, updated, created_by, updated_by, limit_kind, period
+)
+SELECT id, event, dedupe_key, user_id, bet_id, value, threshold, created, updated, created_by, updated_by, limit_kind, period
+FROM alerts_copy;
+
+DROP TABLE alerts_copy;
+
+CREATE INDEX IF NOT EXISTS idx_alerts_user ON alerts(user_id, created);
+CREATE INDEX IF NOT EXISTS idx_alerts_event ON alerts(event, created);
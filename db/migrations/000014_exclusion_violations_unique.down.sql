@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_exclusion_violations_bet;
@@ -0,0 +1,6 @@
+-- A bet is reported once for every exclusion it was placed during, however often it is received.
+-- Violations recorded again for bets delivered more than once are dropped, keeping the first.
+DELETE FROM exclusion_violations
+WHERE rowid NOT IN (SELECT MIN(rowid) FROM exclusion_violations GROUP BY bet_id, exclusion_id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_exclusion_violations_bet ON exclusion_violations(bet_id, exclusion_id);
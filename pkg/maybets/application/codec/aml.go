@@ -0,0 +1,75 @@
+package codec
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+)
+
+// amlReportHeader names the columns of an anti-money laundering report exported as CSV
+var amlReportHeader = []string{
+	"rule", "user_id", "finding_amount", "reporting_currency", "first_bet_at", "last_bet_at", "bets",
+	"bet_id", "amount", "currency", "odds", "outcome", "timestamp",
+}
+
+// WriteAMLReport exports an anti-money laundering report as a single JSON document, or as CSV with a row for every
+// evidence bet repeating the finding it belongs to
+func WriteAMLReport(w io.Writer, report *domain.AMLReport, format enums.ReportFormat) error {
+	switch format {
+	case enums.JSONReport:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(report); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+
+		return nil
+	case enums.CSVReport:
+		return writeAMLReportCSV(w, report)
+	default:
+		return fmt.Errorf("invalid format %q: must be one of %v", format, enums.ReportFormats)
+	}
+}
+
+func writeAMLReportCSV(w io.Writer, report *domain.AMLReport) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(amlReportHeader); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, finding := range report.Findings {
+		for _, bet := range finding.Bets {
+			record := []string{
+				finding.Rule.String(),
+				finding.UserID,
+				finding.Amount.String(),
+				string(report.Currency),
+				finding.From.UTC().Format(time.RFC3339),
+				finding.To.UTC().Format(time.RFC3339),
+				strconv.Itoa(len(finding.Bets)),
+				bet.BetID,
+				bet.Amount.String(),
+				string(bet.Currency),
+				bet.OddsIn(enums.Decimal),
+				bet.Outcome.String(),
+				bet.Timestamp.UTC().Format(time.RFC3339),
+			}
+
+			if err := writer.Write(record); err != nil {
+				return fmt.Errorf("failed to write CSV record: %w", err)
+			}
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
@@ -0,0 +1,94 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+)
+
+func TestWriteAMLReport(t *testing.T) {
+	placed := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	report := &domain.AMLReport{
+		Currency:  "USD",
+		Threshold: domain.MoneyFromFloat(10000),
+		Findings: []domain.AMLFinding{
+			{
+				Rule: enums.LargeTransaction, UserID: "u1", Amount: domain.MoneyFromFloat(12000), From: placed, To: placed,
+				Bets: []*domain.Bet{
+					{BetID: "b1", UserID: "u1", Amount: domain.MoneyFromFloat(12000), Currency: "USD", Odds: 2.5, Outcome: enums.Win, Timestamp: placed},
+				},
+			},
+			{
+				Rule: enums.Structuring, UserID: "u2", Amount: domain.MoneyFromFloat(19000), From: placed, To: placed.Add(time.Hour),
+				Bets: []*domain.Bet{
+					{BetID: "b2", UserID: "u2", Amount: domain.MoneyFromFloat(9500), Currency: "USD", Odds: 1.8, Outcome: enums.Lose, Timestamp: placed},
+					{
+						BetID: "b3", UserID: "u2", Amount: domain.MoneyFromFloat(9500), Currency: "USD", Odds: 1.8, Outcome: enums.Lose,
+						Timestamp: placed.Add(time.Hour),
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		format   enums.ReportFormat
+		wantRows int
+		wantErr  bool
+	}{
+		{
+			name:     "success: csv row for every evidence bet",
+			format:   enums.CSVReport,
+			wantRows: 4,
+		},
+		{
+			name:   "success: json document",
+			format: enums.JSONReport,
+		},
+		{
+			name:    "fail: unknown format",
+			format:  enums.ReportFormat("xml"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := WriteAMLReport(&buf, report, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WriteAMLReport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			switch tt.format {
+			case enums.CSVReport:
+				rows := strings.Split(strings.TrimSpace(buf.String()), "\n")
+				if len(rows) != tt.wantRows {
+					t.Fatalf("WriteAMLReport() wrote %d rows, want %d", len(rows), tt.wantRows)
+				}
+
+				want := "structuring,u2,19000,USD,2026-10-01T09:00:00Z,2026-10-01T10:00:00Z,2,b3,9500,USD,1.8,lose,2026-10-01T10:00:00Z"
+				if rows[3] != want {
+					t.Errorf("WriteAMLReport() last row = %q, want %q", rows[3], want)
+				}
+			case enums.JSONReport:
+				var decoded domain.AMLReport
+				if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+					t.Fatalf("WriteAMLReport() wrote invalid JSON: %v", err)
+				}
+
+				if len(decoded.Findings) != 2 || len(decoded.Findings[1].Bets) != 2 {
+					t.Errorf("WriteAMLReport() = %+v, want 2 findings with their evidence", decoded)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package codec
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+)
+
+// BetReader decodes bets one at a time from an underlying stream.
+// Read returns io.EOF once the stream is exhausted.
+type BetReader interface {
+	Read() (*domain.Bet, error)
+	// Position returns the position just after the last bet returned by Read
+	Position() Position
+}
+
+// Position is a record boundary in a decoded stream, used to checkpoint and resume imports
+type Position struct {
+	// Offset is the number of bytes of the uncompressed stream consumed
+	Offset int64 `json:"offset"`
+	// Records is the number of records read
+	Records int64 `json:"records"`
+}
+
+// BetWriter encodes bets one at a time to an underlying stream.
+// Flush must be called once all bets are written.
+type BetWriter interface {
+	Write(bet *domain.Bet) error
+	Flush() error
+}
+
+// NewReader returns a reader for the given file format
+func NewReader(r io.Reader, format enums.FileFormat, options CSVOptions) (BetReader, error) {
+	switch format {
+	case enums.NDJSON:
+		return NewNDJSONReader(r), nil
+	case enums.CSV:
+		return NewCSVReader(r, options)
+	default:
+		return nil, fmt.Errorf("unsupported file format %q", format)
+	}
+}
+
+// NewReaderAt returns a reader for the given file format that starts at a position
+// previously returned by Position, skipping the bets before it.
+// CSV headers are still read from the start of the stream and must fit on a single line.
+func NewReaderAt(r io.Reader, format enums.FileFormat, options CSVOptions, position Position) (BetReader, error) {
+	if position.Offset == 0 {
+		return NewReader(r, format, options)
+	}
+
+	switch format {
+	case enums.NDJSON:
+		if _, err := io.CopyN(io.Discard, r, position.Offset); err != nil {
+			return nil, fmt.Errorf("failed to skip to offset %d: %w", position.Offset, err)
+		}
+
+		reader := NewNDJSONReader(r)
+		reader.start = position
+
+		return reader, nil
+	case enums.CSV:
+		buffered := bufio.NewReader(r)
+
+		header, err := buffered.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV header: %w", err)
+		}
+
+		skip := position.Offset - int64(len(header))
+		if skip < 0 {
+			return nil, fmt.Errorf("offset %d is inside the CSV header", position.Offset)
+		}
+
+		if _, err := io.CopyN(io.Discard, buffered, skip); err != nil {
+			return nil, fmt.Errorf("failed to skip to offset %d: %w", position.Offset, err)
+		}
+
+		reader, err := NewCSVReader(io.MultiReader(strings.NewReader(header), buffered), options)
+		if err != nil {
+			return nil, err
+		}
+
+		reader.start = Position{Offset: skip, Records: position.Records}
+		reader.line += int(position.Records)
+
+		return reader, nil
+	default:
+		return nil, fmt.Errorf("unsupported file format %q", format)
+	}
+}
+
+// NewWriter returns a writer for the given file format that quotes odds in the given format,
+// or in the odds format of the CSV options when it is empty
+func NewWriter(w io.Writer, format enums.FileFormat, options CSVOptions, odds enums.OddsFormat) (BetWriter, error) {
+	if odds != "" && !odds.IsValid() {
+		return nil, fmt.Errorf("invalid odds format %q", odds)
+	}
+
+	switch format {
+	case enums.NDJSON:
+		return NewNDJSONWriter(w, odds), nil
+	case enums.CSV:
+		if odds != "" {
+			options.OddsFormat = odds
+		}
+
+		return NewCSVWriter(w, options)
+	default:
+		return nil, fmt.Errorf("unsupported file format %q", format)
+	}
+}
+
+// compressionExtensions are ignored when guessing the format of a compressed file
+var compressionExtensions = []string{".gz", ".zst", ".zstd"}
+
+// FormatFromFilename guesses the file format from the file extension, defaulting to NDJSON.
+// A trailing compression extension is ignored, so "bets.csv.gz" is a CSV file.
+func FormatFromFilename(filename string) enums.FileFormat {
+	for _, extension := range compressionExtensions {
+		if strings.EqualFold(filepath.Ext(filename), extension) {
+			filename = strings.TrimSuffix(filename, filepath.Ext(filename))
+			break
+		}
+	}
+
+	if strings.EqualFold(filepath.Ext(filename), ".csv") {
+		return enums.CSV
+	}
+
+	return enums.NDJSON
+}
+
+// ReadAll reads every remaining bet from r
+func ReadAll(r BetReader) ([]*domain.Bet, error) {
+	var bets []*domain.Bet
+
+	for {
+		bet, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return bets, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		bets = append(bets, bet)
+	}
+}
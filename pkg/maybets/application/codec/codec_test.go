@@ -0,0 +1,165 @@
+package codec
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+)
+
+func TestFormatFromFilename(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		want     enums.FileFormat
+	}{
+		{
+			name:     "success: csv extension",
+			filename: "bets.CSV",
+			want:     enums.CSV,
+		},
+		{
+			name:     "success: gzipped csv",
+			filename: "exports/2024-11-22.csv.gz",
+			want:     enums.CSV,
+		},
+		{
+			name:     "success: zstd ndjson",
+			filename: "exports/2024-11-22.ndjson.zst",
+			want:     enums.NDJSON,
+		},
+		{
+			name:     "success: unknown extension defaults to ndjson",
+			filename: "bets.json",
+			want:     enums.NDJSON,
+		},
+		{
+			name:     "success: stdin defaults to ndjson",
+			filename: "-",
+			want:     enums.NDJSON,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatFromFilename(tt.filename); got != tt.want {
+				t.Errorf("FormatFromFilename() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewReaderAt(t *testing.T) {
+	var bets []*domain.Bet
+
+	for i, outcome := range []enums.Outcome{enums.Win, enums.Lose, enums.Win, enums.Lose} {
+		bets = append(bets, &domain.Bet{
+			BetID:     strings.Repeat("b", i+1),
+			UserID:    "u1",
+			Amount:    domain.MoneyFromFloat(float64(10 * (i + 1))),
+			Odds:      1.5,
+			Outcome:   outcome,
+			Timestamp: time.Date(2024, 11, 22, 21, i, 0, 0, time.UTC),
+		})
+	}
+
+	tests := []struct {
+		name    string
+		format  enums.FileFormat
+		options CSVOptions
+		odds    enums.OddsFormat
+	}{
+		{
+			name:   "success: resume ndjson",
+			format: enums.NDJSON,
+		},
+		{
+			name:    "success: resume csv",
+			format:  enums.CSV,
+			options: CSVOptions{Delimiter: ';', DecimalSeparator: ','},
+		},
+		{
+			name:   "success: resume ndjson with fractional odds",
+			format: enums.NDJSON,
+			odds:   enums.Fractional,
+		},
+		{
+			name:    "success: resume csv with american odds",
+			format:  enums.CSV,
+			options: CSVOptions{OddsFormat: enums.American},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			writer, err := NewWriter(&buf, tt.format, tt.options, tt.odds)
+			if err != nil {
+				t.Fatalf("NewWriter() error = %v", err)
+			}
+
+			for _, bet := range bets {
+				if err := writer.Write(bet); err != nil {
+					t.Fatalf("Write() error = %v", err)
+				}
+			}
+
+			if err := writer.Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+
+			reader, err := NewReader(bytes.NewReader(buf.Bytes()), tt.format, tt.options)
+			if err != nil {
+				t.Fatalf("NewReader() error = %v", err)
+			}
+
+			var positions []Position
+
+			for range bets {
+				if _, err := reader.Read(); err != nil {
+					t.Fatalf("Read() error = %v", err)
+				}
+
+				positions = append(positions, reader.Position())
+			}
+
+			for i, position := range positions {
+				if position.Records != int64(i+1) {
+					t.Errorf("Position().Records = %d, want %d", position.Records, i+1)
+				}
+
+				resumed, err := NewReaderAt(bytes.NewReader(buf.Bytes()), tt.format, tt.options, position)
+				if err != nil {
+					t.Fatalf("NewReaderAt() error = %v", err)
+				}
+
+				remaining, err := ReadAll(resumed)
+				if err != nil {
+					t.Fatalf("ReadAll() error = %v", err)
+				}
+
+				if len(remaining) != len(bets)-i-1 {
+					t.Fatalf("resumed at record %d and read %d bets, want %d", i+1, len(remaining), len(bets)-i-1)
+				}
+
+				for j, bet := range remaining {
+					if bet.BetID != bets[i+1+j].BetID {
+						t.Errorf("resumed bet %d = %s, want %s", j, bet.BetID, bets[i+1+j].BetID)
+					}
+
+					if bet.Odds != bets[i+1+j].Odds {
+						t.Errorf("resumed bet %d odds = %v, want %v", j, bet.Odds, bets[i+1+j].Odds)
+					}
+				}
+
+				if got := resumed.Position(); got != positions[len(positions)-1] {
+					t.Errorf("resumed Position() = %+v, want %+v", got, positions[len(positions)-1])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,401 @@
+package codec
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+)
+
+// CSVColumns maps each bet field to the name of the CSV header column holding it
+type CSVColumns struct {
+	BetID     string
+	UserID    string
+	Amount    string
+	Odds      string
+	Outcome   string
+	Timestamp string
+	// Selection is optional when reading, bets are not placed on a selection when the header lacks it
+	Selection string
+	// Currency is optional when reading, bets take the default currency when the header lacks it
+	Currency string
+	// Sport, Competition, EventID and Market are optional when reading, bets do not say what they were placed on
+	// when the header lacks them
+	Sport       string
+	Competition string
+	EventID     string
+	Market      string
+}
+
+// CSVOptions controls how bets are laid out in a CSV file.
+// Zero values fall back to the canonical layout produced by the export.
+type CSVOptions struct {
+	// Columns holds the header names of each field
+	Columns CSVColumns
+	// TimestampLayouts are tried in order when parsing timestamps. The first one is used when writing.
+	TimestampLayouts []string
+	// DecimalSeparator separates the integer and fractional parts of amounts and odds
+	DecimalSeparator rune
+	// Delimiter separates the fields of a record
+	Delimiter rune
+	// OddsFormat is the format of the odds column. Odds are read in it, or in the format guessed from each value
+	// when it is empty, and written in it, decimal when it is empty.
+	OddsFormat enums.OddsFormat
+}
+
+// DefaultCSVColumns are the header names used when no mapping is given
+var DefaultCSVColumns = CSVColumns{
+	BetID:       "bet_id",
+	UserID:      "user_id",
+	Amount:      "amount",
+	Odds:        "odds",
+	Outcome:     "outcome",
+	Timestamp:   "timestamp",
+	Selection:   "selection",
+	Currency:    "currency",
+	Sport:       "sport",
+	Competition: "competition",
+	EventID:     "event_id",
+	Market:      "market",
+}
+
+// withDefaults fills in the unset options
+func (o CSVOptions) withDefaults() CSVOptions {
+	defaults := []struct {
+		field    *string
+		fallback string
+	}{
+		{&o.Columns.BetID, DefaultCSVColumns.BetID},
+		{&o.Columns.UserID, DefaultCSVColumns.UserID},
+		{&o.Columns.Amount, DefaultCSVColumns.Amount},
+		{&o.Columns.Odds, DefaultCSVColumns.Odds},
+		{&o.Columns.Outcome, DefaultCSVColumns.Outcome},
+		{&o.Columns.Timestamp, DefaultCSVColumns.Timestamp},
+		{&o.Columns.Selection, DefaultCSVColumns.Selection},
+		{&o.Columns.Currency, DefaultCSVColumns.Currency},
+		{&o.Columns.Sport, DefaultCSVColumns.Sport},
+		{&o.Columns.Competition, DefaultCSVColumns.Competition},
+		{&o.Columns.EventID, DefaultCSVColumns.EventID},
+		{&o.Columns.Market, DefaultCSVColumns.Market},
+	}
+
+	for _, d := range defaults {
+		if *d.field == "" {
+			*d.field = d.fallback
+		}
+	}
+
+	if len(o.TimestampLayouts) == 0 {
+		o.TimestampLayouts = []string{time.RFC3339Nano}
+	}
+
+	if o.DecimalSeparator == 0 {
+		o.DecimalSeparator = '.'
+	}
+
+	if o.Delimiter == 0 {
+		o.Delimiter = ','
+	}
+
+	return o
+}
+
+// validate checks that the options can describe an unambiguous file
+func (o CSVOptions) validate() error {
+	if o.DecimalSeparator == o.Delimiter {
+		return fmt.Errorf("the decimal separator and the delimiter must differ, both are %q", o.Delimiter)
+	}
+
+	if o.OddsFormat != "" && !o.OddsFormat.IsValid() {
+		return fmt.Errorf("invalid odds format %q", o.OddsFormat)
+	}
+
+	return nil
+}
+
+// ParseCSVColumns parses a column mapping of the form field=header,field=header,
+// e.g "bet_id=BetRef,user_id=Customer". Fields that are not mentioned keep their default header.
+func ParseCSVColumns(mapping string) (CSVColumns, error) {
+	var columns CSVColumns
+
+	if strings.TrimSpace(mapping) == "" {
+		return columns, nil
+	}
+
+	fields := map[string]*string{
+		DefaultCSVColumns.BetID:       &columns.BetID,
+		DefaultCSVColumns.UserID:      &columns.UserID,
+		DefaultCSVColumns.Amount:      &columns.Amount,
+		DefaultCSVColumns.Odds:        &columns.Odds,
+		DefaultCSVColumns.Outcome:     &columns.Outcome,
+		DefaultCSVColumns.Timestamp:   &columns.Timestamp,
+		DefaultCSVColumns.Selection:   &columns.Selection,
+		DefaultCSVColumns.Currency:    &columns.Currency,
+		DefaultCSVColumns.Sport:       &columns.Sport,
+		DefaultCSVColumns.Competition: &columns.Competition,
+		DefaultCSVColumns.EventID:     &columns.EventID,
+		DefaultCSVColumns.Market:      &columns.Market,
+	}
+
+	for _, pair := range strings.Split(mapping, ",") {
+		field, header, ok := strings.Cut(pair, "=")
+		if !ok {
+			return columns, fmt.Errorf("invalid column mapping %q: expected field=header", pair)
+		}
+
+		target, known := fields[strings.TrimSpace(field)]
+		if !known {
+			return columns, fmt.Errorf("invalid column mapping %q: unknown field %q", pair, field)
+		}
+
+		*target = strings.TrimSpace(header)
+	}
+
+	return columns, nil
+}
+
+// CSVReader decodes bets from a CSV file with a header row
+type CSVReader struct {
+	reader  *csv.Reader
+	options CSVOptions
+	index   map[string]int
+	line    int
+	records int64
+	// start is the position of the stream handed to the csv reader
+	start Position
+}
+
+// NewCSVReader initializes a new CSVReader and reads the header row
+func NewCSVReader(r io.Reader, options CSVOptions) (*CSVReader, error) {
+	options = options.withDefaults()
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.Comma = options.Delimiter
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		positions[strings.TrimSpace(name)] = i
+	}
+
+	index := map[string]int{}
+
+	for _, column := range []string{
+		options.Columns.BetID, options.Columns.UserID, options.Columns.Amount,
+		options.Columns.Odds, options.Columns.Outcome, options.Columns.Timestamp,
+	} {
+		position, ok := positions[column]
+		if !ok {
+			return nil, fmt.Errorf("CSV header is missing column %q", column)
+		}
+
+		index[column] = position
+	}
+
+	for _, column := range []string{
+		options.Columns.Selection, options.Columns.Currency, options.Columns.Sport, options.Columns.Competition,
+		options.Columns.EventID, options.Columns.Market,
+	} {
+		if position, ok := positions[column]; ok {
+			index[column] = position
+		}
+	}
+
+	return &CSVReader{reader: reader, options: options, index: index, line: 1}, nil
+}
+
+// Read decodes the next bet
+func (r *CSVReader) Read() (*domain.Bet, error) {
+	record, err := r.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	}
+
+	r.line++
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV line %d: %w", r.line, err)
+	}
+
+	field := func(column string) string {
+		position, ok := r.index[column]
+		if !ok {
+			return ""
+		}
+
+		return strings.TrimSpace(record[position])
+	}
+
+	amount, err := domain.ParseMoney(r.normaliseDecimal(field(r.options.Columns.Amount)))
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %w", r.line, err)
+	}
+
+	timestamp, err := r.parseTimestamp(field(r.options.Columns.Timestamp))
+	if err != nil {
+		return nil, fmt.Errorf("line %d: invalid timestamp: %w", r.line, err)
+	}
+
+	bet := &domain.Bet{
+		BetID:       field(r.options.Columns.BetID),
+		UserID:      field(r.options.Columns.UserID),
+		Amount:      amount,
+		Outcome:     enums.Outcome(strings.ToLower(field(r.options.Columns.Outcome))),
+		Timestamp:   timestamp,
+		Sport:       field(r.options.Columns.Sport),
+		Competition: field(r.options.Columns.Competition),
+		EventID:     field(r.options.Columns.EventID),
+		Market:      field(r.options.Columns.Market),
+		Selection:   field(r.options.Columns.Selection),
+		Currency:    domain.Currency(strings.ToUpper(field(r.options.Columns.Currency))),
+	}
+
+	if err := bet.SetOdds(r.normaliseDecimal(field(r.options.Columns.Odds)), r.options.OddsFormat); err != nil {
+		return nil, fmt.Errorf("line %d: %w", r.line, err)
+	}
+
+	// CSV files have no cashout column so cashed out bets are rejected along with invalid outcomes
+	if err := bet.Derive(); err != nil {
+		return nil, fmt.Errorf("line %d: %w", r.line, err)
+	}
+
+	r.records++
+
+	return bet, nil
+}
+
+// Position returns the position just after the last decoded bet
+func (r *CSVReader) Position() Position {
+	return Position{
+		Offset:  r.start.Offset + r.reader.InputOffset(),
+		Records: r.start.Records + r.records,
+	}
+}
+
+// normaliseDecimal rewrites an amount or odds value with a dot as decimal separator.
+// Dots are treated as thousands separators when another decimal separator is configured.
+func (r *CSVReader) normaliseDecimal(value string) string {
+	if r.options.DecimalSeparator != '.' {
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.ReplaceAll(value, string(r.options.DecimalSeparator), ".")
+	}
+
+	return value
+}
+
+func (r *CSVReader) parseTimestamp(value string) (time.Time, error) {
+	var errs []error
+
+	for _, layout := range r.options.TimestampLayouts {
+		timestamp, err := time.Parse(layout, value)
+		if err == nil {
+			return timestamp, nil
+		}
+
+		errs = append(errs, err)
+	}
+
+	return time.Time{}, errors.Join(errs...)
+}
+
+// CSVWriter encodes bets as CSV with a header row
+type CSVWriter struct {
+	writer        *csv.Writer
+	options       CSVOptions
+	headerWritten bool
+}
+
+// NewCSVWriter initializes a new CSVWriter
+func NewCSVWriter(w io.Writer, options CSVOptions) (*CSVWriter, error) {
+	options = options.withDefaults()
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = options.Delimiter
+
+	return &CSVWriter{writer: writer, options: options}, nil
+}
+
+// Write encodes a single bet, preceded by the header row on the first call
+func (w *CSVWriter) Write(bet *domain.Bet) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	record := []string{
+		bet.BetID,
+		bet.UserID,
+		w.formatDecimal(bet.Amount.String()),
+		w.formatDecimal(bet.OddsIn(w.options.OddsFormat)),
+		bet.Outcome.String(),
+		bet.Timestamp.Format(w.options.TimestampLayouts[0]),
+		bet.Selection,
+		string(bet.Currency),
+		bet.Sport,
+		bet.Competition,
+		bet.EventID,
+		bet.Market,
+	}
+
+	if err := w.writer.Write(record); err != nil {
+		return fmt.Errorf("failed to write CSV record: %w", err)
+	}
+
+	return nil
+}
+
+// Flush writes any buffered data, including the header row when no bet was written
+func (w *CSVWriter) Flush() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	w.writer.Flush()
+
+	return w.writer.Error()
+}
+
+func (w *CSVWriter) writeHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+
+	w.headerWritten = true
+
+	columns := w.options.Columns
+
+	err := w.writer.Write([]string{
+		columns.BetID, columns.UserID, columns.Amount, columns.Odds, columns.Outcome, columns.Timestamp, columns.Selection,
+		columns.Currency, columns.Sport, columns.Competition, columns.EventID, columns.Market,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	return nil
+}
+
+// formatDecimal replaces the dot of a formatted amount or odds value with the configured decimal separator
+func (w *CSVWriter) formatDecimal(formatted string) string {
+	if w.options.DecimalSeparator != '.' {
+		formatted = strings.Replace(formatted, ".", string(w.options.DecimalSeparator), 1)
+	}
+
+	return formatted
+}
@@ -0,0 +1,239 @@
+package codec
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+)
+
+func TestCSVReader_Read(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		options      CSVOptions
+		wantAmount   domain.Money
+		wantCurrency domain.Currency
+		wantTime     time.Time
+		wantOdds     float64
+		wantErr      bool
+	}{
+		{
+			name:       "success: canonical layout",
+			input:      "bet_id,user_id,amount,odds,outcome,timestamp\nb1,u1,12.5,2.1,win,2024-11-22T21:16:29Z\n",
+			wantAmount: domain.MoneyFromFloat(12.5),
+			wantTime:   time.Date(2024, 11, 22, 21, 16, 29, 0, time.UTC),
+		},
+		{
+			name:  "success: mapped columns, comma decimals and custom layout",
+			input: "Ref;Customer;Stake;Price;Result;Placed\nb1;u1;1.234,50;2,1;WIN;22/11/2024 21:16\n",
+			options: CSVOptions{
+				Columns: CSVColumns{
+					BetID: "Ref", UserID: "Customer", Amount: "Stake", Odds: "Price", Outcome: "Result", Timestamp: "Placed",
+				},
+				TimestampLayouts: []string{time.RFC3339, "02/01/2006 15:04"},
+				DecimalSeparator: ',',
+				Delimiter:        ';',
+			},
+			wantAmount: domain.MoneyFromFloat(1234.5),
+			wantTime:   time.Date(2024, 11, 22, 21, 16, 0, 0, time.UTC),
+		},
+		{
+			name:         "success: currency column",
+			input:        "bet_id,user_id,amount,odds,outcome,timestamp,currency\nb1,u1,1500,2.1,win,2024-11-22T21:16:29Z,ugx\n",
+			wantAmount:   domain.MoneyFromFloat(1500),
+			wantCurrency: "UGX",
+			wantTime:     time.Date(2024, 11, 22, 21, 16, 29, 0, time.UTC),
+		},
+		{
+			name:       "success: fractional odds",
+			input:      "bet_id,user_id,amount,odds,outcome,timestamp\nb1,u1,12.5,11/10,win,2024-11-22T21:16:29Z\n",
+			wantAmount: domain.MoneyFromFloat(12.5),
+			wantTime:   time.Date(2024, 11, 22, 21, 16, 29, 0, time.UTC),
+			wantOdds:   2.1,
+		},
+		{
+			name:       "success: hong kong odds with comma decimals",
+			input:      "bet_id;user_id;amount;odds;outcome;timestamp\nb1;u1;12,5;1,1;win;2024-11-22T21:16:29Z\n",
+			options:    CSVOptions{DecimalSeparator: ',', Delimiter: ';', OddsFormat: enums.HongKong},
+			wantAmount: domain.MoneyFromFloat(12.5),
+			wantTime:   time.Date(2024, 11, 22, 21, 16, 29, 0, time.UTC),
+			wantOdds:   2.1,
+		},
+		{
+			name:    "fail: american odds between -100 and +100",
+			input:   "bet_id,user_id,amount,odds,outcome,timestamp\nb1,u1,12.5,+50,win,2024-11-22T21:16:29Z\n",
+			wantErr: true,
+		},
+		{
+			name:    "fail: unknown odds format",
+			input:   "bet_id,user_id,amount,odds,outcome,timestamp\n",
+			options: CSVOptions{OddsFormat: "moneyline"},
+			wantErr: true,
+		},
+		{
+			name:    "fail: amount finer than the minor unit of its currency",
+			input:   "bet_id,user_id,amount,odds,outcome,timestamp,currency\nb1,u1,12.5,2.1,win,2024-11-22T21:16:29Z,UGX\n",
+			wantErr: true,
+		},
+		{
+			name:    "fail: amount with more than four decimal places",
+			input:   "bet_id,user_id,amount,odds,outcome,timestamp\nb1,u1,12.00001,2.1,win,2024-11-22T21:16:29Z\n",
+			wantErr: true,
+		},
+		{
+			name:    "fail: missing column",
+			input:   "bet_id,user_id,amount,odds,outcome\nb1,u1,12.5,2.1,win\n",
+			wantErr: true,
+		},
+		{
+			name:    "fail: invalid outcome",
+			input:   "bet_id,user_id,amount,odds,outcome,timestamp\nb1,u1,12.5,2.1,draw,2024-11-22T21:16:29Z\n",
+			wantErr: true,
+		},
+		{
+			name:    "fail: invalid timestamp",
+			input:   "bet_id,user_id,amount,odds,outcome,timestamp\nb1,u1,12.5,2.1,win,yesterday\n",
+			wantErr: true,
+		},
+		{
+			name:    "fail: delimiter equals decimal separator",
+			input:   "bet_id,user_id,amount,odds,outcome,timestamp\n",
+			options: CSVOptions{DecimalSeparator: ','},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewCSVReader(strings.NewReader(tt.input), tt.options)
+			if err == nil {
+				var bet *domain.Bet
+
+				bet, err = reader.Read()
+				if err == nil {
+					if bet.Amount != tt.wantAmount {
+						t.Errorf("CSVReader.Read() amount = %v, want %v", bet.Amount, tt.wantAmount)
+					}
+
+					if bet.Currency != tt.wantCurrency {
+						t.Errorf("CSVReader.Read() currency = %v, want %v", bet.Currency, tt.wantCurrency)
+					}
+
+					if tt.wantOdds != 0 && bet.Odds != tt.wantOdds {
+						t.Errorf("CSVReader.Read() odds = %v, want %v", bet.Odds, tt.wantOdds)
+					}
+
+					if !bet.Timestamp.Equal(tt.wantTime) {
+						t.Errorf("CSVReader.Read() timestamp = %v, want %v", bet.Timestamp, tt.wantTime)
+					}
+
+					if _, eofErr := reader.Read(); !errors.Is(eofErr, io.EOF) {
+						t.Errorf("CSVReader.Read() error = %v, want EOF", eofErr)
+					}
+				}
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CSVReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCSVWriter_RoundTrip(t *testing.T) {
+	bets := []*domain.Bet{
+		{BetID: "b1", UserID: "u1", Amount: domain.MoneyFromFloat(10.25), Odds: 1.5, OddsFormat: enums.Decimal, Outcome: enums.Win, Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{BetID: "b2", UserID: "u2", Amount: domain.MoneyFromFloat(99), Odds: 3.75, OddsFormat: enums.Decimal, Outcome: enums.Lose, Timestamp: time.Date(2024, 1, 3, 3, 4, 5, 0, time.UTC)},
+		{BetID: "b3", UserID: "u2", Amount: domain.MoneyFromFloat(5), Odds: 2, OddsFormat: enums.Decimal, Outcome: enums.Pending, Timestamp: time.Date(2024, 1, 4, 3, 4, 5, 0, time.UTC), Selection: "ars-che-home", Currency: "KES",
+			Sport: "football", Competition: "Premier League", EventID: "ars-che", Market: "Match Result"},
+	}
+
+	options := CSVOptions{DecimalSeparator: ',', Delimiter: ';'}
+
+	var buf bytes.Buffer
+
+	writer, err := NewCSVWriter(&buf, options)
+	if err != nil {
+		t.Fatalf("NewCSVWriter() error = %v", err)
+	}
+
+	for _, bet := range bets {
+		if err := writer.Write(bet); err != nil {
+			t.Fatalf("CSVWriter.Write() error = %v", err)
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("CSVWriter.Flush() error = %v", err)
+	}
+
+	reader, err := NewCSVReader(&buf, options)
+	if err != nil {
+		t.Fatalf("NewCSVReader() error = %v", err)
+	}
+
+	got, err := ReadAll(reader)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+
+	if len(got) != len(bets) {
+		t.Fatalf("ReadAll() = %v bets, want %v", len(got), len(bets))
+	}
+
+	for i := range bets {
+		if !reflect.DeepEqual(got[i], bets[i]) {
+			t.Errorf("round trip bet %d = %+v, want %+v", i, *got[i], *bets[i])
+		}
+	}
+}
+
+func TestParseCSVColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping string
+		want    CSVColumns
+		wantErr bool
+	}{
+		{
+			name:    "success: partial mapping",
+			mapping: "bet_id=Ref, user_id = Customer",
+			want:    CSVColumns{BetID: "Ref", UserID: "Customer"},
+		},
+		{
+			name:    "success: empty mapping",
+			mapping: "",
+		},
+		{
+			name:    "fail: unknown field",
+			mapping: "stake=Stake",
+			wantErr: true,
+		},
+		{
+			name:    "fail: missing header",
+			mapping: "bet_id",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCSVColumns(tt.mapping)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCSVColumns() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseCSVColumns() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
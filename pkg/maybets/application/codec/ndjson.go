@@ -0,0 +1,94 @@
+package codec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+)
+
+// NDJSONReader decodes newline delimited JSON bets
+type NDJSONReader struct {
+	decoder *json.Decoder
+	line    int
+	// start is the position of the stream handed to the decoder
+	start Position
+}
+
+// NewNDJSONReader initializes a new NDJSONReader
+func NewNDJSONReader(r io.Reader) *NDJSONReader {
+	return &NDJSONReader{decoder: json.NewDecoder(r)}
+}
+
+// Read decodes the next bet
+func (r *NDJSONReader) Read() (*domain.Bet, error) {
+	var bet domain.Bet
+
+	if err := r.decoder.Decode(&bet); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+
+		return nil, fmt.Errorf("failed to decode JSON record %d: %w", r.start.Records+int64(r.line)+1, err)
+	}
+
+	r.line++
+
+	if err := bet.Derive(); err != nil {
+		return nil, fmt.Errorf("record %d: %w", r.start.Records+int64(r.line), err)
+	}
+
+	return &bet, nil
+}
+
+// Position returns the position just after the last decoded bet
+func (r *NDJSONReader) Position() Position {
+	return Position{
+		Offset:  r.start.Offset + r.decoder.InputOffset(),
+		Records: r.start.Records + int64(r.line),
+	}
+}
+
+// UnmarshalBet decodes a single JSON encoded bet, as carried by the streaming transports.
+// The odds and outcome of a multi-leg bet are derived from its legs.
+func UnmarshalBet(data []byte) (*domain.Bet, error) {
+	var bet domain.Bet
+
+	if err := json.Unmarshal(data, &bet); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON bet: %w", err)
+	}
+
+	if err := bet.Derive(); err != nil {
+		return nil, err
+	}
+
+	return &bet, nil
+}
+
+// NDJSONWriter encodes bets as newline delimited JSON
+type NDJSONWriter struct {
+	encoder *json.Encoder
+	odds    enums.OddsFormat
+}
+
+// NewNDJSONWriter initializes a new NDJSONWriter that quotes odds in the given format, decimal when it is empty
+func NewNDJSONWriter(w io.Writer, odds enums.OddsFormat) *NDJSONWriter {
+	return &NDJSONWriter{encoder: json.NewEncoder(w), odds: odds}
+}
+
+// Write encodes a single bet on its own line
+func (w *NDJSONWriter) Write(bet *domain.Bet) error {
+	if err := w.encoder.Encode(bet.Quoted(w.odds)); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	return nil
+}
+
+// Flush is a no-op since every record is written straight to the underlying writer
+func (w *NDJSONWriter) Flush() error {
+	return nil
+}
@@ -0,0 +1,58 @@
+package codec
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+)
+
+func TestNDJSONReader_Read(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantLegs    int
+		wantOdds    float64
+		wantOutcome enums.Outcome
+		wantErr     bool
+	}{
+		{
+			name:        "success: single bet",
+			input:       `{"bet_id":"b1","user_id":"u1","amount":10,"odds":2.5,"outcome":"win","timestamp":"2024-11-22T21:16:29Z"}`,
+			wantOdds:    2.5,
+			wantOutcome: enums.Win,
+		},
+		{
+			name: "success: multi-leg bet without odds or outcome",
+			input: `{"bet_id":"b2","user_id":"u1","amount":10,"timestamp":"2024-11-22T21:16:29Z","legs":[` +
+				`{"selection":"home","odds":2,"outcome":"win"},{"selection":"btts","odds":1.5,"outcome":"lose"}]}`,
+			wantLegs:    2,
+			wantOdds:    3,
+			wantOutcome: enums.Lose,
+		},
+		{
+			name: "fail: multi-leg bet with an invalid leg",
+			input: `{"bet_id":"b3","user_id":"u1","amount":10,"timestamp":"2024-11-22T21:16:29Z","legs":[` +
+				`{"selection":"home","odds":2,"outcome":"cashout"}]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bet, err := NewNDJSONReader(strings.NewReader(tt.input)).Read()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NDJSONReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if len(bet.Legs) != tt.wantLegs || bet.Odds != tt.wantOdds || bet.Outcome != tt.wantOutcome {
+				t.Errorf("NDJSONReader.Read() = %+v, want %d legs with odds %v and outcome %v",
+					bet, tt.wantLegs, tt.wantOdds, tt.wantOutcome)
+			}
+		})
+	}
+}
@@ -0,0 +1,848 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/helpers"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+	"github.com/go-redis/redis"
+	"gopkg.in/yaml.v3"
+)
+
+// sqliteFileName is the name of the database file created in the SQLITE_URL directory
+const sqliteFileName = "bets.db"
+
+// Config holds all the settings needed to run the service.
+// Values are loaded from a YAML file, then overridden by the environment and finally by CLI flags.
+type Config struct {
+	Environment enums.Environment     `yaml:"environment"`
+	Port        int                   `yaml:"port"`
+	GRPCPort    int                   `yaml:"grpc_port"`
+	LogLevel    string                `yaml:"log_level,omitempty"`
+	SQLite      SQLiteConfig          `yaml:"sqlite"`
+	Redis       RedisConfig           `yaml:"redis"`
+	Kafka       KafkaConfig           `yaml:"kafka"`
+	Webhooks    WebhookConfig         `yaml:"webhooks"`
+	Limits      LimitsConfig          `yaml:"limits"`
+	AML         AMLConfig             `yaml:"aml"`
+	Linked      LinkedAccountsConfig  `yaml:"linked_accounts"`
+	Live        LiveConfig            `yaml:"live"`
+	GraphQL     GraphQLConfig         `yaml:"graphql"`
+	Money       MoneyConfig           `yaml:"money"`
+	Tracing     helpers.TracingConfig `yaml:"tracing"`
+}
+
+// SQLiteConfig holds the database settings
+type SQLiteConfig struct {
+	// Path is the location of the SQLite database file
+	Path string `yaml:"path"`
+}
+
+// RedisConfig holds the cache settings
+type RedisConfig struct {
+	// URL is the redis connection URL e.g redis://:password@localhost:6379/0
+	URL string `yaml:"url"`
+	// Stream configures the consumption of bets published on a redis stream
+	Stream RedisStreamConfig `yaml:"stream"`
+}
+
+// RedisStreamConfig holds the redis stream consumer settings
+type RedisStreamConfig struct {
+	// Name is the key of the stream bets are published on
+	Name string `yaml:"name"`
+	// Group is the consumer group shared by every consumer instance
+	Group string `yaml:"group"`
+	// Consumer identifies this instance within the group, defaults to the host name
+	Consumer string `yaml:"consumer,omitempty"`
+	// DeadLetter is the key of the stream receiving the messages that cannot be stored
+	DeadLetter string `yaml:"dead_letter"`
+	// BatchSize is the maximum number of bets stored together
+	BatchSize int `yaml:"batch_size"`
+	// BatchWait is how long a read waits for new messages
+	BatchWait time.Duration `yaml:"batch_wait"`
+	// MaxDeliveries is the number of attempts before a message is dead lettered
+	MaxDeliveries int64 `yaml:"max_deliveries"`
+	// RetryIdle is how long a message stays unacknowledged before it is attempted again
+	RetryIdle time.Duration `yaml:"retry_idle"`
+}
+
+// KafkaConfig holds the kafka consumer settings
+type KafkaConfig struct {
+	// Brokers are the addresses used to bootstrap the connection to the cluster
+	Brokers []string `yaml:"brokers"`
+	// Topic is the topic bets are published on
+	Topic string `yaml:"topic"`
+	// Group is the consumer group shared by every consumer instance
+	Group string `yaml:"group"`
+	// DeadLetter is the topic receiving the messages that cannot be stored
+	DeadLetter string `yaml:"dead_letter"`
+	// BatchSize is the maximum number of bets stored together
+	BatchSize int `yaml:"batch_size"`
+	// BatchWait is how long a batch waits to fill up once its first message has arrived
+	BatchWait time.Duration `yaml:"batch_wait"`
+	// MaxAttempts is the number of attempts before a message is dead lettered
+	MaxAttempts int `yaml:"max_attempts"`
+	// RetryBackoff is the wait before the first retry of a message, doubled on every further attempt
+	RetryBackoff time.Duration `yaml:"retry_backoff"`
+	// StatsInterval is how often the consumer lag is logged
+	StatsInterval time.Duration `yaml:"stats_interval"`
+}
+
+// WebhookConfig holds the alert thresholds and the webhook delivery settings
+type WebhookConfig struct {
+	// LargeBetAmount is the amount, in the reporting currency, from which a single bet raises an alert, zero disables the alert
+	LargeBetAmount float64 `yaml:"large_bet_amount"`
+	// LossLimit is the total amount lost, in the reporting currency, from which a user raises an alert, zero disables the alert
+	LossLimit float64 `yaml:"loss_limit"`
+	// AnomalyInterval is how often anomalous users are looked for
+	AnomalyInterval time.Duration `yaml:"anomaly_interval"`
+	// PollInterval is how often the deliveries that are due are sent
+	PollInterval time.Duration `yaml:"poll_interval"`
+	// Timeout bounds every delivery attempt
+	Timeout time.Duration `yaml:"timeout"`
+	// MaxAttempts is the number of attempts before a delivery is dead lettered
+	MaxAttempts int `yaml:"max_attempts"`
+	// InitialBackoff is the wait before the first retry of a delivery, doubled on every further attempt
+	InitialBackoff time.Duration `yaml:"initial_backoff"`
+	// MaxBackoff caps the wait between two attempts
+	MaxBackoff time.Duration `yaml:"max_backoff"`
+}
+
+// LiveConfig holds the settings of the live feed
+type LiveConfig struct {
+	// Buffer is the number of events held for every client, further events are dropped until it catches up
+	Buffer int `yaml:"buffer"`
+	// LeaderboardInterval is how often the leaderboard is checked for changes
+	LeaderboardInterval time.Duration `yaml:"leaderboard_interval"`
+	// ExposureInterval is how often the exposure of the pending bets is checked for changes
+	ExposureInterval time.Duration `yaml:"exposure_interval"`
+	// Heartbeat is how often idle connections are pinged
+	Heartbeat time.Duration `yaml:"heartbeat"`
+	// WriteTimeout bounds every write to a client, clients that cannot keep up are disconnected
+	WriteTimeout time.Duration `yaml:"write_timeout"`
+}
+
+// LimitsConfig holds the responsible gambling limits every user is held to and how breaches are handled
+type LimitsConfig struct {
+	// Day, Week and Month cap what every user may lose, stake and bet over the last 24 hours, 7 days and 30 days.
+	// Users held to tighter caps of their own are held to those instead.
+	Day   PeriodLimitsConfig `yaml:"day"`
+	Week  PeriodLimitsConfig `yaml:"week"`
+	Month PeriodLimitsConfig `yaml:"month"`
+	// SessionLength is the longest a betting session may last, zero disables the check
+	SessionLength time.Duration `yaml:"session_length"`
+	// SessionGap is the pause between two bets that ends a session
+	SessionGap time.Duration `yaml:"session_gap"`
+	// StakeTrendBets is the number of latest bets whose average stake is compared with that of as many bets before them
+	StakeTrendBets int `yaml:"stake_trend_bets"`
+	// StakeTrendFactor is how many times the earlier average stake the latest may reach, zero disables the check
+	StakeTrendFactor float64 `yaml:"stake_trend_factor"`
+	// Reject turns down the bets sent to the HTTP ingest endpoint that would breach a cap, rather than only raising alerts
+	Reject bool `yaml:"reject"`
+	// ExclusionMode is whether the bets of self-excluded users are stored and flagged or turned down, on every ingest path
+	ExclusionMode enums.ExclusionMode `yaml:"exclusion_mode"`
+}
+
+// PeriodLimitsConfig caps what a user may lose and stake, in the reporting currency, and bet over a period.
+// Zero disables a cap.
+type PeriodLimitsConfig struct {
+	LossCap  float64 `yaml:"loss_cap"`
+	StakeCap float64 `yaml:"stake_cap"`
+	BetCap   int64   `yaml:"bet_cap"`
+}
+
+// AMLConfig holds the anti-money laundering rules bets are screened against and how often they are run
+type AMLConfig struct {
+	// LargeTransactionAmount is the amount, in the reporting currency, from which a single bet is reported.
+	// Zero disables the rules.
+	LargeTransactionAmount float64 `yaml:"large_transaction_amount"`
+	// StructuringMargin is how far below the large transaction amount, as a fraction of it, a bet counts as just below it
+	StructuringMargin float64 `yaml:"structuring_margin"`
+	// StructuringBets is the number of bets just below the large transaction amount a user must place
+	// within StructuringWindow to be reported
+	StructuringBets int `yaml:"structuring_bets"`
+	// StructuringWindow is the longest time the reported bets of a user may span
+	StructuringWindow time.Duration `yaml:"structuring_window"`
+	// Interval is how often the bets placed over the lookback are screened and alerts raised for new findings,
+	// zero disables the scheduled screening
+	Interval time.Duration `yaml:"interval"`
+	// Lookback is how far back the scheduled screening reads bets
+	Lookback time.Duration `yaml:"lookback"`
+}
+
+// LinkedAccountsConfig holds the rules users are linked by when they bet the same selections at the same moments
+type LinkedAccountsConfig struct {
+	// Window is the longest time between two bets on the same selection at the same odds for them to be shared
+	Window time.Duration `yaml:"window"`
+	// MinEvents is the number of events two users must share bets on to be linked
+	MinEvents int `yaml:"min_events"`
+	// Lookback is how far back before their end the reports asked for over the API read bets when they give no start
+	Lookback time.Duration `yaml:"lookback"`
+}
+
+// GraphQLConfig holds the limits applied to GraphQL queries
+type GraphQLConfig struct {
+	// MaxDepth is how deeply selections may be nested
+	MaxDepth int `yaml:"max_depth"`
+	// MaxComplexity caps the estimated cost of a query: every field costs 1, multiplied by the size of the lists above it
+	MaxComplexity int `yaml:"max_complexity"`
+}
+
+// MoneyConfig holds the currencies bets are accepted in and the currency amounts are reported in
+type MoneyConfig struct {
+	// ReportingCurrency is the ISO 4217 code of the currency totals and alert thresholds are expressed in
+	ReportingCurrency string `yaml:"reporting_currency"`
+	// DefaultCurrency is the currency of the bets that do not name one
+	DefaultCurrency string `yaml:"default_currency"`
+	// Rates holds the number of units of the reporting currency one unit of every other accepted currency is worth,
+	// as decimal numbers. Bets in currencies without a rate are rejected.
+	Rates map[string]string `yaml:"rates,omitempty"`
+}
+
+// Default returns the configuration used when nothing is overridden
+func Default() *Config {
+	return &Config{
+		Environment: enums.Local,
+		Port:        8080,
+		GRPCPort:    9090,
+		SQLite: SQLiteConfig{
+			Path: sqliteFileName,
+		},
+		Redis: RedisConfig{
+			URL: "redis://localhost:6379/0",
+			Stream: RedisStreamConfig{
+				Name:          "bets",
+				Group:         "maybets",
+				DeadLetter:    "bets:dead-letter",
+				BatchSize:     500,
+				BatchWait:     time.Second,
+				MaxDeliveries: 5,
+				RetryIdle:     30 * time.Second,
+			},
+		},
+		Kafka: KafkaConfig{
+			Brokers:       []string{"localhost:9092"},
+			Topic:         "bets",
+			Group:         "maybets",
+			DeadLetter:    "bets.dead-letter",
+			BatchSize:     500,
+			BatchWait:     time.Second,
+			MaxAttempts:   5,
+			RetryBackoff:  time.Second,
+			StatsInterval: 30 * time.Second,
+		},
+		Webhooks: WebhookConfig{
+			LargeBetAmount:  1000,
+			LossLimit:       5000,
+			AnomalyInterval: time.Minute,
+			PollInterval:    5 * time.Second,
+			Timeout:         10 * time.Second,
+			MaxAttempts:     8,
+			InitialBackoff:  30 * time.Second,
+			MaxBackoff:      time.Hour,
+		},
+		Limits: LimitsConfig{
+			SessionGap:     30 * time.Minute,
+			StakeTrendBets: 10,
+			ExclusionMode:  enums.Reject,
+		},
+		AML: AMLConfig{
+			LargeTransactionAmount: 10000,
+			StructuringMargin:      0.1,
+			StructuringBets:        3,
+			StructuringWindow:      24 * time.Hour,
+			Interval:               time.Hour,
+			Lookback:               48 * time.Hour,
+		},
+		Linked: LinkedAccountsConfig{
+			Window:    10 * time.Second,
+			MinEvents: 3,
+			Lookback:  7 * 24 * time.Hour,
+		},
+		Live: LiveConfig{
+			Buffer:              1024,
+			LeaderboardInterval: 5 * time.Second,
+			ExposureInterval:    5 * time.Second,
+			Heartbeat:           15 * time.Second,
+			WriteTimeout:        10 * time.Second,
+		},
+		GraphQL: GraphQLConfig{
+			MaxDepth:      8,
+			MaxComplexity: 20000,
+		},
+		Money: MoneyConfig{
+			ReportingCurrency: "USD",
+			DefaultCurrency:   "USD",
+		},
+		Tracing: helpers.TracingConfig{
+			Exporter:    enums.None,
+			SampleRatio: 1,
+		},
+	}
+}
+
+// Load builds the configuration from the defaults, the YAML file at path (if any) and the environment.
+// CLI flags should be applied on the returned value before calling Validate.
+func Load(path string) (*Config, error) {
+	config := Default()
+
+	if path != "" {
+		if err := config.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := config.loadEnv(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(c); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func (c *Config) loadEnv() error {
+	if value, ok := os.LookupEnv("ENVIRONMENT"); ok {
+		c.Environment = enums.Environment(value)
+	}
+
+	if value, ok := os.LookupEnv("PORT"); ok {
+		port, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid PORT value %q: %w", value, err)
+		}
+
+		c.Port = port
+	}
+
+	if value, ok := os.LookupEnv("GRPC_PORT"); ok {
+		port, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid GRPC_PORT value %q: %w", value, err)
+		}
+
+		c.GRPCPort = port
+	}
+
+	if value, ok := os.LookupEnv("LOG_LEVEL"); ok {
+		c.LogLevel = value
+	}
+
+	if value, ok := os.LookupEnv("SQLITE_URL"); ok {
+		c.SQLite.Path = filepath.Join(value, sqliteFileName)
+	}
+
+	if value, ok := os.LookupEnv("REDIS_URL"); ok {
+		c.Redis.URL = value
+	}
+
+	if value, ok := os.LookupEnv("REDIS_STREAM"); ok {
+		c.Redis.Stream.Name = value
+	}
+
+	if value, ok := os.LookupEnv("REDIS_STREAM_GROUP"); ok {
+		c.Redis.Stream.Group = value
+	}
+
+	if value, ok := os.LookupEnv("REDIS_STREAM_CONSUMER"); ok {
+		c.Redis.Stream.Consumer = value
+	}
+
+	if value, ok := os.LookupEnv("REDIS_STREAM_DEAD_LETTER"); ok {
+		c.Redis.Stream.DeadLetter = value
+	}
+
+	if value, ok := os.LookupEnv("KAFKA_BROKERS"); ok {
+		c.Kafka.Brokers = strings.Split(value, ",")
+	}
+
+	if value, ok := os.LookupEnv("KAFKA_TOPIC"); ok {
+		c.Kafka.Topic = value
+	}
+
+	if value, ok := os.LookupEnv("KAFKA_GROUP"); ok {
+		c.Kafka.Group = value
+	}
+
+	if value, ok := os.LookupEnv("KAFKA_DEAD_LETTER"); ok {
+		c.Kafka.DeadLetter = value
+	}
+
+	if value, ok := os.LookupEnv("WEBHOOK_LARGE_BET_AMOUNT"); ok {
+		amount, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid WEBHOOK_LARGE_BET_AMOUNT value %q: %w", value, err)
+		}
+
+		c.Webhooks.LargeBetAmount = amount
+	}
+
+	if value, ok := os.LookupEnv("WEBHOOK_LOSS_LIMIT"); ok {
+		limit, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid WEBHOOK_LOSS_LIMIT value %q: %w", value, err)
+		}
+
+		c.Webhooks.LossLimit = limit
+	}
+
+	if value, ok := os.LookupEnv("REPORTING_CURRENCY"); ok {
+		c.Money.ReportingCurrency = value
+	}
+
+	if value, ok := os.LookupEnv("DEFAULT_CURRENCY"); ok {
+		c.Money.DefaultCurrency = value
+	}
+
+	if value, ok := os.LookupEnv("TRACING_EXPORTER"); ok {
+		c.Tracing.Exporter = enums.TraceExporter(value)
+	}
+
+	if value, ok := os.LookupEnv("JAEGER_ENDPOINT"); ok {
+		c.Tracing.Endpoint = value
+	}
+
+	if value, ok := os.LookupEnv("TRACING_INSECURE"); ok {
+		insecure, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid TRACING_INSECURE value %q: %w", value, err)
+		}
+
+		c.Tracing.Insecure = insecure
+	}
+
+	if value, ok := os.LookupEnv("TRACING_SAMPLE_RATIO"); ok {
+		ratio, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid TRACING_SAMPLE_RATIO value %q: %w", value, err)
+		}
+
+		c.Tracing.SampleRatio = ratio
+	}
+
+	return nil
+}
+
+// Validate checks that every setting holds a usable value.
+// All problems are reported at once so they can be fixed in one go.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if !c.Environment.IsValid() {
+		errs = append(errs, fmt.Errorf("environment: invalid value %q: must be one of %s, %s, %s or %s",
+			c.Environment, enums.Prod, enums.Staging, enums.Test, enums.Local))
+	}
+
+	if c.Port < 1 || c.Port > 65535 {
+		errs = append(errs, fmt.Errorf("port: invalid value %d: must be between 1 and 65535", c.Port))
+	}
+
+	if c.GRPCPort < 1 || c.GRPCPort > 65535 || c.GRPCPort == c.Port {
+		errs = append(errs, fmt.Errorf("grpc_port: invalid value %d: must be between 1 and 65535 and differ from port", c.GRPCPort))
+	}
+
+	if c.LogLevel != "" {
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+			errs = append(errs, fmt.Errorf("log_level: %w", err))
+		}
+	}
+
+	if c.SQLite.Path == "" {
+		errs = append(errs, errors.New("sqlite.path: must not be empty"))
+	}
+
+	if _, err := redis.ParseURL(c.Redis.URL); err != nil {
+		errs = append(errs, fmt.Errorf("redis.url: %w", err))
+	}
+
+	errs = append(errs, c.Redis.Stream.validate()...)
+	errs = append(errs, c.Kafka.validate()...)
+	errs = append(errs, c.Webhooks.validate()...)
+	errs = append(errs, c.Limits.validate()...)
+	errs = append(errs, c.AML.validate()...)
+
+	if err := c.Linked.Rules().Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("linked_accounts: %w", err))
+	}
+
+	if c.Linked.Lookback <= 0 {
+		errs = append(errs, fmt.Errorf("linked_accounts.lookback: invalid value %v: must be positive", c.Linked.Lookback))
+	}
+
+	errs = append(errs, c.Live.validate()...)
+	errs = append(errs, c.GraphQL.validate()...)
+
+	if _, err := c.Money.Exchange(); err != nil {
+		errs = append(errs, fmt.Errorf("money: %w", err))
+	}
+
+	if err := c.Tracing.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("tracing: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// validate reports every invalid stream consumer setting
+func (c RedisStreamConfig) validate() []error {
+	var errs []error
+
+	if c.Name == "" {
+		errs = append(errs, errors.New("redis.stream.name: must not be empty"))
+	}
+
+	if c.Group == "" {
+		errs = append(errs, errors.New("redis.stream.group: must not be empty"))
+	}
+
+	if c.DeadLetter == "" || c.DeadLetter == c.Name {
+		errs = append(errs, fmt.Errorf("redis.stream.dead_letter: invalid value %q: must be set and differ from the stream name",
+			c.DeadLetter))
+	}
+
+	if c.BatchSize < 1 {
+		errs = append(errs, fmt.Errorf("redis.stream.batch_size: invalid value %d: must be positive", c.BatchSize))
+	}
+
+	if c.BatchWait <= 0 {
+		errs = append(errs, fmt.Errorf("redis.stream.batch_wait: invalid value %v: must be positive", c.BatchWait))
+	}
+
+	if c.MaxDeliveries < 1 {
+		errs = append(errs, fmt.Errorf("redis.stream.max_deliveries: invalid value %d: must be positive", c.MaxDeliveries))
+	}
+
+	if c.RetryIdle <= 0 {
+		errs = append(errs, fmt.Errorf("redis.stream.retry_idle: invalid value %v: must be positive", c.RetryIdle))
+	}
+
+	return errs
+}
+
+// validate reports every invalid kafka consumer setting
+func (c KafkaConfig) validate() []error {
+	var errs []error
+
+	if len(c.Brokers) == 0 || slices.Contains(c.Brokers, "") {
+		errs = append(errs, fmt.Errorf("kafka.brokers: invalid value %q: must list at least one address", c.Brokers))
+	}
+
+	if c.Topic == "" {
+		errs = append(errs, errors.New("kafka.topic: must not be empty"))
+	}
+
+	if c.Group == "" {
+		errs = append(errs, errors.New("kafka.group: must not be empty"))
+	}
+
+	if c.DeadLetter == "" || c.DeadLetter == c.Topic {
+		errs = append(errs, fmt.Errorf("kafka.dead_letter: invalid value %q: must be set and differ from the topic",
+			c.DeadLetter))
+	}
+
+	if c.BatchSize < 1 {
+		errs = append(errs, fmt.Errorf("kafka.batch_size: invalid value %d: must be positive", c.BatchSize))
+	}
+
+	if c.BatchWait <= 0 {
+		errs = append(errs, fmt.Errorf("kafka.batch_wait: invalid value %v: must be positive", c.BatchWait))
+	}
+
+	if c.MaxAttempts < 1 {
+		errs = append(errs, fmt.Errorf("kafka.max_attempts: invalid value %d: must be positive", c.MaxAttempts))
+	}
+
+	if c.RetryBackoff < 0 {
+		errs = append(errs, fmt.Errorf("kafka.retry_backoff: invalid value %v: must not be negative", c.RetryBackoff))
+	}
+
+	if c.StatsInterval <= 0 {
+		errs = append(errs, fmt.Errorf("kafka.stats_interval: invalid value %v: must be positive", c.StatsInterval))
+	}
+
+	return errs
+}
+
+// validate reports every invalid alert threshold and webhook delivery setting
+func (c WebhookConfig) validate() []error {
+	var errs []error
+
+	if c.LargeBetAmount < 0 {
+		errs = append(errs, fmt.Errorf("webhooks.large_bet_amount: invalid value %v: must not be negative", c.LargeBetAmount))
+	}
+
+	if c.LossLimit < 0 {
+		errs = append(errs, fmt.Errorf("webhooks.loss_limit: invalid value %v: must not be negative", c.LossLimit))
+	}
+
+	for _, setting := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"anomaly_interval", c.AnomalyInterval},
+		{"poll_interval", c.PollInterval},
+		{"timeout", c.Timeout},
+		{"initial_backoff", c.InitialBackoff},
+	} {
+		if setting.value <= 0 {
+			errs = append(errs, fmt.Errorf("webhooks.%s: invalid value %v: must be positive", setting.name, setting.value))
+		}
+	}
+
+	if c.MaxBackoff < c.InitialBackoff {
+		errs = append(errs, fmt.Errorf("webhooks.max_backoff: invalid value %v: must not be shorter than initial_backoff",
+			c.MaxBackoff))
+	}
+
+	if c.MaxAttempts < 1 {
+		errs = append(errs, fmt.Errorf("webhooks.max_attempts: invalid value %d: must be positive", c.MaxAttempts))
+	}
+
+	return errs
+}
+
+// validate reports every invalid responsible gambling limit
+func (c LimitsConfig) validate() []error {
+	var errs []error
+
+	for _, period := range []struct {
+		name   string
+		limits PeriodLimitsConfig
+	}{
+		{"day", c.Day},
+		{"week", c.Week},
+		{"month", c.Month},
+	} {
+		if period.limits.LossCap < 0 || period.limits.StakeCap < 0 || period.limits.BetCap < 0 {
+			errs = append(errs, fmt.Errorf("limits.%s: invalid caps %+v: must not be negative", period.name, period.limits))
+		}
+	}
+
+	if c.SessionLength < 0 {
+		errs = append(errs, fmt.Errorf("limits.session_length: invalid value %v: must not be negative", c.SessionLength))
+	}
+
+	if c.SessionGap <= 0 {
+		errs = append(errs, fmt.Errorf("limits.session_gap: invalid value %v: must be positive", c.SessionGap))
+	}
+
+	if c.StakeTrendBets < 1 {
+		errs = append(errs, fmt.Errorf("limits.stake_trend_bets: invalid value %d: must be positive", c.StakeTrendBets))
+	}
+
+	if c.StakeTrendFactor < 0 {
+		errs = append(errs, fmt.Errorf("limits.stake_trend_factor: invalid value %v: must not be negative", c.StakeTrendFactor))
+	}
+
+	if !c.ExclusionMode.IsValid() {
+		errs = append(errs, fmt.Errorf("limits.exclusion_mode: invalid value %q: must be one of %v", c.ExclusionMode, enums.ExclusionModes))
+	}
+
+	return errs
+}
+
+// Policy builds the limits every user is held to
+func (c LimitsConfig) Policy() domain.LimitPolicy {
+	defaults := make(map[enums.LimitPeriod]domain.Limits, len(enums.LimitPeriods))
+
+	for period, limits := range map[enums.LimitPeriod]PeriodLimitsConfig{enums.Day: c.Day, enums.Week: c.Week, enums.Month: c.Month} {
+		defaults[period] = domain.Limits{
+			Period:   period,
+			LossCap:  domain.MoneyFromFloat(limits.LossCap),
+			StakeCap: domain.MoneyFromFloat(limits.StakeCap),
+			BetCap:   limits.BetCap,
+		}
+	}
+
+	return domain.LimitPolicy{
+		Defaults:         defaults,
+		SessionLength:    c.SessionLength,
+		SessionGap:       c.SessionGap,
+		StakeTrendBets:   c.StakeTrendBets,
+		StakeTrendFactor: c.StakeTrendFactor,
+	}
+}
+
+// validate reports every invalid anti-money laundering setting
+func (c AMLConfig) validate() []error {
+	var errs []error
+
+	if c.LargeTransactionAmount < 0 {
+		errs = append(errs, fmt.Errorf("aml.large_transaction_amount: invalid value %v: must not be negative", c.LargeTransactionAmount))
+	}
+
+	if c.StructuringMargin <= 0 || c.StructuringMargin >= 1 {
+		errs = append(errs, fmt.Errorf("aml.structuring_margin: invalid value %v: must be between 0 and 1", c.StructuringMargin))
+	}
+
+	if c.StructuringBets < 2 {
+		errs = append(errs, fmt.Errorf("aml.structuring_bets: invalid value %d: must be at least 2", c.StructuringBets))
+	}
+
+	if c.StructuringWindow <= 0 {
+		errs = append(errs, fmt.Errorf("aml.structuring_window: invalid value %v: must be positive", c.StructuringWindow))
+	}
+
+	if c.Interval < 0 {
+		errs = append(errs, fmt.Errorf("aml.interval: invalid value %v: must not be negative", c.Interval))
+	}
+
+	if c.Lookback < c.StructuringWindow {
+		errs = append(errs, fmt.Errorf("aml.lookback: invalid value %v: must not be shorter than structuring_window", c.Lookback))
+	}
+
+	return errs
+}
+
+// Rules builds the anti-money laundering rules bets are screened against
+func (c AMLConfig) Rules() domain.AMLRules {
+	return domain.AMLRules{
+		Threshold:         domain.MoneyFromFloat(c.LargeTransactionAmount),
+		StructuringMargin: c.StructuringMargin,
+		StructuringBets:   c.StructuringBets,
+		StructuringWindow: c.StructuringWindow,
+	}
+}
+
+// Rules builds the rules users are linked by
+func (c LinkedAccountsConfig) Rules() domain.LinkRules {
+	return domain.LinkRules{Window: c.Window, MinEvents: c.MinEvents}
+}
+
+// validate reports every invalid live feed setting
+func (c LiveConfig) validate() []error {
+	var errs []error
+
+	if c.Buffer < 1 {
+		errs = append(errs, fmt.Errorf("live.buffer: invalid value %d: must be positive", c.Buffer))
+	}
+
+	for _, setting := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"leaderboard_interval", c.LeaderboardInterval},
+		{"exposure_interval", c.ExposureInterval},
+		{"heartbeat", c.Heartbeat},
+		{"write_timeout", c.WriteTimeout},
+	} {
+		if setting.value <= 0 {
+			errs = append(errs, fmt.Errorf("live.%s: invalid value %v: must be positive", setting.name, setting.value))
+		}
+	}
+
+	return errs
+}
+
+// validate reports every invalid GraphQL query limit
+func (c GraphQLConfig) validate() []error {
+	var errs []error
+
+	if c.MaxDepth < 1 {
+		errs = append(errs, fmt.Errorf("graphql.max_depth: invalid value %d: must be positive", c.MaxDepth))
+	}
+
+	if c.MaxComplexity < 1 {
+		errs = append(errs, fmt.Errorf("graphql.max_complexity: invalid value %d: must be positive", c.MaxComplexity))
+	}
+
+	return errs
+}
+
+// Exchange builds the exchange converting the accepted currencies into the reporting currency
+func (c MoneyConfig) Exchange() (*domain.Exchange, error) {
+	rates := make(map[domain.Currency]string, len(c.Rates))
+	for currency, rate := range c.Rates {
+		rates[domain.Currency(currency)] = rate
+	}
+
+	return domain.NewExchange(domain.Currency(c.ReportingCurrency), domain.Currency(c.DefaultCurrency), rates)
+}
+
+// ConsumerName returns the name this instance uses in the stream consumer group
+func (c RedisStreamConfig) ConsumerName() string {
+	if c.Consumer != "" {
+		return c.Consumer
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "maybets"
+	}
+
+	return hostname
+}
+
+// ServiceName is the name the service reports itself as to the tracing backend
+func (c *Config) ServiceName() string {
+	if c.Tracing.ServiceName != "" {
+		return c.Tracing.ServiceName
+	}
+
+	return fmt.Sprintf("maybets-%v", c.Environment)
+}
+
+// TracingConfig returns the tracing settings with the service name filled in
+func (c *Config) TracingConfig() helpers.TracingConfig {
+	tracing := c.Tracing
+	tracing.ServiceName = c.ServiceName()
+
+	return tracing
+}
+
+// Level returns the configured log level, falling back to the environment's default
+func (c *Config) Level() slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		return helpers.LogLevel(c.Environment)
+	}
+
+	return level
+}
+
+// Redacted returns a copy of the configuration that is safe to print
+func (c *Config) Redacted() *Config {
+	redacted := *c
+
+	if parsed, err := url.Parse(c.Redis.URL); err == nil {
+		redacted.Redis.URL = parsed.Redacted()
+	}
+
+	return &redacted
+}
+
+// YAML renders the configuration as a YAML document
+func (c *Config) YAML() (string, error) {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	return string(data), nil
+}
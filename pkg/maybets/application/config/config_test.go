@@ -0,0 +1,310 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		env      map[string]string
+		wantPort int
+		wantEnv  enums.Environment
+		// wantBatchWait is only checked when set
+		wantBatchWait time.Duration
+		// wantBrokers is only checked when set
+		wantBrokers []string
+		wantErr     bool
+	}{
+		{
+			name:     "success: defaults without file",
+			wantPort: 8080,
+			wantEnv:  enums.Local,
+			wantErr:  false,
+		},
+		{
+			name:     "success: values from file",
+			file:     "environment: STAGING\nport: 9000\n",
+			wantPort: 9000,
+			wantEnv:  enums.Staging,
+			wantErr:  false,
+		},
+		{
+			name:     "success: environment overrides file",
+			file:     "environment: STAGING\nport: 9000\n",
+			env:      map[string]string{"PORT": "9100", "ENVIRONMENT": "PROD"},
+			wantPort: 9100,
+			wantEnv:  enums.Prod,
+			wantErr:  false,
+		},
+		{
+			name:          "success: stream durations from file",
+			file:          "redis:\n  stream:\n    batch_wait: 250ms\n",
+			wantPort:      8080,
+			wantEnv:       enums.Local,
+			wantBatchWait: 250 * time.Millisecond,
+			wantErr:       false,
+		},
+		{
+			name:        "success: kafka brokers from environment",
+			env:         map[string]string{"KAFKA_BROKERS": "kafka-1:9092,kafka-2:9092"},
+			wantPort:    8080,
+			wantEnv:     enums.Local,
+			wantBrokers: []string{"kafka-1:9092", "kafka-2:9092"},
+			wantErr:     false,
+		},
+		{
+			name:    "fail: unknown field in file",
+			file:    "prot: 9000\n",
+			wantErr: true,
+		},
+		{
+			name:    "fail: invalid loss limit in environment",
+			env:     map[string]string{"WEBHOOK_LOSS_LIMIT": "a lot"},
+			wantErr: true,
+		},
+		{
+			name:    "fail: invalid port in environment",
+			env:     map[string]string{"PORT": "eighty"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"ENVIRONMENT", "PORT"} {
+				t.Setenv(key, "")
+				os.Unsetenv(key)
+			}
+
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			path := ""
+			if tt.file != "" {
+				path = writeConfigFile(t, tt.file)
+			}
+
+			got, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if got.Port != tt.wantPort {
+				t.Errorf("Load() port = %v, want %v", got.Port, tt.wantPort)
+			}
+
+			if got.Environment != tt.wantEnv {
+				t.Errorf("Load() environment = %v, want %v", got.Environment, tt.wantEnv)
+			}
+
+			if tt.wantBatchWait != 0 && got.Redis.Stream.BatchWait != tt.wantBatchWait {
+				t.Errorf("Load() stream batch wait = %v, want %v", got.Redis.Stream.BatchWait, tt.wantBatchWait)
+			}
+
+			if tt.wantBrokers != nil && !slices.Equal(got.Kafka.Brokers, tt.wantBrokers) {
+				t.Errorf("Load() kafka brokers = %v, want %v", got.Kafka.Brokers, tt.wantBrokers)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr string
+	}{
+		{
+			name:   "success: default config",
+			modify: func(_ *Config) {},
+		},
+		{
+			name:    "fail: invalid environment",
+			modify:  func(c *Config) { c.Environment = "DEV" },
+			wantErr: "environment",
+		},
+		{
+			name:    "fail: port out of range",
+			modify:  func(c *Config) { c.Port = 70000 },
+			wantErr: "port",
+		},
+		{
+			name:    "fail: invalid log level",
+			modify:  func(c *Config) { c.LogLevel = "loud" },
+			wantErr: "log_level",
+		},
+		{
+			name:    "fail: invalid redis url",
+			modify:  func(c *Config) { c.Redis.URL = "localhost:6379" },
+			wantErr: "redis.url",
+		},
+		{
+			name:    "fail: dead letter stream is the consumed stream",
+			modify:  func(c *Config) { c.Redis.Stream.DeadLetter = c.Redis.Stream.Name },
+			wantErr: "redis.stream.dead_letter",
+		},
+		{
+			name:    "fail: empty stream batch",
+			modify:  func(c *Config) { c.Redis.Stream.BatchSize = 0 },
+			wantErr: "redis.stream.batch_size",
+		},
+		{
+			name:    "fail: no kafka broker",
+			modify:  func(c *Config) { c.Kafka.Brokers = nil },
+			wantErr: "kafka.brokers",
+		},
+		{
+			name:    "fail: dead letter topic is the consumed topic",
+			modify:  func(c *Config) { c.Kafka.DeadLetter = c.Kafka.Topic },
+			wantErr: "kafka.dead_letter",
+		},
+		{
+			name:    "fail: negative loss limit",
+			modify:  func(c *Config) { c.Webhooks.LossLimit = -1 },
+			wantErr: "webhooks.loss_limit",
+		},
+		{
+			name:    "fail: webhook backoff cap below the first backoff",
+			modify:  func(c *Config) { c.Webhooks.MaxBackoff = time.Second },
+			wantErr: "webhooks.max_backoff",
+		},
+		{
+			name:    "fail: grpc server on the port of the api server",
+			modify:  func(c *Config) { c.GRPCPort = c.Port },
+			wantErr: "grpc_port",
+		},
+		{
+			name: "success: responsible gambling limits",
+			modify: func(c *Config) {
+				c.Limits.Day = PeriodLimitsConfig{LossCap: 100, BetCap: 50}
+				c.Limits.SessionLength = 4 * time.Hour
+				c.Limits.StakeTrendFactor = 3
+			},
+		},
+		{
+			name:    "fail: negative stake cap",
+			modify:  func(c *Config) { c.Limits.Week.StakeCap = -1 },
+			wantErr: "limits.week",
+		},
+		{
+			name:    "fail: sessions without a gap",
+			modify:  func(c *Config) { c.Limits.SessionGap = 0 },
+			wantErr: "limits.session_gap",
+		},
+		{
+			name:    "fail: unknown self-exclusion mode",
+			modify:  func(c *Config) { c.Limits.ExclusionMode = "ignore" },
+			wantErr: "limits.exclusion_mode",
+		},
+		{
+			name:    "fail: structuring margin of the whole threshold",
+			modify:  func(c *Config) { c.AML.StructuringMargin = 1 },
+			wantErr: "aml.structuring_margin",
+		},
+		{
+			name:    "fail: lookback shorter than the structuring window",
+			modify:  func(c *Config) { c.AML.Lookback = time.Hour },
+			wantErr: "aml.lookback",
+		},
+		{
+			name:    "fail: linked accounts without a window",
+			modify:  func(c *Config) { c.Linked.Window = 0 },
+			wantErr: "linked_accounts",
+		},
+		{
+			name:    "fail: linked accounts without a lookback",
+			modify:  func(c *Config) { c.Linked.Lookback = 0 },
+			wantErr: "linked_accounts.lookback",
+		},
+		{
+			name:    "fail: live feed without a client buffer",
+			modify:  func(c *Config) { c.Live.Buffer = 0 },
+			wantErr: "live.buffer",
+		},
+		{
+			name:    "fail: graphql queries without a complexity limit",
+			modify:  func(c *Config) { c.GraphQL.MaxComplexity = 0 },
+			wantErr: "graphql.max_complexity",
+		},
+		{
+			name: "success: bets taken in another currency by default",
+			modify: func(c *Config) {
+				c.Money.ReportingCurrency = "KES"
+				c.Money.Rates = map[string]string{"USD": "129.3", "UGX": "0.0352"}
+			},
+		},
+		{
+			name:    "fail: default currency without a rate",
+			modify:  func(c *Config) { c.Money.DefaultCurrency = "KES" },
+			wantErr: "money",
+		},
+		{
+			name:    "fail: exchange rate that is not a number",
+			modify:  func(c *Config) { c.Money.Rates = map[string]string{"KES": "cheap"} },
+			wantErr: "money",
+		},
+		{
+			name:    "fail: tracing endpoint missing",
+			modify:  func(c *Config) { c.Tracing.Exporter = enums.OTLPGRPC },
+			wantErr: "tracing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Default()
+			tt.modify(c)
+
+			err := c.Validate()
+			if (err != nil) != (tt.wantErr != "") {
+				t.Errorf("Config.Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil && !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Config.Validate() error = %v, want it to mention %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_Redacted(t *testing.T) {
+	c := Default()
+	c.Redis.URL = "redis://:s3cret@localhost:6379/0"
+
+	redacted := c.Redacted()
+
+	if strings.Contains(redacted.Redis.URL, "s3cret") {
+		t.Errorf("Config.Redacted() leaked the redis password: %v", redacted.Redis.URL)
+	}
+
+	if c.Redis.URL != "redis://:s3cret@localhost:6379/0" {
+		t.Errorf("Config.Redacted() modified the original config: %v", c.Redis.URL)
+	}
+}
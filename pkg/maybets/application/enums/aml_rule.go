@@ -0,0 +1,38 @@
+package enums
+
+// AMLRule is an anti-money laundering rule bets are screened against
+type AMLRule string
+
+const (
+	// LargeTransaction reports every bet whose amount reaches the reporting threshold
+	LargeTransaction AMLRule = "large_transaction"
+	// Structuring reports a user placing many bets just below the reporting threshold within a short window
+	Structuring AMLRule = "structuring"
+)
+
+// AMLRules lists every anti-money laundering rule
+var AMLRules = []AMLRule{LargeTransaction, Structuring}
+
+// IsValid checks whether the anti-money laundering rule is a valid enum
+func (r AMLRule) IsValid() bool {
+	switch r {
+	case LargeTransaction, Structuring:
+		return true
+	default:
+		return false
+	}
+}
+
+// Event returns the alert event raised for the findings of the rule
+func (r AMLRule) Event() WebhookEvent {
+	if r == Structuring {
+		return AMLStructuring
+	}
+
+	return AMLLargeTransaction
+}
+
+// String converts enum to string
+func (r AMLRule) String() string {
+	return string(r)
+}
@@ -0,0 +1,32 @@
+package enums
+
+import (
+	"testing"
+)
+
+func TestAMLRule_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		r    AMLRule
+		want bool
+	}{
+		{
+			name: "success: valid enum",
+			r:    Structuring,
+			want: true,
+		},
+		{
+			name: "fail: invalid enum",
+			r:    AMLRule("smurfing"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.IsValid(); got != tt.want {
+				t.Errorf("AMLRule.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
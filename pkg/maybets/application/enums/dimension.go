@@ -0,0 +1,35 @@
+package enums
+
+// Dimension is something about what a bet was placed on that analytics can be broken down by
+type Dimension string
+
+const (
+	// Sport is the sport of the event, e.g football
+	Sport Dimension = "sport"
+	// Competition is the league or tournament the event is part of
+	Competition Dimension = "competition"
+	// EventID identifies the event, e.g a fixture
+	EventID Dimension = "event_id"
+	// Market is the question about the event the bet answers, e.g match result
+	Market Dimension = "market"
+	// Selection is the answer to the market the bet backs
+	Selection Dimension = "selection"
+)
+
+// Dimensions lists every dimension
+var Dimensions = []Dimension{Sport, Competition, EventID, Market, Selection}
+
+// IsValid checks whether the dimension is a valid enum
+func (d Dimension) IsValid() bool {
+	switch d {
+	case Sport, Competition, EventID, Market, Selection:
+		return true
+	default:
+		return false
+	}
+}
+
+// String converts enum to string
+func (d Dimension) String() string {
+	return string(d)
+}
@@ -0,0 +1,32 @@
+package enums
+
+import (
+	"testing"
+)
+
+func TestDimension_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		f    Dimension
+		want bool
+	}{
+		{
+			name: "success: valid enum",
+			f:    EventID,
+			want: true,
+		},
+		{
+			name: "fail: invalid enum",
+			f:    Dimension("bookmaker"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.f.IsValid(); got != tt.want {
+				t.Errorf("Dimension.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
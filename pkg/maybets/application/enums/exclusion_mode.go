@@ -0,0 +1,29 @@
+package enums
+
+// ExclusionMode is how the bets of a self-excluded user are handled at ingest
+type ExclusionMode string
+
+const (
+	// Flag stores the bets and records the violation
+	Flag ExclusionMode = "flag"
+	// Reject turns the bets down and records the violation
+	Reject ExclusionMode = "reject"
+)
+
+// ExclusionModes lists every exclusion mode
+var ExclusionModes = []ExclusionMode{Flag, Reject}
+
+// IsValid checks whether the exclusion mode is a valid enum
+func (m ExclusionMode) IsValid() bool {
+	switch m {
+	case Flag, Reject:
+		return true
+	default:
+		return false
+	}
+}
+
+// String converts enum to string
+func (m ExclusionMode) String() string {
+	return string(m)
+}
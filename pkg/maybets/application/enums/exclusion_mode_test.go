@@ -0,0 +1,32 @@
+package enums
+
+import (
+	"testing"
+)
+
+func TestExclusionMode_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		m    ExclusionMode
+		want bool
+	}{
+		{
+			name: "success: valid enum",
+			m:    Flag,
+			want: true,
+		},
+		{
+			name: "fail: invalid enum",
+			m:    ExclusionMode("ignore"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.IsValid(); got != tt.want {
+				t.Errorf("ExclusionMode.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
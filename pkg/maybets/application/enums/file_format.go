@@ -0,0 +1,23 @@
+package enums
+
+type FileFormat string
+
+const (
+	NDJSON FileFormat = "ndjson"
+	CSV    FileFormat = "csv"
+)
+
+// IsValid checks whether the file format is a valid enum
+func (f FileFormat) IsValid() bool {
+	switch f {
+	case NDJSON, CSV:
+		return true
+	default:
+		return false
+	}
+}
+
+// String converts enum to string
+func (f FileFormat) String() string {
+	return string(f)
+}
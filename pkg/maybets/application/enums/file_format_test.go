@@ -0,0 +1,54 @@
+package enums
+
+import (
+	"testing"
+)
+
+func TestFileFormat_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		f    FileFormat
+		want bool
+	}{
+		{
+			name: "success: valid enum",
+			f:    CSV,
+			want: true,
+		},
+		{
+			name: "fail: invalid enum",
+			f:    FileFormat("xlsx"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.f.IsValid(); got != tt.want {
+				t.Errorf("FileFormat.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileFormat_String(t *testing.T) {
+	tests := []struct {
+		name string
+		f    FileFormat
+		want string
+	}{
+		{
+			name: "success: convert to string",
+			f:    NDJSON,
+			want: "ndjson",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.f.String(); got != tt.want {
+				t.Errorf("FileFormat.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
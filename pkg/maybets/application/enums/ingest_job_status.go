@@ -0,0 +1,24 @@
+package enums
+
+type IngestJobStatus string
+
+const (
+	IngestRunning   IngestJobStatus = "running"
+	IngestCompleted IngestJobStatus = "completed"
+	IngestFailed    IngestJobStatus = "failed"
+)
+
+// IsValid checks whether the ingest job status is a valid enum
+func (s IngestJobStatus) IsValid() bool {
+	switch s {
+	case IngestRunning, IngestCompleted, IngestFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// String converts enum to string
+func (s IngestJobStatus) String() string {
+	return string(s)
+}
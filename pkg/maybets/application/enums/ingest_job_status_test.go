@@ -0,0 +1,54 @@
+package enums
+
+import (
+	"testing"
+)
+
+func TestIngestJobStatus_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		s    IngestJobStatus
+		want bool
+	}{
+		{
+			name: "success: valid enum",
+			s:    IngestCompleted,
+			want: true,
+		},
+		{
+			name: "fail: invalid enum",
+			s:    IngestJobStatus("paused"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.IsValid(); got != tt.want {
+				t.Errorf("IngestJobStatus.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIngestJobStatus_String(t *testing.T) {
+	tests := []struct {
+		name string
+		s    IngestJobStatus
+		want string
+	}{
+		{
+			name: "success: convert to string",
+			s:    IngestRunning,
+			want: "running",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.String(); got != tt.want {
+				t.Errorf("IngestJobStatus.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package enums
+
+// LimitKind is what a responsible gambling limit caps or watches
+type LimitKind string
+
+const (
+	// LossCap caps the amount a user loses over a period
+	LossCap LimitKind = "loss_cap"
+	// StakeCap caps the amount a user stakes over a period
+	StakeCap LimitKind = "stake_cap"
+	// BetCap caps the number of bets a user places over a period
+	BetCap LimitKind = "bet_cap"
+	// SessionLength watches how long a user has been betting without a break
+	SessionLength LimitKind = "session_length"
+	// StakeTrend watches how much the latest stakes of a user grew over the earlier ones
+	StakeTrend LimitKind = "stake_trend"
+)
+
+// LimitKinds lists every limit kind
+var LimitKinds = []LimitKind{LossCap, StakeCap, BetCap, SessionLength, StakeTrend}
+
+// IsValid checks whether the limit kind is a valid enum
+func (k LimitKind) IsValid() bool {
+	switch k {
+	case LossCap, StakeCap, BetCap, SessionLength, StakeTrend:
+		return true
+	default:
+		return false
+	}
+}
+
+// String converts enum to string
+func (k LimitKind) String() string {
+	return string(k)
+}
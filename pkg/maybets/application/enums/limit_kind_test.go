@@ -0,0 +1,32 @@
+package enums
+
+import (
+	"testing"
+)
+
+func TestLimitKind_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		k    LimitKind
+		want bool
+	}{
+		{
+			name: "success: valid enum",
+			k:    StakeTrend,
+			want: true,
+		},
+		{
+			name: "fail: invalid enum",
+			k:    LimitKind("deposit_cap"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.k.IsValid(); got != tt.want {
+				t.Errorf("LimitKind.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
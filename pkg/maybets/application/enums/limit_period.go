@@ -0,0 +1,47 @@
+package enums
+
+import "time"
+
+// LimitPeriod is the rolling window a responsible gambling cap is counted over
+type LimitPeriod string
+
+const (
+	// Day counts over the last 24 hours
+	Day LimitPeriod = "day"
+	// Week counts over the last 7 days
+	Week LimitPeriod = "week"
+	// Month counts over the last 30 days
+	Month LimitPeriod = "month"
+)
+
+// LimitPeriods lists every limit period, shortest first
+var LimitPeriods = []LimitPeriod{Day, Week, Month}
+
+// IsValid checks whether the limit period is a valid enum
+func (p LimitPeriod) IsValid() bool {
+	switch p {
+	case Day, Week, Month:
+		return true
+	default:
+		return false
+	}
+}
+
+// Duration returns the length of the window, zero for an invalid period
+func (p LimitPeriod) Duration() time.Duration {
+	switch p {
+	case Day:
+		return 24 * time.Hour
+	case Week:
+		return 7 * 24 * time.Hour
+	case Month:
+		return 30 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// String converts enum to string
+func (p LimitPeriod) String() string {
+	return string(p)
+}
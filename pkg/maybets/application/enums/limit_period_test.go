@@ -0,0 +1,65 @@
+package enums
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimitPeriod_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		p    LimitPeriod
+		want bool
+	}{
+		{
+			name: "success: valid enum",
+			p:    Week,
+			want: true,
+		},
+		{
+			name: "fail: invalid enum",
+			p:    LimitPeriod("year"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.IsValid(); got != tt.want {
+				t.Errorf("LimitPeriod.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimitPeriod_Duration(t *testing.T) {
+	tests := []struct {
+		name string
+		p    LimitPeriod
+		want time.Duration
+	}{
+		{
+			name: "success: day",
+			p:    Day,
+			want: 24 * time.Hour,
+		},
+		{
+			name: "success: month",
+			p:    Month,
+			want: 720 * time.Hour,
+		},
+		{
+			name: "fail: invalid enum",
+			p:    LimitPeriod("year"),
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.Duration(); got != tt.want {
+				t.Errorf("LimitPeriod.Duration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
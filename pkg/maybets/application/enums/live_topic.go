@@ -0,0 +1,35 @@
+package enums
+
+// LiveTopic is a kind of event that live feed clients can subscribe to
+type LiveTopic string
+
+const (
+	// BetsTopic carries every bet once it is stored
+	BetsTopic LiveTopic = "bets"
+	// LeaderboardTopic carries the top users whenever they change
+	LeaderboardTopic LiveTopic = "leaderboard"
+	// AlertsTopic carries every alert once it is raised
+	AlertsTopic LiveTopic = "alerts"
+	// SettlementsTopic carries the summary of every result submitted for a selection
+	SettlementsTopic LiveTopic = "settlements"
+	// ExposureTopic carries the open liability of the pending bets whenever it changes
+	ExposureTopic LiveTopic = "exposure"
+)
+
+// LiveTopics lists every live topic
+var LiveTopics = []LiveTopic{BetsTopic, LeaderboardTopic, AlertsTopic, SettlementsTopic, ExposureTopic}
+
+// IsValid checks whether the live topic is a valid enum
+func (t LiveTopic) IsValid() bool {
+	switch t {
+	case BetsTopic, LeaderboardTopic, AlertsTopic, SettlementsTopic, ExposureTopic:
+		return true
+	default:
+		return false
+	}
+}
+
+// String converts enum to string
+func (t LiveTopic) String() string {
+	return string(t)
+}
@@ -0,0 +1,54 @@
+package enums
+
+import (
+	"testing"
+)
+
+func TestLiveTopic_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		s    LiveTopic
+		want bool
+	}{
+		{
+			name: "success: valid enum",
+			s:    BetsTopic,
+			want: true,
+		},
+		{
+			name: "fail: invalid enum",
+			s:    LiveTopic("odds"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.IsValid(); got != tt.want {
+				t.Errorf("LiveTopic.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLiveTopic_String(t *testing.T) {
+	tests := []struct {
+		name string
+		s    LiveTopic
+		want string
+	}{
+		{
+			name: "success: convert to string",
+			s:    LeaderboardTopic,
+			want: "leaderboard",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.String(); got != tt.want {
+				t.Errorf("LiveTopic.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
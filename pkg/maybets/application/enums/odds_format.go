@@ -0,0 +1,37 @@
+package enums
+
+// OddsFormat is the way odds are quoted
+type OddsFormat string
+
+const (
+	// Decimal odds are the amount returned per unit staked, e.g 2.5
+	Decimal OddsFormat = "decimal"
+	// Fractional odds are the profit per unit staked as a fraction, e.g 3/2
+	Fractional OddsFormat = "fractional"
+	// American odds are the profit on a stake of 100 when positive, or the stake needed to profit 100 when negative,
+	// e.g +150 or -200
+	American OddsFormat = "american"
+	// HongKong odds are the profit per unit staked, e.g 1.5
+	HongKong OddsFormat = "hongkong"
+	// Malay odds are the profit per unit staked up to 1, and the stake needed to profit 1 as a negative number above it,
+	// e.g 0.5 or -0.8
+	Malay OddsFormat = "malay"
+)
+
+// OddsFormats lists every odds format
+var OddsFormats = []OddsFormat{Decimal, Fractional, American, HongKong, Malay}
+
+// IsValid checks whether the odds format is a valid enum
+func (f OddsFormat) IsValid() bool {
+	switch f {
+	case Decimal, Fractional, American, HongKong, Malay:
+		return true
+	default:
+		return false
+	}
+}
+
+// String converts enum to string
+func (f OddsFormat) String() string {
+	return string(f)
+}
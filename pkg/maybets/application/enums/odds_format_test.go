@@ -0,0 +1,32 @@
+package enums
+
+import (
+	"testing"
+)
+
+func TestOddsFormat_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		f    OddsFormat
+		want bool
+	}{
+		{
+			name: "success: valid enum",
+			f:    Fractional,
+			want: true,
+		},
+		{
+			name: "fail: invalid enum",
+			f:    OddsFormat("moneyline"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.f.IsValid(); got != tt.want {
+				t.Errorf("OddsFormat.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
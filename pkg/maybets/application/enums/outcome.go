@@ -1,22 +1,40 @@
 package enums
 
+// Outcome is the state of a bet in its lifecycle, from placement to settlement
 type Outcome string
 
 const (
-	Win  Outcome = "win"
-	Lose Outcome = "lose"
+	// Pending bets are placed but not settled yet
+	Pending Outcome = "pending"
+	Win     Outcome = "win"
+	Lose    Outcome = "lose"
+	// Void bets are cancelled and their stake is refunded
+	Void Outcome = "void"
+	// Push bets are tied and their stake is refunded
+	Push Outcome = "push"
+	// Cashout bets are settled early for an agreed amount
+	Cashout Outcome = "cashout"
+	// HalfWin bets win on half of their stake and refund the other half
+	HalfWin Outcome = "half_win"
+	// HalfLose bets lose half of their stake and refund the other half
+	HalfLose Outcome = "half_lose"
 )
 
 // IsValid checks whether the outcome is a valid enum
 func (o Outcome) IsValid() bool {
 	switch o {
-	case Win, Lose:
+	case Pending, Win, Lose, Void, Push, Cashout, HalfWin, HalfLose:
 		return true
 	default:
 		return false
 	}
 }
 
+// IsSettled checks whether the bet is settled
+func (o Outcome) IsSettled() bool {
+	return o.IsValid() && o != Pending
+}
+
 // String converts enum to string
 func (o Outcome) String() string {
 	return string(o)
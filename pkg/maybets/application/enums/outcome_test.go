@@ -15,6 +15,11 @@ func TestOutcome_IsValid(t *testing.T) {
 			o:    Win,
 			want: true,
 		},
+		{
+			name: "success: valid lifecycle state",
+			o:    HalfLose,
+			want: true,
+		},
 		{
 			name: "fail: invalid enum",
 			o:    Outcome("invalid"),
@@ -31,6 +36,38 @@ func TestOutcome_IsValid(t *testing.T) {
 	}
 }
 
+func TestOutcome_IsSettled(t *testing.T) {
+	tests := []struct {
+		name string
+		o    Outcome
+		want bool
+	}{
+		{
+			name: "success: settled bet",
+			o:    Void,
+			want: true,
+		},
+		{
+			name: "fail: pending bet",
+			o:    Pending,
+			want: false,
+		},
+		{
+			name: "fail: invalid enum",
+			o:    Outcome("invalid"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.o.IsSettled(); got != tt.want {
+				t.Errorf("Outcome.IsSettled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestOutcome_String(t *testing.T) {
 	tests := []struct {
 		name string
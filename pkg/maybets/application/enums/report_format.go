@@ -0,0 +1,29 @@
+package enums
+
+// ReportFormat is the format a report is exported in
+type ReportFormat string
+
+const (
+	// JSONReport exports the report as a single JSON document
+	JSONReport ReportFormat = "json"
+	// CSVReport exports the report as CSV, one row per evidence bet
+	CSVReport ReportFormat = "csv"
+)
+
+// ReportFormats lists every report format
+var ReportFormats = []ReportFormat{JSONReport, CSVReport}
+
+// IsValid checks whether the report format is a valid enum
+func (f ReportFormat) IsValid() bool {
+	switch f {
+	case JSONReport, CSVReport:
+		return true
+	default:
+		return false
+	}
+}
+
+// String converts enum to string
+func (f ReportFormat) String() string {
+	return string(f)
+}
@@ -0,0 +1,32 @@
+package enums
+
+import (
+	"testing"
+)
+
+func TestReportFormat_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		f    ReportFormat
+		want bool
+	}{
+		{
+			name: "success: valid enum",
+			f:    CSVReport,
+			want: true,
+		},
+		{
+			name: "fail: invalid enum",
+			f:    ReportFormat("xml"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.f.IsValid(); got != tt.want {
+				t.Errorf("ReportFormat.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package enums
+
+type TraceExporter string
+
+const (
+	OTLPHTTP TraceExporter = "otlp-http"
+	OTLPGRPC TraceExporter = "otlp-grpc"
+	Stdout   TraceExporter = "stdout"
+	None     TraceExporter = "none"
+)
+
+// IsValid checks whether the trace exporter is a valid enum
+func (t TraceExporter) IsValid() bool {
+	switch t {
+	case OTLPHTTP, OTLPGRPC, Stdout, None:
+		return true
+	default:
+		return false
+	}
+}
+
+// String converts enum to string
+func (t TraceExporter) String() string {
+	return string(t)
+}
@@ -0,0 +1,54 @@
+package enums
+
+import (
+	"testing"
+)
+
+func TestTraceExporter_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		e    TraceExporter
+		want bool
+	}{
+		{
+			name: "success: valid enum",
+			e:    OTLPGRPC,
+			want: true,
+		},
+		{
+			name: "fail: invalid enum",
+			e:    TraceExporter("jaeger"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.IsValid(); got != tt.want {
+				t.Errorf("TraceExporter.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTraceExporter_String(t *testing.T) {
+	tests := []struct {
+		name string
+		e    TraceExporter
+		want string
+	}{
+		{
+			name: "success: convert to string",
+			e:    OTLPHTTP,
+			want: "otlp-http",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.String(); got != tt.want {
+				t.Errorf("TraceExporter.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package enums
+
+// WebhookEvent is the type of an alert that webhook subscriptions can be notified of
+type WebhookEvent string
+
+const (
+	// AnomalyDetected is raised when a user starts betting significantly more than the average
+	AnomalyDetected WebhookEvent = "anomaly.detected"
+	// LargeBet is raised for every bet whose amount reaches the large bet threshold
+	LargeBet WebhookEvent = "bet.large"
+	// LossLimitCrossed is raised when the amount a user lost reaches the loss limit
+	LossLimitCrossed WebhookEvent = "user.loss_limit_crossed"
+	// LimitBreached is raised when a user reaches one of their responsible gambling limits
+	LimitBreached WebhookEvent = "user.limit_breached"
+	// AMLLargeTransaction is raised for every bet whose amount reaches the anti-money laundering reporting threshold
+	AMLLargeTransaction WebhookEvent = "aml.large_transaction"
+	// AMLStructuring is raised when a user places many bets just below the reporting threshold within a short window
+	AMLStructuring WebhookEvent = "aml.structuring"
+)
+
+// WebhookEvents lists every webhook event
+var WebhookEvents = []WebhookEvent{
+	AnomalyDetected, LargeBet, LossLimitCrossed, LimitBreached, AMLLargeTransaction, AMLStructuring,
+}
+
+// IsValid checks whether the webhook event is a valid enum
+func (e WebhookEvent) IsValid() bool {
+	switch e {
+	case AnomalyDetected, LargeBet, LossLimitCrossed, LimitBreached, AMLLargeTransaction, AMLStructuring:
+		return true
+	default:
+		return false
+	}
+}
+
+// String converts enum to string
+func (e WebhookEvent) String() string {
+	return string(e)
+}
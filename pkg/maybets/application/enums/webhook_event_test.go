@@ -0,0 +1,54 @@
+package enums
+
+import (
+	"testing"
+)
+
+func TestWebhookEvent_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		s    WebhookEvent
+		want bool
+	}{
+		{
+			name: "success: valid enum",
+			s:    LargeBet,
+			want: true,
+		},
+		{
+			name: "fail: invalid enum",
+			s:    WebhookEvent("bet.small"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.IsValid(); got != tt.want {
+				t.Errorf("WebhookEvent.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookEvent_String(t *testing.T) {
+	tests := []struct {
+		name string
+		s    WebhookEvent
+		want string
+	}{
+		{
+			name: "success: convert to string",
+			s:    AnomalyDetected,
+			want: "anomaly.detected",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.String(); got != tt.want {
+				t.Errorf("WebhookEvent.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package helpers
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SetupLogger configures the process wide structured logger.
+// Records are written as JSON and are enriched with the trace and span IDs of the logging context.
+func SetupLogger(level slog.Level) *slog.Logger {
+	logger := NewLogger(os.Stdout, level)
+
+	slog.SetDefault(logger)
+
+	return logger
+}
+
+// NewLogger creates a JSON logger writing to w at the given level
+func NewLogger(w io.Writer, level slog.Level) *slog.Logger {
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level: level,
+	})
+
+	return slog.New(&ContextHandler{Handler: handler})
+}
+
+// LogLevel returns the minimum log level for an environment
+func LogLevel(environment enums.Environment) slog.Level {
+	switch environment {
+	case enums.Local:
+		return slog.LevelDebug
+	case enums.Test:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// ContextHandler is a slog handler that adds the trace and span IDs found in the context to every record
+type ContextHandler struct {
+	slog.Handler
+}
+
+// Handle adds trace_id and span_id attributes before passing the record to the wrapped handler
+func (h *ContextHandler) Handle(ctx context.Context, record slog.Record) error {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if spanContext.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", spanContext.TraceID().String()),
+			slog.String("span_id", spanContext.SpanID().String()),
+		)
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+// WithAttrs returns a new ContextHandler whose wrapped handler includes the given attributes
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup returns a new ContextHandler whose wrapped handler uses the given group
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,95 @@
+package helpers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestContextHandler_Handle(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+
+	spanCtx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		wantTraceID string
+		wantSpanID  string
+	}{
+		{
+			name:        "success: record includes trace and span ids",
+			ctx:         spanCtx,
+			wantTraceID: traceID.String(),
+			wantSpanID:  spanID.String(),
+		},
+		{
+			name: "success: record without span context",
+			ctx:  context.Background(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			logger := NewLogger(&buf, slog.LevelInfo)
+			logger.InfoContext(tt.ctx, "message")
+
+			record := map[string]interface{}{}
+			if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+				t.Fatalf("failed to decode log record: %v", err)
+			}
+
+			if got, _ := record["trace_id"].(string); got != tt.wantTraceID {
+				t.Errorf("trace_id = %v, want %v", got, tt.wantTraceID)
+			}
+
+			if got, _ := record["span_id"].(string); got != tt.wantSpanID {
+				t.Errorf("span_id = %v, want %v", got, tt.wantSpanID)
+			}
+		})
+	}
+}
+
+func TestLogLevel(t *testing.T) {
+	tests := []struct {
+		name        string
+		environment enums.Environment
+		want        slog.Level
+	}{
+		{
+			name:        "success: local logs debug",
+			environment: enums.Local,
+			want:        slog.LevelDebug,
+		},
+		{
+			name:        "success: prod logs info",
+			environment: enums.Prod,
+			want:        slog.LevelInfo,
+		},
+		{
+			name:        "success: test logs warnings",
+			environment: enums.Test,
+			want:        slog.LevelWarn,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LogLevel(tt.environment); got != tt.want {
+				t.Errorf("LogLevel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
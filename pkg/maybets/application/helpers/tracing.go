@@ -0,0 +1,210 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+// TracingConfig holds the settings used to build the trace pipeline
+type TracingConfig struct {
+	// Exporter selects where spans are sent
+	Exporter enums.TraceExporter `json:"exporter" yaml:"exporter"`
+	// Endpoint is the host:port of the OTLP collector
+	Endpoint string `json:"endpoint" yaml:"endpoint"`
+	// Insecure disables TLS when talking to the collector
+	Insecure bool `json:"insecure" yaml:"insecure"`
+	// SampleRatio is the fraction of root traces that are sampled. Child spans follow their parent's decision.
+	SampleRatio float64 `json:"sample_ratio" yaml:"sample_ratio"`
+	// ServiceName is reported as the service.name resource attribute
+	ServiceName string `json:"service_name" yaml:"service_name"`
+}
+
+// Validate checks that the tracing configuration can be used to build a trace pipeline
+func (c TracingConfig) Validate() error {
+	if !c.Exporter.IsValid() {
+		return fmt.Errorf("invalid trace exporter %q: must be one of %s, %s, %s or %s",
+			c.Exporter, enums.OTLPHTTP, enums.OTLPGRPC, enums.Stdout, enums.None)
+	}
+
+	if (c.Exporter == enums.OTLPHTTP || c.Exporter == enums.OTLPGRPC) && c.Endpoint == "" {
+		return fmt.Errorf("a collector endpoint is required for the %s trace exporter", c.Exporter)
+	}
+
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return fmt.Errorf("invalid trace sample ratio %v: must be between 0 and 1", c.SampleRatio)
+	}
+
+	return nil
+}
+
+// SetupOTelSDK bootstraps the OpenTelemetry pipeline for traces and metrics.
+// If it does not return an error, make sure to call shutdown for proper cleanup.
+func SetupOTelSDK(ctx context.Context, config TracingConfig) (shutdown func(context.Context) error, err error) {
+	var shutdownFuncs []func(context.Context) error
+
+	// shutdown calls cleanup functions registered via shutdownFuncs.
+	// The errors from the calls are joined.
+	// Each registered cleanup will be invoked once.
+	shutdown = func(ctx context.Context) error {
+		var err error
+		for _, fn := range shutdownFuncs {
+			err = errors.Join(err, fn(ctx))
+		}
+
+		shutdownFuncs = nil
+
+		return err
+	}
+
+	if err := config.Validate(); err != nil {
+		return shutdown, fmt.Errorf("invalid tracing config: %w", err)
+	}
+
+	// handleErr calls shutdown for cleanup and makes sure that all errors are returned.
+	handleErr := func(inErr error) {
+		err = errors.Join(inErr, shutdown(ctx))
+	}
+
+	// Set up propagator.
+	prop := propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	)
+	otel.SetTextMapPropagator(prop)
+
+	if config.Exporter == enums.None {
+		return
+	}
+
+	// Set up trace provider.
+	tracerProvider, err := newTraceProvider(ctx, config)
+	if err != nil {
+		handleErr(err)
+		return
+	}
+
+	shutdownFuncs = append(shutdownFuncs, tracerProvider.Shutdown)
+
+	otel.SetTracerProvider(tracerProvider)
+
+	// Set up meter provider. Metrics are sent through the same exporter as the spans.
+	meterProvider, err := newMeterProvider(ctx, config)
+	if err != nil {
+		handleErr(err)
+		return
+	}
+
+	shutdownFuncs = append(shutdownFuncs, meterProvider.Shutdown)
+
+	otel.SetMeterProvider(meterProvider)
+
+	return
+}
+
+func newTraceExporter(ctx context.Context, config TracingConfig) (trace.SpanExporter, error) {
+	switch config.Exporter {
+	case enums.OTLPHTTP:
+		options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(config.Endpoint)}
+		if config.Insecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+
+		return otlptracehttp.New(ctx, options...)
+	case enums.OTLPGRPC:
+		options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(config.Endpoint)}
+		if config.Insecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+
+		return otlptracegrpc.New(ctx, options...)
+	case enums.Stdout:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stderr))
+	default:
+		return nil, fmt.Errorf("unsupported trace exporter %q", config.Exporter)
+	}
+}
+
+func newMetricExporter(ctx context.Context, config TracingConfig) (metric.Exporter, error) {
+	switch config.Exporter {
+	case enums.OTLPHTTP:
+		options := []otlpmetrichttp.Option{otlpmetrichttp.WithEndpoint(config.Endpoint)}
+		if config.Insecure {
+			options = append(options, otlpmetrichttp.WithInsecure())
+		}
+
+		return otlpmetrichttp.New(ctx, options...)
+	case enums.OTLPGRPC:
+		options := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(config.Endpoint)}
+		if config.Insecure {
+			options = append(options, otlpmetricgrpc.WithInsecure())
+		}
+
+		return otlpmetricgrpc.New(ctx, options...)
+	case enums.Stdout:
+		return stdoutmetric.New(stdoutmetric.WithWriter(os.Stderr))
+	default:
+		return nil, fmt.Errorf("unsupported metric exporter %q", config.Exporter)
+	}
+}
+
+func newMeterProvider(ctx context.Context, config TracingConfig) (*metric.MeterProvider, error) {
+	metricExporter, err := newMetricExporter(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s metric exporter: %w", config.Exporter, err)
+	}
+
+	meterProvider := metric.NewMeterProvider(
+		metric.WithReader(metric.NewPeriodicReader(metricExporter)),
+		metric.WithResource(
+			resource.NewWithAttributes(
+				semconv.SchemaURL,
+				semconv.ServiceNameKey.String(config.ServiceName),
+			),
+		),
+	)
+
+	return meterProvider, nil
+}
+
+func newTraceProvider(ctx context.Context, config TracingConfig) (*trace.TracerProvider, error) {
+	traceExporter, err := newTraceExporter(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s trace exporter: %w", config.Exporter, err)
+	}
+
+	traceProvider := trace.NewTracerProvider(
+		trace.WithBatcher(
+			traceExporter,
+			trace.WithMaxExportBatchSize(trace.DefaultMaxExportBatchSize),
+			trace.WithBatchTimeout(trace.DefaultScheduleDelay*time.Millisecond),
+		),
+		trace.WithSampler(trace.ParentBased(trace.TraceIDRatioBased(config.SampleRatio))),
+		trace.WithResource(
+			resource.NewWithAttributes(
+				semconv.SchemaURL,
+				semconv.ServiceNameKey.String(config.ServiceName),
+			),
+		),
+	)
+
+	_ = traceProvider.Tracer("maybets-analytics-svc")
+
+	return traceProvider, nil
+}
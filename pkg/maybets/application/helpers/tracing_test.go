@@ -0,0 +1,93 @@
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+)
+
+func TestTracingConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  TracingConfig
+		wantErr bool
+	}{
+		{
+			name:    "success: otlp http with endpoint",
+			config:  TracingConfig{Exporter: enums.OTLPHTTP, Endpoint: "localhost:4318", SampleRatio: 1},
+			wantErr: false,
+		},
+		{
+			name:    "success: stdout without endpoint",
+			config:  TracingConfig{Exporter: enums.Stdout, SampleRatio: 0.5},
+			wantErr: false,
+		},
+		{
+			name:    "success: tracing disabled",
+			config:  TracingConfig{Exporter: enums.None},
+			wantErr: false,
+		},
+		{
+			name:    "fail: unknown exporter",
+			config:  TracingConfig{Exporter: enums.TraceExporter("zipkin"), SampleRatio: 1},
+			wantErr: true,
+		},
+		{
+			name:    "fail: otlp grpc without endpoint",
+			config:  TracingConfig{Exporter: enums.OTLPGRPC, SampleRatio: 1},
+			wantErr: true,
+		},
+		{
+			name:    "fail: sample ratio out of range",
+			config:  TracingConfig{Exporter: enums.Stdout, SampleRatio: 1.5},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("TracingConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetupOTelSDK(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  TracingConfig
+		wantErr bool
+	}{
+		{
+			name:    "success: stdout exporter",
+			config:  TracingConfig{Exporter: enums.Stdout, SampleRatio: 1, ServiceName: "maybets-test"},
+			wantErr: false,
+		},
+		{
+			name:    "success: tracing disabled",
+			config:  TracingConfig{Exporter: enums.None},
+			wantErr: false,
+		},
+		{
+			name:    "fail: invalid config",
+			config:  TracingConfig{Exporter: enums.OTLPHTTP},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shutdown, err := SetupOTelSDK(context.Background(), tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetupOTelSDK() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err := shutdown(context.Background()); err != nil {
+				t.Errorf("shutdown() error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package ingest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Identify returns the size and SHA-256 hash of a file's raw content.
+// Together they recognise a file that was imported before, even after it was renamed or moved.
+func Identify(name string) (size int64, hash string, err error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to open file: %w", err)
+	}
+
+	defer file.Close()
+
+	hasher := sha256.New()
+
+	size, err = io.Copy(hasher, file)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to hash file: %w", err)
+	}
+
+	return size, hex.EncodeToString(hasher.Sum(nil)), nil
+}
@@ -0,0 +1,50 @@
+package ingest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIdentify(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "bets.ndjson")
+	if err := os.WriteFile(path, []byte("abc"), 0o600); err != nil {
+		t.Fatalf("failed to write input: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		wantSize int64
+		wantHash string
+		wantErr  bool
+	}{
+		{
+			name:     "success: size and sha256 of the content",
+			path:     path,
+			wantSize: 3,
+			wantHash: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		},
+		{
+			name:    "fail: missing file",
+			path:    filepath.Join(dir, "missing.ndjson"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			size, hash, err := Identify(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Identify() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if size != tt.wantSize || hash != tt.wantHash {
+				t.Errorf("Identify() = %d, %s, want %d, %s", size, hash, tt.wantSize, tt.wantHash)
+			}
+		})
+	}
+}
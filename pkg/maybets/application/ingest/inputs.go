@@ -0,0 +1,91 @@
+package ingest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ExpandInputs resolves the process command arguments into a list of inputs.
+// Each argument may be a file, a directory (its regular files are used), a glob pattern or "-" for stdin.
+// The result keeps the argument order and contains no duplicates.
+func ExpandInputs(args []string) ([]string, error) {
+	var inputs []string
+
+	seen := map[string]bool{}
+
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			inputs = append(inputs, name)
+		}
+	}
+
+	for _, arg := range args {
+		if arg == Stdin {
+			add(arg)
+			continue
+		}
+
+		info, err := os.Stat(arg)
+
+		switch {
+		case err == nil && info.IsDir():
+			files, err := directoryFiles(arg)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, file := range files {
+				add(file)
+			}
+		case err == nil:
+			add(arg)
+		default:
+			matches, globErr := filepath.Glob(arg)
+			if globErr != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %w", arg, globErr)
+			}
+
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no input matches %q", arg)
+			}
+
+			for _, match := range matches {
+				if info, err := os.Stat(match); err == nil && !info.IsDir() {
+					add(match)
+				}
+			}
+		}
+	}
+
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("no input files given")
+	}
+
+	return inputs, nil
+}
+
+// directoryFiles lists the regular, non hidden files of a directory in name order
+func directoryFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	var files []string
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		files = append(files, filepath.Join(dir, entry.Name()))
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
@@ -0,0 +1,72 @@
+package ingest
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExpandInputs(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"b.ndjson.gz", "a.ndjson", "c.csv", ".hidden"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o600); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+	}
+
+	if err := os.Mkdir(filepath.Join(dir, "nested"), 0o700); err != nil {
+		t.Fatalf("failed to create nested directory: %v", err)
+	}
+
+	path := func(name string) string {
+		return filepath.Join(dir, name)
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "success: directory lists regular files in order",
+			args: []string{dir},
+			want: []string{path("a.ndjson"), path("b.ndjson.gz"), path("c.csv")},
+		},
+		{
+			name: "success: glob pattern",
+			args: []string{filepath.Join(dir, "*.ndjson*")},
+			want: []string{path("a.ndjson"), path("b.ndjson.gz")},
+		},
+		{
+			name: "success: duplicates are removed and stdin kept",
+			args: []string{path("c.csv"), "-", dir},
+			want: []string{path("c.csv"), "-", path("a.ndjson"), path("b.ndjson.gz")},
+		},
+		{
+			name:    "fail: nothing matches",
+			args:    []string{filepath.Join(dir, "*.parquet")},
+			wantErr: true,
+		},
+		{
+			name:    "fail: no arguments",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandInputs(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExpandInputs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExpandInputs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
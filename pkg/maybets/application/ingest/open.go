@@ -0,0 +1,90 @@
+package ingest
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Stdin is the input name that reads bets from standard input
+const Stdin = "-"
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// Open opens the named input for reading, transparently decompressing gzip and zstd data.
+// The compression is detected from the magic bytes, not the file extension.
+func Open(name string) (io.ReadCloser, error) {
+	if name == Stdin {
+		return Decompress(io.NopCloser(os.Stdin))
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	return Decompress(file)
+}
+
+// Decompress wraps rc in a gzip or zstd decoder when its content starts with the matching magic bytes.
+// Closing the returned reader closes rc.
+func Decompress(rc io.ReadCloser) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(rc)
+
+	header, err := buffered.Peek(len(zstdMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		rc.Close()
+		return nil, fmt.Errorf("failed to read input header: %w", err)
+	}
+
+	switch {
+	case bytes.HasPrefix(header, gzipMagic):
+		decoder, err := gzip.NewReader(buffered)
+		if err != nil {
+			rc.Close()
+			return nil, fmt.Errorf("failed to open gzip stream: %w", err)
+		}
+
+		return &decompressor{Reader: decoder, closers: []func() error{decoder.Close, rc.Close}}, nil
+	case bytes.HasPrefix(header, zstdMagic):
+		decoder, err := zstd.NewReader(buffered)
+		if err != nil {
+			rc.Close()
+			return nil, fmt.Errorf("failed to open zstd stream: %w", err)
+		}
+
+		closeDecoder := func() error {
+			decoder.Close()
+			return nil
+		}
+
+		return &decompressor{Reader: decoder, closers: []func() error{closeDecoder, rc.Close}}, nil
+	default:
+		return &decompressor{Reader: buffered, closers: []func() error{rc.Close}}, nil
+	}
+}
+
+// decompressor reads from a decoder and closes it together with the underlying input
+type decompressor struct {
+	io.Reader
+	closers []func() error
+}
+
+// Close closes the decoder and the underlying input
+func (d *decompressor) Close() error {
+	var err error
+	for _, closeFn := range d.closers {
+		err = errors.Join(err, closeFn())
+	}
+
+	return err
+}
@@ -0,0 +1,101 @@
+package ingest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestOpen(t *testing.T) {
+	content := []byte(`{"bet_id":"b1","user_id":"u1","amount":10,"odds":2,"outcome":"win","timestamp":"2024-11-22T21:16:29Z"}` + "\n")
+
+	var gzipped bytes.Buffer
+
+	gzipWriter := gzip.NewWriter(&gzipped)
+	if _, err := gzipWriter.Write(content); err != nil {
+		t.Fatalf("failed to gzip content: %v", err)
+	}
+
+	gzipWriter.Close()
+
+	zstdEncoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		t.Fatalf("failed to create zstd encoder: %v", err)
+	}
+
+	zstded := zstdEncoder.EncodeAll(content, nil)
+
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		file    string
+		data    []byte
+		wantErr bool
+	}{
+		{
+			name: "success: plain file",
+			file: "bets.ndjson",
+			data: content,
+		},
+		{
+			name: "success: gzip detected by magic bytes",
+			file: "bets.data",
+			data: gzipped.Bytes(),
+		},
+		{
+			name: "success: zstd detected by magic bytes",
+			file: "bets.ndjson.zst",
+			data: zstded,
+		},
+		{
+			name: "success: empty file",
+			file: "empty.ndjson",
+			data: []byte{},
+		},
+		{
+			name:    "fail: truncated gzip stream",
+			file:    "truncated.ndjson.gz",
+			data:    gzipped.Bytes()[:4],
+			wantErr: true,
+		},
+		{
+			name:    "fail: missing file",
+			file:    "missing.ndjson",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+
+			if tt.data != nil {
+				if err := os.WriteFile(path, tt.data, 0o600); err != nil {
+					t.Fatalf("failed to write input: %v", err)
+				}
+			}
+
+			reader, err := Open(path)
+			if err == nil {
+				defer reader.Close()
+
+				var got []byte
+
+				got, err = io.ReadAll(reader)
+				if err == nil && !bytes.Equal(got, tt.data) && !bytes.Equal(got, content) {
+					t.Errorf("Open() read %q, want %q", got, content)
+				}
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Open() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
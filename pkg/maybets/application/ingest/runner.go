@@ -0,0 +1,116 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+var tracer = otel.Tracer("github.com/KathurimaKimathi/maybets/pkg/maybets/application/ingest")
+
+// ErrSkipped marks inputs that were deliberately not processed. Skipped inputs are not failures.
+var ErrSkipped = errors.New("skipped")
+
+// errSkippedAfterFailure is reported for the inputs left over once an input failed in fail fast mode
+var errSkippedAfterFailure = fmt.Errorf("%w after an earlier failure", ErrSkipped)
+
+// Options controls how a set of inputs is processed
+type Options struct {
+	// Concurrency is the number of inputs processed at the same time. Values below one process inputs one by one.
+	Concurrency int
+	// FailFast stops processing the remaining inputs once one of them fails
+	FailFast bool
+}
+
+// Result describes the outcome of processing a single input
+type Result struct {
+	Input string
+	Bets  int64
+	Err   error
+}
+
+// ProcessFunc processes one input and returns the number of bets it stored
+type ProcessFunc func(ctx context.Context, input string) (int64, error)
+
+// Run processes the inputs concurrently and returns one result per input, in input order.
+// The returned error joins the errors of every failed input.
+func Run(ctx context.Context, inputs []string, options Options, process ProcessFunc) ([]Result, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	concurrency := max(options.Concurrency, 1)
+
+	results := make([]Result, len(inputs))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for range min(concurrency, len(inputs)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				if ctx.Err() != nil {
+					results[i] = Result{Input: inputs[i], Err: errSkippedAfterFailure}
+					continue
+				}
+
+				results[i] = runOne(ctx, inputs[i], process)
+
+				if results[i].Err != nil && options.FailFast {
+					cancel()
+				}
+			}
+		}()
+	}
+
+	for i := range inputs {
+		if ctx.Err() != nil {
+			results[i] = Result{Input: inputs[i], Err: errSkippedAfterFailure}
+			continue
+		}
+
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			results[i] = Result{Input: inputs[i], Err: errSkippedAfterFailure}
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	var errs []error
+
+	for _, result := range results {
+		if result.Err != nil && !errors.Is(result.Err, ErrSkipped) {
+			errs = append(errs, fmt.Errorf("%s: %w", result.Input, result.Err))
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
+
+func runOne(ctx context.Context, input string, process ProcessFunc) Result {
+	ctx, span := tracer.Start(ctx, "ProcessInput")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("input", input))
+
+	bets, err := process(ctx, input)
+	if err != nil {
+		span.SetStatus(codes.Error, "failed to process input")
+		span.RecordError(err)
+	}
+
+	span.SetAttributes(attribute.Int64("bets", bets))
+
+	return Result{Input: input, Bets: bets, Err: err}
+}
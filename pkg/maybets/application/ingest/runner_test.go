@@ -0,0 +1,86 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	process := func(_ context.Context, input string) (int64, error) {
+		if input == "bad" {
+			return 0, errors.New("corrupt input")
+		}
+
+		return 10, nil
+	}
+
+	tests := []struct {
+		name        string
+		inputs      []string
+		options     Options
+		wantBets    int64
+		wantSkipped int
+		wantErr     bool
+	}{
+		{
+			name:     "success: every input processed",
+			inputs:   []string{"a", "b", "c"},
+			options:  Options{Concurrency: 2},
+			wantBets: 30,
+		},
+		{
+			name:     "fail: continue after a failure",
+			inputs:   []string{"a", "bad", "b", "c"},
+			options:  Options{Concurrency: 1},
+			wantBets: 30,
+			wantErr:  true,
+		},
+		{
+			name:        "fail: fail fast skips the remaining inputs",
+			inputs:      []string{"bad", "a", "b"},
+			options:     Options{Concurrency: 1, FailFast: true},
+			wantSkipped: 2,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := Run(context.Background(), tt.inputs, tt.options, process)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Run() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if len(results) != len(tt.inputs) {
+				t.Fatalf("Run() returned %d results, want %d", len(results), len(tt.inputs))
+			}
+
+			var (
+				bets    int64
+				skipped int
+			)
+
+			for i, result := range results {
+				if result.Input != tt.inputs[i] {
+					t.Errorf("result %d is for %s, want %s", i, result.Input, tt.inputs[i])
+				}
+
+				bets += result.Bets
+
+				if errors.Is(result.Err, ErrSkipped) {
+					skipped++
+				}
+			}
+
+			if bets != tt.wantBets {
+				t.Errorf("Run() stored %d bets, want %d", bets, tt.wantBets)
+			}
+
+			if skipped != tt.wantSkipped {
+				t.Errorf("Run() skipped %d inputs, want %d", skipped, tt.wantSkipped)
+			}
+		})
+	}
+}
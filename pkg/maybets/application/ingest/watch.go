@@ -0,0 +1,261 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// partialSuffixes mark files that are still being uploaded
+var partialSuffixes = []string{".part", ".partial", ".filepart", ".tmp", ".uploading"}
+
+// WatchOptions controls how an inbox directory is watched
+type WatchOptions struct {
+	// Inbox is the directory new bet files are dropped in
+	Inbox string
+	// ProcessedDir receives the files that were imported. Defaults to the processed directory of the inbox.
+	ProcessedDir string
+	// FailedDir receives the files that could not be imported and their error reports.
+	// Defaults to the failed directory of the inbox.
+	FailedDir string
+	// Interval is the time between two scans of the inbox
+	Interval time.Duration
+	// Settle is how long a file must remain unchanged before it is considered completely written
+	Settle time.Duration
+}
+
+// ErrorReport is written next to a file moved to the failed directory
+type ErrorReport struct {
+	File     string    `json:"file"`
+	FailedAt time.Time `json:"failed_at"`
+	Bets     int64     `json:"bets_stored"`
+	Error    string    `json:"error"`
+}
+
+// observation is the last known state of a file in the inbox
+type observation struct {
+	size    int64
+	modTime time.Time
+	// since is when the file was first seen in this state
+	since time.Time
+}
+
+// Watcher imports the files dropped in an inbox directory, then moves them out of it
+type Watcher struct {
+	options  WatchOptions
+	process  ProcessFunc
+	observed map[string]observation
+	now      func() time.Time
+}
+
+// NewWatcher initializes a new Watcher and creates its processed and failed directories
+func NewWatcher(options WatchOptions, process ProcessFunc) (*Watcher, error) {
+	if options.ProcessedDir == "" {
+		options.ProcessedDir = filepath.Join(options.Inbox, "processed")
+	}
+
+	if options.FailedDir == "" {
+		options.FailedDir = filepath.Join(options.Inbox, "failed")
+	}
+
+	if options.Interval <= 0 {
+		return nil, fmt.Errorf("invalid watch interval %v: must be positive", options.Interval)
+	}
+
+	info, err := os.Stat(options.Inbox)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open inbox: %w", err)
+	}
+
+	if !info.IsDir() {
+		return nil, fmt.Errorf("inbox %s is not a directory", options.Inbox)
+	}
+
+	for _, dir := range []string{options.ProcessedDir, options.FailedDir} {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return nil, fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+
+	return &Watcher{
+		options:  options,
+		process:  process,
+		observed: map[string]observation{},
+		now:      time.Now,
+	}, nil
+}
+
+// Run scans the inbox every interval until the context is canceled
+func (w *Watcher) Run(ctx context.Context) error {
+	slog.InfoContext(ctx, "watching inbox", "inbox", w.options.Inbox, "interval", w.options.Interval, "settle", w.options.Settle)
+
+	ticker := time.NewTicker(w.options.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Scan(ctx); err != nil {
+			slog.ErrorContext(ctx, "failed to scan inbox", "inbox", w.options.Inbox, "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Scan imports every completely written file of the inbox, one at a time
+func (w *Watcher) Scan(ctx context.Context) error {
+	files, err := directoryFiles(w.options.Inbox)
+	if err != nil {
+		return err
+	}
+
+	present := map[string]bool{}
+
+	for _, file := range files {
+		if isPartial(file) {
+			continue
+		}
+
+		present[file] = true
+
+		ready, err := w.settled(file)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to inspect inbox file", "file", file, "error", err)
+			continue
+		}
+
+		if !ready {
+			continue
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		w.importFile(ctx, file)
+		delete(w.observed, file)
+	}
+
+	for file := range w.observed {
+		if !present[file] {
+			delete(w.observed, file)
+		}
+	}
+
+	return nil
+}
+
+// settled reports whether a file has not changed for the settle duration
+func (w *Watcher) settled(file string) (bool, error) {
+	info, err := os.Stat(file)
+	if err != nil {
+		return false, err
+	}
+
+	now := w.now()
+
+	previous, seen := w.observed[file]
+	if !seen || previous.size != info.Size() || !previous.modTime.Equal(info.ModTime()) {
+		previous = observation{size: info.Size(), modTime: info.ModTime(), since: now}
+		w.observed[file] = previous
+	}
+
+	// files untouched for long enough, e.g found when the watcher starts, do not need to be observed first
+	since := previous.since
+	if info.ModTime().Before(since) {
+		since = info.ModTime()
+	}
+
+	return now.Sub(since) >= w.options.Settle, nil
+}
+
+// importFile imports a file and moves it to the processed or failed directory.
+// A file whose import was interrupted by cancellation is left in the inbox so that it is resumed later.
+func (w *Watcher) importFile(ctx context.Context, file string) {
+	result := runOne(ctx, file, w.process)
+
+	if ctx.Err() != nil {
+		slog.WarnContext(ctx, "import interrupted, file left in inbox", "file", file, "bets", result.Bets)
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+
+	if result.Err != nil && !errors.Is(result.Err, ErrSkipped) {
+		slog.ErrorContext(ctx, "failed to import file", "file", file, "bets", result.Bets, "error", result.Err)
+
+		if err := w.fail(file, result); err != nil {
+			slog.ErrorContext(ctx, "failed to move file to the failed directory", "file", file, "error", err)
+		}
+
+		return
+	}
+
+	slog.InfoContext(ctx, "imported file", "file", file, "bets", result.Bets, "skipped", result.Err != nil)
+
+	if _, err := move(file, w.options.ProcessedDir, w.now()); err != nil {
+		slog.ErrorContext(ctx, "failed to move file to the processed directory", "file", file, "error", err)
+	}
+}
+
+// fail moves a file to the failed directory and writes its error report next to it
+func (w *Watcher) fail(file string, result Result) error {
+	failedAt := w.now()
+
+	moved, err := move(file, w.options.FailedDir, failedAt)
+	if err != nil {
+		return err
+	}
+
+	report, err := json.MarshalIndent(ErrorReport{
+		File:     filepath.Base(file),
+		FailedAt: failedAt.UTC(),
+		Bets:     result.Bets,
+		Error:    result.Err.Error(),
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode error report: %w", err)
+	}
+
+	if err := os.WriteFile(moved+".error.json", append(report, '\n'), 0o640); err != nil {
+		return fmt.Errorf("failed to write error report: %w", err)
+	}
+
+	return nil
+}
+
+// move moves a file into dir and returns its new path.
+// The file is prefixed with the current time when dir already holds a file with the same name.
+func move(file, dir string, now time.Time) (string, error) {
+	target := filepath.Join(dir, filepath.Base(file))
+
+	if _, err := os.Stat(target); err == nil {
+		target = filepath.Join(dir, now.UTC().Format("20060102T150405Z")+"-"+filepath.Base(file))
+	}
+
+	if err := os.Rename(file, target); err != nil {
+		return "", fmt.Errorf("failed to move %s to %s: %w", file, dir, err)
+	}
+
+	return target, nil
+}
+
+// isPartial reports whether a file name marks an upload in progress
+func isPartial(file string) bool {
+	for _, suffix := range partialSuffixes {
+		if strings.HasSuffix(strings.ToLower(file), suffix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,202 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatcher_Scan(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name          string
+		file          string
+		modTime       time.Time
+		settle        time.Duration
+		processErr    error
+		cancel        bool
+		wantProcessed bool
+		wantFailed    bool
+		wantInInbox   bool
+	}{
+		{
+			name:          "success: settled file is moved to processed",
+			file:          "bets.ndjson",
+			modTime:       now.Add(-time.Minute),
+			settle:        10 * time.Second,
+			wantProcessed: true,
+		},
+		{
+			name:          "success: file imported before is moved to processed",
+			file:          "bets.ndjson",
+			modTime:       now.Add(-time.Minute),
+			settle:        10 * time.Second,
+			processErr:    ErrSkipped,
+			wantProcessed: true,
+		},
+		{
+			name:        "success: file still being written is left in the inbox",
+			file:        "bets.ndjson",
+			modTime:     now,
+			settle:      time.Hour,
+			wantInInbox: true,
+		},
+		{
+			name:        "success: partial upload is ignored",
+			file:        "bets.ndjson.part",
+			modTime:     now.Add(-time.Hour),
+			settle:      10 * time.Second,
+			wantInInbox: true,
+		},
+		{
+			name:        "success: interrupted import is left in the inbox",
+			file:        "bets.ndjson",
+			modTime:     now.Add(-time.Minute),
+			settle:      10 * time.Second,
+			processErr:  context.Canceled,
+			cancel:      true,
+			wantInInbox: true,
+		},
+		{
+			name:       "fail: file is moved to failed with an error report",
+			file:       "bets.ndjson",
+			modTime:    now.Add(-time.Minute),
+			settle:     10 * time.Second,
+			processErr: errors.New("record 3: invalid outcome"),
+			wantFailed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inbox := t.TempDir()
+			path := filepath.Join(inbox, tt.file)
+
+			if err := os.WriteFile(path, []byte("{}\n"), 0o600); err != nil {
+				t.Fatalf("failed to write inbox file: %v", err)
+			}
+
+			if err := os.Chtimes(path, tt.modTime, tt.modTime); err != nil {
+				t.Fatalf("failed to set modification time: %v", err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			processed := 0
+
+			watcher, err := NewWatcher(WatchOptions{Inbox: inbox, Interval: time.Second, Settle: tt.settle},
+				func(_ context.Context, _ string) (int64, error) {
+					processed++
+
+					if tt.cancel {
+						cancel()
+					}
+
+					return 1, tt.processErr
+				})
+			if err != nil {
+				t.Fatalf("NewWatcher() error = %v", err)
+			}
+
+			if err := watcher.Scan(ctx); err != nil {
+				t.Fatalf("Watcher.Scan() error = %v", err)
+			}
+
+			exists := func(path string) bool {
+				_, err := os.Stat(path)
+				return err == nil
+			}
+
+			if got := exists(path); got != tt.wantInInbox {
+				t.Errorf("file in inbox = %v, want %v", got, tt.wantInInbox)
+			}
+
+			if got := exists(filepath.Join(inbox, "processed", tt.file)); got != tt.wantProcessed {
+				t.Errorf("file in processed = %v, want %v", got, tt.wantProcessed)
+			}
+
+			if got := exists(filepath.Join(inbox, "failed", tt.file)); got != tt.wantFailed {
+				t.Errorf("file in failed = %v, want %v", got, tt.wantFailed)
+			}
+
+			if tt.wantFailed {
+				data, err := os.ReadFile(filepath.Join(inbox, "failed", tt.file+".error.json"))
+				if err != nil {
+					t.Fatalf("failed to read error report: %v", err)
+				}
+
+				var report ErrorReport
+				if err := json.Unmarshal(data, &report); err != nil {
+					t.Fatalf("failed to decode error report: %v", err)
+				}
+
+				if report.Error != tt.processErr.Error() || report.File != tt.file || report.Bets != 1 {
+					t.Errorf("error report = %+v", report)
+				}
+			}
+
+			if tt.wantInInbox && !tt.cancel && processed != 0 {
+				t.Errorf("file was processed %d times before it settled", processed)
+			}
+		})
+	}
+}
+
+func TestWatcher_settled(t *testing.T) {
+	inbox := t.TempDir()
+	path := filepath.Join(inbox, "bets.ndjson")
+
+	if err := os.WriteFile(path, []byte("{}\n"), 0o600); err != nil {
+		t.Fatalf("failed to write inbox file: %v", err)
+	}
+
+	watcher, err := NewWatcher(WatchOptions{Inbox: inbox, Interval: time.Second, Settle: 10 * time.Second},
+		func(_ context.Context, _ string) (int64, error) { return 0, nil })
+	if err != nil {
+		t.Fatalf("NewWatcher() error = %v", err)
+	}
+
+	clock := time.Now()
+	watcher.now = func() time.Time { return clock }
+
+	steps := []struct {
+		name    string
+		advance time.Duration
+		grow    bool
+		want    bool
+	}{
+		{name: "fresh file is not settled", want: false},
+		{name: "unchanged but too recent", advance: 5 * time.Second, want: false},
+		{name: "growing file restarts the settle period", advance: 6 * time.Second, grow: true, want: false},
+		{name: "unchanged for the settle period", advance: 20 * time.Second, want: true},
+	}
+
+	for _, step := range steps {
+		clock = clock.Add(step.advance)
+
+		if step.grow {
+			if err := os.WriteFile(path, []byte("{}\n{}\n"), 0o600); err != nil {
+				t.Fatalf("failed to grow inbox file: %v", err)
+			}
+
+			if err := os.Chtimes(path, clock, clock); err != nil {
+				t.Fatalf("failed to set modification time: %v", err)
+			}
+		}
+
+		got, err := watcher.settled(path)
+		if err != nil {
+			t.Fatalf("%s: settled() error = %v", step.name, err)
+		}
+
+		if got != step.want {
+			t.Errorf("%s: settled() = %v, want %v", step.name, got, step.want)
+		}
+	}
+}
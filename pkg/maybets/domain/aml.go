@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+)
+
+// AMLRules are the anti-money laundering rules bets are screened against, amounts in the reporting currency
+type AMLRules struct {
+	// Threshold is the amount from which a single bet is reported, zero disables the rules
+	Threshold Money
+	// StructuringMargin is how far below the threshold, as a fraction of it, a bet still counts as just below it
+	StructuringMargin float64
+	// StructuringBets is the number of bets just below the threshold a user must place within the window to be reported
+	StructuringBets int
+	// StructuringWindow is the longest time the reported bets of a user may span
+	StructuringWindow time.Duration
+}
+
+// Enabled reports whether bets are screened against the rules
+func (r AMLRules) Enabled() bool {
+	return r.Threshold > 0
+}
+
+// floor returns the lowest amount that counts as just below the threshold
+func (r AMLRules) floor() Money {
+	return Money(math.Ceil(float64(r.Threshold) * (1 - r.StructuringMargin)))
+}
+
+// AMLFinding is a bet, or a group of bets of a single user, reported by an anti-money laundering rule
+// along with the bets as evidence
+type AMLFinding struct {
+	Rule   enums.AMLRule `json:"rule"`
+	UserID string        `json:"user_id"`
+	// Amount is the total of the evidence bets in the reporting currency
+	Amount Money `json:"amount"`
+	// From and To are when the first and the last of the evidence bets were placed
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	Bets []*Bet    `json:"bets"`
+}
+
+// Alert returns the alert raised for the finding, holding the IDs of its evidence bets, and names the bet it is about:
+// the large bet, or the first bet of a structuring run that the alerts raised before did not report.
+// Runs regroup as their first bets leave the period screened, so a run whose every bet was reported before is not
+// alerted on again, while the bets that join a run already reported are. reported holds the IDs of those bets.
+func (f AMLFinding) Alert(threshold Money, reported map[string]bool) (Alert, bool) {
+	alert := Alert{
+		Event:     f.Rule.Event(),
+		UserID:    f.UserID,
+		Value:     f.Amount.Float64(),
+		Threshold: threshold.Float64(),
+		Evidence:  make([]string, 0, len(f.Bets)),
+	}
+
+	for _, bet := range f.Bets {
+		alert.Evidence = append(alert.Evidence, bet.BetID)
+
+		if alert.BetID == "" && (f.Rule == enums.LargeTransaction || !reported[bet.BetID]) {
+			alert.BetID = bet.BetID
+		}
+	}
+
+	return alert, alert.BetID != ""
+}
+
+// AMLReport lists the findings of the anti-money laundering rules over a period, oldest first.
+// A nil bound leaves the period open on that side.
+type AMLReport struct {
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+	// Currency is the reporting currency the threshold and the amounts of the findings are in
+	Currency  Currency     `json:"currency"`
+	Threshold Money        `json:"threshold"`
+	Findings  []AMLFinding `json:"findings"`
+}
+
+// screenedBet is a bet along with its amount in the reporting currency
+type screenedBet struct {
+	bet    *Bet
+	amount Money
+}
+
+// AMLScanner screens bets against the anti-money laundering rules
+type AMLScanner struct {
+	rules    AMLRules
+	exchange *Exchange
+	large    []AMLFinding
+	// below holds the bets of each user just below the threshold
+	below map[string][]screenedBet
+}
+
+// NewAMLScanner initializes an AMLScanner screening bets against the rules
+func NewAMLScanner(rules AMLRules, exchange *Exchange) *AMLScanner {
+	return &AMLScanner{
+		rules:    rules,
+		exchange: exchange,
+		below:    map[string][]screenedBet{},
+	}
+}
+
+// Add screens a bet. Only the bets reaching the threshold or just below it are held on to.
+func (s *AMLScanner) Add(bet *Bet) error {
+	if !s.rules.Enabled() {
+		return errors.New("anti-money laundering rules are disabled: no reporting threshold is set")
+	}
+
+	amount, err := s.exchange.Convert(bet.Amount, cmp.Or(bet.Currency, s.exchange.Default))
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case amount >= s.rules.Threshold:
+		s.large = append(s.large, AMLFinding{
+			Rule:   enums.LargeTransaction,
+			UserID: bet.UserID,
+			Amount: amount,
+			From:   bet.Timestamp,
+			To:     bet.Timestamp,
+			Bets:   []*Bet{bet},
+		})
+	case amount >= s.rules.floor():
+		s.below[bet.UserID] = append(s.below[bet.UserID], screenedBet{bet: bet, amount: amount})
+	}
+
+	return nil
+}
+
+// Findings returns the findings of every rule over the bets added, oldest first
+func (s *AMLScanner) Findings() []AMLFinding {
+	findings := slices.Clone(s.large)
+
+	for userID, bets := range s.below {
+		findings = append(findings, s.structuring(userID, bets)...)
+	}
+
+	slices.SortFunc(findings, func(a, b AMLFinding) int {
+		return cmp.Or(
+			a.From.Compare(b.From),
+			cmp.Compare(a.Rule, b.Rule),
+			cmp.Compare(a.UserID, b.UserID),
+			cmp.Compare(a.Bets[0].BetID, b.Bets[0].BetID),
+		)
+	})
+
+	return findings
+}
+
+// structuring groups the bets of a user just below the threshold into runs spanning less than the window and reports
+// every run of enough bets. Runs do not overlap: a run is extended as far as the window allows before the next starts.
+func (s *AMLScanner) structuring(userID string, bets []screenedBet) []AMLFinding {
+	bets = slices.Clone(bets)
+
+	slices.SortFunc(bets, func(a, b screenedBet) int {
+		return cmp.Or(a.bet.Timestamp.Compare(b.bet.Timestamp), cmp.Compare(a.bet.BetID, b.bet.BetID))
+	})
+
+	var findings []AMLFinding
+
+	for start := 0; start < len(bets); {
+		end := start + 1
+		for end < len(bets) && bets[end].bet.Timestamp.Sub(bets[start].bet.Timestamp) < s.rules.StructuringWindow {
+			end++
+		}
+
+		if end-start < s.rules.StructuringBets {
+			start++
+			continue
+		}
+
+		finding := AMLFinding{
+			Rule:   enums.Structuring,
+			UserID: userID,
+			From:   bets[start].bet.Timestamp,
+			To:     bets[end-1].bet.Timestamp,
+		}
+
+		for _, bet := range bets[start:end] {
+			finding.Amount += bet.amount
+			finding.Bets = append(finding.Bets, bet.bet)
+		}
+
+		findings = append(findings, finding)
+		start = end
+	}
+
+	return findings
+}
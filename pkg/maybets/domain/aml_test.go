@@ -0,0 +1,225 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+)
+
+func TestAMLScanner_Findings(t *testing.T) {
+	exchange, err := NewExchange("USD", "USD", map[Currency]string{"KES": "0.0077"})
+	if err != nil {
+		t.Fatalf("NewExchange() error = %v", err)
+	}
+
+	rules := AMLRules{
+		Threshold:         MoneyFromFloat(10000),
+		StructuringMargin: 0.1,
+		StructuringBets:   3,
+		StructuringWindow: 24 * time.Hour,
+	}
+
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	bet := func(id, userID string, amount float64, currency Currency, after time.Duration) *Bet {
+		return &Bet{BetID: id, UserID: userID, Amount: MoneyFromFloat(amount), Currency: currency, Timestamp: start.Add(after)}
+	}
+
+	type finding struct {
+		rule   enums.AMLRule
+		userID string
+		amount float64
+		bets   []string
+	}
+
+	tests := []struct {
+		name    string
+		rules   AMLRules
+		bets    []*Bet
+		want    []finding
+		wantErr bool
+	}{
+		{
+			name:  "success: bet reaching the threshold",
+			rules: rules,
+			bets:  []*Bet{bet("b1", "u1", 10000, "USD", 0), bet("b2", "u1", 50, "USD", time.Hour)},
+			want:  []finding{{rule: enums.LargeTransaction, userID: "u1", amount: 10000, bets: []string{"b1"}}},
+		},
+		{
+			name:  "success: bet reaching the threshold once converted",
+			rules: rules,
+			bets:  []*Bet{bet("b1", "u1", 1500000, "KES", 0)},
+			want:  []finding{{rule: enums.LargeTransaction, userID: "u1", amount: 11550, bets: []string{"b1"}}},
+		},
+		{
+			name:  "success: bets just below the threshold within the window",
+			rules: rules,
+			bets: []*Bet{
+				bet("b3", "u1", 9900, "USD", 20*time.Hour),
+				bet("b1", "u1", 9500, "USD", 0),
+				bet("b2", "u1", 9000, "USD", 2*time.Hour),
+				bet("b4", "u2", 9900, "USD", time.Hour),
+			},
+			want: []finding{{rule: enums.Structuring, userID: "u1", amount: 28400, bets: []string{"b1", "b2", "b3"}}},
+		},
+		{
+			name:  "success: runs of bets do not overlap",
+			rules: rules,
+			bets: []*Bet{
+				bet("b1", "u1", 9500, "USD", 0),
+				bet("b2", "u1", 9500, "USD", time.Hour),
+				bet("b3", "u1", 9500, "USD", 2*time.Hour),
+				bet("b4", "u1", 9500, "USD", 48*time.Hour),
+				bet("b5", "u1", 9500, "USD", 49*time.Hour),
+				bet("b6", "u1", 9500, "USD", 50*time.Hour),
+			},
+			want: []finding{
+				{rule: enums.Structuring, userID: "u1", amount: 28500, bets: []string{"b1", "b2", "b3"}},
+				{rule: enums.Structuring, userID: "u1", amount: 28500, bets: []string{"b4", "b5", "b6"}},
+			},
+		},
+		{
+			name:  "fail: bets just below the threshold spread over more than the window",
+			rules: rules,
+			bets: []*Bet{
+				bet("b1", "u1", 9500, "USD", 0),
+				bet("b2", "u1", 9500, "USD", 12*time.Hour),
+				bet("b3", "u1", 9500, "USD", 24*time.Hour),
+			},
+		},
+		{
+			name:  "fail: bets below the margin",
+			rules: rules,
+			bets: []*Bet{
+				bet("b1", "u1", 8999, "USD", 0),
+				bet("b2", "u1", 8999, "USD", time.Hour),
+				bet("b3", "u1", 8999, "USD", 2*time.Hour),
+			},
+		},
+		{
+			name:    "sad: bet in a currency without a rate",
+			rules:   rules,
+			bets:    []*Bet{bet("b1", "u1", 10000, "EUR", 0)},
+			wantErr: true,
+		},
+		{
+			name:    "sad: rules disabled",
+			bets:    []*Bet{bet("b1", "u1", 10000, "USD", 0)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanner := NewAMLScanner(tt.rules, exchange)
+
+			for _, bet := range tt.bets {
+				if err = scanner.Add(bet); err != nil {
+					break
+				}
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AMLScanner.Add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			var got []finding
+
+			for _, f := range scanner.Findings() {
+				ids := make([]string, 0, len(f.Bets))
+				for _, bet := range f.Bets {
+					ids = append(ids, bet.BetID)
+				}
+
+				got = append(got, finding{rule: f.Rule, userID: f.UserID, amount: f.Amount.Float64(), bets: ids})
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AMLScanner.Findings() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAMLFinding_Alert(t *testing.T) {
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		finding  AMLFinding
+		reported map[string]bool
+		wantKey  string
+		wantOK   bool
+	}{
+		{
+			name: "success: large transaction keyed by its bet",
+			finding: AMLFinding{
+				Rule: enums.LargeTransaction, UserID: "u1", Amount: MoneyFromFloat(10000), From: start, To: start,
+				Bets: []*Bet{{BetID: "b1", UserID: "u1"}},
+			},
+			wantKey: "aml.large_transaction:b1",
+			wantOK:  true,
+		},
+		{
+			name: "success: structuring keyed by the user and the first bet of the run",
+			finding: AMLFinding{
+				Rule: enums.Structuring, UserID: "u1", Amount: MoneyFromFloat(28500), From: start, To: start.Add(time.Hour),
+				Bets: []*Bet{{BetID: "b1", UserID: "u1"}, {BetID: "b2", UserID: "u1"}, {BetID: "b3", UserID: "u1"}},
+			},
+			wantKey: "aml.structuring:u1:b1",
+			wantOK:  true,
+		},
+		{
+			name: "success: structuring keyed by the first bet of the run not reported before",
+			finding: AMLFinding{
+				Rule: enums.Structuring, UserID: "u1", Amount: MoneyFromFloat(28500), From: start, To: start.Add(time.Hour),
+				Bets: []*Bet{{BetID: "b2", UserID: "u1"}, {BetID: "b3", UserID: "u1"}, {BetID: "b4", UserID: "u1"}},
+			},
+			reported: map[string]bool{"b1": true, "b2": true, "b3": true},
+			wantKey:  "aml.structuring:u1:b4",
+			wantOK:   true,
+		},
+		{
+			name: "success: structuring run whose every bet was reported before not alerted on",
+			finding: AMLFinding{
+				Rule: enums.Structuring, UserID: "u1", Amount: MoneyFromFloat(19000), From: start, To: start.Add(time.Hour),
+				Bets: []*Bet{{BetID: "b2", UserID: "u1"}, {BetID: "b3", UserID: "u1"}},
+			},
+			reported: map[string]bool{"b1": true, "b2": true, "b3": true},
+			wantKey:  "aml.structuring:u1:",
+		},
+		{
+			name: "success: large transaction alerted on whatever was reported",
+			finding: AMLFinding{
+				Rule: enums.LargeTransaction, UserID: "u1", Amount: MoneyFromFloat(10000), From: start, To: start,
+				Bets: []*Bet{{BetID: "b1", UserID: "u1"}},
+			},
+			reported: map[string]bool{"b1": true},
+			wantKey:  "aml.large_transaction:b1",
+			wantOK:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alert, ok := tt.finding.Alert(MoneyFromFloat(10000), tt.reported)
+			if ok != tt.wantOK {
+				t.Errorf("AMLFinding.Alert() ok = %v, want %v", ok, tt.wantOK)
+			}
+
+			if got := alert.Key(); got != tt.wantKey {
+				t.Errorf("AMLFinding.Alert().Key() = %v, want %v", got, tt.wantKey)
+			}
+
+			if len(alert.Evidence) != len(tt.finding.Bets) || alert.Value != tt.finding.Amount.Float64() {
+				t.Errorf("AMLFinding.Alert() = %+v, want the evidence and amount of %+v", alert, tt.finding)
+			}
+		})
+	}
+}
@@ -1,22 +1,333 @@
 package domain
 
 import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
 	"time"
 
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
 )
 
+// ErrInvalidBet marks the bets turned down by storage because of what they hold, as opposed to storage failures
+var ErrInvalidBet = errors.New("invalid bet")
+
 type Bet struct {
-	BetID     string        `json:"bet_id"`
-	UserID    string        `json:"user_id"`
-	Amount    float64       `json:"amount"`
+	BetID  string `json:"bet_id"`
+	UserID string `json:"user_id"`
+	Amount Money  `json:"amount"`
+	// Odds are the decimal odds of the bet, whatever the format they were quoted in
 	Odds      float64       `json:"odds"`
 	Outcome   enums.Outcome `json:"outcome"`
 	Timestamp time.Time     `json:"timestamp"`
+	// OddsFormat is the format the odds were quoted in when the bet was placed
+	OddsFormat enums.OddsFormat `json:"odds_format,omitempty"`
+	// QuotedOdds are the odds as they were quoted, empty when they were decimal
+	QuotedOdds string `json:"quoted_odds,omitempty"`
+	// Sport, Competition, EventID and Market describe what the bet was placed on so that analytics can be broken
+	// down by them. They are optional and taken as they are sent.
+	Sport       string `json:"sport,omitempty"`
+	Competition string `json:"competition,omitempty"`
+	EventID     string `json:"event_id,omitempty"`
+	Market      string `json:"market,omitempty"`
+	// Selection is the ID of the selection a single bet is placed on, which settles it when its result is submitted.
+	// It is optional; a multi-leg bet has one per leg instead.
+	Selection string `json:"selection,omitempty"`
+	// Currency is the currency the bet is staked and paid out in. Bets that do not name one take the default currency.
+	Currency Currency `json:"currency,omitempty"`
+	// Cashout is the amount a cashed out bet was settled for
+	Cashout Money `json:"cashout,omitempty"`
+	// Legs holds the selections of a multi-leg (accumulator) bet, whose odds and outcome are derived from them.
+	// It is empty for a single bet.
+	Legs []BetLeg `json:"legs,omitempty"`
+}
+
+// BetLeg is a single selection of a multi-leg bet
+type BetLeg struct {
+	// Selection names the selection of the leg. When it is the ID of a selection, the leg is settled
+	// by the results submitted for it.
+	Selection string        `json:"selection"`
+	Odds      float64       `json:"odds"`
+	Outcome   enums.Outcome `json:"outcome"`
+}
+
+// validLegOutcome checks whether a leg can be in the outcome. Legs are not cashed out on their own
+// and an accumulator cannot pay out on half of a leg.
+func validLegOutcome(outcome enums.Outcome) bool {
+	switch outcome {
+	case enums.Pending, enums.Win, enums.Lose, enums.Void, enums.Push:
+		return true
+	default:
+		return false
+	}
+}
+
+// Derive validates the bet and, for a multi-leg bet, derives its odds and outcome from the legs.
+// The odds are the product of the odds of the legs, void and pushed legs counting as 1.
+// The bet is lost as soon as a leg is lost, pending while a leg is pending, void when every leg is void or pushed
+// and won otherwise. A cashed out bet keeps its outcome whatever the state of its legs.
+// A single bet keeps its own odds and outcome, which must be at least 1.
+func (b *Bet) Derive() error {
+	if !b.Outcome.IsValid() && (len(b.Legs) == 0 || b.Outcome != "") {
+		return fmt.Errorf("invalid outcome %q", b.Outcome)
+	}
+
+	if b.Outcome == enums.Cashout && b.Cashout <= 0 {
+		return fmt.Errorf("invalid cashout %v: a cashed out bet must be settled for a positive amount", b.Cashout)
+	}
+
+	if b.Outcome != enums.Cashout && b.Cashout != 0 {
+		return fmt.Errorf("invalid cashout %v: only cashed out bets have a cashout amount", b.Cashout)
+	}
+
+	if b.Currency != "" {
+		if err := b.ValidateCurrency(); err != nil {
+			return err
+		}
+	}
+
+	if len(b.Legs) == 0 {
+		if b.Odds < 1 {
+			return fmt.Errorf("invalid odds %v: must be at least 1", b.Odds)
+		}
+
+		return nil
+	}
+
+	if b.Selection != "" {
+		return fmt.Errorf("selection: a multi-leg bet is placed on the selections of its legs")
+	}
+
+	odds := 1.0
+	lost, pending, refunded := false, false, 0
+
+	for i, leg := range b.Legs {
+		if leg.Selection == "" {
+			return fmt.Errorf("leg %d: selection: must not be empty", i+1)
+		}
+
+		if leg.Odds < 1 {
+			return fmt.Errorf("leg %d: invalid odds %v: must be at least 1", i+1, leg.Odds)
+		}
+
+		if !validLegOutcome(leg.Outcome) {
+			return fmt.Errorf("leg %d: invalid outcome %q", i+1, leg.Outcome)
+		}
+
+		switch leg.Outcome {
+		case enums.Lose:
+			lost = true
+		case enums.Pending:
+			pending = true
+		case enums.Void, enums.Push:
+			refunded++
+			continue
+		}
+
+		odds *= leg.Odds
+	}
+
+	b.Odds, b.QuotedOdds = odds, ""
+
+	switch {
+	case b.Outcome == enums.Cashout:
+	case lost:
+		b.Outcome = enums.Lose
+	case pending:
+		b.Outcome = enums.Pending
+	case refunded == len(b.Legs):
+		b.Outcome = enums.Void
+	default:
+		b.Outcome = enums.Win
+	}
+
+	return nil
+}
+
+// ValidateCurrency checks the currency of the bet and that its amounts are whole numbers of its minor unit
+func (b *Bet) ValidateCurrency() error {
+	if !b.Currency.IsValid() {
+		return fmt.Errorf("invalid currency %q: must be an ISO 4217 code", b.Currency)
+	}
+
+	if !b.Amount.Fits(b.Currency) {
+		return fmt.Errorf("invalid amount %v: %s amounts have at most %d decimal places", b.Amount, b.Currency, b.Currency.Exponent())
+	}
+
+	if !b.Cashout.Fits(b.Currency) {
+		return fmt.Errorf("invalid cashout %v: %s amounts have at most %d decimal places", b.Cashout, b.Currency, b.Currency.Exponent())
+	}
+
+	return nil
+}
+
+// exactOdds returns the odds the bet pays out at as an exact number: the odds of a single bet, as they were quoted,
+// or the product of the odds of the legs of a multi-leg bet that are neither void nor pushed.
+func (b *Bet) exactOdds() *big.Rat {
+	if len(b.Legs) == 0 {
+		if odds, _, err := parseExactOdds(b.QuotedOdds, b.OddsFormat); b.QuotedOdds != "" && err == nil {
+			return odds
+		}
+
+		return decimalRat(b.Odds)
+	}
+
+	odds := big.NewRat(1, 1)
+
+	for _, leg := range b.Legs {
+		if leg.Outcome != enums.Void && leg.Outcome != enums.Push {
+			odds.Mul(odds, decimalRat(leg.Odds))
+		}
+	}
+
+	return odds
+}
+
+// decimalRat returns the decimal number a floating point number was written as, e.g 1.15 rather than
+// the closest binary fraction to it
+func decimalRat(value float64) *big.Rat {
+	rat, _ := new(big.Rat).SetString(strconv.FormatFloat(value, 'f', -1, 64))
+
+	return rat
+}
+
+// Payout is the amount returned for the bet: the stake times the odds when it is won, the stake when it is void
+// or pushed, the cashout amount when it is cashed out and the refunded half of the stake plus the winnings
+// on the other half for half results. Lost and pending bets return nothing.
+// Payouts are computed exactly and rounded to the minor unit of the currency of the bet, halves away from zero.
+func (b *Bet) Payout() Money {
+	half := big.NewRat(1, 2)
+	payout := b.Amount.rat()
+
+	switch b.Outcome {
+	case enums.Win:
+		return b.PotentialPayout()
+	case enums.HalfWin:
+		payout.Mul(payout, half).Mul(payout, new(big.Rat).Add(b.exactOdds(), big.NewRat(1, 1)))
+	case enums.HalfLose:
+		payout.Mul(payout, half)
+	case enums.Void, enums.Push:
+		return b.Amount
+	case enums.Cashout:
+		return b.Cashout
+	default:
+		return 0
+	}
+
+	return roundMoney(payout, b.Currency)
+}
+
+// PotentialPayout is the amount the bet returns if it is won, whatever its state: the stake times the odds,
+// rounded like Payout
+func (b *Bet) PotentialPayout() Money {
+	payout := b.Amount.rat()
+
+	return roundMoney(payout.Mul(payout, b.exactOdds()), b.Currency)
+}
+
+// Loss is the part of the stake of a settled bet that was not returned
+func (b *Bet) Loss() Money {
+	if !b.Outcome.IsSettled() {
+		return 0
+	}
+
+	return max(b.Amount-b.Payout(), 0)
+}
+
+// Settlement changes the state of a placed bet
+type Settlement struct {
+	BetID string `json:"-"`
+	// Outcome is the new state of a single bet. A multi-leg bet takes it from its legs
+	// and only accepts it to be cashed out.
+	Outcome enums.Outcome `json:"outcome"`
+	Cashout Money         `json:"cashout"`
+	// Legs holds the new state of every leg of a multi-leg bet, in order
+	Legs []enums.Outcome `json:"legs"`
+	// SettledBy identifies who settled the bet
+	SettledBy string `json:"settled_by"`
+}
+
+// Settle applies the settlement to the bet and derives its new odds and outcome
+func (b *Bet) Settle(settlement Settlement) error {
+	if len(b.Legs) == 0 && len(settlement.Legs) > 0 {
+		return fmt.Errorf("bet %s is a single bet: legs cannot be settled", b.BetID)
+	}
+
+	if len(b.Legs) > 0 {
+		if settlement.Outcome != "" && settlement.Outcome != enums.Cashout {
+			return fmt.Errorf("invalid outcome %q: bet %s takes its outcome from its legs and can only be cashed out", settlement.Outcome, b.BetID)
+		}
+
+		if len(settlement.Legs) > 0 && len(settlement.Legs) != len(b.Legs) {
+			return fmt.Errorf("bet %s has %d legs, %d given", b.BetID, len(b.Legs), len(settlement.Legs))
+		}
+
+		for i, outcome := range settlement.Legs {
+			b.Legs[i].Outcome = outcome
+		}
+	}
+
+	b.Outcome = settlement.Outcome
+	b.Cashout = settlement.Cashout
+
+	return b.Derive()
 }
 
 type User struct {
-	ID            string  `json:"id"`
-	TotalBets     int64   `json:"total_bets,omitempty"`
-	TotalWinnings float64 `json:"winnings,omitempty"`
+	ID            string `json:"id"`
+	TotalBets     int64  `json:"total_bets,omitempty"`
+	TotalWinnings Money  `json:"winnings,omitempty"`
+	TotalLosses   Money  `json:"losses,omitempty"`
+	TotalPayout   Money  `json:"payout,omitempty"`
+	// Currency is the reporting currency the amounts above are converted into, empty when there are none
+	Currency Currency `json:"currency,omitempty"`
+	// WinRate is the share of the decided stakes that was won, half results counting half
+	WinRate float64 `json:"win_rate,omitempty"`
+}
+
+// Breakdown holds the totals of the bets that share the same values of the dimensions analytics are broken down by
+type Breakdown struct {
+	// Dimensions holds the value of each dimension broken down by, empty for bets that do not say
+	Dimensions map[enums.Dimension]string `json:"dimensions"`
+	Bets       int64                      `json:"bets"`
+	// Volume adds up the stakes of the bets and Payout the amounts returned for the settled ones
+	Volume Money `json:"volume"`
+	Payout Money `json:"payout"`
+	// Margin is what the settled bets kept, their stakes less their payouts, and MarginRate its share of their stakes.
+	// Pending bets count towards the volume only.
+	Margin     Money   `json:"margin"`
+	MarginRate float64 `json:"margin_rate"`
+	// Currency is the reporting currency the amounts are converted into
+	Currency Currency `json:"currency"`
+}
+
+// BetFilter narrows down the bets returned by a query.
+// Zero values do not filter.
+type BetFilter struct {
+	UserID  string
+	EventID string
+	Outcome enums.Outcome
+	From    *time.Time
+	To      *time.Time
+	// Limit caps the number of bets returned
+	Limit int
+}
+
+// IngestJob tracks the import of a single file so that an interrupted import can be resumed
+type IngestJob struct {
+	ID     string                `json:"id"`
+	Path   string                `json:"path"`
+	Size   int64                 `json:"size"`
+	Hash   string                `json:"hash"`
+	Format enums.FileFormat      `json:"format"`
+	Status enums.IngestJobStatus `json:"status"`
+	// Offset is the number of bytes of the uncompressed file committed so far
+	Offset int64 `json:"offset"`
+	// Records is the number of records committed so far
+	Records     int64      `json:"records"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
 }
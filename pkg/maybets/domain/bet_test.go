@@ -0,0 +1,397 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+)
+
+func TestBet_Derive(t *testing.T) {
+	tests := []struct {
+		name        string
+		bet         Bet
+		wantOdds    float64
+		wantOutcome enums.Outcome
+		wantPayout  Money
+		wantErr     bool
+	}{
+		{
+			name:        "success: single bet keeps its odds and outcome",
+			bet:         Bet{Amount: 10 * moneyUnit, Odds: 2.5, Outcome: enums.Win},
+			wantOdds:    2.5,
+			wantOutcome: enums.Win,
+			wantPayout:  25 * moneyUnit,
+		},
+		{
+			name: "success: accumulator with every leg won",
+			bet: Bet{Amount: 10 * moneyUnit, Legs: []BetLeg{
+				{Selection: "home", Odds: 2, Outcome: enums.Win},
+				{Selection: "over 2.5", Odds: 1.5, Outcome: enums.Win},
+				{Selection: "draw", Odds: 4, Outcome: enums.Win},
+			}},
+			wantOdds:    12,
+			wantOutcome: enums.Win,
+			wantPayout:  120 * moneyUnit,
+		},
+		{
+			name: "success: accumulator with a lost leg is lost",
+			bet: Bet{Amount: 10 * moneyUnit, Odds: 99, Outcome: enums.Win, Legs: []BetLeg{
+				{Selection: "home", Odds: 2, Outcome: enums.Win},
+				{Selection: "away", Odds: 3, Outcome: enums.Lose},
+			}},
+			wantOdds:    6,
+			wantOutcome: enums.Lose,
+			wantPayout:  0,
+		},
+		{
+			name: "success: accumulator with a pending leg is pending",
+			bet: Bet{Amount: 10 * moneyUnit, Legs: []BetLeg{
+				{Selection: "home", Odds: 2, Outcome: enums.Win},
+				{Selection: "away", Odds: 3, Outcome: enums.Pending},
+			}},
+			wantOdds:    6,
+			wantOutcome: enums.Pending,
+			wantPayout:  0,
+		},
+		{
+			name: "success: void legs count as odds of 1",
+			bet: Bet{Amount: 10 * moneyUnit, Legs: []BetLeg{
+				{Selection: "home", Odds: 2, Outcome: enums.Win},
+				{Selection: "away", Odds: 3, Outcome: enums.Void},
+				{Selection: "draw", Odds: 4, Outcome: enums.Push},
+			}},
+			wantOdds:    2,
+			wantOutcome: enums.Win,
+			wantPayout:  20 * moneyUnit,
+		},
+		{
+			name: "success: accumulator with every leg void is void",
+			bet: Bet{Amount: 10 * moneyUnit, Legs: []BetLeg{
+				{Selection: "home", Odds: 2, Outcome: enums.Void},
+				{Selection: "away", Odds: 3, Outcome: enums.Push},
+			}},
+			wantOdds:    1,
+			wantOutcome: enums.Void,
+			wantPayout:  10 * moneyUnit,
+		},
+		{
+			name: "success: cashed out accumulator keeps its outcome",
+			bet: Bet{Amount: 10 * moneyUnit, Outcome: enums.Cashout, Cashout: 14 * moneyUnit, Legs: []BetLeg{
+				{Selection: "home", Odds: 2, Outcome: enums.Win},
+				{Selection: "away", Odds: 3, Outcome: enums.Pending},
+			}},
+			wantOdds:    6,
+			wantOutcome: enums.Cashout,
+			wantPayout:  14 * moneyUnit,
+		},
+		{
+			name:        "success: half won bet",
+			bet:         Bet{Amount: 10 * moneyUnit, Odds: 1.9, Outcome: enums.HalfWin},
+			wantOdds:    1.9,
+			wantOutcome: enums.HalfWin,
+			wantPayout:  14.5 * moneyUnit,
+		},
+		{
+			name:        "success: half lost bet refunds half of the stake",
+			bet:         Bet{Amount: 10 * moneyUnit, Odds: 1.9, Outcome: enums.HalfLose},
+			wantOdds:    1.9,
+			wantOutcome: enums.HalfLose,
+			wantPayout:  5 * moneyUnit,
+		},
+		{
+			name:        "success: pushed bet refunds the stake",
+			bet:         Bet{Amount: 10 * moneyUnit, Odds: 1.9, Outcome: enums.Push},
+			wantOdds:    1.9,
+			wantOutcome: enums.Push,
+			wantPayout:  10 * moneyUnit,
+		},
+		{
+			name:        "success: pending bet returns nothing yet",
+			bet:         Bet{Amount: 10 * moneyUnit, Odds: 1.9, Outcome: enums.Pending},
+			wantOdds:    1.9,
+			wantOutcome: enums.Pending,
+			wantPayout:  0,
+		},
+		{
+			name:        "success: payout rounded to the cent",
+			bet:         Bet{Amount: 100100, Odds: 1.95, Outcome: enums.Win, Currency: "USD"},
+			wantOdds:    1.95,
+			wantOutcome: enums.Win,
+			wantPayout:  195200,
+		},
+		{
+			name:        "success: payout computed with the decimal odds rather than their binary approximation",
+			bet:         Bet{Amount: 1000, Odds: 1.15, Outcome: enums.Win, Currency: "KES"},
+			wantOdds:    1.15,
+			wantOutcome: enums.Win,
+			wantPayout:  1200,
+		},
+		{
+			name: "success: payout computed with the odds as they were quoted",
+			bet: Bet{
+				Amount: 10 * moneyUnit, Odds: 4.0 / 3, OddsFormat: enums.Fractional, QuotedOdds: "1/3", Outcome: enums.Win,
+				Currency: "USD",
+			},
+			wantOdds:    4.0 / 3,
+			wantOutcome: enums.Win,
+			wantPayout:  133300,
+		},
+		{
+			name:        "success: payout rounded to the minor unit of the currency",
+			bet:         Bet{Amount: 1000 * moneyUnit, Odds: 1.2555, Outcome: enums.Win, Currency: "JPY"},
+			wantOdds:    1.2555,
+			wantOutcome: enums.Win,
+			wantPayout:  1256 * moneyUnit,
+		},
+		{
+			name:    "fail: amount finer than the minor unit of its currency",
+			bet:     Bet{Amount: 10.5 * moneyUnit, Odds: 2, Outcome: enums.Pending, Currency: "JPY"},
+			wantErr: true,
+		},
+		{
+			name:    "fail: invalid currency",
+			bet:     Bet{Amount: 10 * moneyUnit, Odds: 2, Outcome: enums.Pending, Currency: "kes"},
+			wantErr: true,
+		},
+		{
+			name:    "fail: single bet with an invalid outcome",
+			bet:     Bet{Amount: 10 * moneyUnit, Odds: 2, Outcome: "settled"},
+			wantErr: true,
+		},
+		{
+			name:    "fail: single bet without odds",
+			bet:     Bet{Amount: 10 * moneyUnit, Outcome: enums.Win},
+			wantErr: true,
+		},
+		{
+			name:    "fail: single bet with odds below 1",
+			bet:     Bet{Amount: 10 * moneyUnit, Odds: 0.5, Outcome: enums.Win},
+			wantErr: true,
+		},
+		{
+			name: "fail: leg without a selection",
+			bet: Bet{Amount: 10 * moneyUnit, Legs: []BetLeg{
+				{Odds: 2, Outcome: enums.Win},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "fail: accumulator placed on a selection of its own",
+			bet: Bet{Amount: 10 * moneyUnit, Selection: "s1", Legs: []BetLeg{
+				{Selection: "home", Odds: 2, Outcome: enums.Win},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "fail: leg with odds below 1",
+			bet: Bet{Amount: 10 * moneyUnit, Legs: []BetLeg{
+				{Selection: "home", Odds: 0.5, Outcome: enums.Win},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "fail: leg with an invalid outcome",
+			bet: Bet{Amount: 10 * moneyUnit, Legs: []BetLeg{
+				{Selection: "home", Odds: 2, Outcome: enums.HalfWin},
+			}},
+			wantErr: true,
+		},
+		{
+			name:    "fail: cashout without an amount",
+			bet:     Bet{Amount: 10 * moneyUnit, Odds: 2, Outcome: enums.Cashout},
+			wantErr: true,
+		},
+		{
+			name:    "fail: cashout amount on a bet that was not cashed out",
+			bet:     Bet{Amount: 10 * moneyUnit, Odds: 2, Outcome: enums.Win, Cashout: 5 * moneyUnit},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.bet.Derive()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Bet.Derive() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.bet.Odds != tt.wantOdds || tt.bet.Outcome != tt.wantOutcome {
+				t.Errorf("Bet.Derive() odds, outcome = %v, %v, want %v, %v", tt.bet.Odds, tt.bet.Outcome, tt.wantOdds, tt.wantOutcome)
+			}
+
+			if payout := tt.bet.Payout(); payout != tt.wantPayout {
+				t.Errorf("Bet.Payout() = %v, want %v", payout, tt.wantPayout)
+			}
+		})
+	}
+}
+
+func TestBet_PotentialPayout(t *testing.T) {
+	tests := []struct {
+		name string
+		bet  Bet
+		want Money
+	}{
+		{
+			name: "success: pending bet returns its stake times the odds",
+			bet:  Bet{Amount: 10 * moneyUnit, Odds: 2.1, Currency: "USD", Outcome: enums.Pending},
+			want: 21 * moneyUnit,
+		},
+		{
+			name: "success: exact quoted odds rounded to the minor unit",
+			bet:  Bet{Amount: 10 * moneyUnit, Odds: 1.33, QuotedOdds: "1/3", OddsFormat: enums.Fractional, Currency: "USD", Outcome: enums.Pending},
+			want: 133300,
+		},
+		{
+			name: "success: lost bet still has the payout it would have had",
+			bet:  Bet{Amount: 10 * moneyUnit, Odds: 2, Currency: "USD", Outcome: enums.Lose},
+			want: 20 * moneyUnit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.bet.PotentialPayout(); got != tt.want {
+				t.Errorf("Bet.PotentialPayout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBet_Loss(t *testing.T) {
+	tests := []struct {
+		name string
+		bet  Bet
+		want Money
+	}{
+		{
+			name: "success: lost bet loses its stake",
+			bet:  Bet{Amount: 10 * moneyUnit, Odds: 2, Outcome: enums.Lose},
+			want: 10 * moneyUnit,
+		},
+		{
+			name: "success: half lost bet loses half of its stake",
+			bet:  Bet{Amount: 10 * moneyUnit, Odds: 2, Outcome: enums.HalfLose},
+			want: 5 * moneyUnit,
+		},
+		{
+			name: "success: bet cashed out below its stake loses the difference",
+			bet:  Bet{Amount: 10 * moneyUnit, Odds: 2, Outcome: enums.Cashout, Cashout: 4 * moneyUnit},
+			want: 6 * moneyUnit,
+		},
+		{
+			name: "success: won bet loses nothing",
+			bet:  Bet{Amount: 10 * moneyUnit, Odds: 2, Outcome: enums.Win},
+			want: 0,
+		},
+		{
+			name: "success: pending bet loses nothing yet",
+			bet:  Bet{Amount: 10 * moneyUnit, Odds: 2, Outcome: enums.Pending},
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.bet.Loss(); got != tt.want {
+				t.Errorf("Bet.Loss() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBet_Settle(t *testing.T) {
+	accumulator := func() Bet {
+		return Bet{BetID: "b1", Amount: 10 * moneyUnit, Odds: 6, Outcome: enums.Pending, Legs: []BetLeg{
+			{Selection: "home", Odds: 2, Outcome: enums.Pending},
+			{Selection: "away", Odds: 3, Outcome: enums.Pending},
+		}}
+	}
+
+	tests := []struct {
+		name        string
+		bet         Bet
+		settlement  Settlement
+		wantOutcome enums.Outcome
+		wantPayout  Money
+		wantErr     bool
+	}{
+		{
+			name:        "success: settle a single bet",
+			bet:         Bet{BetID: "b1", Amount: 10 * moneyUnit, Odds: 2, Outcome: enums.Pending},
+			settlement:  Settlement{Outcome: enums.Win},
+			wantOutcome: enums.Win,
+			wantPayout:  20 * moneyUnit,
+		},
+		{
+			name:        "success: correct a settled single bet",
+			bet:         Bet{BetID: "b1", Amount: 10 * moneyUnit, Odds: 2, Outcome: enums.Win},
+			settlement:  Settlement{Outcome: enums.Void},
+			wantOutcome: enums.Void,
+			wantPayout:  10 * moneyUnit,
+		},
+		{
+			name:        "success: settle the legs of an accumulator",
+			bet:         accumulator(),
+			settlement:  Settlement{Legs: []enums.Outcome{enums.Win, enums.Win}},
+			wantOutcome: enums.Win,
+			wantPayout:  60 * moneyUnit,
+		},
+		{
+			name:        "success: cash out an accumulator",
+			bet:         accumulator(),
+			settlement:  Settlement{Outcome: enums.Cashout, Cashout: 12 * moneyUnit},
+			wantOutcome: enums.Cashout,
+			wantPayout:  12 * moneyUnit,
+		},
+		{
+			name:       "fail: accumulator outcome not taken from its legs",
+			bet:        accumulator(),
+			settlement: Settlement{Outcome: enums.Win},
+			wantErr:    true,
+		},
+		{
+			name:       "fail: wrong number of legs",
+			bet:        accumulator(),
+			settlement: Settlement{Legs: []enums.Outcome{enums.Win}},
+			wantErr:    true,
+		},
+		{
+			name:       "fail: legs of a single bet",
+			bet:        Bet{BetID: "b1", Amount: 10 * moneyUnit, Odds: 2, Outcome: enums.Pending},
+			settlement: Settlement{Outcome: enums.Win, Legs: []enums.Outcome{enums.Win}},
+			wantErr:    true,
+		},
+		{
+			name:       "fail: invalid outcome",
+			bet:        Bet{BetID: "b1", Amount: 10 * moneyUnit, Odds: 2, Outcome: enums.Pending},
+			settlement: Settlement{Outcome: "settled"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.bet.Settle(tt.settlement)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Bet.Settle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.bet.Outcome != tt.wantOutcome {
+				t.Errorf("Bet.Settle() outcome = %v, want %v", tt.bet.Outcome, tt.wantOutcome)
+			}
+
+			if payout := tt.bet.Payout(); payout != tt.wantPayout {
+				t.Errorf("Bet.Payout() = %v, want %v", payout, tt.wantPayout)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+)
+
+// Event is a fixture whose markets bets are placed on
+type Event struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	StartsAt time.Time `json:"starts_at"`
+	Markets  []Market  `json:"markets"`
+}
+
+// Market is a question about an event, answered by the results of its selections
+type Market struct {
+	ID         string      `json:"id"`
+	Name       string      `json:"name"`
+	Selections []Selection `json:"selections"`
+}
+
+// Selection is a possible answer to a market. Bets and legs placed on it name its ID as their selection.
+type Selection struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Result is the last result submitted for the selection, empty until one is
+	Result enums.Outcome `json:"result,omitempty"`
+}
+
+// Validate checks that the event and every market and selection of it are named.
+// IDs are optional and generated when they are missing.
+func (e Event) Validate() error {
+	if e.Name == "" {
+		return fmt.Errorf("name: must not be empty")
+	}
+
+	if e.StartsAt.IsZero() {
+		return fmt.Errorf("starts_at: must not be empty")
+	}
+
+	for i, market := range e.Markets {
+		if market.Name == "" {
+			return fmt.Errorf("market %d: name: must not be empty", i+1)
+		}
+
+		if len(market.Selections) == 0 {
+			return fmt.Errorf("market %d: selections: must not be empty", i+1)
+		}
+
+		for j, selection := range market.Selections {
+			if selection.Name == "" {
+				return fmt.Errorf("market %d: selection %d: name: must not be empty", i+1, j+1)
+			}
+
+			if selection.Result != "" {
+				return fmt.Errorf("market %d: selection %d: result: results are submitted on their own", i+1, j+1)
+			}
+		}
+	}
+
+	return nil
+}
+
+// SelectionResult is a result submitted for a selection, along with the summary of the bets it settled.
+// Every submission is kept, so that the results a selection was resettled with can be audited.
+type SelectionResult struct {
+	ID          string        `json:"id"`
+	SelectionID string        `json:"selection_id"`
+	Result      enums.Outcome `json:"result"`
+	// PreviousResult is the result the submission replaced, empty for the first one
+	PreviousResult enums.Outcome `json:"previous_result,omitempty"`
+	// SubmittedBy identifies who submitted the result
+	SubmittedBy string    `json:"submitted_by"`
+	SubmittedAt time.Time `json:"submitted_at"`
+	// BetsSettled is the number of bets the result settled, or settled again
+	BetsSettled int `json:"bets_settled"`
+	// Stake and Payout add up the amounts and the payouts of the bets settled, in Currency
+	Stake    Money    `json:"stake"`
+	Payout   Money    `json:"payout"`
+	Currency Currency `json:"currency"`
+}
+
+// Validate checks the submission. A selection is won, lost, void or pushed; half results only settle bets one by one.
+func (r SelectionResult) Validate() error {
+	if r.SelectionID == "" {
+		return fmt.Errorf("selection_id: must not be empty")
+	}
+
+	switch r.Result {
+	case enums.Win, enums.Lose, enums.Void, enums.Push:
+	default:
+		return fmt.Errorf("invalid result %q: a selection is won, lost, void or pushed", r.Result)
+	}
+
+	if r.SubmittedBy == "" {
+		return fmt.Errorf("submitted_by: must identify who submitted the result")
+	}
+
+	return nil
+}
+
+// Resettlement reports whether the submission corrects an earlier result
+func (r SelectionResult) Resettlement() bool {
+	return r.PreviousResult != ""
+}
+
+// ApplyResult settles the bet, or its legs, on the selection with the result and reports whether it changed.
+// Only the bets and legs still pending, or in the state the previous result of the selection left them in,
+// take the result: bets settled by hand and cashed out bets are left alone.
+func (b *Bet) ApplyResult(selectionID string, result, previous enums.Outcome) (bool, error) {
+	if b.Outcome == enums.Cashout {
+		return false, nil
+	}
+
+	applies := func(outcome enums.Outcome) bool {
+		return outcome != result && (outcome == enums.Pending || (previous != "" && outcome == previous))
+	}
+
+	if len(b.Legs) == 0 {
+		if b.Selection != selectionID || !applies(b.Outcome) {
+			return false, nil
+		}
+
+		b.Outcome = result
+
+		return true, b.Derive()
+	}
+
+	var changed bool
+
+	for i, leg := range b.Legs {
+		if leg.Selection == selectionID && applies(leg.Outcome) {
+			b.Legs[i].Outcome = result
+			changed = true
+		}
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	return true, b.Derive()
+}
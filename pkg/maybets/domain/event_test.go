@@ -0,0 +1,240 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+)
+
+func TestEvent_Validate(t *testing.T) {
+	startsAt := time.Date(2024, 11, 1, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		event   Event
+		wantErr bool
+	}{
+		{
+			name: "success: event with markets and selections",
+			event: Event{Name: "ARS-CHE", StartsAt: startsAt, Markets: []Market{
+				{Name: "Match result", Selections: []Selection{{ID: "ars-che-home", Name: "Home"}, {Name: "Away"}}},
+			}},
+		},
+		{
+			name:  "success: event without markets",
+			event: Event{Name: "ARS-CHE", StartsAt: startsAt},
+		},
+		{
+			name:    "fail: event without a name",
+			event:   Event{StartsAt: startsAt},
+			wantErr: true,
+		},
+		{
+			name:    "fail: event without a start",
+			event:   Event{Name: "ARS-CHE"},
+			wantErr: true,
+		},
+		{
+			name:    "fail: market without selections",
+			event:   Event{Name: "ARS-CHE", StartsAt: startsAt, Markets: []Market{{Name: "Match result"}}},
+			wantErr: true,
+		},
+		{
+			name: "fail: selection without a name",
+			event: Event{Name: "ARS-CHE", StartsAt: startsAt, Markets: []Market{
+				{Name: "Match result", Selections: []Selection{{ID: "ars-che-home"}}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "fail: selection with a result",
+			event: Event{Name: "ARS-CHE", StartsAt: startsAt, Markets: []Market{
+				{Name: "Match result", Selections: []Selection{{Name: "Home", Result: enums.Win}}},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.event.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Event.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSelectionResult_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  SelectionResult
+		wantErr bool
+	}{
+		{
+			name:   "success: won selection",
+			result: SelectionResult{SelectionID: "s1", Result: enums.Win, SubmittedBy: "feed"},
+		},
+		{
+			name:   "success: void selection",
+			result: SelectionResult{SelectionID: "s1", Result: enums.Void, SubmittedBy: "feed"},
+		},
+		{
+			name:    "fail: half result",
+			result:  SelectionResult{SelectionID: "s1", Result: enums.HalfWin, SubmittedBy: "feed"},
+			wantErr: true,
+		},
+		{
+			name:    "fail: pending result",
+			result:  SelectionResult{SelectionID: "s1", Result: enums.Pending, SubmittedBy: "feed"},
+			wantErr: true,
+		},
+		{
+			name:    "fail: result without submitter",
+			result:  SelectionResult{SelectionID: "s1", Result: enums.Win},
+			wantErr: true,
+		},
+		{
+			name:    "fail: result without selection",
+			result:  SelectionResult{Result: enums.Win, SubmittedBy: "feed"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.result.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("SelectionResult.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBet_ApplyResult(t *testing.T) {
+	accumulator := func(first, second enums.Outcome) Bet {
+		return Bet{BetID: "b1", Amount: 10 * moneyUnit, Odds: 6, Outcome: enums.Pending, Legs: []BetLeg{
+			{Selection: "s1", Odds: 2, Outcome: first},
+			{Selection: "s2", Odds: 3, Outcome: second},
+		}}
+	}
+
+	type args struct {
+		selectionID string
+		result      enums.Outcome
+		previous    enums.Outcome
+	}
+
+	tests := []struct {
+		name        string
+		bet         Bet
+		args        args
+		wantChanged bool
+		wantOutcome enums.Outcome
+		wantPayout  Money
+	}{
+		{
+			name:        "success: settle a pending single bet",
+			bet:         Bet{Amount: 10 * moneyUnit, Odds: 2, Outcome: enums.Pending, Selection: "s1"},
+			args:        args{selectionID: "s1", result: enums.Win},
+			wantChanged: true,
+			wantOutcome: enums.Win,
+			wantPayout:  20 * moneyUnit,
+		},
+		{
+			name:        "success: resettle a single bet left by the previous result",
+			bet:         Bet{Amount: 10 * moneyUnit, Odds: 2, Outcome: enums.Win, Selection: "s1"},
+			args:        args{selectionID: "s1", result: enums.Lose, previous: enums.Win},
+			wantChanged: true,
+			wantOutcome: enums.Lose,
+			wantPayout:  0,
+		},
+		{
+			name:        "success: bet settled by hand is left alone on resettlement",
+			bet:         Bet{Amount: 10 * moneyUnit, Odds: 2, Outcome: enums.Void, Selection: "s1"},
+			args:        args{selectionID: "s1", result: enums.Lose, previous: enums.Win},
+			wantChanged: false,
+			wantOutcome: enums.Void,
+			wantPayout:  10 * moneyUnit,
+		},
+		{
+			name:        "success: settled bet is left alone on the first result",
+			bet:         Bet{Amount: 10 * moneyUnit, Odds: 2, Outcome: enums.Lose, Selection: "s1"},
+			args:        args{selectionID: "s1", result: enums.Win},
+			wantChanged: false,
+			wantOutcome: enums.Lose,
+			wantPayout:  0,
+		},
+		{
+			name:        "success: bet on another selection is left alone",
+			bet:         Bet{Amount: 10 * moneyUnit, Odds: 2, Outcome: enums.Pending, Selection: "s2"},
+			args:        args{selectionID: "s1", result: enums.Win},
+			wantChanged: false,
+			wantOutcome: enums.Pending,
+			wantPayout:  0,
+		},
+		{
+			name:        "success: cashed out bet is left alone",
+			bet:         Bet{Amount: 10 * moneyUnit, Odds: 2, Outcome: enums.Cashout, Cashout: 8 * moneyUnit, Selection: "s1"},
+			args:        args{selectionID: "s1", result: enums.Lose},
+			wantChanged: false,
+			wantOutcome: enums.Cashout,
+			wantPayout:  8 * moneyUnit,
+		},
+		{
+			name:        "success: settled leg keeps the accumulator pending",
+			bet:         accumulator(enums.Pending, enums.Pending),
+			args:        args{selectionID: "s1", result: enums.Win},
+			wantChanged: true,
+			wantOutcome: enums.Pending,
+			wantPayout:  0,
+		},
+		{
+			name:        "success: last leg settles the accumulator",
+			bet:         accumulator(enums.Win, enums.Pending),
+			args:        args{selectionID: "s2", result: enums.Void},
+			wantChanged: true,
+			wantOutcome: enums.Win,
+			wantPayout:  20 * moneyUnit,
+		},
+		{
+			name:        "success: resettled leg loses the accumulator",
+			bet:         accumulator(enums.Win, enums.Win),
+			args:        args{selectionID: "s1", result: enums.Lose, previous: enums.Win},
+			wantChanged: true,
+			wantOutcome: enums.Lose,
+			wantPayout:  0,
+		},
+		{
+			name: "success: same result again changes nothing",
+			bet: Bet{Amount: 10 * moneyUnit, Odds: 6, Outcome: enums.Win, Legs: []BetLeg{
+				{Selection: "s1", Odds: 2, Outcome: enums.Win},
+				{Selection: "s2", Odds: 3, Outcome: enums.Win},
+			}},
+			args:        args{selectionID: "s1", result: enums.Win, previous: enums.Win},
+			wantChanged: false,
+			wantOutcome: enums.Win,
+			wantPayout:  60 * moneyUnit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed, err := tt.bet.ApplyResult(tt.args.selectionID, tt.args.result, tt.args.previous)
+			if err != nil {
+				t.Fatalf("Bet.ApplyResult() error = %v", err)
+			}
+
+			if changed != tt.wantChanged {
+				t.Errorf("Bet.ApplyResult() = %v, want %v", changed, tt.wantChanged)
+			}
+
+			if tt.bet.Outcome != tt.wantOutcome {
+				t.Errorf("Bet.ApplyResult() outcome = %v, want %v", tt.bet.Outcome, tt.wantOutcome)
+			}
+
+			if payout := tt.bet.Payout(); payout != tt.wantPayout {
+				t.Errorf("Bet.Payout() = %v, want %v", payout, tt.wantPayout)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+)
+
+// Exclusion is a period a user excluded themselves from betting over
+type Exclusion struct {
+	ID       string    `json:"id"`
+	UserID   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	// EndsAt is when the exclusion ends, nil when it lasts until it is lifted
+	EndsAt *time.Time `json:"ends_at,omitempty"`
+	Reason string     `json:"reason"`
+	// LiftedAt is when the exclusion was lifted and LiftedBy who lifted it, empty while it stands
+	LiftedAt  *time.Time `json:"lifted_at,omitempty"`
+	LiftedBy  string     `json:"lifted_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Validate checks that the exclusion names a user and a reason and that it ends after it starts
+func (e Exclusion) Validate() error {
+	if e.UserID == "" {
+		return errors.New("user_id: must not be empty")
+	}
+
+	if e.Reason == "" {
+		return errors.New("reason: must not be empty")
+	}
+
+	if e.StartsAt.IsZero() {
+		return errors.New("starts_at: must be set")
+	}
+
+	if e.EndsAt != nil && !e.EndsAt.After(e.StartsAt) {
+		return fmt.Errorf("invalid ends_at %s: must be after starts_at %s", e.EndsAt.Format(time.RFC3339), e.StartsAt.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// Until returns when the exclusion stops covering bets, the earlier of its end and when it was lifted,
+// nil when it lasts until it is lifted
+func (e Exclusion) Until() *time.Time {
+	switch {
+	case e.LiftedAt == nil:
+		return e.EndsAt
+	case e.EndsAt == nil || e.LiftedAt.Before(*e.EndsAt):
+		return e.LiftedAt
+	default:
+		return e.EndsAt
+	}
+}
+
+// Covers reports whether a bet placed at the given time falls within the exclusion
+func (e Exclusion) Covers(at time.Time) bool {
+	until := e.Until()
+
+	return !at.Before(e.StartsAt) && (until == nil || at.Before(*until))
+}
+
+// Overlaps reports whether the exclusion covers any time from the start of the period to its end.
+// A nil bound leaves the period open on that side.
+func (e Exclusion) Overlaps(from, to *time.Time) bool {
+	until := e.Until()
+
+	return (to == nil || e.StartsAt.Before(*to)) && (from == nil || until == nil || until.After(*from))
+}
+
+// Lift ends the exclusion at the given time, recording who lifted it
+func (e *Exclusion) Lift(at time.Time, liftedBy string) error {
+	if liftedBy == "" {
+		return errors.New("lifted_by: must identify who lifted the exclusion")
+	}
+
+	if until := e.Until(); until != nil && !until.After(at) {
+		return fmt.Errorf("exclusion %s is already over since %s", e.ID, until.Format(time.RFC3339))
+	}
+
+	e.LiftedAt = &at
+	e.LiftedBy = liftedBy
+
+	return nil
+}
+
+// Describe explains the exclusion to whoever sent a bet it covers, leaving out the reason the user gave
+func (e Exclusion) Describe() string {
+	until := "until lifted"
+	if e.EndsAt != nil {
+		until = "until " + e.EndsAt.UTC().Format(time.RFC3339)
+	}
+
+	return fmt.Sprintf("user is self-excluded from %s %s", e.StartsAt.UTC().Format(time.RFC3339), until)
+}
+
+// CoveringExclusion returns the exclusion of the user covering a bet placed at the given time, if any
+func CoveringExclusion(exclusions []Exclusion, userID string, at time.Time) (Exclusion, bool) {
+	for _, exclusion := range exclusions {
+		if exclusion.UserID == userID && exclusion.Covers(at) {
+			return exclusion, true
+		}
+	}
+
+	return Exclusion{}, false
+}
+
+// ExclusionViolation is a bet received from a user during one of their exclusions along with how it was handled
+type ExclusionViolation struct {
+	ID          string              `json:"id"`
+	ExclusionID string              `json:"exclusion_id"`
+	UserID      string              `json:"user_id"`
+	BetID       string              `json:"bet_id"`
+	Amount      Money               `json:"amount"`
+	Currency    Currency            `json:"currency"`
+	PlacedAt    time.Time           `json:"placed_at"`
+	Action      enums.ExclusionMode `json:"action"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
+
+// ExcludedBet is a bet accepted from a user during one of their exclusions
+type ExcludedBet struct {
+	ExclusionID string `json:"exclusion_id"`
+	Bet         *Bet   `json:"bet"`
+}
+
+// ExclusionReport lists the bets accepted from self-excluded users that were placed over a period,
+// oldest first. A nil bound leaves the period open on that side.
+type ExclusionReport struct {
+	From *time.Time    `json:"from,omitempty"`
+	To   *time.Time    `json:"to,omitempty"`
+	Bets []ExcludedBet `json:"bets"`
+}
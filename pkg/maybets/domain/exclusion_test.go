@@ -0,0 +1,275 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExclusion_Validate(t *testing.T) {
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(30 * 24 * time.Hour)
+
+	tests := []struct {
+		name      string
+		exclusion Exclusion
+		wantErr   bool
+	}{
+		{
+			name:      "success: exclusion with an end",
+			exclusion: Exclusion{UserID: "u1", StartsAt: start, EndsAt: &end, Reason: "taking a break"},
+		},
+		{
+			name:      "success: exclusion until lifted",
+			exclusion: Exclusion{UserID: "u1", StartsAt: start, Reason: "taking a break"},
+		},
+		{
+			name:      "fail: missing user",
+			exclusion: Exclusion{StartsAt: start, Reason: "taking a break"},
+			wantErr:   true,
+		},
+		{
+			name:      "fail: missing reason",
+			exclusion: Exclusion{UserID: "u1", StartsAt: start},
+			wantErr:   true,
+		},
+		{
+			name:      "fail: missing start",
+			exclusion: Exclusion{UserID: "u1", Reason: "taking a break"},
+			wantErr:   true,
+		},
+		{
+			name:      "fail: ends before it starts",
+			exclusion: Exclusion{UserID: "u1", StartsAt: end, EndsAt: &start, Reason: "taking a break"},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.exclusion.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Exclusion.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExclusion_Covers(t *testing.T) {
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(30 * 24 * time.Hour)
+	lifted := start.Add(10 * 24 * time.Hour)
+
+	tests := []struct {
+		name      string
+		exclusion Exclusion
+		at        time.Time
+		want      bool
+	}{
+		{
+			name:      "success: bet at the start",
+			exclusion: Exclusion{StartsAt: start, EndsAt: &end},
+			at:        start,
+			want:      true,
+		},
+		{
+			name:      "success: bet long after the start of an exclusion until lifted",
+			exclusion: Exclusion{StartsAt: start},
+			at:        start.Add(365 * 24 * time.Hour),
+			want:      true,
+		},
+		{
+			name:      "fail: bet before the start",
+			exclusion: Exclusion{StartsAt: start, EndsAt: &end},
+			at:        start.Add(-time.Second),
+			want:      false,
+		},
+		{
+			name:      "fail: bet at the end",
+			exclusion: Exclusion{StartsAt: start, EndsAt: &end},
+			at:        end,
+			want:      false,
+		},
+		{
+			name:      "fail: bet after the exclusion was lifted",
+			exclusion: Exclusion{StartsAt: start, EndsAt: &end, LiftedAt: &lifted},
+			at:        lifted.Add(time.Hour),
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.exclusion.Covers(tt.at); got != tt.want {
+				t.Errorf("Exclusion.Covers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExclusion_Overlaps(t *testing.T) {
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(30 * 24 * time.Hour)
+	before, after := start.Add(-time.Hour), end.Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		exclusion Exclusion
+		from, to  *time.Time
+		want      bool
+	}{
+		{
+			name:      "success: open period",
+			exclusion: Exclusion{StartsAt: start, EndsAt: &end},
+			want:      true,
+		},
+		{
+			name:      "success: period starting before the exclusion",
+			exclusion: Exclusion{StartsAt: start, EndsAt: &end},
+			from:      &before,
+			to:        &after,
+			want:      true,
+		},
+		{
+			name:      "success: period after the start of an exclusion until lifted",
+			exclusion: Exclusion{StartsAt: start},
+			from:      &after,
+			want:      true,
+		},
+		{
+			name:      "fail: period ending before the exclusion",
+			exclusion: Exclusion{StartsAt: start, EndsAt: &end},
+			to:        &before,
+			want:      false,
+		},
+		{
+			name:      "fail: period starting at the end of the exclusion",
+			exclusion: Exclusion{StartsAt: start, EndsAt: &end},
+			from:      &end,
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.exclusion.Overlaps(tt.from, tt.to); got != tt.want {
+				t.Errorf("Exclusion.Overlaps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExclusion_Lift(t *testing.T) {
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(30 * 24 * time.Hour)
+	lifted := start.Add(24 * time.Hour)
+
+	tests := []struct {
+		name      string
+		exclusion Exclusion
+		at        time.Time
+		liftedBy  string
+		wantErr   bool
+	}{
+		{
+			name:      "success: lift a standing exclusion",
+			exclusion: Exclusion{ID: "e1", StartsAt: start, EndsAt: &end},
+			at:        start.Add(48 * time.Hour),
+			liftedBy:  "compliance-1",
+		},
+		{
+			name:      "success: lift an exclusion before it starts",
+			exclusion: Exclusion{ID: "e1", StartsAt: start},
+			at:        start.Add(-time.Hour),
+			liftedBy:  "compliance-1",
+		},
+		{
+			name:      "fail: missing lifted_by",
+			exclusion: Exclusion{ID: "e1", StartsAt: start, EndsAt: &end},
+			at:        start.Add(48 * time.Hour),
+			wantErr:   true,
+		},
+		{
+			name:      "fail: exclusion already lifted",
+			exclusion: Exclusion{ID: "e1", StartsAt: start, LiftedAt: &lifted, LiftedBy: "compliance-1"},
+			at:        start.Add(48 * time.Hour),
+			liftedBy:  "compliance-2",
+			wantErr:   true,
+		},
+		{
+			name:      "fail: exclusion already ended",
+			exclusion: Exclusion{ID: "e1", StartsAt: start, EndsAt: &end},
+			at:        end,
+			liftedBy:  "compliance-1",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.exclusion.Lift(tt.at, tt.liftedBy)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Exclusion.Lift() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if tt.exclusion.Covers(tt.at) || tt.exclusion.LiftedBy != tt.liftedBy {
+				t.Errorf("Exclusion.Lift() left %+v covering bets at %v", tt.exclusion, tt.at)
+			}
+		})
+	}
+}
+
+func TestCoveringExclusion(t *testing.T) {
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(30 * 24 * time.Hour)
+
+	exclusions := []Exclusion{
+		{ID: "e1", UserID: "u1", StartsAt: start, EndsAt: &end},
+		{ID: "e2", UserID: "u1", StartsAt: end.Add(24 * time.Hour)},
+		{ID: "e3", UserID: "u2", StartsAt: start.Add(-24 * time.Hour)},
+	}
+
+	tests := []struct {
+		name   string
+		userID string
+		at     time.Time
+		wantID string
+		want   bool
+	}{
+		{
+			name:   "success: bet within the first exclusion",
+			userID: "u1",
+			at:     start.Add(time.Hour),
+			wantID: "e1",
+			want:   true,
+		},
+		{
+			name:   "success: bet within the later exclusion",
+			userID: "u1",
+			at:     end.Add(48 * time.Hour),
+			wantID: "e2",
+			want:   true,
+		},
+		{
+			name:   "fail: bet between the exclusions",
+			userID: "u1",
+			at:     end.Add(time.Hour),
+		},
+		{
+			name:   "fail: user without exclusions",
+			userID: "u3",
+			at:     start.Add(time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := CoveringExclusion(exclusions, tt.userID, tt.at)
+			if ok != tt.want || got.ID != tt.wantID {
+				t.Errorf("CoveringExclusion() = %v, %v, want %v, %v", got.ID, ok, tt.wantID, tt.want)
+			}
+		})
+	}
+}
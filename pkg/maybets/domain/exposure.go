@@ -0,0 +1,284 @@
+package domain
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+)
+
+// SelectionExposure is the risk the pending bets on a selection hold
+type SelectionExposure struct {
+	Selection string `json:"selection"`
+	Bets      int64  `json:"bets"`
+	Stake     Money  `json:"stake"`
+	// PotentialPayout is the amount paid out if the selection wins
+	PotentialPayout Money `json:"potential_payout"`
+	// Liability is what the book loses if the selection wins: its potential payout less the stakes taken on the whole
+	// market, which are kept. It is negative when the book comes out ahead.
+	Liability Money `json:"liability"`
+}
+
+// MarketExposure is the risk the pending bets on the selections of a market hold
+type MarketExposure struct {
+	Market string `json:"market"`
+	Bets   int64  `json:"bets"`
+	Stake  Money  `json:"stake"`
+	// PotentialPayout and WorstCaseLiability are those of the selection with the largest liability,
+	// since only one selection of a market wins
+	PotentialPayout    Money               `json:"potential_payout"`
+	WorstCaseLiability Money               `json:"worst_case_liability"`
+	Selections         []SelectionExposure `json:"selections"`
+}
+
+// EventExposure is the risk the pending bets on the markets of an event hold
+type EventExposure struct {
+	EventID string `json:"event_id"`
+	Bets    int64  `json:"bets"`
+	Stake   Money  `json:"stake"`
+	// PotentialPayout and WorstCaseLiability add up those of the markets, as if the worst selection of each won
+	PotentialPayout    Money            `json:"potential_payout"`
+	WorstCaseLiability Money            `json:"worst_case_liability"`
+	Markets            []MarketExposure `json:"markets"`
+}
+
+// AccumulatorSelection is a selection the pending legs of multi-leg bets are placed on
+type AccumulatorSelection struct {
+	Selection string `json:"selection"`
+	Bets      int64  `json:"bets"`
+	// PotentialPayout is the amount the bets pay out if every one of their pending legs wins
+	PotentialPayout Money `json:"potential_payout"`
+}
+
+// AccumulatorExposure is the risk the pending multi-leg bets hold. It is reported apart from the events, since their
+// legs only name their selections and a bet only pays out once all of them win.
+type AccumulatorExposure struct {
+	Bets            int64 `json:"bets"`
+	Stake           Money `json:"stake"`
+	PotentialPayout Money `json:"potential_payout"`
+	// Liability is what the book loses if every accumulator wins: their potential payout less their stakes
+	Liability Money `json:"liability"`
+	// Selections are those of the pending legs, ordered by the payout riding on them, largest first
+	Selections []AccumulatorSelection `json:"selections"`
+}
+
+// Exposure is the open liability of the pending bets per event, market and selection, each ordered by their
+// worst case liability, largest first, and of the pending multi-leg bets. Amounts are in the reporting currency.
+type Exposure struct {
+	Bets               int64               `json:"bets"`
+	Stake              Money               `json:"stake"`
+	PotentialPayout    Money               `json:"potential_payout"`
+	WorstCaseLiability Money               `json:"worst_case_liability"`
+	Currency           Currency            `json:"currency"`
+	Events             []EventExposure     `json:"events"`
+	Accumulators       AccumulatorExposure `json:"accumulators"`
+}
+
+// exposureKey identifies a selection within its market and event
+type exposureKey struct {
+	eventID, market, selection string
+}
+
+// exposureTotals adds up the bets on a selection in every currency they were placed in
+type exposureTotals struct {
+	bets            int64
+	stakes, payouts map[Currency]Money
+}
+
+// newExposureTotals initializes exposureTotals without any bets
+func newExposureTotals() *exposureTotals {
+	return &exposureTotals{stakes: map[Currency]Money{}, payouts: map[Currency]Money{}}
+}
+
+// add counts a bet into the totals
+func (t *exposureTotals) add(bet *Bet) {
+	t.bets++
+	t.stakes[bet.Currency] += bet.Amount
+	t.payouts[bet.Currency] += bet.PotentialPayout()
+}
+
+// ExposureCalculator adds up pending bets into an Exposure
+type ExposureCalculator struct {
+	exchange   *Exchange
+	selections map[exposureKey]*exposureTotals
+	// accumulators adds up the pending multi-leg bets and legs those on the selection of each of their pending legs
+	accumulators *exposureTotals
+	legs         map[string]*exposureTotals
+}
+
+// NewExposureCalculator initializes an ExposureCalculator reporting in the reporting currency of the exchange
+func NewExposureCalculator(exchange *Exchange) *ExposureCalculator {
+	return &ExposureCalculator{
+		exchange:     exchange,
+		selections:   map[exposureKey]*exposureTotals{},
+		accumulators: newExposureTotals(),
+		legs:         map[string]*exposureTotals{},
+	}
+}
+
+// Add adds a bet to the exposure. Only pending bets are counted, since settled bets no longer hold any risk.
+// A multi-leg bet is counted as an accumulator and towards the selections of its pending legs.
+func (c *ExposureCalculator) Add(bet *Bet) {
+	if bet.Outcome != enums.Pending {
+		return
+	}
+
+	if len(bet.Legs) > 0 {
+		c.accumulators.add(bet)
+
+		for _, leg := range bet.Legs {
+			if leg.Outcome != enums.Pending {
+				continue
+			}
+
+			totals, ok := c.legs[leg.Selection]
+			if !ok {
+				totals = newExposureTotals()
+				c.legs[leg.Selection] = totals
+			}
+
+			totals.add(bet)
+		}
+
+		return
+	}
+
+	key := exposureKey{eventID: bet.EventID, market: bet.Market, selection: bet.Selection}
+
+	totals, ok := c.selections[key]
+	if !ok {
+		totals = newExposureTotals()
+		c.selections[key] = totals
+	}
+
+	totals.add(bet)
+}
+
+// Exposure converts the totals of every selection into the reporting currency and works out the liabilities.
+// The totals cover the accumulators too, as if every one of them won along with the worst selection of every market.
+func (c *ExposureCalculator) Exposure() (Exposure, error) {
+	exposure := Exposure{Currency: c.exchange.Reporting, Events: []EventExposure{}}
+
+	markets := map[exposureKey]*MarketExposure{}
+
+	for key, totals := range c.selections {
+		stake, err := c.exchange.Total(totals.stakes)
+		if err != nil {
+			return Exposure{}, err
+		}
+
+		payout, err := c.exchange.Total(totals.payouts)
+		if err != nil {
+			return Exposure{}, err
+		}
+
+		marketKey := exposureKey{eventID: key.eventID, market: key.market}
+
+		market, ok := markets[marketKey]
+		if !ok {
+			market = &MarketExposure{Market: key.market}
+			markets[marketKey] = market
+		}
+
+		market.Bets += totals.bets
+		market.Stake += stake
+		market.Selections = append(market.Selections, SelectionExposure{
+			Selection: key.selection, Bets: totals.bets, Stake: stake, PotentialPayout: payout,
+		})
+	}
+
+	events := map[string]*EventExposure{}
+
+	for key, market := range markets {
+		for i := range market.Selections {
+			market.Selections[i].Liability = market.Selections[i].PotentialPayout - market.Stake
+		}
+
+		slices.SortFunc(market.Selections, func(a, b SelectionExposure) int {
+			return cmp.Or(cmp.Compare(b.Liability, a.Liability), cmp.Compare(a.Selection, b.Selection))
+		})
+
+		worst := market.Selections[0]
+		market.PotentialPayout, market.WorstCaseLiability = worst.PotentialPayout, worst.Liability
+
+		event, ok := events[key.eventID]
+		if !ok {
+			event = &EventExposure{EventID: key.eventID}
+			events[key.eventID] = event
+		}
+
+		event.Bets += market.Bets
+		event.Stake += market.Stake
+		event.PotentialPayout += market.PotentialPayout
+		event.WorstCaseLiability += market.WorstCaseLiability
+		event.Markets = append(event.Markets, *market)
+	}
+
+	for _, event := range events {
+		slices.SortFunc(event.Markets, func(a, b MarketExposure) int {
+			return cmp.Or(cmp.Compare(b.WorstCaseLiability, a.WorstCaseLiability), cmp.Compare(a.Market, b.Market))
+		})
+
+		exposure.Bets += event.Bets
+		exposure.Stake += event.Stake
+		exposure.PotentialPayout += event.PotentialPayout
+		exposure.WorstCaseLiability += event.WorstCaseLiability
+		exposure.Events = append(exposure.Events, *event)
+	}
+
+	slices.SortFunc(exposure.Events, func(a, b EventExposure) int {
+		return cmp.Or(cmp.Compare(b.WorstCaseLiability, a.WorstCaseLiability), cmp.Compare(a.EventID, b.EventID))
+	})
+
+	accumulators, err := c.accumulatorExposure()
+	if err != nil {
+		return Exposure{}, err
+	}
+
+	exposure.Bets += accumulators.Bets
+	exposure.Stake += accumulators.Stake
+	exposure.PotentialPayout += accumulators.PotentialPayout
+	exposure.WorstCaseLiability += accumulators.Liability
+	exposure.Accumulators = accumulators
+
+	return exposure, nil
+}
+
+// accumulatorExposure converts the totals of the pending multi-leg bets and of the selections of their pending legs
+// into the reporting currency
+func (c *ExposureCalculator) accumulatorExposure() (AccumulatorExposure, error) {
+	stake, err := c.exchange.Total(c.accumulators.stakes)
+	if err != nil {
+		return AccumulatorExposure{}, err
+	}
+
+	payout, err := c.exchange.Total(c.accumulators.payouts)
+	if err != nil {
+		return AccumulatorExposure{}, err
+	}
+
+	accumulators := AccumulatorExposure{
+		Bets:            c.accumulators.bets,
+		Stake:           stake,
+		PotentialPayout: payout,
+		Liability:       payout - stake,
+		Selections:      make([]AccumulatorSelection, 0, len(c.legs)),
+	}
+
+	for selection, totals := range c.legs {
+		payout, err := c.exchange.Total(totals.payouts)
+		if err != nil {
+			return AccumulatorExposure{}, err
+		}
+
+		accumulators.Selections = append(accumulators.Selections, AccumulatorSelection{
+			Selection: selection, Bets: totals.bets, PotentialPayout: payout,
+		})
+	}
+
+	slices.SortFunc(accumulators.Selections, func(a, b AccumulatorSelection) int {
+		return cmp.Or(cmp.Compare(b.PotentialPayout, a.PotentialPayout), cmp.Compare(a.Selection, b.Selection))
+	})
+
+	return accumulators, nil
+}
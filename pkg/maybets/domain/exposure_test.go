@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+)
+
+func TestExposureCalculator_Exposure(t *testing.T) {
+	exchange, err := NewExchange("USD", "USD", map[Currency]string{"KES": "0.0077"})
+	if err != nil {
+		t.Fatalf("NewExchange() error = %v", err)
+	}
+
+	pending := func(eventID, market, selection string, amount Money, currency Currency, odds float64) *Bet {
+		return &Bet{
+			EventID: eventID, Market: market, Selection: selection, Amount: amount, Currency: currency, Odds: odds, Outcome: enums.Pending,
+		}
+	}
+
+	accumulator := func(amount Money, currency Currency, legs ...BetLeg) *Bet {
+		bet := &Bet{Amount: amount, Currency: currency, Outcome: enums.Pending, Legs: legs}
+		if err := bet.Derive(); err != nil {
+			t.Fatalf("Bet.Derive() error = %v", err)
+		}
+
+		return bet
+	}
+
+	tests := []struct {
+		name    string
+		bets    []*Bet
+		want    Exposure
+		wantErr bool
+	}{
+		{
+			name: "success: worst case liability per market, event and overall",
+			bets: []*Bet{
+				pending("e1", "result", "home", 10*moneyUnit, "USD", 2.5),
+				pending("e1", "result", "away", 20*moneyUnit, "USD", 1.5),
+				pending("e1", "result", "home", 1000*moneyUnit, "KES", 3),
+				pending("e1", "goals", "over", 5*moneyUnit, "USD", 2),
+				pending("e2", "result", "draw", 4*moneyUnit, "USD", 3),
+				{EventID: "e1", Market: "result", Selection: "home", Amount: 100 * moneyUnit, Currency: "USD", Odds: 2, Outcome: enums.Win},
+			},
+			want: Exposure{
+				Bets: 5, Stake: 467000, PotentialPayout: 701000, WorstCaseLiability: 234000, Currency: "USD",
+				Events: []EventExposure{
+					{
+						EventID: "e1", Bets: 4, Stake: 427000, PotentialPayout: 581000, WorstCaseLiability: 154000,
+						Markets: []MarketExposure{
+							{
+								Market: "result", Bets: 3, Stake: 377000, PotentialPayout: 481000, WorstCaseLiability: 104000,
+								Selections: []SelectionExposure{
+									// 25 USD plus 3000 KES at 0.0077 less the 37.7 staked on the market
+									{Selection: "home", Bets: 2, Stake: 177000, PotentialPayout: 481000, Liability: 104000},
+									{Selection: "away", Bets: 1, Stake: 200000, PotentialPayout: 300000, Liability: -77000},
+								},
+							},
+							{
+								Market: "goals", Bets: 1, Stake: 50000, PotentialPayout: 100000, WorstCaseLiability: 50000,
+								Selections: []SelectionExposure{
+									{Selection: "over", Bets: 1, Stake: 50000, PotentialPayout: 100000, Liability: 50000},
+								},
+							},
+						},
+					},
+					{
+						EventID: "e2", Bets: 1, Stake: 40000, PotentialPayout: 120000, WorstCaseLiability: 80000,
+						Markets: []MarketExposure{
+							{
+								Market: "result", Bets: 1, Stake: 40000, PotentialPayout: 120000, WorstCaseLiability: 80000,
+								Selections: []SelectionExposure{
+									{Selection: "draw", Bets: 1, Stake: 40000, PotentialPayout: 120000, Liability: 80000},
+								},
+							},
+						},
+					},
+				},
+				Accumulators: AccumulatorExposure{Selections: []AccumulatorSelection{}},
+			},
+		},
+		{
+			name: "success: pending multi-leg bets reported as accumulators and counted in the totals",
+			bets: []*Bet{
+				pending("e1", "result", "home", 10*moneyUnit, "USD", 2.5),
+				accumulator(10*moneyUnit, "USD", BetLeg{Selection: "home", Odds: 2, Outcome: enums.Win}, BetLeg{Selection: "away", Odds: 2, Outcome: enums.Pending}),
+				accumulator(1000*moneyUnit, "KES", BetLeg{Selection: "away", Odds: 1.5, Outcome: enums.Pending}, BetLeg{Selection: "over", Odds: 2, Outcome: enums.Pending}),
+				{Amount: 10 * moneyUnit, Currency: "USD", Odds: 4, Outcome: enums.Lose, Legs: []BetLeg{{Selection: "home", Odds: 2, Outcome: enums.Lose}}},
+			},
+			want: Exposure{
+				// the single bet stands to lose 15 USD and the accumulators 30 USD plus 2000 KES at 0.0077
+				Bets: 3, Stake: 277000, PotentialPayout: 881000, WorstCaseLiability: 604000, Currency: "USD",
+				Events: []EventExposure{
+					{
+						EventID: "e1", Bets: 1, Stake: 100000, PotentialPayout: 250000, WorstCaseLiability: 150000,
+						Markets: []MarketExposure{
+							{
+								Market: "result", Bets: 1, Stake: 100000, PotentialPayout: 250000, WorstCaseLiability: 150000,
+								Selections: []SelectionExposure{
+									{Selection: "home", Bets: 1, Stake: 100000, PotentialPayout: 250000, Liability: 150000},
+								},
+							},
+						},
+					},
+				},
+				Accumulators: AccumulatorExposure{
+					Bets: 2, Stake: 177000, PotentialPayout: 631000, Liability: 454000,
+					Selections: []AccumulatorSelection{
+						{Selection: "away", Bets: 2, PotentialPayout: 631000},
+						{Selection: "over", Bets: 1, PotentialPayout: 231000},
+					},
+				},
+			},
+		},
+		{
+			name: "success: no pending bets",
+			bets: []*Bet{
+				{EventID: "e1", Amount: 10 * moneyUnit, Currency: "USD", Odds: 2, Outcome: enums.Lose},
+			},
+			want: Exposure{Currency: "USD", Events: []EventExposure{}, Accumulators: AccumulatorExposure{Selections: []AccumulatorSelection{}}},
+		},
+		{
+			name: "sad: currency without a rate",
+			bets: []*Bet{
+				pending("e1", "result", "home", 10*moneyUnit, "EUR", 2),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calculator := NewExposureCalculator(exchange)
+
+			for _, bet := range tt.bets {
+				calculator.Add(bet)
+			}
+
+			got, err := calculator.Exposure()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExposureCalculator.Exposure() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExposureCalculator.Exposure() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
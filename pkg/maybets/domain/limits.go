@@ -0,0 +1,383 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+)
+
+const (
+	// UserLimitSource marks a cap set for the user
+	UserLimitSource = "user"
+	// DefaultLimitSource marks a cap every user is held to
+	DefaultLimitSource = "default"
+)
+
+// Limits caps what a user may lose, stake and bet over a rolling period. A zero cap is not set.
+// Amounts are in the reporting currency.
+type Limits struct {
+	UserID    string            `json:"user_id"`
+	Period    enums.LimitPeriod `json:"period"`
+	LossCap   Money             `json:"loss_cap"`
+	StakeCap  Money             `json:"stake_cap"`
+	BetCap    int64             `json:"bet_cap"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// Validate checks that the limits name a user and a period and that no cap is negative
+func (l Limits) Validate() error {
+	if l.UserID == "" {
+		return fmt.Errorf("user_id: must not be empty")
+	}
+
+	if !l.Period.IsValid() {
+		return fmt.Errorf("invalid period %q: must be one of %v", l.Period, enums.LimitPeriods)
+	}
+
+	if l.LossCap < 0 || l.StakeCap < 0 || l.BetCap < 0 {
+		return fmt.Errorf("caps must not be negative")
+	}
+
+	return nil
+}
+
+// cap returns the cap of the given kind, zero when it is not set. Amounts are returned as Money.
+func (l Limits) cap(kind enums.LimitKind) int64 {
+	switch kind {
+	case enums.LossCap:
+		return int64(l.LossCap)
+	case enums.StakeCap:
+		return int64(l.StakeCap)
+	case enums.BetCap:
+		return l.BetCap
+	default:
+		return 0
+	}
+}
+
+// LimitPolicy holds the responsible gambling limits every user is held to
+type LimitPolicy struct {
+	// Defaults holds the caps of each period, a user being held to their own caps where those are tighter
+	Defaults map[enums.LimitPeriod]Limits
+	// SessionLength is the longest a session may last, zero leaves sessions unwatched
+	SessionLength time.Duration
+	// SessionGap is the pause between two bets that ends a session
+	SessionGap time.Duration
+	// StakeTrendBets is the number of latest bets whose average stake is compared with that of as many bets before them
+	StakeTrendBets int
+	// StakeTrendFactor is how many times the earlier average stake the latest may reach, zero leaves stakes unwatched
+	StakeTrendFactor float64
+}
+
+// Enabled reports whether the policy holds every user to any limit
+func (p LimitPolicy) Enabled() bool {
+	for _, limits := range p.Defaults {
+		if limits.LossCap > 0 || limits.StakeCap > 0 || limits.BetCap > 0 {
+			return true
+		}
+	}
+
+	return p.SessionLength > 0 || p.StakeTrendFactor > 0
+}
+
+// LimitStatus is where a user stands against a single limit
+type LimitStatus struct {
+	Limit enums.LimitKind `json:"limit"`
+	// Period is the window caps are counted over, empty for the session length and stake trend
+	Period enums.LimitPeriod `json:"period,omitempty"`
+	// Value is the amount lost or staked, the number of bets, the length of the current session in seconds
+	// or the ratio of the latest average stake to the earlier one
+	Value float64 `json:"value"`
+	// Cap is the limit Value is held to, zero when none is set
+	Cap float64 `json:"cap"`
+	// Source says whether the cap was set for the user or is the default, empty when none is set
+	Source   string `json:"source,omitempty"`
+	Breached bool   `json:"breached"`
+}
+
+// LimitStanding is where a user stands against every responsible gambling limit, amounts in the reporting currency
+type LimitStanding struct {
+	UserID   string        `json:"user_id"`
+	Currency Currency      `json:"currency"`
+	Limits   []LimitStatus `json:"limits"`
+}
+
+// RejectedBet is a bet turned down at ingest along with the reason
+type RejectedBet struct {
+	BetID  string `json:"bet_id"`
+	UserID string `json:"user_id"`
+	Reason string `json:"reason"`
+}
+
+// LimitTotals are the amount lost and staked and the number of bets a user placed over a limit period,
+// amounts in the reporting currency
+type LimitTotals struct {
+	UserID string
+	Period enums.LimitPeriod
+	Loss   Money
+	Stake  Money
+	Bets   int64
+}
+
+// trackedBet holds when a bet was placed and its stake in the reporting currency,
+// what the session length and stake trend are worked out from
+type trackedBet struct {
+	timestamp time.Time
+	stake     Money
+}
+
+// LimitTracker works out where a user stands against their limits from the totals of the periods
+// and from the latest bets they placed
+type LimitTracker struct {
+	userID   string
+	policy   LimitPolicy
+	limits   map[enums.LimitPeriod]Limits
+	exchange *Exchange
+	now      time.Time
+	totals   map[enums.LimitPeriod]LimitTotals
+	bets     []trackedBet
+}
+
+// NewLimitTracker initializes a LimitTracker for a user held to the policy and to their own limits,
+// counting the periods back from now
+func NewLimitTracker(userID string, policy LimitPolicy, limits []Limits, exchange *Exchange, now time.Time) *LimitTracker {
+	tracker := &LimitTracker{
+		userID:   userID,
+		policy:   policy,
+		limits:   make(map[enums.LimitPeriod]Limits, len(limits)),
+		exchange: exchange,
+		now:      now,
+		totals:   make(map[enums.LimitPeriod]LimitTotals, len(enums.LimitPeriods)),
+	}
+
+	for _, limit := range limits {
+		tracker.limits[limit.Period] = limit
+	}
+
+	return tracker
+}
+
+// Add counts a bet of the user in the periods it was placed in and follows it
+func (t *LimitTracker) Add(bet *Bet) error {
+	stake, err := t.convert(bet.Amount, bet.Currency)
+	if err != nil {
+		return err
+	}
+
+	loss, err := t.convert(bet.Loss(), bet.Currency)
+	if err != nil {
+		return err
+	}
+
+	for _, period := range enums.LimitPeriods {
+		if bet.Timestamp.Before(t.now.Add(-period.Duration())) {
+			continue
+		}
+
+		t.Count(LimitTotals{Period: period, Loss: loss, Stake: stake, Bets: 1})
+	}
+
+	t.bets = append(t.bets, trackedBet{timestamp: bet.Timestamp, stake: stake})
+
+	return nil
+}
+
+// Count adds the totals of a period to those already counted
+func (t *LimitTracker) Count(totals LimitTotals) {
+	counted := t.totals[totals.Period]
+
+	counted.Loss += totals.Loss
+	counted.Stake += totals.Stake
+	counted.Bets += totals.Bets
+
+	t.totals[totals.Period] = counted
+}
+
+// Follow keeps a bet already counted in the totals for the session length and stake trend
+func (t *LimitTracker) Follow(bet *Bet) error {
+	stake, err := t.convert(bet.Amount, bet.Currency)
+	if err != nil {
+		return err
+	}
+
+	t.bets = append(t.bets, trackedBet{timestamp: bet.Timestamp, stake: stake})
+
+	return nil
+}
+
+// Check returns an error naming the cap a bet would breach if the user placed it: a loss or bet cap the user already
+// reached, or a stake cap the bet would take them over. The session length and stake trend are only watched.
+func (t *LimitTracker) Check(bet *Bet) error {
+	stake, err := t.convert(bet.Amount, bet.Currency)
+	if err != nil {
+		return err
+	}
+
+	currency := t.exchange.Reporting
+
+	for _, period := range enums.LimitPeriods {
+		loss, staked, bets := t.periodTotals(period)
+
+		if limit, _ := t.cap(enums.LossCap, period); limit > 0 && int64(loss) >= limit {
+			return fmt.Errorf("%s %s of %v %s reached with %v %s lost", period, enums.LossCap, Money(limit), currency, loss, currency)
+		}
+
+		if limit, _ := t.cap(enums.StakeCap, period); limit > 0 && int64(staked+stake) > limit {
+			return fmt.Errorf("%s %s of %v %s exceeded with %v %s staked", period, enums.StakeCap, Money(limit), currency,
+				staked+stake, currency)
+		}
+
+		if limit, _ := t.cap(enums.BetCap, period); limit > 0 && bets >= limit {
+			return fmt.Errorf("%s %s of %d bets reached", period, enums.BetCap, limit)
+		}
+	}
+
+	return nil
+}
+
+// Standing returns where the user stands against the caps of every period, the session length and the stake trend
+func (t *LimitTracker) Standing() LimitStanding {
+	standing := LimitStanding{UserID: t.userID, Currency: t.exchange.Reporting}
+
+	for _, period := range enums.LimitPeriods {
+		loss, stake, bets := t.periodTotals(period)
+
+		standing.Limits = append(standing.Limits,
+			t.capStatus(enums.LossCap, period, int64(loss)),
+			t.capStatus(enums.StakeCap, period, int64(stake)),
+			t.capStatus(enums.BetCap, period, bets),
+		)
+	}
+
+	session := LimitStatus{Limit: enums.SessionLength, Value: t.session().Seconds(), Cap: t.policy.SessionLength.Seconds()}
+	if t.policy.SessionLength > 0 {
+		session.Source = DefaultLimitSource
+		session.Breached = session.Value >= session.Cap
+	}
+
+	trend := LimitStatus{Limit: enums.StakeTrend, Value: t.stakeTrend(), Cap: t.policy.StakeTrendFactor}
+	if t.policy.StakeTrendFactor > 0 {
+		trend.Source = DefaultLimitSource
+		trend.Breached = trend.Value >= trend.Cap
+	}
+
+	standing.Limits = append(standing.Limits, session, trend)
+
+	return standing
+}
+
+// convert converts an amount of a bet into the reporting currency, taking bets that do not name a currency
+// to be in the default one as they are stored
+func (t *LimitTracker) convert(amount Money, currency Currency) (Money, error) {
+	if currency == "" {
+		currency = t.exchange.Default
+	}
+
+	return t.exchange.Convert(amount, currency)
+}
+
+// cap returns the cap of a kind over a period the user is held to, the tighter of their own and the default,
+// and where it comes from. Amounts are returned as Money.
+func (t *LimitTracker) cap(kind enums.LimitKind, period enums.LimitPeriod) (int64, string) {
+	own, fallback := t.limits[period].cap(kind), t.policy.Defaults[period].cap(kind)
+
+	switch {
+	case own > 0 && (fallback == 0 || own <= fallback):
+		return own, UserLimitSource
+	case fallback > 0:
+		return fallback, DefaultLimitSource
+	default:
+		return 0, ""
+	}
+}
+
+// capStatus returns where the user stands against a cap given the value it caps
+func (t *LimitTracker) capStatus(kind enums.LimitKind, period enums.LimitPeriod, value int64) LimitStatus {
+	limit, source := t.cap(kind, period)
+
+	status := LimitStatus{
+		Limit:    kind,
+		Period:   period,
+		Value:    Money(value).Float64(),
+		Cap:      Money(limit).Float64(),
+		Source:   source,
+		Breached: limit > 0 && value >= limit,
+	}
+
+	if kind == enums.BetCap {
+		status.Value, status.Cap = float64(value), float64(limit)
+	}
+
+	return status
+}
+
+// periodTotals returns the loss, the stake and the number of the bets counted in the period ending now
+func (t *LimitTracker) periodTotals(period enums.LimitPeriod) (loss, stake Money, bets int64) {
+	totals := t.totals[period]
+
+	return totals.Loss, totals.Stake, totals.Bets
+}
+
+// sorted returns the bets ordered by the time they were placed
+func (t *LimitTracker) sorted() []trackedBet {
+	bets := slices.Clone(t.bets)
+
+	slices.SortStableFunc(bets, func(a, b trackedBet) int {
+		return a.timestamp.Compare(b.timestamp)
+	})
+
+	return bets
+}
+
+// session returns how long the current session has lasted, from its first bet to its last.
+// A session ends with a pause longer than the session gap, zero is returned when the last one is over.
+func (t *LimitTracker) session() time.Duration {
+	bets := t.sorted()
+	if len(bets) == 0 {
+		return 0
+	}
+
+	last := bets[len(bets)-1].timestamp
+	if t.now.Sub(last) > t.policy.SessionGap {
+		return 0
+	}
+
+	start := last
+
+	for i := len(bets) - 2; i >= 0; i-- {
+		if start.Sub(bets[i].timestamp) > t.policy.SessionGap {
+			break
+		}
+
+		start = bets[i].timestamp
+	}
+
+	return last.Sub(start)
+}
+
+// stakeTrend returns the ratio of the average stake of the latest bets to that of as many bets before them,
+// zero when there are not enough bets to compare
+func (t *LimitTracker) stakeTrend() float64 {
+	bets, n := t.sorted(), t.policy.StakeTrendBets
+	if n < 1 || len(bets) < 2*n {
+		return 0
+	}
+
+	var earlier, latest Money
+
+	for i, bet := range bets[len(bets)-2*n:] {
+		if i < n {
+			earlier += bet.stake
+		} else {
+			latest += bet.stake
+		}
+	}
+
+	if earlier <= 0 {
+		return 0
+	}
+
+	return float64(latest) / float64(earlier)
+}
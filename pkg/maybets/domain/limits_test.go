@@ -0,0 +1,243 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+)
+
+func TestLimits_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  Limits
+		wantErr bool
+	}{
+		{
+			name:   "success: caps on a period",
+			limits: Limits{UserID: "u1", Period: enums.Week, LossCap: 100 * moneyUnit, BetCap: 50},
+		},
+		{
+			name:   "success: no caps",
+			limits: Limits{UserID: "u1", Period: enums.Day},
+		},
+		{
+			name:    "fail: missing user",
+			limits:  Limits{Period: enums.Day},
+			wantErr: true,
+		},
+		{
+			name:    "fail: invalid period",
+			limits:  Limits{UserID: "u1", Period: "year"},
+			wantErr: true,
+		},
+		{
+			name:    "fail: negative cap",
+			limits:  Limits{UserID: "u1", Period: enums.Day, StakeCap: -1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.limits.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Limits.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// newTestLimitTracker returns a tracker over bets placed in the last 10 days, 4 of them in a session
+// that started 70 minutes before now with stakes doubling
+func newTestLimitTracker(t *testing.T, policy LimitPolicy, limits []Limits, now time.Time) *LimitTracker {
+	exchange, err := NewExchange("USD", "USD", map[Currency]string{"KES": "0.0077"})
+	if err != nil {
+		t.Fatalf("NewExchange() error = %v", err)
+	}
+
+	tracker := NewLimitTracker("u1", policy, limits, exchange, now)
+
+	for _, bet := range []*Bet{
+		{Amount: 20 * moneyUnit, Currency: "USD", Odds: 2, Outcome: enums.Lose, Timestamp: now.Add(-10 * 24 * time.Hour)},
+		{Amount: 100 * moneyUnit, Currency: "USD", Odds: 2, Outcome: enums.Lose, Timestamp: now.Add(-3 * 24 * time.Hour)},
+		{Amount: 10 * moneyUnit, Currency: "USD", Odds: 2, Outcome: enums.Lose, Timestamp: now.Add(-70 * time.Minute)},
+		{Amount: 1300 * moneyUnit, Currency: "KES", Odds: 2, Outcome: enums.Win, Timestamp: now.Add(-40 * time.Minute)},
+		{Amount: 20 * moneyUnit, Currency: "USD", Odds: 2, Outcome: enums.Pending, Timestamp: now.Add(-5 * time.Minute)},
+		{Amount: 20 * moneyUnit, Currency: "USD", Odds: 2, Outcome: enums.Pending, Timestamp: now.Add(-15 * time.Minute)},
+	} {
+		if err := tracker.Add(bet); err != nil {
+			t.Fatalf("LimitTracker.Add() error = %v", err)
+		}
+	}
+
+	return tracker
+}
+
+func TestLimitTracker_Standing(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	policy := LimitPolicy{
+		Defaults: map[enums.LimitPeriod]Limits{
+			enums.Day:  {LossCap: 100 * moneyUnit, BetCap: 5},
+			enums.Week: {StakeCap: 500 * moneyUnit},
+		},
+		SessionLength:    time.Hour,
+		SessionGap:       30 * time.Minute,
+		StakeTrendBets:   2,
+		StakeTrendFactor: 2,
+	}
+
+	tests := []struct {
+		name   string
+		policy LimitPolicy
+		limits []Limits
+		want   []LimitStatus
+	}{
+		{
+			name:   "success: tighter caps of the user and defaults",
+			policy: policy,
+			// the bet cap of the user is looser than the default, which stays in place
+			limits: []Limits{{UserID: "u1", Period: enums.Day, StakeCap: 50 * moneyUnit, BetCap: 10}},
+			want: []LimitStatus{
+				{Limit: enums.LossCap, Period: enums.Day, Value: 10, Cap: 100, Source: DefaultLimitSource},
+				// 10.01 USD staked in KES
+				{Limit: enums.StakeCap, Period: enums.Day, Value: 60.01, Cap: 50, Source: UserLimitSource, Breached: true},
+				{Limit: enums.BetCap, Period: enums.Day, Value: 4, Cap: 5, Source: DefaultLimitSource},
+				{Limit: enums.LossCap, Period: enums.Week, Value: 110},
+				{Limit: enums.StakeCap, Period: enums.Week, Value: 160.01, Cap: 500, Source: DefaultLimitSource},
+				{Limit: enums.BetCap, Period: enums.Week, Value: 5},
+				{Limit: enums.LossCap, Period: enums.Month, Value: 130},
+				{Limit: enums.StakeCap, Period: enums.Month, Value: 180.01},
+				{Limit: enums.BetCap, Period: enums.Month, Value: 6},
+				// from 70 to 5 minutes ago, the pauses in between being no longer than 30 minutes
+				{Limit: enums.SessionLength, Value: 3900, Cap: 3600, Source: DefaultLimitSource, Breached: true},
+				// 40 USD staked on the last 2 bets against 20.01 on the 2 before
+				{Limit: enums.StakeTrend, Value: 400000.0 / 200100, Cap: 2, Source: DefaultLimitSource},
+			},
+		},
+		{
+			name:   "success: nothing watched",
+			policy: LimitPolicy{SessionGap: 30 * time.Minute},
+			want: []LimitStatus{
+				{Limit: enums.LossCap, Period: enums.Day, Value: 10},
+				{Limit: enums.StakeCap, Period: enums.Day, Value: 60.01},
+				{Limit: enums.BetCap, Period: enums.Day, Value: 4},
+				{Limit: enums.LossCap, Period: enums.Week, Value: 110},
+				{Limit: enums.StakeCap, Period: enums.Week, Value: 160.01},
+				{Limit: enums.BetCap, Period: enums.Week, Value: 5},
+				{Limit: enums.LossCap, Period: enums.Month, Value: 130},
+				{Limit: enums.StakeCap, Period: enums.Month, Value: 180.01},
+				{Limit: enums.BetCap, Period: enums.Month, Value: 6},
+				{Limit: enums.SessionLength, Value: 3900},
+				{Limit: enums.StakeTrend},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newTestLimitTracker(t, tt.policy, tt.limits, now).Standing()
+
+			want := LimitStanding{UserID: "u1", Currency: "USD", Limits: tt.want}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("LimitTracker.Standing() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestLimitTracker_Count(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	policy := LimitPolicy{SessionLength: time.Hour, SessionGap: 30 * time.Minute, StakeTrendBets: 2, StakeTrendFactor: 2}
+
+	// the totals newTestLimitTracker adds up bet by bet, and the bets of its current session
+	totals := []LimitTotals{
+		{UserID: "u1", Period: enums.Day, Loss: 10 * moneyUnit, Stake: 60.01 * moneyUnit, Bets: 4},
+		{UserID: "u1", Period: enums.Week, Loss: 110 * moneyUnit, Stake: 160.01 * moneyUnit, Bets: 5},
+		{UserID: "u1", Period: enums.Month, Loss: 130 * moneyUnit, Stake: 180.01 * moneyUnit, Bets: 6},
+	}
+
+	exchange, err := NewExchange("USD", "USD", map[Currency]string{"KES": "0.0077"})
+	if err != nil {
+		t.Fatalf("NewExchange() error = %v", err)
+	}
+
+	tracker := NewLimitTracker("u1", policy, nil, exchange, now)
+
+	for _, total := range totals {
+		tracker.Count(total)
+	}
+
+	for _, bet := range []*Bet{
+		{Amount: 10 * moneyUnit, Currency: "USD", Timestamp: now.Add(-70 * time.Minute)},
+		{Amount: 1300 * moneyUnit, Currency: "KES", Timestamp: now.Add(-40 * time.Minute)},
+		{Amount: 20 * moneyUnit, Currency: "USD", Timestamp: now.Add(-15 * time.Minute)},
+		{Amount: 20 * moneyUnit, Currency: "USD", Timestamp: now.Add(-5 * time.Minute)},
+	} {
+		if err := tracker.Follow(bet); err != nil {
+			t.Fatalf("LimitTracker.Follow() error = %v", err)
+		}
+	}
+
+	got, want := tracker.Standing(), newTestLimitTracker(t, policy, nil, now).Standing()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LimitTracker.Standing() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLimitTracker_Check(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	bet := &Bet{Amount: 5 * moneyUnit, Currency: "USD", Odds: 2, Outcome: enums.Pending, Timestamp: now}
+
+	tests := []struct {
+		name    string
+		policy  LimitPolicy
+		limits  []Limits
+		bet     *Bet
+		wantErr bool
+	}{
+		{
+			name:   "success: bet within every cap",
+			policy: LimitPolicy{Defaults: map[enums.LimitPeriod]Limits{enums.Day: {LossCap: 100 * moneyUnit, StakeCap: 65.01 * moneyUnit, BetCap: 5}}},
+			bet:    bet,
+		},
+		{
+			name:   "success: bet without a currency is taken to be in the default one",
+			policy: LimitPolicy{Defaults: map[enums.LimitPeriod]Limits{enums.Day: {StakeCap: 65.01 * moneyUnit}}},
+			bet:    &Bet{Amount: 5 * moneyUnit, Odds: 2, Outcome: enums.Pending, Timestamp: now},
+		},
+		{
+			name:    "fail: stake cap exceeded by the bet",
+			limits:  []Limits{{UserID: "u1", Period: enums.Week, StakeCap: 165 * moneyUnit}},
+			bet:     bet,
+			wantErr: true,
+		},
+		{
+			name:    "fail: loss cap reached",
+			policy:  LimitPolicy{Defaults: map[enums.LimitPeriod]Limits{enums.Month: {LossCap: 130 * moneyUnit}}},
+			bet:     bet,
+			wantErr: true,
+		},
+		{
+			name:    "fail: bet cap reached",
+			limits:  []Limits{{UserID: "u1", Period: enums.Day, BetCap: 4}},
+			bet:     bet,
+			wantErr: true,
+		},
+		{
+			name:    "sad: currency without a rate",
+			bet:     &Bet{Amount: 5 * moneyUnit, Currency: "EUR", Odds: 2, Outcome: enums.Pending, Timestamp: now},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newTestLimitTracker(t, tt.policy, tt.limits, now).Check(tt.bet)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LimitTracker.Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,363 @@
+package domain
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+// LinkRules decide when users bet so alike that their accounts are taken to be linked
+type LinkRules struct {
+	// Window is the longest time between two bets on the same selection at the same odds for them to be shared
+	Window time.Duration
+	// MinEvents is the number of events two users must share bets on to be linked
+	MinEvents int
+}
+
+// Validate checks that the window is positive and that users share bets on at least one event to be linked
+func (r LinkRules) Validate() error {
+	if r.Window <= 0 {
+		return fmt.Errorf("invalid window %v: must be positive", r.Window)
+	}
+
+	if r.MinEvents < 1 {
+		return fmt.Errorf("invalid min_events %d: must be positive", r.MinEvents)
+	}
+
+	return nil
+}
+
+// SharedSelection holds the bets linked users placed on the same selection at the same odds within the window
+type SharedSelection struct {
+	Selection string `json:"selection"`
+	// EventID is the event of the selection, empty when the bets do not name one
+	EventID string  `json:"event_id,omitempty"`
+	Odds    float64 `json:"odds"`
+	Bets    []*Bet  `json:"bets"`
+}
+
+// LinkedGroup is a group of users whose accounts are taken to be linked, with the bets they shared as evidence
+type LinkedGroup struct {
+	Users []string `json:"users"`
+	// Score is how alike the users bet, from 0 to 1: the share of the bets of every linked pair that the pair shared,
+	// averaged over the pairs
+	Score float64 `json:"score"`
+	// Events is the number of events the users shared bets on
+	Events int               `json:"events"`
+	Shared []SharedSelection `json:"shared"`
+}
+
+// LinkedAccountsReport lists the groups of linked users found in the bets placed over a period, most alike first.
+// A nil bound leaves the period open on that side.
+type LinkedAccountsReport struct {
+	From      *time.Time    `json:"from,omitempty"`
+	To        *time.Time    `json:"to,omitempty"`
+	Window    string        `json:"window"`
+	MinEvents int           `json:"min_events"`
+	Groups    []LinkedGroup `json:"groups"`
+}
+
+// placedSelection is a selection a bet, or one of its legs, was placed on
+type placedSelection struct {
+	bet       *Bet
+	selection string
+	// eventID is the event of a single bet, empty when it names none and for the legs of a multi-leg bet
+	eventID string
+	// event is the event the selection counts for: its event, or the selection itself when it is not known
+	event string
+	odds  float64
+}
+
+// selectionKey groups the selections placed at the same odds, compared to four decimal places
+type selectionKey struct {
+	selection string
+	odds      int64
+}
+
+// userPair is two users, the lesser ID first
+type userPair struct {
+	a, b string
+}
+
+// pairLink holds what two users shared
+type pairLink struct {
+	events map[string]bool
+	// shared holds the bets of each user shared with the other
+	shared map[string]map[*Bet]bool
+	// selections holds the shared bets by the selection, event and odds they were placed on
+	selections map[placedKey]map[*Bet]bool
+}
+
+// placedKey names a selection at some odds within an event
+type placedKey struct {
+	selection, event string
+	odds             float64
+}
+
+// LinkDetector finds the users that bet the same selections at the same odds at the same moments over many events
+type LinkDetector struct {
+	rules      LinkRules
+	selections map[selectionKey][]placedSelection
+	// bets counts the bets of every user placed on any selection
+	bets map[string]int
+}
+
+// NewLinkDetector initializes a LinkDetector linking users by the rules
+func NewLinkDetector(rules LinkRules) *LinkDetector {
+	return &LinkDetector{
+		rules:      rules,
+		selections: map[selectionKey][]placedSelection{},
+		bets:       map[string]int{},
+	}
+}
+
+// Add takes in a bet. Bets that name no selection, neither of their own nor on their legs, are left out.
+func (d *LinkDetector) Add(bet *Bet) {
+	placed := make([]placedSelection, 0, 1+len(bet.Legs))
+
+	if bet.Selection != "" && len(bet.Legs) == 0 {
+		placed = append(placed, placedSelection{
+			bet: bet, selection: bet.Selection, eventID: bet.EventID, event: cmp.Or(bet.EventID, bet.Selection), odds: bet.Odds,
+		})
+	}
+
+	for _, leg := range bet.Legs {
+		if leg.Selection != "" {
+			placed = append(placed, placedSelection{bet: bet, selection: leg.Selection, event: leg.Selection, odds: leg.Odds})
+		}
+	}
+
+	if len(placed) == 0 {
+		return
+	}
+
+	d.bets[bet.UserID]++
+
+	for _, selection := range placed {
+		key := selectionKey{selection: selection.selection, odds: int64(math.Round(selection.odds * 1e4))}
+		d.selections[key] = append(d.selections[key], selection)
+	}
+}
+
+// linkedGroupBuilder gathers what the pairs of a group shared
+type linkedGroupBuilder struct {
+	users      map[string]bool
+	pairs      int
+	score      float64
+	events     map[string]bool
+	selections map[placedKey]map[*Bet]bool
+}
+
+// Groups returns the groups of linked users, most alike first
+func (d *LinkDetector) Groups() []LinkedGroup {
+	links := d.links()
+
+	// users linked to each other directly or through other users make up a group
+	parent := map[string]string{}
+
+	var find func(user string) string
+	find = func(user string) string {
+		if parent[user] == user {
+			return user
+		}
+
+		parent[user] = find(parent[user])
+
+		return parent[user]
+	}
+
+	for pair := range links {
+		for _, user := range []string{pair.a, pair.b} {
+			if _, ok := parent[user]; !ok {
+				parent[user] = user
+			}
+		}
+
+		if a, b := find(pair.a), find(pair.b); a != b {
+			parent[max(a, b)] = min(a, b)
+		}
+	}
+
+	builders := map[string]*linkedGroupBuilder{}
+
+	for pair, link := range links {
+		root := find(pair.a)
+
+		builder, ok := builders[root]
+		if !ok {
+			builder = &linkedGroupBuilder{
+				users:      map[string]bool{},
+				events:     map[string]bool{},
+				selections: map[placedKey]map[*Bet]bool{},
+			}
+			builders[root] = builder
+		}
+
+		builder.users[pair.a] = true
+		builder.users[pair.b] = true
+		builder.pairs++
+		builder.score += d.similarity(pair, link)
+
+		for event := range link.events {
+			builder.events[event] = true
+		}
+
+		for key, bets := range link.selections {
+			if builder.selections[key] == nil {
+				builder.selections[key] = map[*Bet]bool{}
+			}
+
+			for bet := range bets {
+				builder.selections[key][bet] = true
+			}
+		}
+	}
+
+	linked := make([]LinkedGroup, 0, len(builders))
+
+	for _, builder := range builders {
+		users := make([]string, 0, len(builder.users))
+		for user := range builder.users {
+			users = append(users, user)
+		}
+
+		slices.Sort(users)
+
+		linked = append(linked, LinkedGroup{
+			Users:  users,
+			Score:  math.Round(builder.score/float64(builder.pairs)*1e4) / 1e4,
+			Events: len(builder.events),
+			Shared: sharedSelections(builder.selections),
+		})
+	}
+
+	slices.SortFunc(linked, func(a, b LinkedGroup) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(b.Events, a.Events), cmp.Compare(a.Users[0], b.Users[0]))
+	})
+
+	return linked
+}
+
+// pairSide names the bets of one user of a pair
+type pairSide struct {
+	pair userPair
+	user string
+}
+
+// links returns what every pair of users who shared bets on enough events shared.
+// The bets on each selection are walked in time order through a sliding window indexed by user, so that a bet is
+// paired once with each other user who bet within the window rather than with every one of their bets.
+func (d *LinkDetector) links() map[userPair]*pairLink {
+	links := map[userPair]*pairLink{}
+
+	for _, placed := range d.selections {
+		placed = slices.Clone(placed)
+
+		slices.SortFunc(placed, func(a, b placedSelection) int {
+			return cmp.Or(a.bet.Timestamp.Compare(b.bet.Timestamp), cmp.Compare(a.bet.BetID, b.bet.BetID))
+		})
+
+		// window holds the positions of the bets of each user placed within the window of the current bet, in order
+		window := map[string][]int{}
+		// marked holds the position of the last bet of each side of a pair already counted as shared
+		marked := map[pairSide]int{}
+		oldest := 0
+
+		for i, second := range placed {
+			for ; second.bet.Timestamp.Sub(placed[oldest].bet.Timestamp) > d.rules.Window; oldest++ {
+				user := placed[oldest].bet.UserID
+				if window[user] = window[user][1:]; len(window[user]) == 0 {
+					delete(window, user)
+				}
+			}
+
+			for user, positions := range window {
+				if user == second.bet.UserID {
+					continue
+				}
+
+				pair := userPair{a: min(user, second.bet.UserID), b: max(user, second.bet.UserID)}
+
+				link, ok := links[pair]
+				if !ok {
+					link = &pairLink{
+						events:     map[string]bool{},
+						shared:     map[string]map[*Bet]bool{pair.a: {}, pair.b: {}},
+						selections: map[placedKey]map[*Bet]bool{},
+					}
+					links[pair] = link
+				}
+
+				link.events[second.event] = true
+
+				key := placedKey{selection: second.selection, event: second.eventID, odds: second.odds}
+				if link.selections[key] == nil {
+					link.selections[key] = map[*Bet]bool{}
+				}
+
+				link.shared[second.bet.UserID][second.bet] = true
+				link.selections[key][second.bet] = true
+
+				side := pairSide{pair: pair, user: user}
+
+				from := 0
+				if last, ok := marked[side]; ok {
+					from, _ = slices.BinarySearch(positions, last+1)
+				}
+
+				for _, position := range positions[from:] {
+					link.shared[user][placed[position].bet] = true
+					link.selections[key][placed[position].bet] = true
+				}
+
+				marked[side] = positions[len(positions)-1]
+			}
+
+			window[second.bet.UserID] = append(window[second.bet.UserID], i)
+		}
+	}
+
+	for pair, link := range links {
+		if len(link.events) < d.rules.MinEvents {
+			delete(links, pair)
+		}
+	}
+
+	return links
+}
+
+// similarity returns the share of the bets of the pair that they shared with each other
+func (d *LinkDetector) similarity(pair userPair, link *pairLink) float64 {
+	total := d.bets[pair.a] + d.bets[pair.b]
+	if total == 0 {
+		return 0
+	}
+
+	return float64(len(link.shared[pair.a])+len(link.shared[pair.b])) / float64(total)
+}
+
+// sharedSelections orders the shared bets by the selection they were placed on, earliest first
+func sharedSelections(selections map[placedKey]map[*Bet]bool) []SharedSelection {
+	shared := make([]SharedSelection, 0, len(selections))
+
+	for key, set := range selections {
+		bets := make([]*Bet, 0, len(set))
+		for bet := range set {
+			bets = append(bets, bet)
+		}
+
+		slices.SortFunc(bets, func(a, b *Bet) int {
+			return cmp.Or(a.Timestamp.Compare(b.Timestamp), cmp.Compare(a.BetID, b.BetID))
+		})
+
+		shared = append(shared, SharedSelection{Selection: key.selection, EventID: key.event, Odds: key.odds, Bets: bets})
+	}
+
+	slices.SortFunc(shared, func(a, b SharedSelection) int {
+		return cmp.Or(a.Bets[0].Timestamp.Compare(b.Bets[0].Timestamp), cmp.Compare(a.Selection, b.Selection))
+	})
+
+	return shared
+}
@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLinkRules_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   LinkRules
+		wantErr bool
+	}{
+		{
+			name:  "success: window and events set",
+			rules: LinkRules{Window: 10 * time.Second, MinEvents: 3},
+		},
+		{
+			name:    "fail: no window",
+			rules:   LinkRules{MinEvents: 3},
+			wantErr: true,
+		},
+		{
+			name:    "fail: no events",
+			rules:   LinkRules{Window: 10 * time.Second},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rules.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("LinkRules.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLinkDetector_Groups(t *testing.T) {
+	start := time.Date(2026, 10, 1, 18, 0, 0, 0, time.UTC)
+	rules := LinkRules{Window: 10 * time.Second, MinEvents: 2}
+
+	bet := func(id, userID, eventID, selection string, odds float64, after time.Duration) *Bet {
+		return &Bet{BetID: id, UserID: userID, EventID: eventID, Selection: selection, Odds: odds, Timestamp: start.Add(after)}
+	}
+
+	type group struct {
+		users  []string
+		score  float64
+		events int
+		shared int
+	}
+
+	tests := []struct {
+		name string
+		bets []*Bet
+		want []group
+	}{
+		{
+			name: "success: users betting alike over enough events",
+			bets: []*Bet{
+				bet("a1", "u1", "e1", "s1", 2.5, 0),
+				bet("b1", "u2", "e1", "s1", 2.5, 4*time.Second),
+				bet("a2", "u1", "e2", "s2", 1.8, time.Hour),
+				bet("b2", "u2", "e2", "s2", 1.8, time.Hour+10*time.Second),
+				bet("a3", "u1", "e3", "s3", 3, 2*time.Hour),
+				bet("b3", "u2", "e4", "s4", 3, 3*time.Hour),
+			},
+			want: []group{{users: []string{"u1", "u2"}, score: 0.6667, events: 2, shared: 2}},
+		},
+		{
+			name: "success: users linked through another one make up a group",
+			bets: []*Bet{
+				bet("a1", "u1", "e1", "s1", 2.5, 0),
+				bet("b1", "u2", "e1", "s1", 2.5, time.Second),
+				bet("a2", "u1", "e2", "s2", 1.8, time.Hour),
+				bet("b2", "u2", "e2", "s2", 1.8, time.Hour+time.Second),
+				bet("b3", "u2", "e3", "s3", 3, 2*time.Hour),
+				bet("c3", "u3", "e3", "s3", 3, 2*time.Hour+time.Second),
+				bet("b4", "u2", "e4", "s4", 3, 3*time.Hour),
+				bet("c4", "u3", "e4", "s4", 3, 3*time.Hour+time.Second),
+			},
+			want: []group{{users: []string{"u1", "u2", "u3"}, score: 0.6667, events: 4, shared: 4}},
+		},
+		{
+			name: "success: legs of multi-leg bets count for their selections",
+			bets: []*Bet{
+				{BetID: "a1", UserID: "u1", Timestamp: start, Legs: []BetLeg{{Selection: "s1", Odds: 2}, {Selection: "s2", Odds: 1.5}}},
+				{BetID: "b1", UserID: "u2", Timestamp: start.Add(time.Second), Legs: []BetLeg{{Selection: "s1", Odds: 2}, {Selection: "s2", Odds: 1.5}}},
+			},
+			want: []group{{users: []string{"u1", "u2"}, score: 1, events: 2, shared: 2}},
+		},
+		{
+			name: "success: bets of a user left behind by the window are not shared",
+			bets: []*Bet{
+				bet("a1", "u1", "e1", "s1", 2.5, 0),
+				bet("b1", "u2", "e1", "s1", 2.5, 11*time.Second),
+				bet("a2", "u1", "e1", "s1", 2.5, 12*time.Second),
+				bet("a3", "u1", "e2", "s2", 1.8, time.Hour),
+				bet("b2", "u2", "e2", "s2", 1.8, time.Hour+time.Second),
+			},
+			want: []group{{users: []string{"u1", "u2"}, score: 0.8, events: 2, shared: 2}},
+		},
+		{
+			name: "success: every bet of a user within the window is shared once",
+			bets: []*Bet{
+				bet("a1", "u1", "e1", "s1", 2.5, 0),
+				bet("a2", "u1", "e1", "s1", 2.5, 2*time.Second),
+				bet("b1", "u2", "e1", "s1", 2.5, 4*time.Second),
+				bet("b2", "u2", "e1", "s1", 2.5, 6*time.Second),
+				bet("a3", "u1", "e2", "s2", 1.8, time.Hour),
+				bet("b3", "u2", "e2", "s2", 1.8, time.Hour+time.Second),
+			},
+			want: []group{{users: []string{"u1", "u2"}, score: 1, events: 2, shared: 2}},
+		},
+		{
+			name: "fail: bets further apart than the window",
+			bets: []*Bet{
+				bet("a1", "u1", "e1", "s1", 2.5, 0),
+				bet("b1", "u2", "e1", "s1", 2.5, 11*time.Second),
+				bet("a2", "u1", "e2", "s2", 1.8, time.Hour),
+				bet("b2", "u2", "e2", "s2", 1.8, time.Hour+11*time.Second),
+			},
+		},
+		{
+			name: "fail: same selections at other odds",
+			bets: []*Bet{
+				bet("a1", "u1", "e1", "s1", 2.5, 0),
+				bet("b1", "u2", "e1", "s1", 2.4, time.Second),
+				bet("a2", "u1", "e2", "s2", 1.8, time.Hour),
+				bet("b2", "u2", "e2", "s2", 1.75, time.Hour+time.Second),
+			},
+		},
+		{
+			name: "fail: bets shared on a single event",
+			bets: []*Bet{
+				bet("a1", "u1", "e1", "s1", 2.5, 0),
+				bet("b1", "u2", "e1", "s1", 2.5, time.Second),
+				bet("a2", "u1", "e1", "s2", 1.8, time.Minute),
+				bet("b2", "u2", "e1", "s2", 1.8, time.Minute+time.Second),
+			},
+		},
+		{
+			name: "fail: bets without selections",
+			bets: []*Bet{
+				{BetID: "a1", UserID: "u1", Odds: 2.5, Timestamp: start},
+				{BetID: "b1", UserID: "u2", Odds: 2.5, Timestamp: start},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := NewLinkDetector(rules)
+
+			for _, bet := range tt.bets {
+				detector.Add(bet)
+			}
+
+			var got []group
+
+			for _, g := range detector.Groups() {
+				got = append(got, group{users: g.Users, score: g.Score, events: g.Events, shared: len(g.Shared)})
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LinkDetector.Groups() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"slices"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+)
+
+// LiveEvent is published to the clients of the live feed subscribed to its topic
+type LiveEvent struct {
+	Topic enums.LiveTopic `json:"topic"`
+	// UserID is the user the event is about, empty for events about every user such as the leaderboard
+	UserID string `json:"-"`
+	Data   any    `json:"data"`
+}
+
+// LiveFilter selects the events a live feed client receives.
+// Zero values do not filter.
+type LiveFilter struct {
+	Topics []enums.LiveTopic
+	UserID string
+}
+
+// Matches reports whether an event passes the filter.
+// Events that are not about a single user pass any user filter.
+func (f LiveFilter) Matches(event LiveEvent) bool {
+	if len(f.Topics) > 0 && !slices.Contains(f.Topics, event.Topic) {
+		return false
+	}
+
+	return f.UserID == "" || event.UserID == "" || event.UserID == f.UserID
+}
@@ -0,0 +1,233 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// moneyScale is the number of decimal places money is held with, enough for the minor unit of every currency
+const moneyScale = 4
+
+// moneyUnit is one unit of a currency, e.g one shilling, in Money
+const moneyUnit = 10_000
+
+// Money is an exact amount of money, held as a whole number of ten-thousandths of a unit of its currency
+// so that adding amounts up never drifts the way floating point numbers do. It is written in JSON as a decimal number.
+type Money int64
+
+// ParseMoney parses a decimal amount such as "12.50". Amounts with more than four decimal places are rejected
+// rather than rounded.
+func ParseMoney(value string) (Money, error) {
+	value = strings.TrimSpace(value)
+
+	amount, ok := new(big.Rat).SetString(value)
+	if !ok || strings.Contains(value, "/") {
+		return 0, fmt.Errorf("invalid amount %q: must be a decimal number", value)
+	}
+
+	amount.Mul(amount, big.NewRat(moneyUnit, 1))
+
+	if !amount.IsInt() {
+		return 0, fmt.Errorf("invalid amount %q: at most %d decimal places are supported", value, moneyScale)
+	}
+
+	if !amount.Num().IsInt64() {
+		return 0, fmt.Errorf("invalid amount %q: out of range", value)
+	}
+
+	return Money(amount.Num().Int64()), nil
+}
+
+// MoneyFromFloat converts a floating point amount, rounding it to four decimal places.
+// It is meant for the transports and settings that carry amounts as floating point numbers.
+func MoneyFromFloat(value float64) Money {
+	return Money(math.Round(value * moneyUnit))
+}
+
+// Float64 returns the amount as a floating point number, for display only
+func (m Money) Float64() float64 {
+	return float64(m) / moneyUnit
+}
+
+// String formats the amount as a decimal number without trailing zeros, e.g 12.5
+func (m Money) String() string {
+	sign := ""
+	value := uint64(m)
+
+	if m < 0 {
+		sign = "-"
+		value = uint64(-m)
+	}
+
+	units, fraction := value/moneyUnit, value%moneyUnit
+	if fraction == 0 {
+		return sign + strconv.FormatUint(units, 10)
+	}
+
+	return sign + strconv.FormatUint(units, 10) + "." + strings.TrimRight(fmt.Sprintf("%0*d", moneyScale, fraction), "0")
+}
+
+// MarshalJSON writes the amount as a JSON number
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON reads the amount from a JSON number, or from a string holding one
+func (m *Money) UnmarshalJSON(data []byte) error {
+	value := string(data)
+	if value == "null" {
+		return nil
+	}
+
+	parsed, err := ParseMoney(strings.Trim(value, `"`))
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+
+	return nil
+}
+
+// rat returns the amount as an exact number of units of its currency
+func (m Money) rat() *big.Rat {
+	return big.NewRat(int64(m), moneyUnit)
+}
+
+// roundMoney rounds an amount of units of the currency to its minor unit, halves away from zero
+func roundMoney(amount *big.Rat, currency Currency) Money {
+	step := int64(math.Pow10(moneyScale - currency.Exponent()))
+
+	// the amount in minor units, rounded by adding a half before truncating towards zero
+	minor := new(big.Rat).Mul(amount, big.NewRat(moneyUnit, step))
+	half := big.NewRat(int64(minor.Sign()), 2)
+	minor.Add(minor, half)
+
+	rounded := new(big.Int).Quo(minor.Num(), minor.Denom())
+
+	return Money(rounded.Int64() * step)
+}
+
+// Round rounds the amount to the minor unit of the currency, halves away from zero
+func (m Money) Round(currency Currency) Money {
+	return roundMoney(m.rat(), currency)
+}
+
+// Fits checks that the amount is a whole number of minor units of the currency
+func (m Money) Fits(currency Currency) bool {
+	return m.Round(currency) == m
+}
+
+// Currency is an ISO 4217 currency code, e.g KES
+type Currency string
+
+// currencyExponents lists the currencies whose minor unit is not a hundredth of their unit
+var currencyExponents = map[Currency]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// IsValid checks that the currency is made of three capital letters
+func (c Currency) IsValid() bool {
+	if len(c) != 3 {
+		return false
+	}
+
+	for _, r := range c {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Exponent is the number of decimal places of the minor unit of the currency, 2 for most of them
+func (c Currency) Exponent() int {
+	if exponent, ok := currencyExponents[c]; ok {
+		return exponent
+	}
+
+	return 2
+}
+
+// Exchange holds the currencies bets are accepted in and converts amounts of them into the reporting currency
+type Exchange struct {
+	// Reporting is the currency aggregated amounts are reported in
+	Reporting Currency
+	// Default is the currency of the bets that do not name one
+	Default Currency
+	// rates holds the number of units of the reporting currency one unit of every other currency is worth
+	rates map[Currency]*big.Rat
+}
+
+// NewExchange builds an exchange from decimal rates, the number of units of the reporting currency
+// one unit of every other currency is worth. The reporting currency is always accepted, at a rate of 1.
+func NewExchange(reporting, fallback Currency, rates map[Currency]string) (*Exchange, error) {
+	if !reporting.IsValid() {
+		return nil, fmt.Errorf("invalid reporting currency %q: must be an ISO 4217 code", reporting)
+	}
+
+	exchange := &Exchange{
+		Reporting: reporting,
+		Default:   fallback,
+		rates:     map[Currency]*big.Rat{reporting: big.NewRat(1, 1)},
+	}
+
+	for currency, value := range rates {
+		if !currency.IsValid() {
+			return nil, fmt.Errorf("invalid currency %q: must be an ISO 4217 code", currency)
+		}
+
+		rate, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+		if !ok || strings.Contains(value, "/") || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid rate %q for %s: must be a positive decimal number", value, currency)
+		}
+
+		if currency == reporting && rate.Cmp(big.NewRat(1, 1)) != 0 {
+			return nil, fmt.Errorf("invalid rate %q for %s: the reporting currency is worth 1", value, currency)
+		}
+
+		exchange.rates[currency] = rate
+	}
+
+	if !exchange.Accepts(fallback) {
+		return nil, fmt.Errorf("invalid default currency %q: must be the reporting currency or have a rate", fallback)
+	}
+
+	return exchange, nil
+}
+
+// Accepts checks that amounts in the currency can be converted
+func (e *Exchange) Accepts(currency Currency) bool {
+	_, ok := e.rates[currency]
+
+	return ok
+}
+
+// Total adds up amounts held in different currencies in the reporting currency. Every amount is converted exactly
+// and only the total is rounded to the minor unit of the reporting currency, so that it is exact to it.
+func (e *Exchange) Total(amounts map[Currency]Money) (Money, error) {
+	total := new(big.Rat)
+
+	for currency, amount := range amounts {
+		rate, ok := e.rates[currency]
+		if !ok {
+			return 0, fmt.Errorf("no exchange rate for %q", currency)
+		}
+
+		total.Add(total, new(big.Rat).Mul(amount.rat(), rate))
+	}
+
+	return roundMoney(total, e.Reporting), nil
+}
+
+// Convert converts an amount into the reporting currency
+func (e *Exchange) Convert(amount Money, currency Currency) (Money, error) {
+	return e.Total(map[Currency]Money{currency: amount})
+}
@@ -0,0 +1,263 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    Money
+		wantErr bool
+	}{
+		{
+			name:  "success: whole amount",
+			value: "12",
+			want:  120000,
+		},
+		{
+			name:  "success: decimal amount",
+			value: "12.34",
+			want:  123400,
+		},
+		{
+			name:  "success: four decimal places",
+			value: "0.0001",
+			want:  1,
+		},
+		{
+			name:  "success: negative amount",
+			value: "-1.5",
+			want:  -15000,
+		},
+		{
+			name:  "success: exponent",
+			value: "1.5e2",
+			want:  1500000,
+		},
+		{
+			name:    "fail: more than four decimal places",
+			value:   "0.00001",
+			wantErr: true,
+		},
+		{
+			name:    "fail: fraction",
+			value:   "1/3",
+			wantErr: true,
+		},
+		{
+			name:    "fail: not a number",
+			value:   "ten",
+			wantErr: true,
+		},
+		{
+			name:    "fail: out of range",
+			value:   "1e20",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMoney(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMoney() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseMoney() = %v, want %v", int64(got), int64(tt.want))
+			}
+		})
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		money Money
+		want  string
+	}{
+		{
+			name:  "success: whole amount",
+			money: 120000,
+			want:  "12",
+		},
+		{
+			name:  "success: trailing zeros are dropped",
+			money: 123400,
+			want:  "12.34",
+		},
+		{
+			name:  "success: small negative amount",
+			money: -5,
+			want:  "-0.0005",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.money)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+
+			if string(data) != tt.want {
+				t.Errorf("json.Marshal() = %s, want %s", data, tt.want)
+			}
+
+			var decoded Money
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+
+			if decoded != tt.money {
+				t.Errorf("json.Unmarshal() = %v, want %v", decoded, tt.money)
+			}
+		})
+	}
+}
+
+func TestMoney_Round(t *testing.T) {
+	tests := []struct {
+		name     string
+		money    Money
+		currency Currency
+		want     Money
+	}{
+		{
+			name:     "success: rounded to the cent",
+			money:    123449,
+			currency: "KES",
+			want:     123400,
+		},
+		{
+			name:     "success: half a cent rounded up",
+			money:    123450,
+			currency: "USD",
+			want:     123500,
+		},
+		{
+			name:     "success: negative half rounded away from zero",
+			money:    -123450,
+			currency: "USD",
+			want:     -123500,
+		},
+		{
+			name:     "success: currency without minor unit",
+			money:    15000,
+			currency: "UGX",
+			want:     20000,
+		},
+		{
+			name:     "success: currency with three decimal places",
+			money:    12345,
+			currency: "KWD",
+			want:     12350,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.money.Round(tt.currency); got != tt.want {
+				t.Errorf("Money.Round() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExchange_Total(t *testing.T) {
+	exchange, err := NewExchange("KES", "KES", map[Currency]string{"USD": "129.3", "UGX": "0.0352"})
+	if err != nil {
+		t.Fatalf("NewExchange() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		amounts map[Currency]Money
+		want    Money
+		wantErr bool
+	}{
+		{
+			name:    "success: amounts in the reporting currency are added up",
+			amounts: map[Currency]Money{"KES": 100100},
+			want:    100100,
+		},
+		{
+			name:    "success: amounts are converted before the total is rounded",
+			amounts: map[Currency]Money{"KES": 10000, "USD": 100, "UGX": 150000},
+			// 1 + 0.01 * 129.3 + 15 * 0.0352 = 2.821
+			want: 28200,
+		},
+		{
+			name:    "sad: currency without a rate",
+			amounts: map[Currency]Money{"EUR": 10000},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := exchange.Total(tt.amounts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Exchange.Total() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("Exchange.Total() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewExchange(t *testing.T) {
+	tests := []struct {
+		name      string
+		reporting Currency
+		fallback  Currency
+		rates     map[Currency]string
+		wantErr   bool
+	}{
+		{
+			name:      "success: default currency with a rate",
+			reporting: "KES",
+			fallback:  "USD",
+			rates:     map[Currency]string{"USD": "129.3"},
+		},
+		{
+			name:      "fail: invalid reporting currency",
+			reporting: "shilling",
+			fallback:  "shilling",
+			wantErr:   true,
+		},
+		{
+			name:      "fail: default currency without a rate",
+			reporting: "KES",
+			fallback:  "USD",
+			wantErr:   true,
+		},
+		{
+			name:      "fail: rate that is not positive",
+			reporting: "KES",
+			fallback:  "KES",
+			rates:     map[Currency]string{"USD": "0"},
+			wantErr:   true,
+		},
+		{
+			name:      "fail: reporting currency at another rate",
+			reporting: "KES",
+			fallback:  "KES",
+			rates:     map[Currency]string{"KES": "2"},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewExchange(tt.reporting, tt.fallback, tt.rates); (err != nil) != tt.wantErr {
+				t.Errorf("NewExchange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,327 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+)
+
+// maxFractionalDenominator bounds the denominator of fractional odds converted from other formats
+const maxFractionalDenominator = 100
+
+// evens are the fractional odds of a bet that doubles its stake
+var evens = []string{"evens", "evs", "even"}
+
+// InferOddsFormat guesses the format odds are quoted in: fractional when they hold a slash or are evens,
+// American when they are signed and decimal otherwise. Hong Kong and Malay odds cannot be told apart
+// from decimal ones and have to be named.
+func InferOddsFormat(value string) enums.OddsFormat {
+	value = strings.TrimSpace(value)
+
+	switch {
+	case strings.Contains(value, "/"), isEvens(value):
+		return enums.Fractional
+	case strings.HasPrefix(value, "+"), strings.HasPrefix(value, "-"):
+		return enums.American
+	default:
+		return enums.Decimal
+	}
+}
+
+func isEvens(value string) bool {
+	for _, word := range evens {
+		if strings.EqualFold(value, word) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseOdds parses odds quoted in a format, guessed from the value when the format is empty,
+// and returns the decimal odds they normalise to along with the format they were read in.
+func ParseOdds(value string, format enums.OddsFormat) (float64, enums.OddsFormat, error) {
+	odds, format, err := parseExactOdds(value, format)
+	if err != nil {
+		return 0, format, err
+	}
+
+	decimal, _ := odds.Float64()
+
+	return decimal, format, nil
+}
+
+// parseExactOdds parses odds quoted in a format into the exact decimal odds they stand for
+func parseExactOdds(value string, format enums.OddsFormat) (*big.Rat, enums.OddsFormat, error) {
+	value = strings.TrimSpace(value)
+
+	if format == "" {
+		format = InferOddsFormat(value)
+	}
+
+	if !format.IsValid() {
+		return nil, format, fmt.Errorf("invalid odds format %q", format)
+	}
+
+	if format == enums.Fractional && isEvens(value) {
+		return big.NewRat(2, 1), format, nil
+	}
+
+	number, ok := new(big.Rat).SetString(strings.TrimPrefix(value, "+"))
+	if !ok || value == "" || (format != enums.Fractional && strings.Contains(value, "/")) {
+		return nil, format, fmt.Errorf("invalid %s odds %q", format, value)
+	}
+
+	one := big.NewRat(1, 1)
+	odds := new(big.Rat)
+
+	switch format {
+	case enums.Decimal:
+		if number.Cmp(one) < 0 {
+			return nil, format, fmt.Errorf("invalid decimal odds %q: must be at least 1", value)
+		}
+
+		odds.Set(number)
+	case enums.Fractional:
+		numerator, denominator, _ := strings.Cut(value, "/")
+		if !isWholeNumber(numerator) || !isWholeNumber(denominator) {
+			return nil, format, fmt.Errorf("invalid fractional odds %q: must be a whole number over another", value)
+		}
+
+		odds.Add(one, number)
+	case enums.American:
+		hundred := big.NewRat(100, 1)
+
+		switch {
+		case number.Cmp(hundred) >= 0:
+			odds.Add(one, new(big.Rat).Quo(number, hundred))
+		case number.Cmp(new(big.Rat).Neg(hundred)) <= 0:
+			odds.Add(one, new(big.Rat).Quo(hundred, new(big.Rat).Neg(number)))
+		default:
+			return nil, format, fmt.Errorf("invalid american odds %q: must be at least +100 or at most -100", value)
+		}
+	case enums.HongKong:
+		if number.Sign() < 0 {
+			return nil, format, fmt.Errorf("invalid hongkong odds %q: must not be negative", value)
+		}
+
+		odds.Add(one, number)
+	case enums.Malay:
+		switch {
+		case number.Sign() >= 0 && number.Cmp(one) <= 0:
+			odds.Add(one, number)
+		case number.Sign() < 0 && number.Cmp(new(big.Rat).Neg(one)) >= 0:
+			odds.Add(one, new(big.Rat).Quo(one, new(big.Rat).Neg(number)))
+		default:
+			return nil, format, fmt.Errorf("invalid malay odds %q: must be between -1 and 1", value)
+		}
+	}
+
+	return odds, format, nil
+}
+
+func isWholeNumber(value string) bool {
+	_, err := strconv.ParseUint(value, 10, 64)
+
+	return err == nil
+}
+
+// FormatOdds quotes decimal odds in a format, decimal when it is empty. Fractional odds are the closest fraction
+// with a denominator of at most 100, American odds are rounded to a whole number and Hong Kong and Malay odds to two
+// decimal places. Odds of 1 pay no profit and are quoted as +0 in the American format.
+func FormatOdds(odds float64, format enums.OddsFormat) string {
+	profit := odds - 1
+
+	switch format {
+	case enums.Fractional:
+		numerator, denominator := closestFraction(profit)
+
+		return strconv.FormatInt(numerator, 10) + "/" + strconv.FormatInt(denominator, 10)
+	case enums.American:
+		switch {
+		case profit >= 1:
+			return "+" + strconv.FormatFloat(math.Round(profit*100), 'f', 0, 64)
+		case profit <= 0:
+			return "+0"
+		default:
+			return "-" + strconv.FormatFloat(math.Round(100/profit), 'f', 0, 64)
+		}
+	case enums.HongKong:
+		return strconv.FormatFloat(profit, 'f', 2, 64)
+	case enums.Malay:
+		if profit > 1 {
+			return strconv.FormatFloat(-1/profit, 'f', 2, 64)
+		}
+
+		return strconv.FormatFloat(profit, 'f', 2, 64)
+	default:
+		return strconv.FormatFloat(odds, 'f', -1, 64)
+	}
+}
+
+// closestFraction returns the fraction closest to a non-negative number whose denominator is at most
+// maxFractionalDenominator, preferring the smallest denominator
+func closestFraction(value float64) (int64, int64) {
+	bestNumerator, bestDenominator, bestError := int64(math.Round(value)), int64(1), math.Inf(1)
+
+	for denominator := int64(1); denominator <= maxFractionalDenominator; denominator++ {
+		numerator := int64(math.Round(value * float64(denominator)))
+
+		// a small tolerance keeps floating point noise from picking a larger denominator
+		if err := math.Abs(value - float64(numerator)/float64(denominator)); err < bestError-1e-9 {
+			bestNumerator, bestDenominator, bestError = numerator, denominator, err
+		}
+	}
+
+	return max(bestNumerator, 0), bestDenominator
+}
+
+// SetOdds sets the odds of the bet from odds quoted in a format, guessed from the value when the format is empty.
+// The bet keeps the format and, unless they are decimal, the odds as quoted.
+func (b *Bet) SetOdds(value string, format enums.OddsFormat) error {
+	odds, format, err := ParseOdds(value, format)
+	if err != nil {
+		return err
+	}
+
+	b.Odds, b.OddsFormat, b.QuotedOdds = odds, format, ""
+	if format != enums.Decimal {
+		b.QuotedOdds = strings.TrimSpace(value)
+	}
+
+	return nil
+}
+
+// OddsIn quotes the odds of the bet in a format, decimal when it is empty.
+// Odds asked for in the format the bet was placed in are quoted as they were placed.
+func (b *Bet) OddsIn(format enums.OddsFormat) string {
+	if format == b.OddsFormat && b.QuotedOdds != "" {
+		return b.QuotedOdds
+	}
+
+	return FormatOdds(b.Odds, format)
+}
+
+// rawOdds returns the odds held by a JSON number or string, empty when they are missing
+func rawOdds(data json.RawMessage) (string, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return "", nil
+	}
+
+	if data[0] != '"' {
+		return string(data), nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return "", err
+	}
+
+	return value, nil
+}
+
+// UnmarshalJSON reads a bet whose odds are a decimal number or a string quoted in any format.
+// Odds are read in the odds_format of the bet, guessed from them when it is missing, and quoted_odds,
+// as written by the export, take precedence over them.
+func (b *Bet) UnmarshalJSON(data []byte) error {
+	type plain Bet
+
+	decoded := struct {
+		*plain
+		Odds json.RawMessage `json:"odds"`
+	}{plain: (*plain)(b)}
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	value := b.QuotedOdds
+	if value == "" {
+		raw, err := rawOdds(decoded.Odds)
+		if err != nil {
+			return fmt.Errorf("invalid odds: %w", err)
+		}
+
+		value = raw
+	}
+
+	// multi-leg bets may leave their odds out since they are derived from the legs
+	if value == "" {
+		if len(b.Legs) == 0 {
+			return fmt.Errorf("invalid odds: a single bet must have odds")
+		}
+
+		b.Odds, b.QuotedOdds = 0, ""
+
+		return nil
+	}
+
+	return b.SetOdds(value, b.OddsFormat)
+}
+
+// UnmarshalJSON reads a leg whose odds are a decimal number or a string in the decimal, fractional or American format
+func (l *BetLeg) UnmarshalJSON(data []byte) error {
+	type plain BetLeg
+
+	decoded := struct {
+		*plain
+		Odds json.RawMessage `json:"odds"`
+	}{plain: (*plain)(l)}
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	value, err := rawOdds(decoded.Odds)
+	if err != nil {
+		return fmt.Errorf("invalid odds: %w", err)
+	}
+
+	l.Odds = 0
+	if value == "" {
+		return nil
+	}
+
+	l.Odds, _, err = ParseOdds(value, "")
+
+	return err
+}
+
+// QuotedBet is a bet whose odds, and the odds of its legs, are quoted in a given format for display.
+// QuotedOdds always hold the odds as the bet was placed so that the bet reads back the same.
+type QuotedBet struct {
+	*Bet
+	Odds       string         `json:"odds"`
+	QuotedOdds string         `json:"quoted_odds,omitempty"`
+	Legs       []QuotedBetLeg `json:"legs,omitempty"`
+}
+
+// QuotedBetLeg is a leg of a bet whose odds are quoted in a given format for display
+type QuotedBetLeg struct {
+	BetLeg
+	Odds string `json:"odds"`
+}
+
+// Quoted returns the bet to display with its odds quoted in a format.
+// Bets displayed in decimal odds, or when no format is given, are returned as they are.
+func (b *Bet) Quoted(format enums.OddsFormat) any {
+	if format == "" || format == enums.Decimal {
+		return b
+	}
+
+	quoted := QuotedBet{Bet: b, Odds: b.OddsIn(format)}
+	if len(b.Legs) == 0 {
+		quoted.QuotedOdds = b.OddsIn(b.OddsFormat)
+	}
+
+	for _, leg := range b.Legs {
+		quoted.Legs = append(quoted.Legs, QuotedBetLeg{BetLeg: leg, Odds: FormatOdds(leg.Odds, format)})
+	}
+
+	return quoted
+}
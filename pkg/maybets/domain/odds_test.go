@@ -0,0 +1,320 @@
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+)
+
+func TestParseOdds(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		format     enums.OddsFormat
+		want       float64
+		wantFormat enums.OddsFormat
+		wantErr    bool
+	}{
+		{
+			name:       "success: decimal odds",
+			value:      "2.5",
+			want:       2.5,
+			wantFormat: enums.Decimal,
+		},
+		{
+			name:       "success: fractional odds",
+			value:      "5/2",
+			want:       3.5,
+			wantFormat: enums.Fractional,
+		},
+		{
+			name:       "success: evens",
+			value:      "Evens",
+			want:       2,
+			wantFormat: enums.Fractional,
+		},
+		{
+			name:       "success: positive american odds",
+			value:      "+150",
+			want:       2.5,
+			wantFormat: enums.American,
+		},
+		{
+			name:       "success: negative american odds",
+			value:      "-200",
+			want:       1.5,
+			wantFormat: enums.American,
+		},
+		{
+			name:       "success: american odds without a sign",
+			value:      "150",
+			format:     enums.American,
+			want:       2.5,
+			wantFormat: enums.American,
+		},
+		{
+			name:       "success: hong kong odds",
+			value:      "0.8",
+			format:     enums.HongKong,
+			want:       1.8,
+			wantFormat: enums.HongKong,
+		},
+		{
+			name:       "success: positive malay odds",
+			value:      "0.5",
+			format:     enums.Malay,
+			want:       1.5,
+			wantFormat: enums.Malay,
+		},
+		{
+			name:       "success: negative malay odds",
+			value:      "-0.5",
+			format:     enums.Malay,
+			want:       3,
+			wantFormat: enums.Malay,
+		},
+		{
+			name:    "fail: decimal odds below 1",
+			value:   "0.5",
+			wantErr: true,
+		},
+		{
+			name:    "fail: fraction of decimals",
+			value:   "2.5/1",
+			wantErr: true,
+		},
+		{
+			name:    "fail: fraction without denominator",
+			value:   "5/0",
+			wantErr: true,
+		},
+		{
+			name:    "fail: american odds between -100 and +100",
+			value:   "+50",
+			wantErr: true,
+		},
+		{
+			name:    "fail: malay odds out of range",
+			value:   "1.5",
+			format:  enums.Malay,
+			wantErr: true,
+		},
+		{
+			name:    "fail: fractional odds given as a decimal",
+			value:   "3.5",
+			format:  enums.Fractional,
+			wantErr: true,
+		},
+		{
+			name:    "fail: unknown format",
+			value:   "2",
+			format:  "moneyline",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, format, err := ParseOdds(tt.value, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOdds() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if got != tt.want || format != tt.wantFormat {
+				t.Errorf("ParseOdds() = %v, %v, want %v, %v", got, format, tt.want, tt.wantFormat)
+			}
+		})
+	}
+}
+
+func TestFormatOdds(t *testing.T) {
+	tests := []struct {
+		name   string
+		odds   float64
+		format enums.OddsFormat
+		want   string
+	}{
+		{
+			name: "success: decimal by default",
+			odds: 2.5,
+			want: "2.5",
+		},
+		{
+			name:   "success: fractional",
+			odds:   3.5,
+			format: enums.Fractional,
+			want:   "5/2",
+		},
+		{
+			name:   "success: fractional with the closest small denominator",
+			odds:   1.909,
+			format: enums.Fractional,
+			want:   "10/11",
+		},
+		{
+			name:   "success: american favourite",
+			odds:   1.5,
+			format: enums.American,
+			want:   "-200",
+		},
+		{
+			name:   "success: american underdog",
+			odds:   2.5,
+			format: enums.American,
+			want:   "+150",
+		},
+		{
+			name:   "success: hong kong",
+			odds:   1.8,
+			format: enums.HongKong,
+			want:   "0.80",
+		},
+		{
+			name:   "success: malay above evens",
+			odds:   3,
+			format: enums.Malay,
+			want:   "-0.50",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatOdds(tt.odds, tt.format); got != tt.want {
+				t.Errorf("FormatOdds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBet_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		wantOdds   float64
+		wantFormat enums.OddsFormat
+		wantQuoted string
+		wantErr    bool
+	}{
+		{
+			name:       "success: decimal number",
+			data:       `{"odds":2.5}`,
+			wantOdds:   2.5,
+			wantFormat: enums.Decimal,
+		},
+		{
+			name:       "success: fractional string",
+			data:       `{"odds":"5/2"}`,
+			wantOdds:   3.5,
+			wantFormat: enums.Fractional,
+			wantQuoted: "5/2",
+		},
+		{
+			name:       "success: number in a named format",
+			data:       `{"odds":-110,"odds_format":"american"}`,
+			wantOdds:   1 + 100.0/110,
+			wantFormat: enums.American,
+			wantQuoted: "-110",
+		},
+		{
+			name:       "success: quoted odds written by the export",
+			data:       `{"odds":1.5,"odds_format":"malay","quoted_odds":"0.5"}`,
+			wantOdds:   1.5,
+			wantFormat: enums.Malay,
+			wantQuoted: "0.5",
+		},
+		{
+			name:       "success: multi-leg bet without odds",
+			data:       `{"legs":[{"selection":"s1","odds":"1/2","outcome":"win"}]}`,
+			wantOdds:   0,
+			wantFormat: "",
+		},
+		{
+			name:    "fail: single bet without odds",
+			data:    `{"outcome":"win"}`,
+			wantErr: true,
+		},
+		{
+			name:    "fail: single bet with odds below 1",
+			data:    `{"odds":0.5}`,
+			wantErr: true,
+		},
+		{
+			name:    "fail: invalid odds",
+			data:    `{"odds":"5/2/1"}`,
+			wantErr: true,
+		},
+		{
+			name:    "fail: invalid odds format",
+			data:    `{"odds":2,"odds_format":"moneyline"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bet Bet
+
+			err := json.Unmarshal([]byte(tt.data), &bet)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Bet.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if bet.Odds != tt.wantOdds || bet.OddsFormat != tt.wantFormat || bet.QuotedOdds != tt.wantQuoted {
+				t.Errorf("Bet.UnmarshalJSON() = %v %v %q, want %v %v %q",
+					bet.Odds, bet.OddsFormat, bet.QuotedOdds, tt.wantOdds, tt.wantFormat, tt.wantQuoted)
+			}
+
+			if len(bet.Legs) > 0 && bet.Legs[0].Odds != 1.5 {
+				t.Errorf("Bet.UnmarshalJSON() leg odds = %v, want 1.5", bet.Legs[0].Odds)
+			}
+		})
+	}
+}
+
+func TestBet_Quoted(t *testing.T) {
+	bet := &Bet{BetID: "b1", Odds: 2.5, OddsFormat: enums.Fractional, QuotedOdds: "3/2", Amount: 10 * moneyUnit, Currency: "USD"}
+
+	tests := []struct {
+		name   string
+		format enums.OddsFormat
+		want   string
+	}{
+		{
+			name: "success: decimal odds by default",
+			want: `"odds":2.5`,
+		},
+		{
+			name:   "success: odds as they were placed",
+			format: enums.Fractional,
+			want:   `"odds":"3/2"`,
+		},
+		{
+			name:   "success: odds converted to another format",
+			format: enums.American,
+			want:   `"odds":"+150"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(bet.Quoted(tt.format))
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+
+			if !strings.Contains(string(data), tt.want) || strings.Count(string(data), `"odds"`) != 1 {
+				t.Errorf("Bet.Quoted() = %s, want it to hold %s once", data, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+)
+
+// WebhookSubscription registers a URL to be notified of alerts of the given events
+type WebhookSubscription struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// Secret signs every delivery. It is only shown when the subscription is created.
+	Secret    string               `json:"secret,omitempty"`
+	Events    []enums.WebhookEvent `json:"events"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+// Subscribes reports whether the subscription is notified of the event
+func (s WebhookSubscription) Subscribes(event enums.WebhookEvent) bool {
+	return slices.Contains(s.Events, event)
+}
+
+// Alert is a notable event detected in the bets. It is raised once and delivered to every subscription of its event.
+type Alert struct {
+	ID     string             `json:"id"`
+	Event  enums.WebhookEvent `json:"event"`
+	UserID string             `json:"user_id"`
+	BetID  string             `json:"bet_id,omitempty"`
+	// Limit and Period name the responsible gambling limit a user breached, see LimitStatus
+	Limit  enums.LimitKind   `json:"limit,omitempty"`
+	Period enums.LimitPeriod `json:"period,omitempty"`
+	// Value is the bet amount of a large bet, the amount lost by a user crossing the loss limit,
+	// the number of bets of an anomalous user, the value of the limit a user breached
+	// or the total amount of the bets reported by an anti-money laundering rule
+	Value float64 `json:"value"`
+	// Threshold is the limit Value reached
+	Threshold float64 `json:"threshold,omitempty"`
+	// Evidence holds the IDs of the bets reported by an anti-money laundering rule
+	Evidence  []string  `json:"evidence,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Key identifies what the alert is about so that it is only raised once:
+// a large bet or a large transaction per bet, a loss limit or an anomaly per user,
+// a responsible gambling limit per user, limit and day it was breached on
+// and structuring per user and first bet of the run not reported before.
+func (a Alert) Key() string {
+	if a.Event == enums.LargeBet || a.Event == enums.AMLLargeTransaction {
+		return a.Event.String() + ":" + a.BetID
+	}
+
+	if a.Event == enums.AMLStructuring {
+		return a.Event.String() + ":" + a.UserID + ":" + a.BetID
+	}
+
+	if a.Event == enums.LimitBreached {
+		return a.Event.String() + ":" + a.UserID + ":" + a.Limit.String() + ":" + a.Period.String() + ":" +
+			a.CreatedAt.UTC().Format(time.DateOnly)
+	}
+
+	return a.Event.String() + ":" + a.UserID
+}
+
+// WebhookDelivery is the notification of an alert to a subscription, attempted until it succeeds or is dead lettered
+type WebhookDelivery struct {
+	ID             string `json:"id"`
+	SubscriptionID string `json:"subscription_id"`
+	// URL and Secret are those of the subscription
+	URL     string             `json:"url"`
+	Secret  string             `json:"-"`
+	AlertID string             `json:"alert_id"`
+	Event   enums.WebhookEvent `json:"event"`
+	// Payload is the JSON body sent, identical on every attempt
+	Payload       json.RawMessage `json:"payload"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	LastStatus    int             `json:"last_status,omitempty"`
+	LastError     string          `json:"last_error,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// WebhookDeadLetter is a delivery that kept failing after the last attempt
+type WebhookDeadLetter struct {
+	ID             string             `json:"id"`
+	DeliveryID     string             `json:"delivery_id"`
+	SubscriptionID string             `json:"subscription_id"`
+	URL            string             `json:"url"`
+	AlertID        string             `json:"alert_id"`
+	Event          enums.WebhookEvent `json:"event"`
+	Payload        json.RawMessage    `json:"payload"`
+	Attempts       int                `json:"attempts"`
+	LastStatus     int                `json:"last_status,omitempty"`
+	LastError      string             `json:"last_error,omitempty"`
+	FailedAt       time.Time          `json:"failed_at"`
+}
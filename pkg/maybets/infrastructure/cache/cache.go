@@ -76,3 +76,19 @@ func (cs *StoreCache) Set(ctx context.Context, key string, value interface{}, ex
 
 	return nil
 }
+
+// Delete removes the given keys from the cache. Keys that are not cached are ignored.
+func (cs *StoreCache) Delete(ctx context.Context, keys ...string) error {
+	_, span := tracer.Start(ctx, "Delete")
+	defer span.End()
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	if err := cs.storer.Del(keys...).Err(); err != nil {
+		return fmt.Errorf("failed to delete values from Redis: %w", err)
+	}
+
+	return nil
+}
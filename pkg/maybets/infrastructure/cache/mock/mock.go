@@ -7,8 +7,9 @@ import (
 
 // StoreCacheMock mocks caching implementations
 type StoreCacheMock struct {
-	MockGetFn func(ctx context.Context, key string, valueType interface{}) (interface{}, error)
-	MockSetFn func(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	MockGetFn    func(ctx context.Context, key string, valueType interface{}) (interface{}, error)
+	MockSetFn    func(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	MockDeleteFn func(ctx context.Context, keys ...string) error
 }
 
 // NewStoreCacheMock initializes our client mocks
@@ -20,6 +21,9 @@ func NewStoreCacheMock() *StoreCacheMock {
 		MockSetFn: func(ctx context.Context, key string, value interface{}, expiration time.Duration) error { //nolint:all
 			return nil
 		},
+		MockDeleteFn: func(ctx context.Context, keys ...string) error { //nolint:all
+			return nil
+		},
 	}
 }
 
@@ -32,3 +36,8 @@ func (c StoreCacheMock) Get(ctx context.Context, key string, valueType interface
 func (c StoreCacheMock) Set(ctx context.Context, key string, valueType interface{}, expiration time.Duration) error {
 	return c.MockSetFn(ctx, key, valueType, expiration)
 }
+
+// Delete mocks the implementation of removing values from the cache store
+func (c StoreCacheMock) Delete(ctx context.Context, keys ...string) error {
+	return c.MockDeleteFn(ctx, keys...)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"testing"
 	"text/template"
 
@@ -48,9 +49,15 @@ var (
 func TestMain(m *testing.M) {
 	log.Println("setting up test database")
 
-	var err error
+	dir, err := os.MkdirTemp("", "maybets-test")
+	if err != nil {
+		fmt.Println("failed to create test directory:", err)
+		os.Exit(1)
+	}
 
-	testingDB, err = gorm.NewDBInstance()
+	dbPath := filepath.Join(dir, "bets.db")
+
+	testingDB, err = gorm.NewDBInstance(dbPath)
 	if err != nil {
 		fmt.Println("failed to initialize db:", err)
 		os.Exit(1)
@@ -62,7 +69,7 @@ func TestMain(m *testing.M) {
 		os.Exit(1)
 	}
 
-	err = postgres.RunMigrations()
+	err = postgres.RunMigrations(dbPath)
 	if err != nil {
 		fmt.Println("failed to run migrations:", err)
 		os.Exit(1)
@@ -115,7 +122,11 @@ func TestMain(m *testing.M) {
 	}
 
 	log.Printf("Running tests ...")
-	os.Exit(m.Run())
+
+	code := m.Run()
+
+	os.RemoveAll(dir)
+	os.Exit(code)
 }
 
 func prepareTestDatabase() error {
@@ -5,14 +5,16 @@ import (
 	"fmt"
 
 	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // StoreBetData is used to store bet records in the database
 func (db DBInstance) StoreBetData(ctx context.Context, bet []Bet) error {
-	_, span := tracer.Start(ctx, "StoreBetData")
+	ctx, span := tracer.Start(ctx, "StoreBetData")
 	defer span.End()
 
-	err := db.DB.Create(&bet).Error
+	err := db.DB.WithContext(ctx).Create(&bet).Error
 	if err != nil {
 		span.SetStatus(codes.Error, "Failed to store bet data")
 		span.RecordError(err)
@@ -22,3 +24,490 @@ func (db DBInstance) StoreBetData(ctx context.Context, bet []Bet) error {
 
 	return nil
 }
+
+// StoreNewBetData stores the bets whose bet_id is not stored yet and skips the others, so that bets delivered again
+// by a streaming transport are not taken for failures. It returns the number of bets stored.
+func (db DBInstance) StoreNewBetData(ctx context.Context, bets []Bet) (int64, error) {
+	ctx, span := tracer.Start(ctx, "StoreNewBetData")
+	defer span.End()
+
+	result := db.DB.WithContext(ctx).Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "bet_id"}}, DoNothing: true}).Create(&bets)
+	if result.Error != nil {
+		span.SetStatus(codes.Error, "Failed to store new bet data")
+		span.RecordError(result.Error)
+
+		return 0, fmt.Errorf("failed to store bet data: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// SettleBet saves the state of a settled bet and of its legs, identified by their bet_id and position.
+// The odds and payout are saved along with the outcome since they are derived from it.
+// Only the legs whose outcome changed are updated.
+func (db DBInstance) SettleBet(ctx context.Context, bet *Bet) error {
+	ctx, span := tracer.Start(ctx, "SettleBet")
+	defer span.End()
+
+	err := db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return settleBet(tx, bet)
+	})
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to settle bet")
+		span.RecordError(err)
+
+		return err
+	}
+
+	return nil
+}
+
+// settleBet saves the state of a settled bet and of its legs within a transaction
+func settleBet(tx *gorm.DB, bet *Bet) error {
+	result := tx.Model(bet).
+		Where("bet_id = ?", bet.BetID).
+		Select("outcome", "odds", "payout", "updated", "updated_by").
+		Updates(bet)
+	if result.Error != nil {
+		return fmt.Errorf("failed to settle bet %s: %w", bet.BetID, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("failed to settle bet %s: %w", bet.BetID, gorm.ErrRecordNotFound)
+	}
+
+	for i := range bet.Legs {
+		leg := &bet.Legs[i]
+		leg.UpdatedBy = bet.UpdatedBy
+
+		err := tx.Model(leg).
+			Where("bet_id = ? AND position = ? AND outcome <> ?", bet.BetID, leg.Position, leg.Outcome).
+			Select("outcome", "updated", "updated_by").
+			Updates(leg).Error
+		if err != nil {
+			return fmt.Errorf("failed to settle leg %d of bet %s: %w", leg.Position, bet.BetID, err)
+		}
+	}
+
+	return nil
+}
+
+// SaveEvent stores an event with its markets and selections. When the event exists, its name and start are updated
+// and the markets and selections it does not have yet are added; the results of its selections are kept.
+func (db DBInstance) SaveEvent(ctx context.Context, event *Event) error {
+	ctx, span := tracer.Start(ctx, "SaveEvent")
+	defer span.End()
+
+	err := db.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "starts_at", "updated", "updated_by"}),
+	}).Create(event).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to save event")
+		span.RecordError(err)
+
+		return fmt.Errorf("failed to save event: %w", err)
+	}
+
+	return nil
+}
+
+// SaveUserLimits stores the caps of a user over a period, replacing the caps they had over it
+func (db DBInstance) SaveUserLimits(ctx context.Context, limits *UserLimits) error {
+	ctx, span := tracer.Start(ctx, "SaveUserLimits")
+	defer span.End()
+
+	err := db.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "period"}},
+		DoUpdates: clause.AssignmentColumns([]string{"loss_cap", "stake_cap", "bet_cap", "updated", "updated_by"}),
+	}).Create(limits).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to save user limits")
+		span.RecordError(err)
+
+		return fmt.Errorf("failed to save user limits: %w", err)
+	}
+
+	return nil
+}
+
+// SettleSelection records a result submitted for a selection and saves the bets it settles in a single transaction.
+// settle is called with every bet on the selection that is not cashed out, once the previous result
+// of the selection is set on the result, and reports whether it changed the bet.
+// The bets it changed are saved as settled by the submitter and counted in the result. Their stakes and payouts
+// are in different currencies and are left for settle to add up in the result.
+func (db DBInstance) SettleSelection(ctx context.Context, result *SelectionResult, settle func(bet *Bet) (bool, error)) error {
+	ctx, span := tracer.Start(ctx, "SettleSelection")
+	defer span.End()
+
+	err := db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var selection Selection
+		if err := tx.Where("id = ?", result.SelectionID).First(&selection).Error; err != nil {
+			return fmt.Errorf("failed to get selection %s: %w", result.SelectionID, err)
+		}
+
+		result.PreviousResult = selection.Result
+
+		settled, err := settleSelectionBets(tx, result.SelectionID, settle)
+		if err != nil {
+			return err
+		}
+
+		for _, bet := range settled {
+			bet.UpdatedBy = result.CreatedBy
+
+			if err := settleBet(tx, bet); err != nil {
+				return err
+			}
+
+			result.BetsSettled++
+		}
+
+		selection.Result = &result.Result
+		selection.UpdatedBy = result.CreatedBy
+
+		err = tx.Model(&selection).
+			Select("result", "updated", "updated_by").
+			Updates(&selection).Error
+		if err != nil {
+			return fmt.Errorf("failed to save the result of selection %s: %w", result.SelectionID, err)
+		}
+
+		if err := tx.Create(result).Error; err != nil {
+			return fmt.Errorf("failed to record the result of selection %s: %w", result.SelectionID, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to settle selection")
+		span.RecordError(err)
+
+		return err
+	}
+
+	return nil
+}
+
+// settleSelectionBets calls settle with every bet on the selection that is not cashed out and returns those it changed.
+// The bets are read before any is saved, since a transaction cannot write while its rows are being read.
+func settleSelectionBets(tx *gorm.DB, selectionID string, settle func(bet *Bet) (bool, error)) ([]*Bet, error) {
+	rows, err := tx.Model(&Bet{}).
+		Where("outcome <> 'cashout' AND (selection = ? OR bet_id IN (SELECT bet_id FROM bet_legs WHERE selection = ?))", selectionID, selectionID).
+		Select(betColumns).
+		Rows()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the bets on selection %s: %w", selectionID, err)
+	}
+
+	defer rows.Close()
+
+	var settled []*Bet
+
+	for rows.Next() {
+		bet, err := scanBet(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		changed, err := settle(bet)
+		if err != nil {
+			return nil, fmt.Errorf("failed to settle bet %s: %w", bet.BetID, err)
+		}
+
+		if changed {
+			settled = append(settled, bet)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the bets on selection %s: %w", selectionID, err)
+	}
+
+	return settled, nil
+}
+
+// CreateSelfExclusion stores a new self-exclusion
+func (db DBInstance) CreateSelfExclusion(ctx context.Context, exclusion *SelfExclusion) error {
+	ctx, span := tracer.Start(ctx, "CreateSelfExclusion")
+	defer span.End()
+
+	err := db.DB.WithContext(ctx).Create(exclusion).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to create self-exclusion")
+		span.RecordError(err)
+
+		return fmt.Errorf("failed to create self-exclusion: %w", err)
+	}
+
+	return nil
+}
+
+// LiftSelfExclusion saves when a self-exclusion was lifted and by whom, unless it was already lifted
+func (db DBInstance) LiftSelfExclusion(ctx context.Context, exclusion *SelfExclusion) error {
+	ctx, span := tracer.Start(ctx, "LiftSelfExclusion")
+	defer span.End()
+
+	result := db.DB.WithContext(ctx).Model(exclusion).
+		Where("lifted_at IS NULL").
+		Select("lifted_at", "lifted_by", "updated", "updated_by").
+		Updates(exclusion)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = fmt.Errorf("self-exclusion %s: %w", *exclusion.ID, gorm.ErrRecordNotFound)
+	}
+
+	if result.Error != nil {
+		span.SetStatus(codes.Error, "Failed to lift self-exclusion")
+		span.RecordError(result.Error)
+
+		return fmt.Errorf("failed to lift self-exclusion: %w", result.Error)
+	}
+
+	return nil
+}
+
+// RecordExclusionViolations stores the bets received from self-excluded users, skipping those already recorded
+// for the same exclusion
+func (db DBInstance) RecordExclusionViolations(ctx context.Context, violations []ExclusionViolation) error {
+	ctx, span := tracer.Start(ctx, "RecordExclusionViolations")
+	defer span.End()
+
+	err := recordExclusionViolations(db.DB.WithContext(ctx), violations)
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to record exclusion violations")
+		span.RecordError(err)
+
+		return fmt.Errorf("failed to record exclusion violations: %w", err)
+	}
+
+	return nil
+}
+
+// recordExclusionViolations stores the violations that were not recorded for the same bet and exclusion before
+func recordExclusionViolations(tx *gorm.DB, violations []ExclusionViolation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "bet_id"}, {Name: "exclusion_id"}},
+		DoNothing: true,
+	}).Create(&violations).Error
+}
+
+// CreateIngestJob stores a new ingest job
+func (db DBInstance) CreateIngestJob(ctx context.Context, job *IngestJob) error {
+	ctx, span := tracer.Start(ctx, "CreateIngestJob")
+	defer span.End()
+
+	err := db.DB.WithContext(ctx).Create(job).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to create ingest job")
+		span.RecordError(err)
+
+		return fmt.Errorf("failed to create ingest job: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateIngestJob saves the status, error and progress of an ingest job
+func (db DBInstance) UpdateIngestJob(ctx context.Context, job *IngestJob) error {
+	ctx, span := tracer.Start(ctx, "UpdateIngestJob")
+	defer span.End()
+
+	err := db.DB.WithContext(ctx).Model(job).
+		Select("status", "error", "committed_offset", "committed_records", "completed_at", "updated").
+		Updates(job).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to update ingest job")
+		span.RecordError(err)
+
+		return fmt.Errorf("failed to update ingest job: %w", err)
+	}
+
+	return nil
+}
+
+// CommitIngestBatch stores a batch of bets with the exclusion violations found in it and advances the ingest job
+// checkpoint in a single transaction, so that the checkpoint never points past bets that were not stored or before
+// bets that were
+func (db DBInstance) CommitIngestBatch(ctx context.Context, job *IngestJob, bets []Bet, violations []ExclusionViolation) error {
+	ctx, span := tracer.Start(ctx, "CommitIngestBatch")
+	defer span.End()
+
+	err := db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// every bet of a batch may have been turned down, the checkpoint still moves past them
+		if len(bets) > 0 {
+			if err := tx.Create(&bets).Error; err != nil {
+				return fmt.Errorf("failed to store bet data: %w", err)
+			}
+		}
+
+		if err := recordExclusionViolations(tx, violations); err != nil {
+			return fmt.Errorf("failed to record exclusion violations: %w", err)
+		}
+
+		err := tx.Model(job).
+			Select("committed_offset", "committed_records", "updated").
+			Updates(job).Error
+		if err != nil {
+			return fmt.Errorf("failed to update ingest job checkpoint: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to commit ingest batch")
+		span.RecordError(err)
+
+		return err
+	}
+
+	return nil
+}
+
+// CreateWebhookSubscription stores a new webhook subscription
+func (db DBInstance) CreateWebhookSubscription(ctx context.Context, subscription *WebhookSubscription) error {
+	ctx, span := tracer.Start(ctx, "CreateWebhookSubscription")
+	defer span.End()
+
+	err := db.DB.WithContext(ctx).Create(subscription).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to create webhook subscription")
+		span.RecordError(err)
+
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteWebhookSubscription deletes a webhook subscription along with its pending deliveries
+func (db DBInstance) DeleteWebhookSubscription(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "DeleteWebhookSubscription")
+	defer span.End()
+
+	err := db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&WebhookDelivery{}).Error; err != nil {
+			return fmt.Errorf("failed to delete webhook deliveries: %w", err)
+		}
+
+		result := tx.Where("id = ?", id).Delete(&WebhookSubscription{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete webhook subscription: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("failed to delete webhook subscription %s: %w", id, gorm.ErrRecordNotFound)
+		}
+
+		return nil
+	})
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to delete webhook subscription")
+		span.RecordError(err)
+
+		return err
+	}
+
+	return nil
+}
+
+// RecordAlert stores an alert together with its deliveries, unless an alert with the same dedupe key was already recorded.
+// It reports whether the alert is new. The deliveries of an alert that is not new are discarded.
+func (db DBInstance) RecordAlert(ctx context.Context, alert *Alert, deliveries []WebhookDelivery) (bool, error) {
+	ctx, span := tracer.Start(ctx, "RecordAlert")
+	defer span.End()
+
+	var created bool
+
+	err := db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "dedupe_key"}},
+			DoNothing: true,
+		}).Create(alert)
+		if result.Error != nil {
+			return fmt.Errorf("failed to store alert: %w", result.Error)
+		}
+
+		created = result.RowsAffected > 0
+		if !created || len(deliveries) == 0 {
+			return nil
+		}
+
+		if err := tx.Create(&deliveries).Error; err != nil {
+			return fmt.Errorf("failed to store webhook deliveries: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to record alert")
+		span.RecordError(err)
+
+		return false, err
+	}
+
+	return created, nil
+}
+
+// UpdateWebhookDelivery saves the outcome of a failed attempt and when the next one is due
+func (db DBInstance) UpdateWebhookDelivery(ctx context.Context, delivery *WebhookDelivery) error {
+	ctx, span := tracer.Start(ctx, "UpdateWebhookDelivery")
+	defer span.End()
+
+	err := db.DB.WithContext(ctx).Model(delivery).
+		Select("attempts", "next_attempt_at", "last_status", "last_error", "updated").
+		Updates(delivery).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to update webhook delivery")
+		span.RecordError(err)
+
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteWebhookDelivery deletes a delivery once it succeeded
+func (db DBInstance) DeleteWebhookDelivery(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "DeleteWebhookDelivery")
+	defer span.End()
+
+	err := db.DB.WithContext(ctx).Where("id = ?", id).Delete(&WebhookDelivery{}).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to delete webhook delivery")
+		span.RecordError(err)
+
+		return fmt.Errorf("failed to delete webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// DeadLetterWebhookDelivery moves a delivery that failed its last attempt to the dead letter table in a single transaction
+func (db DBInstance) DeadLetterWebhookDelivery(ctx context.Context, deadLetter *WebhookDeadLetter) error {
+	ctx, span := tracer.Start(ctx, "DeadLetterWebhookDelivery")
+	defer span.End()
+
+	err := db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(deadLetter).Error; err != nil {
+			return fmt.Errorf("failed to store webhook dead letter: %w", err)
+		}
+
+		if err := tx.Where("id = ?", deadLetter.DeliveryID).Delete(&WebhookDelivery{}).Error; err != nil {
+			return fmt.Errorf("failed to delete webhook delivery: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to dead letter webhook delivery")
+		span.RecordError(err)
+
+		return err
+	}
+
+	return nil
+}
@@ -2,14 +2,20 @@ package gorm_test
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"testing"
 	"time"
 
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/database/postgres/gorm"
 	"github.com/brianvoe/gofakeit"
 )
 
 func TestDBInstance_StoreBetData(t *testing.T) {
+	accumulatorBetID, accumulatorUserID := gofakeit.UUID(), gofakeit.UUID()
+	quotedBetID := gofakeit.UUID()
+
 	type args struct {
 		ctx context.Context
 		bet []gorm.Bet
@@ -25,19 +31,62 @@ func TestDBInstance_StoreBetData(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				bet: []gorm.Bet{
-					{BetID: gofakeit.UUID(), UserID: userID, Amount: 100, Odds: 2.78, Outcome: "win", Timestamp: time.Now()},
-					{BetID: gofakeit.UUID(), UserID: userID2, Amount: 59, Odds: 1.78, Outcome: "lose", Timestamp: time.Now()},
+					{BetID: gofakeit.UUID(), UserID: userID, Amount: 100, Currency: "USD", Odds: 2.78, Outcome: "win", Timestamp: time.Now()},
+					{BetID: gofakeit.UUID(), UserID: userID2, Amount: 59, Currency: "USD", Odds: 1.78, Outcome: "lose", Timestamp: time.Now()},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success: store a multi-leg bet with its legs",
+			args: args{
+				ctx: context.Background(),
+				bet: []gorm.Bet{
+					{
+						BetID: accumulatorBetID, UserID: accumulatorUserID, Amount: 10, Currency: "USD", Odds: 6, Outcome: "win",
+						Timestamp: time.Now(), Payout: 60,
+						Legs: []gorm.BetLeg{
+							{BetID: accumulatorBetID, Position: 1, Selection: "home", Odds: 2, Outcome: "win"},
+							{BetID: accumulatorBetID, Position: 2, Selection: "over 2.5", Odds: 3, Outcome: "win"},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success: store a bet with its odds as quoted",
+			args: args{
+				ctx: context.Background(),
+				bet: []gorm.Bet{
+					{
+						BetID: quotedBetID, UserID: userID, Amount: 100, Currency: "USD", Odds: 3.5, OddsFormat: "fractional",
+						QuotedOdds: "5/2", Outcome: "pending", Timestamp: time.Now(),
+					},
 				},
 			},
 			wantErr: false,
 		},
+		{
+			name: "fail: unknown odds format",
+			args: args{
+				ctx: context.Background(),
+				bet: []gorm.Bet{
+					{
+						BetID: gofakeit.UUID(), UserID: userID, Amount: 100, Currency: "USD", Odds: 2.5, OddsFormat: "moneyline",
+						QuotedOdds: "150", Outcome: "pending", Timestamp: time.Now(),
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "Sad: unable to store bets in db",
 			args: args{
 				ctx: context.Background(),
 				bet: []gorm.Bet{
-					{BetID: gofakeit.UUID(), UserID: userID, Amount: 100, Odds: 2.78, Outcome: "win", Timestamp: time.Now()},
-					{BetID: bet1UserID, UserID: userID2, Amount: 59, Odds: 1.78, Outcome: "lose", Timestamp: time.Now()},
+					{BetID: gofakeit.UUID(), UserID: userID, Amount: 100, Currency: "USD", Odds: 2.78, Outcome: "win", Timestamp: time.Now()},
+					{BetID: bet1UserID, UserID: userID2, Amount: 59, Currency: "USD", Odds: 1.78, Outcome: "lose", Timestamp: time.Now()},
 				},
 			},
 			wantErr: true,
@@ -49,6 +98,998 @@ func TestDBInstance_StoreBetData(t *testing.T) {
 			if err := testingDB.StoreBetData(tt.args.ctx, tt.args.bet); (err != nil) != tt.wantErr {
 				t.Errorf("DBInstance.StoreBetData() error = %v, wantErr %v", err, tt.wantErr)
 			}
+
+			if tt.name == "success: store a bet with its odds as quoted" {
+				stored, err := testingDB.GetBet(tt.args.ctx, quotedBetID)
+				if err != nil {
+					t.Fatalf("DBInstance.GetBet() error = %v", err)
+				}
+
+				if stored.Odds != 3.5 || stored.OddsFormat != "fractional" || stored.QuotedOdds != "5/2" {
+					t.Errorf("DBInstance.GetBet() = %+v, want the odds with their format and quote", stored)
+				}
+			}
+
+			if tt.name != "success: store a multi-leg bet with its legs" {
+				return
+			}
+
+			var stored []*gorm.Bet
+
+			err := testingDB.StreamBets(tt.args.ctx, domain.BetFilter{UserID: accumulatorUserID}, func(bet *gorm.Bet) error {
+				stored = append(stored, bet)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("DBInstance.StreamBets() error = %v", err)
+			}
+
+			if len(stored) != 1 || stored[0].Payout != 60 || len(stored[0].Legs) != 2 ||
+				stored[0].Legs[0].Selection != "home" || stored[0].Legs[1].Odds != 3 {
+				t.Errorf("DBInstance.StreamBets() = %+v, want the bet with its payout and legs in order", stored)
+			}
+		})
+	}
+}
+
+func TestDBInstance_StoreNewBetData(t *testing.T) {
+	redeliveredBetID, accumulatorBetID := gofakeit.UUID(), gofakeit.UUID()
+
+	redelivered := []gorm.Bet{
+		{BetID: redeliveredBetID, UserID: userID, Amount: 100, Currency: "USD", Odds: 2.78, Outcome: "win", Timestamp: time.Now()},
+		{
+			BetID: accumulatorBetID, UserID: userID2, Amount: 10, Currency: "USD", Odds: 6, Outcome: "pending", Timestamp: time.Now(),
+			Legs: []gorm.BetLeg{
+				{BetID: accumulatorBetID, Position: 1, Selection: "home", Odds: 2, Outcome: "win"},
+				{BetID: accumulatorBetID, Position: 2, Selection: "away", Odds: 3, Outcome: "pending"},
+			},
+		},
+	}
+
+	if _, err := testingDB.StoreNewBetData(context.Background(), redelivered); err != nil {
+		t.Fatalf("DBInstance.StoreNewBetData() error = %v", err)
+	}
+
+	type args struct {
+		ctx  context.Context
+		bets []gorm.Bet
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "success: store new bets",
+			args: args{
+				ctx: context.Background(),
+				bets: []gorm.Bet{
+					{BetID: gofakeit.UUID(), UserID: userID, Amount: 100, Currency: "USD", Odds: 2.78, Outcome: "win", Timestamp: time.Now()},
+				},
+			},
+			want: 1,
+		},
+		{
+			name: "success: skip bets delivered again",
+			args: args{
+				ctx:  context.Background(),
+				bets: redelivered,
+			},
+			want: 0,
+		},
+		{
+			name: "success: store the new bets of a batch delivered again",
+			args: args{
+				ctx: context.Background(),
+				bets: append([]gorm.Bet{
+					{BetID: gofakeit.UUID(), UserID: userID, Amount: 20, Currency: "USD", Odds: 1.5, Outcome: "lose", Timestamp: time.Now()},
+				}, redelivered...),
+			},
+			want: 1,
+		},
+		{
+			name: "fail: unknown odds format",
+			args: args{
+				ctx: context.Background(),
+				bets: []gorm.Bet{
+					{
+						BetID: gofakeit.UUID(), UserID: userID, Amount: 100, Currency: "USD", Odds: 2.5, OddsFormat: "moneyline",
+						QuotedOdds: "150", Outcome: "pending", Timestamp: time.Now(),
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.StoreNewBetData(tt.args.ctx, tt.args.bets)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DBInstance.StoreNewBetData() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("DBInstance.StoreNewBetData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	stored, err := testingDB.GetBet(context.Background(), accumulatorBetID)
+	if err != nil {
+		t.Fatalf("DBInstance.GetBet() error = %v", err)
+	}
+
+	if len(stored.Legs) != 2 || stored.Legs[1].Outcome != "pending" {
+		t.Errorf("DBInstance.GetBet() = %+v, want the legs stored once", stored)
+	}
+}
+
+func TestDBInstance_SettleBet(t *testing.T) {
+	singleBetID, accumulatorBetID := gofakeit.UUID(), gofakeit.UUID()
+	settledBy := "trader-1"
+
+	err := testingDB.StoreBetData(context.Background(), []gorm.Bet{
+		{BetID: singleBetID, UserID: userID, Amount: 10, Currency: "USD", Odds: 2, Outcome: "pending", Timestamp: time.Now()},
+		{
+			BetID: accumulatorBetID, UserID: userID, Amount: 10, Currency: "USD", Odds: 6, Outcome: "pending", Timestamp: time.Now(),
+			Legs: []gorm.BetLeg{
+				{BetID: accumulatorBetID, Position: 1, Selection: "home", Odds: 2, Outcome: "win"},
+				{BetID: accumulatorBetID, Position: 2, Selection: "away", Odds: 3, Outcome: "pending"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to store bets: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		bet     *gorm.Bet
+		wantErr bool
+	}{
+		{
+			name: "success: settle a single bet",
+			bet:  &gorm.Bet{BetID: singleBetID, Odds: 2, Outcome: "win", Payout: 20},
+		},
+		{
+			name: "success: settle the legs of an accumulator",
+			bet: &gorm.Bet{
+				BetID: accumulatorBetID, Odds: 2, Outcome: "win", Payout: 20,
+				Legs: []gorm.BetLeg{{Position: 1, Outcome: "win"}, {Position: 2, Outcome: "void"}},
+			},
+		},
+		{
+			name:    "fail: state outside the lifecycle",
+			bet:     &gorm.Bet{BetID: singleBetID, Odds: 2, Outcome: "settled"},
+			wantErr: true,
+		},
+		{
+			name:    "fail: unknown bet",
+			bet:     &gorm.Bet{BetID: "unknown", Odds: 2, Outcome: "win"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.bet.UpdatedBy = &settledBy
+
+			if err := testingDB.SettleBet(context.Background(), tt.bet); (err != nil) != tt.wantErr {
+				t.Fatalf("DBInstance.SettleBet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			stored, err := testingDB.GetBet(context.Background(), tt.bet.BetID)
+			if err != nil {
+				t.Fatalf("DBInstance.GetBet() error = %v", err)
+			}
+
+			if stored.Outcome != tt.bet.Outcome || stored.Odds != tt.bet.Odds || stored.Payout != tt.bet.Payout {
+				t.Errorf("DBInstance.SettleBet() stored %v at %v paying %v, want %v at %v paying %v",
+					stored.Outcome, stored.Odds, stored.Payout, tt.bet.Outcome, tt.bet.Odds, tt.bet.Payout)
+			}
+
+			var updatedBy string
+
+			err = testingDB.DB.Raw("SELECT updated_by FROM bets WHERE bet_id = ? AND updated >= created", tt.bet.BetID).
+				Scan(&updatedBy).Error
+			if err != nil || updatedBy != settledBy {
+				t.Errorf("DBInstance.SettleBet() updated by %q, want %q", updatedBy, settledBy)
+			}
+
+			for i, leg := range tt.bet.Legs {
+				if stored.Legs[i].Outcome != leg.Outcome {
+					t.Errorf("DBInstance.SettleBet() leg %d = %v, want %v", leg.Position, stored.Legs[i].Outcome, leg.Outcome)
+				}
+			}
+		})
+	}
+}
+
+func TestDBInstance_SaveEvent(t *testing.T) {
+	eventID, marketID, selectionID := gofakeit.UUID(), gofakeit.UUID(), gofakeit.UUID()
+
+	tests := []struct {
+		name           string
+		event          *gorm.Event
+		wantName       string
+		wantSelections int
+		wantErr        bool
+	}{
+		{
+			name: "success: save a new event",
+			event: &gorm.Event{
+				AbstractBase: gorm.AbstractBase{ID: &eventID},
+				Name:         "ARS-CHE",
+				StartsAt:     time.Now(),
+				Markets: []gorm.Market{
+					{
+						AbstractBase: gorm.AbstractBase{ID: &marketID},
+						Name:         "Match result",
+						Selections: []gorm.Selection{
+							{AbstractBase: gorm.AbstractBase{ID: &selectionID}, Name: "Home"},
+							{Name: "Away"},
+						},
+					},
+				},
+			},
+			wantName:       "ARS-CHE",
+			wantSelections: 2,
+		},
+		{
+			name: "success: save an event that exists adds to it",
+			event: &gorm.Event{
+				AbstractBase: gorm.AbstractBase{ID: &eventID},
+				Name:         "Arsenal v Chelsea",
+				StartsAt:     time.Now(),
+				Markets: []gorm.Market{
+					{
+						AbstractBase: gorm.AbstractBase{ID: &marketID},
+						Name:         "Match result",
+						Selections: []gorm.Selection{
+							{AbstractBase: gorm.AbstractBase{ID: &selectionID}, Name: "Home"},
+							{Name: "Draw"},
+						},
+					},
+				},
+			},
+			wantName:       "Arsenal v Chelsea",
+			wantSelections: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.SaveEvent(context.Background(), tt.event); (err != nil) != tt.wantErr {
+				t.Fatalf("DBInstance.SaveEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			stored, err := testingDB.GetEvent(context.Background(), eventID)
+			if err != nil {
+				t.Fatalf("DBInstance.GetEvent() error = %v", err)
+			}
+
+			if stored.Name != tt.wantName || len(stored.Markets) != 1 || len(stored.Markets[0].Selections) != tt.wantSelections {
+				t.Errorf("DBInstance.SaveEvent() stored %+v, want %s with %d selections", stored, tt.wantName, tt.wantSelections)
+			}
+		})
+	}
+}
+
+func TestDBInstance_SettleSelection(t *testing.T) {
+	eventID, selectionID := gofakeit.UUID(), gofakeit.UUID()
+	singleBetID, accumulatorBetID, cashedOutBetID, otherBetID := gofakeit.UUID(), gofakeit.UUID(), gofakeit.UUID(), gofakeit.UUID()
+
+	err := testingDB.SaveEvent(context.Background(), &gorm.Event{
+		AbstractBase: gorm.AbstractBase{ID: &eventID},
+		Name:         "LIV-MUN",
+		StartsAt:     time.Now(),
+		Markets: []gorm.Market{
+			{Name: "Match result", Selections: []gorm.Selection{{AbstractBase: gorm.AbstractBase{ID: &selectionID}, Name: "Home"}}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to save event: %v", err)
+	}
+
+	err = testingDB.StoreBetData(context.Background(), []gorm.Bet{
+		{BetID: singleBetID, UserID: userID, Amount: 100_000, Currency: "USD", Odds: 2, Outcome: "pending", Timestamp: time.Now(), Selection: selectionID},
+		{
+			BetID: accumulatorBetID, UserID: userID, Amount: 100_000, Currency: "USD", Odds: 6, Outcome: "pending", Timestamp: time.Now(),
+			Legs: []gorm.BetLeg{
+				{BetID: accumulatorBetID, Position: 1, Selection: "home", Odds: 2, Outcome: "win"},
+				{BetID: accumulatorBetID, Position: 2, Selection: selectionID, Odds: 3, Outcome: "pending"},
+			},
+		},
+		{BetID: cashedOutBetID, UserID: userID, Amount: 100_000, Currency: "USD", Odds: 2, Outcome: "cashout", Payout: 80_000, Timestamp: time.Now(), Selection: selectionID},
+		{BetID: otherBetID, UserID: userID, Amount: 100_000, Currency: "USD", Odds: 2, Outcome: "pending", Timestamp: time.Now(), Selection: "other"},
+	})
+	if err != nil {
+		t.Fatalf("failed to store bets: %v", err)
+	}
+
+	// settleWith settles every bet it is given with the outcome, as the usecase would
+	settleWith := func(outcome string, seen *[]string) func(bet *gorm.Bet) (bool, error) {
+		return func(bet *gorm.Bet) (bool, error) {
+			*seen = append(*seen, bet.BetID)
+
+			bet.Outcome = outcome
+			if len(bet.Legs) > 0 {
+				bet.Legs[1].Position, bet.Legs[1].Outcome = 2, outcome
+			}
+
+			if outcome == "win" {
+				bet.Payout = int64(float64(bet.Amount) * bet.Odds)
+			} else {
+				bet.Payout = 0
+			}
+
+			return true, nil
+		}
+	}
+
+	tests := []struct {
+		name         string
+		selectionID  string
+		result       string
+		settleErr    bool
+		wantPrevious string
+		wantOutcome  string
+		wantPayout   int64
+		wantErr      bool
+	}{
+		{
+			name:        "success: settle every bet on the selection",
+			selectionID: selectionID,
+			result:      "win",
+			wantOutcome: "win",
+			wantPayout:  200_000,
+		},
+		{
+			name:         "success: resettle the selection",
+			selectionID:  selectionID,
+			result:       "lose",
+			wantPrevious: "win",
+			wantOutcome:  "lose",
+			wantPayout:   0,
+		},
+		{
+			name:        "fail: failed settlement is rolled back",
+			selectionID: selectionID,
+			result:      "void",
+			settleErr:   true,
+			wantOutcome: "lose",
+			wantErr:     true,
+		},
+		{
+			name:        "fail: unknown selection",
+			selectionID: "unknown",
+			result:      "void",
+			wantOutcome: "lose",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			submittedBy := "feed"
+			result := &gorm.SelectionResult{
+				AbstractBase: gorm.AbstractBase{CreatedBy: &submittedBy},
+				SelectionID:  tt.selectionID,
+				Result:       tt.result,
+			}
+
+			var seen []string
+
+			settle := settleWith(tt.result, &seen)
+			if tt.settleErr {
+				settle = func(bet *gorm.Bet) (bool, error) {
+					_, _ = settleWith(tt.result, &seen)(bet)
+					return false, fmt.Errorf("error")
+				}
+			}
+
+			if err := testingDB.SettleSelection(context.Background(), result, settle); (err != nil) != tt.wantErr {
+				t.Fatalf("DBInstance.SettleSelection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			stored, err := testingDB.GetBet(context.Background(), singleBetID)
+			if err != nil {
+				t.Fatalf("DBInstance.GetBet() error = %v", err)
+			}
+
+			if stored.Outcome != tt.wantOutcome {
+				t.Errorf("DBInstance.SettleSelection() left the bet %v, want %v", stored.Outcome, tt.wantOutcome)
+			}
+
+			if !tt.wantErr && stored.Payout != tt.wantPayout {
+				t.Errorf("DBInstance.SettleSelection() left the bet paying %v, want %v", stored.Payout, tt.wantPayout)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if !slices.Equal(seen, []string{singleBetID, accumulatorBetID}) {
+				t.Errorf("DBInstance.SettleSelection() settled %v, want the pending bet and the accumulator", seen)
+			}
+
+			var previous string
+			if result.PreviousResult != nil {
+				previous = *result.PreviousResult
+			}
+
+			if previous != tt.wantPrevious || result.BetsSettled != 2 {
+				t.Errorf("DBInstance.SettleSelection() summary %+v after %q, want 2 bets after %q", result, previous, tt.wantPrevious)
+			}
+
+			accumulator, err := testingDB.GetBet(context.Background(), accumulatorBetID)
+			if err != nil {
+				t.Fatalf("DBInstance.GetBet() error = %v", err)
+			}
+
+			if accumulator.Legs[0].Outcome != "win" || accumulator.Legs[1].Outcome != tt.wantOutcome {
+				t.Errorf("DBInstance.SettleSelection() left the legs %+v, want the second one %v", accumulator.Legs, tt.wantOutcome)
+			}
+
+			results, err := testingDB.ListSelectionResults(context.Background(), selectionID)
+			if err != nil {
+				t.Fatalf("DBInstance.ListSelectionResults() error = %v", err)
+			}
+
+			if len(results) == 0 || *results[0].ID != *result.ID || *results[0].CreatedBy != submittedBy {
+				t.Errorf("DBInstance.SettleSelection() recorded %+v, want %+v first", results, result)
+			}
+		})
+	}
+}
+
+func TestDBInstance_CommitIngestBatch(t *testing.T) {
+	job := &gorm.IngestJob{
+		Path:   "bets.ndjson",
+		Size:   2048,
+		Hash:   gofakeit.UUID(),
+		Format: "ndjson",
+		Status: "running",
+	}
+
+	if err := testingDB.CreateIngestJob(context.Background(), job); err != nil {
+		t.Fatalf("failed to create ingest job: %v", err)
+	}
+
+	excludedUserID := gofakeit.UUID()
+
+	violation := func() gorm.ExclusionViolation {
+		return gorm.ExclusionViolation{
+			ExclusionID: gofakeit.UUID(), UserID: excludedUserID, BetID: gofakeit.UUID(), Amount: 100, Currency: "USD",
+			PlacedAt: time.Now().UTC(), Action: "reject",
+		}
+	}
+
+	type args struct {
+		ctx        context.Context
+		bets       []gorm.Bet
+		violations []gorm.ExclusionViolation
+	}
+
+	tests := []struct {
+		name           string
+		args           args
+		wantOffset     int64
+		wantRecords    int64
+		wantViolations int
+		wantErr        bool
+	}{
+		{
+			name: "success: store batch and advance checkpoint",
+			args: args{
+				ctx: context.Background(),
+				bets: []gorm.Bet{
+					{BetID: gofakeit.UUID(), UserID: userID, Amount: 100, Currency: "USD", Odds: 2.78, Outcome: "win", Timestamp: time.Now()},
+				},
+			},
+			wantOffset:  512,
+			wantRecords: 1,
+		},
+		{
+			name: "fail: checkpoint is not advanced when the batch is rejected",
+			args: args{
+				ctx: context.Background(),
+				bets: []gorm.Bet{
+					{BetID: gofakeit.UUID(), UserID: userID, Amount: 100, Currency: "USD", Odds: 2.78, Outcome: "win", Timestamp: time.Now()},
+					{BetID: bet1UserID, UserID: userID2, Amount: 59, Currency: "USD", Odds: 1.78, Outcome: "lose", Timestamp: time.Now()},
+				},
+				violations: []gorm.ExclusionViolation{violation()},
+			},
+			wantOffset:  512,
+			wantRecords: 1,
+			wantErr:     true,
+		},
+		{
+			name: "success: advance checkpoint past a batch whose bets were all turned down",
+			args: args{
+				ctx:        context.Background(),
+				violations: []gorm.ExclusionViolation{violation()},
+			},
+			wantOffset:     1024,
+			wantRecords:    1,
+			wantViolations: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkpoint := *job
+			checkpoint.Offset += 512
+			checkpoint.Records += int64(len(tt.args.bets))
+
+			err := testingDB.CommitIngestBatch(tt.args.ctx, &checkpoint, tt.args.bets, tt.args.violations)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DBInstance.CommitIngestBatch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			stored, err := testingDB.GetIngestJob(tt.args.ctx, *job.ID)
+			if err != nil {
+				t.Fatalf("failed to get ingest job: %v", err)
+			}
+
+			if stored.Offset != tt.wantOffset || stored.Records != tt.wantRecords {
+				t.Errorf("checkpoint = %d bytes / %d records, want %d / %d",
+					stored.Offset, stored.Records, tt.wantOffset, tt.wantRecords)
+			}
+
+			violations, err := testingDB.ListExclusionViolations(tt.args.ctx, excludedUserID, 10)
+			if err != nil {
+				t.Fatalf("failed to list exclusion violations: %v", err)
+			}
+
+			if len(violations) != tt.wantViolations {
+				t.Errorf("DBInstance.CommitIngestBatch() recorded %d violations, want %d", len(violations), tt.wantViolations)
+			}
+
+			*job = *stored
+		})
+	}
+}
+
+func TestDBInstance_UpdateIngestJob(t *testing.T) {
+	job := &gorm.IngestJob{
+		Path:   "bets.csv.gz",
+		Size:   4096,
+		Hash:   gofakeit.UUID(),
+		Format: "csv",
+		Status: "running",
+	}
+
+	if err := testingDB.CreateIngestJob(context.Background(), job); err != nil {
+		t.Fatalf("failed to create ingest job: %v", err)
+	}
+
+	completedAt := time.Now()
+
+	tests := []struct {
+		name    string
+		job     gorm.IngestJob
+		wantErr bool
+	}{
+		{
+			name: "success: mark job failed",
+			job:  gorm.IngestJob{AbstractBase: job.AbstractBase, Status: "failed", Error: "line 3: invalid outcome"},
+		},
+		{
+			name: "success: mark job completed",
+			job:  gorm.IngestJob{AbstractBase: job.AbstractBase, Status: "completed", Offset: 900, Records: 10, CompletedAt: &completedAt},
+		},
+		{
+			name:    "fail: invalid status",
+			job:     gorm.IngestJob{AbstractBase: job.AbstractBase, Status: "paused"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testingDB.UpdateIngestJob(context.Background(), &tt.job)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DBInstance.UpdateIngestJob() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			stored, err := testingDB.GetIngestJob(context.Background(), *job.ID)
+			if err != nil {
+				t.Fatalf("failed to get ingest job: %v", err)
+			}
+
+			if stored.Status != tt.job.Status || stored.Error != tt.job.Error || stored.Records != tt.job.Records {
+				t.Errorf("stored job = %+v, want %+v", stored, tt.job)
+			}
+
+			if (stored.CompletedAt == nil) != (tt.job.CompletedAt == nil) {
+				t.Errorf("stored completed_at = %v, want %v", stored.CompletedAt, tt.job.CompletedAt)
+			}
+		})
+	}
+}
+
+func TestDBInstance_RecordAlert(t *testing.T) {
+	subscription := &gorm.WebhookSubscription{URL: "http://localhost:9000/hooks", Secret: "s3cret", Events: "bet.large"}
+	if err := testingDB.CreateWebhookSubscription(context.Background(), subscription); err != nil {
+		t.Fatalf("failed to create webhook subscription: %v", err)
+	}
+
+	betID := gofakeit.UUID()
+
+	newAlert := func() *gorm.Alert {
+		id := gofakeit.UUID()
+
+		return &gorm.Alert{
+			AbstractBase: gorm.AbstractBase{ID: &id},
+			Event:        "bet.large",
+			DedupeKey:    "bet.large:" + betID,
+			UserID:       userID,
+			BetID:        betID,
+			Value:        5000,
+			Threshold:    1000,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		alert          *gorm.Alert
+		wantCreated    bool
+		wantDeliveries int
+		wantErr        bool
+	}{
+		{
+			name:           "success: new alert is stored with its deliveries",
+			alert:          newAlert(),
+			wantCreated:    true,
+			wantDeliveries: 1,
+		},
+		{
+			name:           "success: alert already raised is ignored",
+			alert:          newAlert(),
+			wantCreated:    false,
+			wantDeliveries: 1,
+		},
+		{
+			name: "success: anti-money laundering finding is stored with its evidence",
+			alert: &gorm.Alert{
+				Event: "aml.structuring", DedupeKey: "aml.structuring:" + userID + ":" + betID, UserID: userID,
+				Value: 28500, Threshold: 10000, Evidence: betID + "," + gofakeit.UUID(),
+			},
+			wantCreated:    true,
+			wantDeliveries: 2,
+		},
+		{
+			name:    "fail: invalid event",
+			alert:   &gorm.Alert{Event: "bet.small", DedupeKey: gofakeit.UUID(), UserID: userID},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deliveries := []gorm.WebhookDelivery{{
+				SubscriptionID: *subscription.ID,
+				Event:          tt.alert.Event,
+				Payload:        `{"event": "bet.large"}`,
+				NextAttemptAt:  time.Now().UTC(),
+			}}
+
+			if tt.alert.ID != nil {
+				deliveries[0].AlertID = *tt.alert.ID
+			}
+
+			created, err := testingDB.RecordAlert(context.Background(), tt.alert, deliveries)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DBInstance.RecordAlert() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if created != tt.wantCreated {
+				t.Errorf("DBInstance.RecordAlert() = %v, want %v", created, tt.wantCreated)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			var count int64
+
+			err = testingDB.DB.Model(&gorm.WebhookDelivery{}).Where("subscription_id = ?", *subscription.ID).Count(&count).Error
+			if err != nil {
+				t.Fatalf("failed to count deliveries: %v", err)
+			}
+
+			if count != int64(tt.wantDeliveries) {
+				t.Errorf("%d deliveries stored, want %d", count, tt.wantDeliveries)
+			}
+		})
+	}
+}
+
+func TestDBInstance_DeadLetterWebhookDelivery(t *testing.T) {
+	subscription := &gorm.WebhookSubscription{URL: "http://localhost:9000/hooks", Secret: "s3cret", Events: "anomaly.detected"}
+	if err := testingDB.CreateWebhookSubscription(context.Background(), subscription); err != nil {
+		t.Fatalf("failed to create webhook subscription: %v", err)
+	}
+
+	alertID := gofakeit.UUID()
+	alert := &gorm.Alert{
+		AbstractBase: gorm.AbstractBase{ID: &alertID},
+		Event:        "anomaly.detected",
+		DedupeKey:    "anomaly.detected:" + gofakeit.UUID(),
+		UserID:       userID,
+		Value:        12,
+	}
+
+	delivery := gorm.WebhookDelivery{
+		SubscriptionID: *subscription.ID,
+		AlertID:        alertID,
+		Event:          alert.Event,
+		Payload:        `{"event": "anomaly.detected"}`,
+		NextAttemptAt:  time.Now().UTC(),
+	}
+
+	if _, err := testingDB.RecordAlert(context.Background(), alert, []gorm.WebhookDelivery{delivery}); err != nil {
+		t.Fatalf("failed to record alert: %v", err)
+	}
+
+	var stored gorm.WebhookDelivery
+	if err := testingDB.DB.Where("alert_id = ?", alertID).First(&stored).Error; err != nil {
+		t.Fatalf("failed to get delivery: %v", err)
+	}
+
+	deadLetterID := gofakeit.UUID()
+
+	tests := []struct {
+		name       string
+		deadLetter *gorm.WebhookDeadLetter
+		wantErr    bool
+	}{
+		{
+			name: "success: delivery is moved to the dead letter table",
+			deadLetter: &gorm.WebhookDeadLetter{
+				AbstractBase:   gorm.AbstractBase{ID: &deadLetterID},
+				DeliveryID:     *stored.ID,
+				SubscriptionID: stored.SubscriptionID,
+				URL:            subscription.URL,
+				AlertID:        stored.AlertID,
+				Event:          stored.Event,
+				Payload:        stored.Payload,
+				Attempts:       5,
+				LastStatus:     500,
+				LastError:      "unexpected status 500",
+			},
+		},
+		{
+			name: "fail: delivery already dead lettered",
+			deadLetter: &gorm.WebhookDeadLetter{
+				AbstractBase: gorm.AbstractBase{ID: &deadLetterID},
+				DeliveryID:   *stored.ID,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testingDB.DeadLetterWebhookDelivery(context.Background(), tt.deadLetter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DBInstance.DeadLetterWebhookDelivery() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			var count int64
+			if err := testingDB.DB.Model(&gorm.WebhookDelivery{}).Where("id = ?", *stored.ID).Count(&count).Error; err != nil {
+				t.Fatalf("failed to count deliveries: %v", err)
+			}
+
+			if count != 0 {
+				t.Errorf("delivery still pending after being dead lettered")
+			}
+
+			deadLetters, err := testingDB.ListWebhookDeadLetters(context.Background(), 1)
+			if err != nil || len(deadLetters) != 1 || deadLetters[0].DeliveryID != *stored.ID {
+				t.Errorf("DBInstance.ListWebhookDeadLetters() = %v, %v, want the dead lettered delivery", deadLetters, err)
+			}
+		})
+	}
+}
+
+func TestDBInstance_DeleteWebhookSubscription(t *testing.T) {
+	subscription := &gorm.WebhookSubscription{URL: "http://localhost:9000/hooks", Secret: "s3cret", Events: "bet.large"}
+	if err := testingDB.CreateWebhookSubscription(context.Background(), subscription); err != nil {
+		t.Fatalf("failed to create webhook subscription: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		id      string
+		wantErr bool
+	}{
+		{
+			name: "success: delete subscription",
+			id:   *subscription.ID,
+		},
+		{
+			name:    "fail: subscription does not exist",
+			id:      *subscription.ID,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testingDB.DeleteWebhookSubscription(context.Background(), tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DBInstance.DeleteWebhookSubscription() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDBInstance_SaveUserLimits(t *testing.T) {
+	userID := gofakeit.UUID()
+
+	tests := []struct {
+		name    string
+		limits  *gorm.UserLimits
+		wantErr bool
+	}{
+		{
+			name:   "success: save the caps of a user",
+			limits: &gorm.UserLimits{UserID: userID, Period: "day", LossCap: 1_000_000, BetCap: 20},
+		},
+		{
+			name:   "success: save the caps of a user again replaces them",
+			limits: &gorm.UserLimits{UserID: userID, Period: "day", StakeCap: 500_000},
+		},
+		{
+			name:    "fail: invalid period",
+			limits:  &gorm.UserLimits{UserID: userID, Period: "year", BetCap: 20},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.SaveUserLimits(context.Background(), tt.limits); (err != nil) != tt.wantErr {
+				t.Fatalf("DBInstance.SaveUserLimits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			stored, err := testingDB.ListUserLimits(context.Background(), []string{userID})
+			if err != nil {
+				t.Fatalf("DBInstance.ListUserLimits() error = %v", err)
+			}
+
+			if len(stored) != 1 || stored[0].LossCap != tt.limits.LossCap || stored[0].StakeCap != tt.limits.StakeCap ||
+				stored[0].BetCap != tt.limits.BetCap {
+				t.Errorf("DBInstance.SaveUserLimits() stored %+v, want %+v", stored, *tt.limits)
+			}
+		})
+	}
+}
+
+func TestDBInstance_LiftSelfExclusion(t *testing.T) {
+	exclusion := &gorm.SelfExclusion{UserID: gofakeit.UUID(), StartsAt: time.Now().UTC(), Reason: "taking a break"}
+
+	if err := testingDB.CreateSelfExclusion(context.Background(), exclusion); err != nil {
+		t.Fatalf("failed to create self-exclusion: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		liftedBy string
+		wantErr  bool
+	}{
+		{
+			name:     "success: lift a standing self-exclusion",
+			liftedBy: "compliance-1",
+		},
+		{
+			name:     "fail: self-exclusion already lifted",
+			liftedBy: "compliance-2",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			liftedAt := time.Now().UTC()
+
+			lift := *exclusion
+			lift.LiftedAt = &liftedAt
+			lift.LiftedBy = &tt.liftedBy
+
+			if err := testingDB.LiftSelfExclusion(context.Background(), &lift); (err != nil) != tt.wantErr {
+				t.Fatalf("DBInstance.LiftSelfExclusion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			stored, err := testingDB.GetSelfExclusion(context.Background(), *exclusion.ID)
+			if err != nil {
+				t.Fatalf("DBInstance.GetSelfExclusion() error = %v", err)
+			}
+
+			if stored.LiftedAt == nil || stored.LiftedBy == nil || *stored.LiftedBy != "compliance-1" {
+				t.Errorf("DBInstance.LiftSelfExclusion() stored lifted_at %v by %v, want it lifted by compliance-1",
+					stored.LiftedAt, stored.LiftedBy)
+			}
+		})
+	}
+}
+
+func TestDBInstance_RecordExclusionViolations(t *testing.T) {
+	userID := gofakeit.UUID()
+
+	violations := []gorm.ExclusionViolation{
+		{ExclusionID: gofakeit.UUID(), UserID: userID, BetID: gofakeit.UUID(), Amount: 500_000, Currency: "USD", PlacedAt: time.Now().UTC(), Action: "reject"},
+		{ExclusionID: gofakeit.UUID(), UserID: userID, BetID: gofakeit.UUID(), Amount: 10_000, Currency: "KES", PlacedAt: time.Now().UTC(), Action: "flag"},
+	}
+
+	tests := []struct {
+		name       string
+		violations []gorm.ExclusionViolation
+		wantErr    bool
+	}{
+		{
+			name:       "success: record the bets of a self-excluded user",
+			violations: slices.Clone(violations),
+		},
+		{
+			name: "success: bets received again for the same exclusion skipped",
+			violations: []gorm.ExclusionViolation{
+				{ExclusionID: violations[0].ExclusionID, UserID: userID, BetID: violations[0].BetID, Amount: 500_000, Currency: "USD", PlacedAt: time.Now().UTC(), Action: "reject"},
+			},
+		},
+		{
+			name: "fail: invalid action",
+			violations: []gorm.ExclusionViolation{
+				{ExclusionID: gofakeit.UUID(), UserID: userID, BetID: gofakeit.UUID(), Amount: 500_000, Currency: "USD", PlacedAt: time.Now().UTC(), Action: "ignore"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.RecordExclusionViolations(context.Background(), tt.violations); (err != nil) != tt.wantErr {
+				t.Fatalf("DBInstance.RecordExclusionViolations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			stored, err := testingDB.ListExclusionViolations(context.Background(), userID, 10)
+			if err != nil {
+				t.Fatalf("DBInstance.ListExclusionViolations() error = %v", err)
+			}
+
+			if len(stored) != 2 {
+				t.Errorf("DBInstance.RecordExclusionViolations() stored %d violations, want 2", len(stored))
+			}
 		})
 	}
 }
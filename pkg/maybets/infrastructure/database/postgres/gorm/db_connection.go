@@ -2,14 +2,13 @@ package gorm
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 	"time"
 
-	log "github.com/sirupsen/logrus"
 	"github.com/uptrace/opentelemetry-go-extra/otelgorm"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 	"gorm.io/gorm/schema"
 )
 
@@ -18,9 +17,9 @@ type DBInstance struct {
 	DB *gorm.DB
 }
 
-// NewDBInstance initializes a new SQLite database instance
-func NewDBInstance() (*DBInstance, error) {
-	db, err := startDatabase()
+// NewDBInstance initializes a new SQLite database instance backed by the file at dbPath
+func NewDBInstance(dbPath string) (*DBInstance, error) {
+	db, err := startDatabase(dbPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start database: %w", err)
 	}
@@ -39,19 +38,11 @@ func NewDBInstance() (*DBInstance, error) {
 }
 
 // startDatabase initializes the SQLite database
-func startDatabase() (*gorm.DB, error) {
-	// Use a persistent SQLite file instead of an in-memory database
-	path := os.Getenv("SQLITE_URL")
-	if path == "" {
-		path = "../../../../../.."
-	}
-
-	dbPath := fmt.Sprintf("%s/bets.db", path)
-
-	// Ensure the file exists
-	file, err := os.Create(dbPath)
-	if err != nil && !os.IsExist(err) {
-		log.Fatalf("Failed to create SQLite DB file: %v", err)
+func startDatabase(dbPath string) (*gorm.DB, error) {
+	// Ensure the file exists without truncating an existing database
+	file, err := os.OpenFile(dbPath, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SQLite DB file: %w", err)
 	}
 
 	file.Close()
@@ -67,29 +58,29 @@ func boot(dbPath string) (*gorm.DB, error) {
 			SingularTable: true,
 		},
 		CreateBatchSize: 1000,
-		Logger:          logger.Default.LogMode(logger.Info),
+		Logger:          slogLogger{},
 	})
 
 	if err != nil {
-		log.Errorf("Failed to connect to database: %s", err)
+		slog.Error("failed to connect to database", "error", err)
 		return nil, err
 	}
 
 	// Check connection
 	sqlDB, err := db.DB()
 	if err != nil {
-		log.Errorf("Failed to get DB instance: %s", err)
+		slog.Error("failed to get DB instance", "error", err)
 		return nil, err
 	}
 
 	if err := sqlDB.Ping(); err != nil {
-		log.Errorf("Unable to ping the database: %s", err)
+		slog.Error("unable to ping the database", "error", err)
 		return nil, err
 	}
 
 	// Add OpenTelemetry plugin for tracing
 	if err := db.Use(otelgorm.NewPlugin()); err != nil {
-		log.Errorf("Unable to add otel plugin: %s", err)
+		slog.Error("unable to add otel plugin", "error", err)
 		return nil, err
 	}
 
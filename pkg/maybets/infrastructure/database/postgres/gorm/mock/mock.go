@@ -2,18 +2,64 @@ package mock
 
 import (
 	"context"
+	"time"
 
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/database/postgres/gorm"
 	"github.com/google/uuid"
 )
 
 // GormMock mocks caching implementations
 type GormMock struct {
-	MockGetTotalBetsFn      func(ctx context.Context, userID string) (int64, error)
-	MockGetTotalWinningsFn  func(ctx context.Context, userID string) (float64, error)
-	MockGetTopUsersFn       func(ctx context.Context, limit int) ([]gorm.User, error)
-	MockGetAnomalousUsersFn func(ctx context.Context) ([]gorm.User, error)
-	MockStoreBetDataFn      func(ctx context.Context, bets []gorm.Bet) error
+	MockGetTotalBetsFn         func(ctx context.Context, userID string) (int64, error)
+	MockGetTotalWinningsFn     func(ctx context.Context, userID string) ([]gorm.User, error)
+	MockGetTopUsersFn          func(ctx context.Context, limit int) ([]gorm.User, error)
+	MockGetAnomalousUsersFn    func(ctx context.Context) ([]gorm.User, error)
+	MockStoreBetDataFn         func(ctx context.Context, bets []gorm.Bet) error
+	MockStoreNewBetDataFn      func(ctx context.Context, bets []gorm.Bet) (int64, error)
+	MockStreamBetsFn           func(ctx context.Context, filter domain.BetFilter, fn func(bet *gorm.Bet) error) error
+	MockGetBetFn               func(ctx context.Context, betID string) (*gorm.Bet, error)
+	MockSettleBetFn            func(ctx context.Context, bet *gorm.Bet) error
+	MockSaveEventFn            func(ctx context.Context, event *gorm.Event) error
+	MockGetEventFn             func(ctx context.Context, id string) (*gorm.Event, error)
+	MockSettleSelectionFn      func(ctx context.Context, result *gorm.SelectionResult, settle func(bet *gorm.Bet) (bool, error)) error
+	MockListSelectionResultsFn func(ctx context.Context, selectionID string) ([]gorm.SelectionResult, error)
+	MockCreateIngestJobFn      func(ctx context.Context, job *gorm.IngestJob) error
+	MockUpdateIngestJobFn      func(ctx context.Context, job *gorm.IngestJob) error
+	MockCommitIngestBatchFn    func(ctx context.Context, job *gorm.IngestJob, bets []gorm.Bet, violations []gorm.ExclusionViolation) error
+	MockGetIngestJobFn         func(ctx context.Context, id string) (*gorm.IngestJob, error)
+	MockFindIngestJobFn        func(ctx context.Context, hash string, size int64) (*gorm.IngestJob, error)
+	MockListIngestJobsFn       func(ctx context.Context, limit int) ([]gorm.IngestJob, error)
+
+	MockGetUserLossesFn             func(ctx context.Context, userIDs []string) ([]gorm.User, error)
+	MockGetUserTotalsFn             func(ctx context.Context, userIDs []string) ([]gorm.User, error)
+	MockGetBreakdownFn              func(ctx context.Context, dimensions []enums.Dimension, filter domain.BetFilter) ([]gorm.Breakdown, error)
+	MockGetRecentBetsFn             func(ctx context.Context, userIDs []string, limit int) ([]gorm.Bet, error)
+	MockGetLimitTotalsFn            func(ctx context.Context, userIDs []string, now time.Time) ([]gorm.LimitTotals, error)
+	MockGetLimitBetsFn              func(ctx context.Context, userIDs []string, from time.Time, since *time.Time, latest int) ([]gorm.Bet, error)
+	MockGetRecentAlertsFn           func(ctx context.Context, userIDs []string, limit int) ([]gorm.Alert, error)
+	MockListAlertsFn                func(ctx context.Context, limit int) ([]gorm.Alert, error)
+	MockListAlertsByEventFn         func(ctx context.Context, events []string, limit int) ([]gorm.Alert, error)
+	MockListUserAlertsSinceFn       func(ctx context.Context, event string, userIDs []string, since time.Time) ([]gorm.Alert, error)
+	MockSaveUserLimitsFn            func(ctx context.Context, limits *gorm.UserLimits) error
+	MockListUserLimitsFn            func(ctx context.Context, userIDs []string) ([]gorm.UserLimits, error)
+	MockCreateSelfExclusionFn       func(ctx context.Context, exclusion *gorm.SelfExclusion) error
+	MockLiftSelfExclusionFn         func(ctx context.Context, exclusion *gorm.SelfExclusion) error
+	MockGetSelfExclusionFn          func(ctx context.Context, id string) (*gorm.SelfExclusion, error)
+	MockListSelfExclusionsFn        func(ctx context.Context, userIDs []string) ([]gorm.SelfExclusion, error)
+	MockRecordExclusionViolationsFn func(ctx context.Context, violations []gorm.ExclusionViolation) error
+	MockListExclusionViolationsFn   func(ctx context.Context, userID string, limit int) ([]gorm.ExclusionViolation, error)
+	MockCreateWebhookSubscriptionFn func(ctx context.Context, subscription *gorm.WebhookSubscription) error
+	MockGetWebhookSubscriptionFn    func(ctx context.Context, id string) (*gorm.WebhookSubscription, error)
+	MockListWebhookSubscriptionsFn  func(ctx context.Context) ([]gorm.WebhookSubscription, error)
+	MockDeleteWebhookSubscriptionFn func(ctx context.Context, id string) error
+	MockRecordAlertFn               func(ctx context.Context, alert *gorm.Alert, deliveries []gorm.WebhookDelivery) (bool, error)
+	MockListDueWebhookDeliveriesFn  func(ctx context.Context, now time.Time, limit int) ([]gorm.WebhookDelivery, error)
+	MockUpdateWebhookDeliveryFn     func(ctx context.Context, delivery *gorm.WebhookDelivery) error
+	MockDeleteWebhookDeliveryFn     func(ctx context.Context, id string) error
+	MockDeadLetterWebhookDeliveryFn func(ctx context.Context, deadLetter *gorm.WebhookDeadLetter) error
+	MockListWebhookDeadLettersFn    func(ctx context.Context, limit int) ([]gorm.WebhookDeadLetter, error)
 }
 
 // NewGormMock initializes our client mocks
@@ -22,8 +68,8 @@ func NewGormMock() *GormMock {
 		MockGetTotalBetsFn: func(_ context.Context, _ string) (int64, error) {
 			return 5, nil
 		},
-		MockGetTotalWinningsFn: func(_ context.Context, _ string) (float64, error) {
-			return 100.00, nil
+		MockGetTotalWinningsFn: func(_ context.Context, userID string) ([]gorm.User, error) {
+			return []gorm.User{{UserID: userID, Currency: "USD", TotalWinnings: 1_000_000}}, nil
 		},
 		MockGetTopUsersFn: func(_ context.Context, _ int) ([]gorm.User, error) {
 			return []gorm.User{
@@ -44,6 +90,376 @@ func NewGormMock() *GormMock {
 		MockStoreBetDataFn: func(_ context.Context, _ []gorm.Bet) error {
 			return nil
 		},
+		MockStoreNewBetDataFn: func(_ context.Context, bets []gorm.Bet) (int64, error) {
+			return int64(len(bets)), nil
+		},
+		MockStreamBetsFn: func(_ context.Context, _ domain.BetFilter, fn func(bet *gorm.Bet) error) error {
+			return fn(&gorm.Bet{
+				BetID:     uuid.NewString(),
+				UserID:    uuid.NewString(),
+				Amount:    1_000_000,
+				Currency:  "USD",
+				Odds:      2.5,
+				Outcome:   "win",
+				Timestamp: time.Now(),
+			})
+		},
+		MockGetBetFn: func(_ context.Context, betID string) (*gorm.Bet, error) {
+			return &gorm.Bet{
+				BetID:     betID,
+				UserID:    uuid.NewString(),
+				Amount:    1_000_000,
+				Currency:  "USD",
+				Odds:      2.5,
+				Outcome:   "pending",
+				Timestamp: time.Now(),
+			}, nil
+		},
+		MockSettleBetFn: func(_ context.Context, _ *gorm.Bet) error {
+			return nil
+		},
+		MockSaveEventFn: func(_ context.Context, event *gorm.Event) error {
+			if event.ID == nil {
+				id := uuid.NewString()
+				event.ID = &id
+			}
+
+			for i := range event.Markets {
+				market := &event.Markets[i]
+				if market.ID == nil {
+					id := uuid.NewString()
+					market.ID = &id
+				}
+
+				for j := range market.Selections {
+					if market.Selections[j].ID == nil {
+						id := uuid.NewString()
+						market.Selections[j].ID = &id
+					}
+				}
+			}
+
+			return nil
+		},
+		MockGetEventFn: func(_ context.Context, id string) (*gorm.Event, error) {
+			marketID, selectionID, result := uuid.NewString(), uuid.NewString(), "win"
+
+			return &gorm.Event{
+				AbstractBase: gorm.AbstractBase{ID: &id},
+				Name:         "ARS-CHE",
+				StartsAt:     time.Now(),
+				Markets: []gorm.Market{
+					{
+						AbstractBase: gorm.AbstractBase{ID: &marketID},
+						Name:         "Match result",
+						Selections: []gorm.Selection{
+							{AbstractBase: gorm.AbstractBase{ID: &selectionID}, Name: "Home", Result: &result},
+						},
+					},
+				},
+			}, nil
+		},
+		MockSettleSelectionFn: func(_ context.Context, result *gorm.SelectionResult, settle func(bet *gorm.Bet) (bool, error)) error {
+			bet := &gorm.Bet{
+				BetID:     uuid.NewString(),
+				UserID:    uuid.NewString(),
+				Amount:    1_000_000,
+				Currency:  "USD",
+				Odds:      2.5,
+				Outcome:   "pending",
+				Timestamp: time.Now(),
+				Selection: result.SelectionID,
+			}
+
+			changed, err := settle(bet)
+			if err != nil {
+				return err
+			}
+
+			if changed {
+				result.BetsSettled++
+			}
+
+			id := uuid.NewString()
+			result.ID = &id
+			result.CreatedAt = time.Now()
+
+			return nil
+		},
+		MockListSelectionResultsFn: func(_ context.Context, selectionID string) ([]gorm.SelectionResult, error) {
+			id, submittedBy := uuid.NewString(), "feed"
+
+			return []gorm.SelectionResult{
+				{
+					AbstractBase: gorm.AbstractBase{ID: &id, CreatedAt: time.Now(), CreatedBy: &submittedBy},
+					SelectionID:  selectionID,
+					Result:       "win",
+					BetsSettled:  1,
+					Stake:        1_000_000,
+					Payout:       2_500_000,
+					Currency:     "USD",
+				},
+			}, nil
+		},
+		MockCreateIngestJobFn: func(_ context.Context, job *gorm.IngestJob) error {
+			id := uuid.NewString()
+			job.ID = &id
+
+			return nil
+		},
+		MockUpdateIngestJobFn: func(_ context.Context, _ *gorm.IngestJob) error {
+			return nil
+		},
+		MockCommitIngestBatchFn: func(_ context.Context, _ *gorm.IngestJob, _ []gorm.Bet, _ []gorm.ExclusionViolation) error {
+			return nil
+		},
+		MockGetIngestJobFn: func(_ context.Context, id string) (*gorm.IngestJob, error) {
+			return &gorm.IngestJob{
+				AbstractBase: gorm.AbstractBase{ID: &id},
+				Path:         "bets.ndjson",
+				Size:         1024,
+				Hash:         "hash",
+				Format:       "ndjson",
+				Status:       "running",
+			}, nil
+		},
+		MockFindIngestJobFn: func(_ context.Context, _ string, _ int64) (*gorm.IngestJob, error) {
+			return nil, nil
+		},
+		MockListIngestJobsFn: func(_ context.Context, _ int) ([]gorm.IngestJob, error) {
+			id := uuid.NewString()
+
+			return []gorm.IngestJob{
+				{
+					AbstractBase: gorm.AbstractBase{ID: &id},
+					Path:         "bets.ndjson",
+					Status:       "completed",
+				},
+			}, nil
+		},
+		MockGetUserLossesFn: func(_ context.Context, userIDs []string) ([]gorm.User, error) {
+			users := make([]gorm.User, len(userIDs))
+			for i, userID := range userIDs {
+				users[i] = gorm.User{UserID: userID, Currency: "USD", TotalBets: 2, TotalLosses: 1_500_000}
+			}
+
+			return users, nil
+		},
+		MockGetUserTotalsFn: func(_ context.Context, userIDs []string) ([]gorm.User, error) {
+			users := make([]gorm.User, len(userIDs))
+			for i, userID := range userIDs {
+				users[i] = gorm.User{
+					UserID: userID, Currency: "USD", TotalBets: 4, TotalWinnings: 1_000_000, TotalLosses: 1_500_000,
+					TotalPayout: 2_000_000, WonBets: 1, DecidedBets: 2,
+				}
+			}
+
+			return users, nil
+		},
+		MockGetBreakdownFn: func(_ context.Context, _ []enums.Dimension, _ domain.BetFilter) ([]gorm.Breakdown, error) {
+			return []gorm.Breakdown{
+				{EventID: "e1", Currency: "USD", Bets: 3, Volume: 3_000_000, SettledStake: 2_000_000, Payout: 1_500_000},
+				{EventID: "e2", Currency: "USD", Bets: 1, Volume: 500_000, SettledStake: 500_000, Payout: 1_000_000},
+			}, nil
+		},
+		MockGetRecentBetsFn: func(_ context.Context, userIDs []string, _ int) ([]gorm.Bet, error) {
+			bets := make([]gorm.Bet, len(userIDs))
+			for i, userID := range userIDs {
+				bets[i] = gorm.Bet{BetID: uuid.NewString(), UserID: userID, Amount: 500_000, Currency: "USD", Odds: 2, Outcome: "win", Timestamp: time.Now()}
+			}
+
+			return bets, nil
+		},
+		MockGetLimitTotalsFn: func(_ context.Context, userIDs []string, _ time.Time) ([]gorm.LimitTotals, error) {
+			totals := make([]gorm.LimitTotals, len(userIDs))
+			for i, userID := range userIDs {
+				totals[i] = gorm.LimitTotals{
+					UserID: userID, Currency: "USD",
+					DayLoss: 500_000, DayStake: 1_000_000, DayBets: 2,
+					WeekLoss: 500_000, WeekStake: 1_000_000, WeekBets: 2,
+					MonthLoss: 500_000, MonthStake: 1_000_000, MonthBets: 2,
+				}
+			}
+
+			return totals, nil
+		},
+		MockGetLimitBetsFn: func(_ context.Context, userIDs []string, _ time.Time, _ *time.Time, _ int) ([]gorm.Bet, error) {
+			bets := make([]gorm.Bet, len(userIDs))
+			for i, userID := range userIDs {
+				bets[i] = gorm.Bet{BetID: uuid.NewString(), UserID: userID, Amount: 500_000, Currency: "USD", Odds: 2, Outcome: "lose", Timestamp: time.Now()}
+			}
+
+			return bets, nil
+		},
+		MockGetRecentAlertsFn: func(_ context.Context, userIDs []string, _ int) ([]gorm.Alert, error) {
+			alerts := make([]gorm.Alert, len(userIDs))
+			for i, userID := range userIDs {
+				id := uuid.NewString()
+				alerts[i] = gorm.Alert{AbstractBase: gorm.AbstractBase{ID: &id}, Event: "user.loss_limit_crossed", UserID: userID, Value: 150}
+			}
+
+			return alerts, nil
+		},
+		MockListAlertsFn: func(_ context.Context, _ int) ([]gorm.Alert, error) {
+			id := uuid.NewString()
+
+			return []gorm.Alert{
+				{AbstractBase: gorm.AbstractBase{ID: &id}, Event: "anomaly.detected", UserID: uuid.NewString(), Value: 12},
+			}, nil
+		},
+		MockListAlertsByEventFn: func(_ context.Context, events []string, _ int) ([]gorm.Alert, error) {
+			id := uuid.NewString()
+
+			return []gorm.Alert{
+				{AbstractBase: gorm.AbstractBase{ID: &id}, Event: events[0], UserID: uuid.NewString(), Value: 12000},
+			}, nil
+		},
+		MockListUserAlertsSinceFn: func(_ context.Context, event string, userIDs []string, _ time.Time) ([]gorm.Alert, error) {
+			id := uuid.NewString()
+
+			return []gorm.Alert{
+				{AbstractBase: gorm.AbstractBase{ID: &id}, Event: event, UserID: userIDs[0], BetID: "b1", Value: 28500, Evidence: "b1,b2,b3"},
+			}, nil
+		},
+		MockSaveUserLimitsFn: func(_ context.Context, limits *gorm.UserLimits) error {
+			limits.UpdatedAt = time.Now()
+
+			return nil
+		},
+		MockListUserLimitsFn: func(_ context.Context, userIDs []string) ([]gorm.UserLimits, error) {
+			limits := make([]gorm.UserLimits, 0, len(userIDs))
+			for _, userID := range userIDs {
+				limits = append(limits, gorm.UserLimits{UserID: userID, Period: "day", LossCap: 1_000_000, BetCap: 20})
+			}
+
+			return limits, nil
+		},
+		MockCreateSelfExclusionFn: func(_ context.Context, exclusion *gorm.SelfExclusion) error {
+			id := uuid.NewString()
+			exclusion.ID = &id
+			exclusion.CreatedAt = time.Now()
+
+			return nil
+		},
+		MockLiftSelfExclusionFn: func(_ context.Context, _ *gorm.SelfExclusion) error {
+			return nil
+		},
+		MockGetSelfExclusionFn: func(_ context.Context, id string) (*gorm.SelfExclusion, error) {
+			return &gorm.SelfExclusion{
+				AbstractBase: gorm.AbstractBase{ID: &id},
+				UserID:       "u1",
+				StartsAt:     time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+				Reason:       "taking a break",
+			}, nil
+		},
+		MockListSelfExclusionsFn: func(_ context.Context, userIDs []string) ([]gorm.SelfExclusion, error) {
+			exclusions := make([]gorm.SelfExclusion, 0, len(userIDs))
+			for _, userID := range userIDs {
+				id := uuid.NewString()
+				exclusions = append(exclusions, gorm.SelfExclusion{
+					AbstractBase: gorm.AbstractBase{ID: &id},
+					UserID:       userID,
+					StartsAt:     time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+					Reason:       "taking a break",
+				})
+			}
+
+			return exclusions, nil
+		},
+		MockRecordExclusionViolationsFn: func(_ context.Context, _ []gorm.ExclusionViolation) error {
+			return nil
+		},
+		MockListExclusionViolationsFn: func(_ context.Context, userID string, _ int) ([]gorm.ExclusionViolation, error) {
+			id := uuid.NewString()
+
+			return []gorm.ExclusionViolation{
+				{
+					AbstractBase: gorm.AbstractBase{ID: &id, CreatedAt: time.Now()},
+					ExclusionID:  uuid.NewString(),
+					UserID:       userID,
+					BetID:        "b1",
+					Amount:       500_000,
+					Currency:     "USD",
+					PlacedAt:     time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC),
+					Action:       "reject",
+				},
+			}, nil
+		},
+		MockCreateWebhookSubscriptionFn: func(_ context.Context, subscription *gorm.WebhookSubscription) error {
+			id := uuid.NewString()
+			subscription.ID = &id
+
+			return nil
+		},
+		MockGetWebhookSubscriptionFn: func(_ context.Context, id string) (*gorm.WebhookSubscription, error) {
+			return &gorm.WebhookSubscription{
+				AbstractBase: gorm.AbstractBase{ID: &id},
+				URL:          "http://localhost:9000/hooks",
+				Secret:       "s3cret",
+				Events:       "bet.large,anomaly.detected",
+			}, nil
+		},
+		MockListWebhookSubscriptionsFn: func(_ context.Context) ([]gorm.WebhookSubscription, error) {
+			id := uuid.NewString()
+
+			return []gorm.WebhookSubscription{
+				{
+					AbstractBase: gorm.AbstractBase{ID: &id},
+					URL:          "http://localhost:9000/hooks",
+					Secret:       "s3cret",
+					Events:       "bet.large",
+				},
+			}, nil
+		},
+		MockDeleteWebhookSubscriptionFn: func(_ context.Context, _ string) error {
+			return nil
+		},
+		MockRecordAlertFn: func(_ context.Context, _ *gorm.Alert, _ []gorm.WebhookDelivery) (bool, error) {
+			return true, nil
+		},
+		MockListDueWebhookDeliveriesFn: func(_ context.Context, now time.Time, _ int) ([]gorm.WebhookDelivery, error) {
+			id, subscriptionID := uuid.NewString(), uuid.NewString()
+
+			return []gorm.WebhookDelivery{
+				{
+					AbstractBase:   gorm.AbstractBase{ID: &id},
+					SubscriptionID: subscriptionID,
+					Subscription: &gorm.WebhookSubscription{
+						AbstractBase: gorm.AbstractBase{ID: &subscriptionID},
+						URL:          "http://localhost:9000/hooks",
+						Secret:       "s3cret",
+						Events:       "bet.large",
+					},
+					AlertID:       uuid.NewString(),
+					Event:         "bet.large",
+					Payload:       `{"event": "bet.large"}`,
+					NextAttemptAt: now,
+				},
+			}, nil
+		},
+		MockUpdateWebhookDeliveryFn: func(_ context.Context, _ *gorm.WebhookDelivery) error {
+			return nil
+		},
+		MockDeleteWebhookDeliveryFn: func(_ context.Context, _ string) error {
+			return nil
+		},
+		MockDeadLetterWebhookDeliveryFn: func(_ context.Context, _ *gorm.WebhookDeadLetter) error {
+			return nil
+		},
+		MockListWebhookDeadLettersFn: func(_ context.Context, _ int) ([]gorm.WebhookDeadLetter, error) {
+			id := uuid.NewString()
+
+			return []gorm.WebhookDeadLetter{
+				{
+					AbstractBase: gorm.AbstractBase{ID: &id},
+					URL:          "http://localhost:9000/hooks",
+					Event:        "bet.large",
+					Payload:      `{"event": "bet.large"}`,
+					Attempts:     5,
+					LastStatus:   500,
+				},
+			}, nil
+		},
 	}
 }
 
@@ -53,7 +469,7 @@ func (g *GormMock) GetTotalBets(ctx context.Context, userID string) (int64, erro
 }
 
 // GetTotalWinnings mocks retrieval of a user's total winnings
-func (g *GormMock) GetTotalWinnings(ctx context.Context, userID string) (float64, error) {
+func (g *GormMock) GetTotalWinnings(ctx context.Context, userID string) ([]gorm.User, error) {
 	return g.MockGetTotalWinningsFn(ctx, userID)
 }
 
@@ -71,3 +487,213 @@ func (g *GormMock) GetAnomalousUsers(ctx context.Context) ([]gorm.User, error) {
 func (g *GormMock) StoreBetData(ctx context.Context, bets []gorm.Bet) error {
 	return g.MockStoreBetDataFn(ctx, bets)
 }
+
+// StoreNewBetData mocks storing the bets that are not stored yet
+func (g *GormMock) StoreNewBetData(ctx context.Context, bets []gorm.Bet) (int64, error) {
+	return g.MockStoreNewBetDataFn(ctx, bets)
+}
+
+// StreamBets mocks streaming bets matching a filter
+func (g *GormMock) StreamBets(ctx context.Context, filter domain.BetFilter, fn func(bet *gorm.Bet) error) error {
+	return g.MockStreamBetsFn(ctx, filter, fn)
+}
+
+// GetBet mocks retrieval of a bet
+func (g *GormMock) GetBet(ctx context.Context, betID string) (*gorm.Bet, error) {
+	return g.MockGetBetFn(ctx, betID)
+}
+
+// SettleBet mocks settling a bet
+func (g *GormMock) SettleBet(ctx context.Context, bet *gorm.Bet) error {
+	return g.MockSettleBetFn(ctx, bet)
+}
+
+// SaveEvent mocks saving an event
+func (g *GormMock) SaveEvent(ctx context.Context, event *gorm.Event) error {
+	return g.MockSaveEventFn(ctx, event)
+}
+
+// GetEvent mocks retrieval of an event
+func (g *GormMock) GetEvent(ctx context.Context, id string) (*gorm.Event, error) {
+	return g.MockGetEventFn(ctx, id)
+}
+
+// SettleSelection mocks settling the bets on a selection
+func (g *GormMock) SettleSelection(ctx context.Context, result *gorm.SelectionResult, settle func(bet *gorm.Bet) (bool, error)) error {
+	return g.MockSettleSelectionFn(ctx, result, settle)
+}
+
+// ListSelectionResults mocks listing the results of a selection
+func (g *GormMock) ListSelectionResults(ctx context.Context, selectionID string) ([]gorm.SelectionResult, error) {
+	return g.MockListSelectionResultsFn(ctx, selectionID)
+}
+
+// CreateIngestJob mocks creating an ingest job
+func (g *GormMock) CreateIngestJob(ctx context.Context, job *gorm.IngestJob) error {
+	return g.MockCreateIngestJobFn(ctx, job)
+}
+
+// UpdateIngestJob mocks updating an ingest job
+func (g *GormMock) UpdateIngestJob(ctx context.Context, job *gorm.IngestJob) error {
+	return g.MockUpdateIngestJobFn(ctx, job)
+}
+
+// CommitIngestBatch mocks storing a batch of bets with its checkpoint
+func (g *GormMock) CommitIngestBatch(ctx context.Context, job *gorm.IngestJob, bets []gorm.Bet, violations []gorm.ExclusionViolation) error {
+	return g.MockCommitIngestBatchFn(ctx, job, bets, violations)
+}
+
+// GetIngestJob mocks retrieval of an ingest job
+func (g *GormMock) GetIngestJob(ctx context.Context, id string) (*gorm.IngestJob, error) {
+	return g.MockGetIngestJobFn(ctx, id)
+}
+
+// FindIngestJob mocks finding the ingest job of a file
+func (g *GormMock) FindIngestJob(ctx context.Context, hash string, size int64) (*gorm.IngestJob, error) {
+	return g.MockFindIngestJobFn(ctx, hash, size)
+}
+
+// ListIngestJobs mocks listing ingest jobs
+func (g *GormMock) ListIngestJobs(ctx context.Context, limit int) ([]gorm.IngestJob, error) {
+	return g.MockListIngestJobsFn(ctx, limit)
+}
+
+// GetUserLosses mocks retrieval of the amount lost by users
+func (g *GormMock) GetUserLosses(ctx context.Context, userIDs []string) ([]gorm.User, error) {
+	return g.MockGetUserLossesFn(ctx, userIDs)
+}
+
+// GetUserTotals mocks retrieval of the totals of users
+func (g *GormMock) GetUserTotals(ctx context.Context, userIDs []string) ([]gorm.User, error) {
+	return g.MockGetUserTotalsFn(ctx, userIDs)
+}
+
+// GetBreakdown mocks retrieval of the totals of bets grouped by dimensions
+func (g *GormMock) GetBreakdown(ctx context.Context, dimensions []enums.Dimension, filter domain.BetFilter) ([]gorm.Breakdown, error) {
+	return g.MockGetBreakdownFn(ctx, dimensions, filter)
+}
+
+// GetRecentBets mocks retrieval of the latest bets of users
+func (g *GormMock) GetRecentBets(ctx context.Context, userIDs []string, limit int) ([]gorm.Bet, error) {
+	return g.MockGetRecentBetsFn(ctx, userIDs, limit)
+}
+
+// GetLimitTotals mocks retrieval of the totals of users over the limit periods
+func (g *GormMock) GetLimitTotals(ctx context.Context, userIDs []string, now time.Time) ([]gorm.LimitTotals, error) {
+	return g.MockGetLimitTotalsFn(ctx, userIDs, now)
+}
+
+// GetLimitBets mocks retrieval of the bets the session length and stake trend are worked out from
+func (g *GormMock) GetLimitBets(ctx context.Context, userIDs []string, from time.Time, since *time.Time, latest int) ([]gorm.Bet, error) {
+	return g.MockGetLimitBetsFn(ctx, userIDs, from, since, latest)
+}
+
+// GetRecentAlerts mocks retrieval of the latest alerts of users
+func (g *GormMock) GetRecentAlerts(ctx context.Context, userIDs []string, limit int) ([]gorm.Alert, error) {
+	return g.MockGetRecentAlertsFn(ctx, userIDs, limit)
+}
+
+// ListAlerts mocks listing alerts
+func (g *GormMock) ListAlerts(ctx context.Context, limit int) ([]gorm.Alert, error) {
+	return g.MockListAlertsFn(ctx, limit)
+}
+
+// ListAlertsByEvent mocks listing the alerts of some events
+func (g *GormMock) ListAlertsByEvent(ctx context.Context, events []string, limit int) ([]gorm.Alert, error) {
+	return g.MockListAlertsByEventFn(ctx, events, limit)
+}
+
+// ListUserAlertsSince mocks listing the alerts of an event raised for some users since a time
+func (g *GormMock) ListUserAlertsSince(ctx context.Context, event string, userIDs []string, since time.Time) ([]gorm.Alert, error) {
+	return g.MockListUserAlertsSinceFn(ctx, event, userIDs, since)
+}
+
+// SaveUserLimits mocks saving the caps of a user over a period
+func (g *GormMock) SaveUserLimits(ctx context.Context, limits *gorm.UserLimits) error {
+	return g.MockSaveUserLimitsFn(ctx, limits)
+}
+
+// ListUserLimits mocks retrieval of the caps of several users
+func (g *GormMock) ListUserLimits(ctx context.Context, userIDs []string) ([]gorm.UserLimits, error) {
+	return g.MockListUserLimitsFn(ctx, userIDs)
+}
+
+// CreateSelfExclusion mocks storing a self-exclusion
+func (g *GormMock) CreateSelfExclusion(ctx context.Context, exclusion *gorm.SelfExclusion) error {
+	return g.MockCreateSelfExclusionFn(ctx, exclusion)
+}
+
+// LiftSelfExclusion mocks lifting a self-exclusion
+func (g *GormMock) LiftSelfExclusion(ctx context.Context, exclusion *gorm.SelfExclusion) error {
+	return g.MockLiftSelfExclusionFn(ctx, exclusion)
+}
+
+// GetSelfExclusion mocks retrieval of a self-exclusion
+func (g *GormMock) GetSelfExclusion(ctx context.Context, id string) (*gorm.SelfExclusion, error) {
+	return g.MockGetSelfExclusionFn(ctx, id)
+}
+
+// ListSelfExclusions mocks retrieval of the self-exclusions of several users
+func (g *GormMock) ListSelfExclusions(ctx context.Context, userIDs []string) ([]gorm.SelfExclusion, error) {
+	return g.MockListSelfExclusionsFn(ctx, userIDs)
+}
+
+// RecordExclusionViolations mocks storing exclusion violations
+func (g *GormMock) RecordExclusionViolations(ctx context.Context, violations []gorm.ExclusionViolation) error {
+	return g.MockRecordExclusionViolationsFn(ctx, violations)
+}
+
+// ListExclusionViolations mocks listing exclusion violations
+func (g *GormMock) ListExclusionViolations(ctx context.Context, userID string, limit int) ([]gorm.ExclusionViolation, error) {
+	return g.MockListExclusionViolationsFn(ctx, userID, limit)
+}
+
+// CreateWebhookSubscription mocks creating a webhook subscription
+func (g *GormMock) CreateWebhookSubscription(ctx context.Context, subscription *gorm.WebhookSubscription) error {
+	return g.MockCreateWebhookSubscriptionFn(ctx, subscription)
+}
+
+// GetWebhookSubscription mocks retrieval of a webhook subscription
+func (g *GormMock) GetWebhookSubscription(ctx context.Context, id string) (*gorm.WebhookSubscription, error) {
+	return g.MockGetWebhookSubscriptionFn(ctx, id)
+}
+
+// ListWebhookSubscriptions mocks listing webhook subscriptions
+func (g *GormMock) ListWebhookSubscriptions(ctx context.Context) ([]gorm.WebhookSubscription, error) {
+	return g.MockListWebhookSubscriptionsFn(ctx)
+}
+
+// DeleteWebhookSubscription mocks deleting a webhook subscription
+func (g *GormMock) DeleteWebhookSubscription(ctx context.Context, id string) error {
+	return g.MockDeleteWebhookSubscriptionFn(ctx, id)
+}
+
+// RecordAlert mocks recording an alert with its deliveries
+func (g *GormMock) RecordAlert(ctx context.Context, alert *gorm.Alert, deliveries []gorm.WebhookDelivery) (bool, error) {
+	return g.MockRecordAlertFn(ctx, alert, deliveries)
+}
+
+// ListDueWebhookDeliveries mocks listing the deliveries due for an attempt
+func (g *GormMock) ListDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]gorm.WebhookDelivery, error) {
+	return g.MockListDueWebhookDeliveriesFn(ctx, now, limit)
+}
+
+// UpdateWebhookDelivery mocks updating a webhook delivery
+func (g *GormMock) UpdateWebhookDelivery(ctx context.Context, delivery *gorm.WebhookDelivery) error {
+	return g.MockUpdateWebhookDeliveryFn(ctx, delivery)
+}
+
+// DeleteWebhookDelivery mocks deleting a webhook delivery
+func (g *GormMock) DeleteWebhookDelivery(ctx context.Context, id string) error {
+	return g.MockDeleteWebhookDeliveryFn(ctx, id)
+}
+
+// DeadLetterWebhookDelivery mocks moving a webhook delivery to the dead letter table
+func (g *GormMock) DeadLetterWebhookDelivery(ctx context.Context, deadLetter *gorm.WebhookDeadLetter) error {
+	return g.MockDeadLetterWebhookDeliveryFn(ctx, deadLetter)
+}
+
+// ListWebhookDeadLetters mocks listing webhook dead letters
+func (g *GormMock) ListWebhookDeadLetters(ctx context.Context, limit int) ([]gorm.WebhookDeadLetter, error) {
+	return g.MockListWebhookDeadLettersFn(ctx, limit)
+}
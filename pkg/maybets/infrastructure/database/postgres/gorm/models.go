@@ -39,12 +39,28 @@ func (base *AbstractBase) BeforeUpdate(_ *gorm.DB) error {
 // Bet models the bet data class model
 type Bet struct {
 	AbstractBase
-	BetID     string    `json:"bet_id" gorm:"column:bet_id;not null"`
-	UserID    string    `json:"user_id" gorm:"column:user_id;not null"`
-	Amount    float64   `json:"amount" gorm:"column:amount;not null"`
+	BetID  string `json:"bet_id" gorm:"column:bet_id;not null"`
+	UserID string `json:"user_id" gorm:"column:user_id;not null"`
+	// Amount is a whole number of ten-thousandths of a unit of Currency, like every amount of money stored
+	Amount    int64     `json:"amount" gorm:"column:amount;not null"`
+	Currency  string    `json:"currency" gorm:"column:currency;not null"`
 	Odds      float64   `json:"odds" gorm:"column:odds;not null"`
 	Outcome   string    `json:"outcome" gorm:"column:outcome;not null"`
 	Timestamp time.Time `json:"timestamp" gorm:"column:timestamp;not null"`
+	// OddsFormat is the format the odds were quoted in and QuotedOdds the odds as quoted, empty for decimal odds
+	OddsFormat string `json:"odds_format" gorm:"column:odds_format;not null;default:decimal"`
+	QuotedOdds string `json:"quoted_odds" gorm:"column:quoted_odds;not null"`
+	// Sport, Competition, EventID and Market describe what the bet was placed on, empty when it does not say
+	Sport       string `json:"sport,omitempty" gorm:"column:sport;not null"`
+	Competition string `json:"competition,omitempty" gorm:"column:competition;not null"`
+	EventID     string `json:"event_id,omitempty" gorm:"column:event_id;not null"`
+	Market      string `json:"market,omitempty" gorm:"column:market;not null"`
+	// Selection is the ID of the selection of a single bet, empty when it is not placed on one
+	Selection string `json:"selection,omitempty" gorm:"column:selection;not null"`
+	// Payout is derived from the amount, odds and outcome when the bet is stored or settled.
+	// It holds the cashout amount of a cashed out bet.
+	Payout int64    `json:"payout" gorm:"column:payout;not null"`
+	Legs   []BetLeg `json:"legs,omitempty" gorm:"foreignKey:BetID;references:BetID"`
 }
 
 // TableName ....
@@ -52,7 +68,264 @@ func (Bet) TableName() string {
 	return "bets"
 }
 
+// BetLeg models a selection of a multi-leg bet
+type BetLeg struct {
+	AbstractBase
+	BetID     string  `json:"-" gorm:"column:bet_id;not null"`
+	Position  int     `json:"-" gorm:"column:position;not null"`
+	Selection string  `json:"selection" gorm:"column:selection;not null"`
+	Odds      float64 `json:"odds" gorm:"column:odds;not null"`
+	Outcome   string  `json:"outcome" gorm:"column:outcome;not null"`
+}
+
+// TableName ....
+func (BetLeg) TableName() string {
+	return "bet_legs"
+}
+
+// Event models a fixture along with its markets
+type Event struct {
+	AbstractBase
+	Name     string    `json:"name" gorm:"column:name;not null"`
+	StartsAt time.Time `json:"starts_at" gorm:"column:starts_at;not null"`
+	Markets  []Market  `json:"markets" gorm:"foreignKey:EventID"`
+}
+
+// TableName ....
+func (Event) TableName() string {
+	return "events"
+}
+
+// Market models a market of an event along with its selections
+type Market struct {
+	AbstractBase
+	EventID    string      `json:"-" gorm:"column:event_id;not null"`
+	Name       string      `json:"name" gorm:"column:name;not null"`
+	Selections []Selection `json:"selections" gorm:"foreignKey:MarketID"`
+}
+
+// TableName ....
+func (Market) TableName() string {
+	return "markets"
+}
+
+// Selection models a selection of a market. Result is nil until a result is submitted for it.
+type Selection struct {
+	AbstractBase
+	MarketID string  `json:"-" gorm:"column:market_id;not null"`
+	Name     string  `json:"name" gorm:"column:name;not null"`
+	Result   *string `json:"result" gorm:"column:result"`
+}
+
+// TableName ....
+func (Selection) TableName() string {
+	return "selections"
+}
+
+// SelectionResult models a result submitted for a selection and the bets it settled.
+// It is created by the submitter at the time of the submission.
+type SelectionResult struct {
+	AbstractBase
+	SelectionID    string  `json:"selection_id" gorm:"column:selection_id;not null"`
+	Result         string  `json:"result" gorm:"column:result;not null"`
+	PreviousResult *string `json:"previous_result" gorm:"column:previous_result"`
+	BetsSettled    int     `json:"bets_settled" gorm:"column:bets_settled;not null"`
+	Stake          int64   `json:"stake" gorm:"column:stake;not null"`
+	Payout         int64   `json:"payout" gorm:"column:payout;not null"`
+	// Currency is the reporting currency Stake and Payout were converted into
+	Currency string `json:"currency" gorm:"column:currency;not null"`
+}
+
+// TableName ....
+func (SelectionResult) TableName() string {
+	return "selection_results"
+}
+
+// IngestJob models the progress of a file import
+type IngestJob struct {
+	AbstractBase
+	Path        string     `json:"path" gorm:"column:path;not null"`
+	Size        int64      `json:"size" gorm:"column:size;not null"`
+	Hash        string     `json:"hash" gorm:"column:hash;not null"`
+	Format      string     `json:"format" gorm:"column:format;not null"`
+	Status      string     `json:"status" gorm:"column:status;not null"`
+	Offset      int64      `json:"committed_offset" gorm:"column:committed_offset;not null"`
+	Records     int64      `json:"committed_records" gorm:"column:committed_records;not null"`
+	Error       string     `json:"error" gorm:"column:error"`
+	CompletedAt *time.Time `json:"completed_at" gorm:"column:completed_at"`
+}
+
+// TableName ....
+func (IngestJob) TableName() string {
+	return "ingest_jobs"
+}
+
+// WebhookSubscription models a URL notified of alerts.
+// Events holds the comma separated events it subscribes to.
+type WebhookSubscription struct {
+	AbstractBase
+	URL    string `json:"url" gorm:"column:url;not null"`
+	Secret string `json:"secret" gorm:"column:secret;not null"`
+	Events string `json:"events" gorm:"column:events;not null"`
+}
+
+// TableName ....
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// Alert models an event raised once per DedupeKey
+type Alert struct {
+	AbstractBase
+	Event     string  `json:"event" gorm:"column:event;not null"`
+	DedupeKey string  `json:"dedupe_key" gorm:"column:dedupe_key;not null"`
+	UserID    string  `json:"user_id" gorm:"column:user_id;not null"`
+	BetID     string  `json:"bet_id" gorm:"column:bet_id"`
+	Value     float64 `json:"value" gorm:"column:value;not null"`
+	Threshold float64 `json:"threshold" gorm:"column:threshold;not null"`
+	// LimitKind and Period name the responsible gambling limit a user breached, empty for other alerts
+	LimitKind string `json:"limit_kind" gorm:"column:limit_kind;not null"`
+	Period    string `json:"period" gorm:"column:period;not null"`
+	// Evidence holds the comma separated IDs of the bets reported by an anti-money laundering rule
+	Evidence string `json:"evidence" gorm:"column:evidence;not null"`
+}
+
+// TableName ....
+func (Alert) TableName() string {
+	return "alerts"
+}
+
+// WebhookDelivery models a pending notification of an alert to a subscription
+type WebhookDelivery struct {
+	AbstractBase
+	SubscriptionID string               `json:"subscription_id" gorm:"column:subscription_id;not null"`
+	Subscription   *WebhookSubscription `json:"subscription,omitempty" gorm:"foreignKey:SubscriptionID"`
+	AlertID        string               `json:"alert_id" gorm:"column:alert_id;not null"`
+	Event          string               `json:"event" gorm:"column:event;not null"`
+	Payload        string               `json:"payload" gorm:"column:payload;not null"`
+	Attempts       int                  `json:"attempts" gorm:"column:attempts;not null"`
+	NextAttemptAt  time.Time            `json:"next_attempt_at" gorm:"column:next_attempt_at;not null"`
+	LastStatus     int                  `json:"last_status" gorm:"column:last_status;not null"`
+	LastError      string               `json:"last_error" gorm:"column:last_error"`
+}
+
+// TableName ....
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// WebhookDeadLetter models a delivery that failed its last attempt.
+// Its creation time is the time it was dead lettered.
+type WebhookDeadLetter struct {
+	AbstractBase
+	DeliveryID     string `json:"delivery_id" gorm:"column:delivery_id;not null"`
+	SubscriptionID string `json:"subscription_id" gorm:"column:subscription_id;not null"`
+	URL            string `json:"url" gorm:"column:url;not null"`
+	AlertID        string `json:"alert_id" gorm:"column:alert_id;not null"`
+	Event          string `json:"event" gorm:"column:event;not null"`
+	Payload        string `json:"payload" gorm:"column:payload;not null"`
+	Attempts       int    `json:"attempts" gorm:"column:attempts;not null"`
+	LastStatus     int    `json:"last_status" gorm:"column:last_status;not null"`
+	LastError      string `json:"last_error" gorm:"column:last_error"`
+}
+
+// TableName ....
+func (WebhookDeadLetter) TableName() string {
+	return "webhook_dead_letters"
+}
+
+// Breakdown holds the totals of the bets in a currency that share the same values of the dimensions grouped by.
+// The dimensions that are not grouped by are left empty.
+type Breakdown struct {
+	Sport       string `json:"sport"`
+	Competition string `json:"competition"`
+	EventID     string `json:"event_id"`
+	Market      string `json:"market"`
+	Selection   string `json:"selection"`
+	Currency    string `json:"currency"`
+	Bets        int64  `json:"bets"`
+	Volume      int64  `json:"volume"`
+	// SettledStake adds up the stakes of the bets that are no longer pending
+	SettledStake int64 `json:"settled_stake"`
+	Payout       int64 `json:"payout"`
+}
+
+// User holds the totals of a user. Amounts are added up separately for every currency the user bet in,
+// in which case there is one row per currency.
 type User struct {
-	UserID    string `json:"user_id"`
-	TotalBets int64  `json:"total_bets"`
+	UserID        string `json:"user_id"`
+	Currency      string `json:"currency"`
+	TotalBets     int64  `json:"total_bets"`
+	TotalWinnings int64  `json:"total_winnings"`
+	TotalLosses   int64  `json:"total_losses"`
+	TotalPayout   int64  `json:"total_payout"`
+	// WonBets and DecidedBets count the bets won and the bets either won or lost, half results counting half
+	WonBets     float64 `json:"won_bets"`
+	DecidedBets float64 `json:"decided_bets"`
+}
+
+// LimitTotals holds the amount lost and staked and the number of bets a user placed in a currency over the last day,
+// week and month
+type LimitTotals struct {
+	UserID     string `json:"user_id"`
+	Currency   string `json:"currency"`
+	DayLoss    int64  `json:"day_loss"`
+	DayStake   int64  `json:"day_stake"`
+	DayBets    int64  `json:"day_bets"`
+	WeekLoss   int64  `json:"week_loss"`
+	WeekStake  int64  `json:"week_stake"`
+	WeekBets   int64  `json:"week_bets"`
+	MonthLoss  int64  `json:"month_loss"`
+	MonthStake int64  `json:"month_stake"`
+	MonthBets  int64  `json:"month_bets"`
+}
+
+// UserLimits models the caps a user is held to over a rolling period, zero when a cap is not set.
+// LossCap and StakeCap are in the reporting currency.
+type UserLimits struct {
+	AbstractBase
+	UserID   string `json:"user_id" gorm:"column:user_id;not null"`
+	Period   string `json:"period" gorm:"column:period;not null"`
+	LossCap  int64  `json:"loss_cap" gorm:"column:loss_cap;not null"`
+	StakeCap int64  `json:"stake_cap" gorm:"column:stake_cap;not null"`
+	BetCap   int64  `json:"bet_cap" gorm:"column:bet_cap;not null"`
+}
+
+// TableName ....
+func (UserLimits) TableName() string {
+	return "user_limits"
+}
+
+// SelfExclusion models a period a user excluded themselves from betting over. EndsAt is nil when it lasts
+// until it is lifted, LiftedAt and LiftedBy are nil while it stands.
+type SelfExclusion struct {
+	AbstractBase
+	UserID   string     `json:"user_id" gorm:"column:user_id;not null"`
+	StartsAt time.Time  `json:"starts_at" gorm:"column:starts_at;not null"`
+	EndsAt   *time.Time `json:"ends_at" gorm:"column:ends_at"`
+	Reason   string     `json:"reason" gorm:"column:reason;not null"`
+	LiftedAt *time.Time `json:"lifted_at" gorm:"column:lifted_at"`
+	LiftedBy *string    `json:"lifted_by" gorm:"column:lifted_by"`
+}
+
+// TableName ....
+func (SelfExclusion) TableName() string {
+	return "self_exclusions"
+}
+
+// ExclusionViolation models a bet received from a user during one of their exclusions and how it was handled
+type ExclusionViolation struct {
+	AbstractBase
+	ExclusionID string    `json:"exclusion_id" gorm:"column:exclusion_id;not null"`
+	UserID      string    `json:"user_id" gorm:"column:user_id;not null"`
+	BetID       string    `json:"bet_id" gorm:"column:bet_id;not null"`
+	Amount      int64     `json:"amount" gorm:"column:amount;not null"`
+	Currency    string    `json:"currency" gorm:"column:currency;not null"`
+	PlacedAt    time.Time `json:"placed_at" gorm:"column:placed_at;not null"`
+	Action      string    `json:"action" gorm:"column:action;not null"`
+}
+
+// TableName ....
+func (ExclusionViolation) TableName() string {
+	return "exclusion_violations"
 }
@@ -2,22 +2,59 @@ package gorm
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+	"github.com/mattn/go-sqlite3"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
 )
 
 var tracer = otel.Tracer("github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/database/postgres/gorm")
 
+// timestampLayouts are the layouts timestamps stored in TEXT columns may be written in
+var timestampLayouts = slices.Concat(sqlite3.SQLiteTimestampFormats, []string{"2006-01-02 15:04:05.999999999-07"})
+
+// parseTimestamp parses a timestamp read from a TEXT column
+func parseTimestamp(value string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognised timestamp %q", value)
+}
+
+// The stake of a bet is won, lost or refunded depending on its state: half results settle half of it and
+// refund the rest, void and pushed bets refund all of it and pending bets are not settled yet.
+// Amounts are whole numbers, so the sums below are exact; they only make sense for the bets of a single currency.
+const (
+	// wonStake is the part of the stake of a bet that was won
+	wonStake = "CASE outcome WHEN 'win' THEN amount WHEN 'half_win' THEN amount / 2 ELSE 0 END"
+	// lostStake is the part of the stake of a bet that was not returned: all of it when the bet is lost, the part
+	// that was not refunded when it is half lost and the difference when it was cashed out for less than its stake
+	lostStake = "CASE outcome WHEN 'lose' THEN amount WHEN 'half_lose' THEN amount - payout WHEN 'cashout' THEN MAX(amount - payout, 0) ELSE 0 END"
+	// decidedShare is the share of a bet that was either won or lost, the base of the win rate
+	decidedShare = "CASE outcome WHEN 'win' THEN 1.0 WHEN 'lose' THEN 1.0 WHEN 'half_win' THEN 0.5 WHEN 'half_lose' THEN 0.5 ELSE 0 END"
+	// wonShare is the share of a bet that was won
+	wonShare = "CASE outcome WHEN 'win' THEN 1.0 WHEN 'half_win' THEN 0.5 ELSE 0 END"
+)
+
 // GetTotalBets fetches the total number of bets placed by a user.
 func (db DBInstance) GetTotalBets(ctx context.Context, userID string) (int64, error) {
-	_, span := tracer.Start(ctx, "GetTotalBets")
+	ctx, span := tracer.Start(ctx, "GetTotalBets")
 	defer span.End()
 
 	var totalBets int64
-	err := db.DB.Model(&Bet{}).
+	err := db.DB.WithContext(ctx).Model(&Bet{}).
 		Where("user_id = ?", userID).
 		Count(&totalBets).Error
 
@@ -31,34 +68,37 @@ func (db DBInstance) GetTotalBets(ctx context.Context, userID string) (int64, er
 	return totalBets, nil
 }
 
-// GetTotalWinnings calculates the total winnings of a user.
-func (db DBInstance) GetTotalWinnings(ctx context.Context, userID string) (float64, error) {
-	_, span := tracer.Start(ctx, "GetTotalWinnings")
+// GetTotalWinnings calculates the total winnings of a user, the stakes of the bets that were won,
+// in every currency the user bet in.
+func (db DBInstance) GetTotalWinnings(ctx context.Context, userID string) ([]User, error) {
+	ctx, span := tracer.Start(ctx, "GetTotalWinnings")
 	defer span.End()
 
-	var totalWinnings float64
-	err := db.DB.Model(&Bet{}).
-		Where("user_id = ? AND outcome = ?", userID, enums.Win).
-		Select("COALESCE(SUM(amount), 0)").
-		Scan(&totalWinnings).Error
+	var totals []User
+	err := db.DB.WithContext(ctx).Model(&Bet{}).
+		Where("user_id = ?", userID).
+		Select("user_id, currency, SUM(" + wonStake + ") as total_winnings").
+		Group("user_id, currency").
+		Order("currency").
+		Scan(&totals).Error
 
 	if err != nil {
 		span.SetStatus(codes.Error, "Failed to calculate total winnings")
 		span.RecordError(err)
 
-		return 0, fmt.Errorf("failed to get total winnings: %w", err)
+		return nil, fmt.Errorf("failed to get total winnings: %w", err)
 	}
 
-	return totalWinnings, nil
+	return totals, nil
 }
 
 // GetTopUsers fetches the top users with the highest betting volume.
 func (db DBInstance) GetTopUsers(ctx context.Context, limit int) ([]User, error) {
-	_, span := tracer.Start(ctx, "GetTopUsers")
+	ctx, span := tracer.Start(ctx, "GetTopUsers")
 	defer span.End()
 
 	var topUsers []User
-	err := db.DB.Model(&Bet{}).
+	err := db.DB.WithContext(ctx).Model(&Bet{}).
 		Select("user_id, COUNT(*) as total_bets").
 		Group("user_id").
 		Order("total_bets DESC").
@@ -75,15 +115,18 @@ func (db DBInstance) GetTopUsers(ctx context.Context, limit int) ([]User, error)
 	return topUsers, nil
 }
 
+// anomalyFactor is how many times the average number of bets per user a user must exceed to be anomalous
+const anomalyFactor = 2
+
 // GetAnomalousUsers fetches users with significantly higher betting activity than the average.
 func (db DBInstance) GetAnomalousUsers(ctx context.Context) ([]User, error) {
-	_, span := tracer.Start(ctx, "GetAnomalousUsers")
+	ctx, span := tracer.Start(ctx, "GetAnomalousUsers")
 	defer span.End()
 
 	// get the total number of bets and the total number of distinct users
 	var totalBets, totalUsers int64
 
-	err := db.DB.Model(&Bet{}).
+	err := db.DB.WithContext(ctx).Model(&Bet{}).
 		Select("COUNT(*)").
 		Scan(&totalBets).Error
 	if err != nil {
@@ -93,7 +136,7 @@ func (db DBInstance) GetAnomalousUsers(ctx context.Context) ([]User, error) {
 		return nil, fmt.Errorf("failed to count total bets: %w", err)
 	}
 
-	err = db.DB.Model(&Bet{}).
+	err = db.DB.WithContext(ctx).Model(&Bet{}).
 		Distinct("user_id").
 		Count(&totalUsers).Error
 	if err != nil {
@@ -106,13 +149,13 @@ func (db DBInstance) GetAnomalousUsers(ctx context.Context) ([]User, error) {
 	// average number of bets per user
 	avgBets := float64(totalBets) / float64(totalUsers)
 
-	// users who have placed bets significantly above the average eg 2 times the average
+	// users who have placed bets significantly above the average
 	var anomalousUsers []User
 
-	err = db.DB.Model(&Bet{}).
+	err = db.DB.WithContext(ctx).Model(&Bet{}).
 		Select("user_id, COUNT(*) as total_bets").
 		Group("user_id").
-		Having("COUNT(*) > ?", avgBets*2.5).
+		Having("COUNT(*) > ?", avgBets*anomalyFactor).
 		Order("total_bets DESC").
 		Scan(&anomalousUsers).Error
 	if err != nil {
@@ -124,3 +167,706 @@ func (db DBInstance) GetAnomalousUsers(ctx context.Context) ([]User, error) {
 
 	return anomalousUsers, nil
 }
+
+// GetUserLosses fetches the total amount lost by each of the given users that lost at least one bet,
+// in every currency they lost in
+func (db DBInstance) GetUserLosses(ctx context.Context, userIDs []string) ([]User, error) {
+	ctx, span := tracer.Start(ctx, "GetUserLosses")
+	defer span.End()
+
+	var users []User
+
+	err := db.DB.WithContext(ctx).Model(&Bet{}).
+		Select("user_id, currency, COUNT(*) as total_bets, SUM("+lostStake+") as total_losses").
+		Where("user_id IN ? AND outcome IN ?", userIDs, []enums.Outcome{enums.Lose, enums.HalfLose, enums.Cashout}).
+		Group("user_id, currency").
+		Order("user_id, currency").
+		Having("SUM(" + lostStake + ") > 0").
+		Scan(&users).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to fetch user losses")
+		span.RecordError(err)
+
+		return nil, fmt.Errorf("failed to get user losses: %w", err)
+	}
+
+	return users, nil
+}
+
+// betColumns are the columns read by scanBet, in order.
+// The legs of a multi-leg bet are read as a JSON array, which is empty for a single bet;
+// the query must name the table or subquery holding the bets "bets".
+const betColumns = "bets.bet_id, bets.user_id, bets.amount, bets.currency, bets.odds, bets.odds_format, bets.quoted_odds, " +
+	"bets.outcome, bets.timestamp, bets.payout, bets.sport, bets.competition, bets.event_id, bets.market, bets.selection, " +
+	"(SELECT json_group_array(json_object('selection', selection, 'odds', odds, 'outcome', outcome) ORDER BY position) " +
+	"FROM bet_legs WHERE bet_legs.bet_id = bets.bet_id)"
+
+// scanBet reads a row of betColumns. The timestamp is stored as TEXT and parsed separately.
+func scanBet(rows *sql.Rows) (*Bet, error) {
+	var (
+		bet       Bet
+		timestamp string
+		legs      string
+	)
+
+	err := rows.Scan(
+		&bet.BetID, &bet.UserID, &bet.Amount, &bet.Currency, &bet.Odds, &bet.OddsFormat, &bet.QuotedOdds,
+		&bet.Outcome, &timestamp, &bet.Payout, &bet.Sport, &bet.Competition, &bet.EventID, &bet.Market, &bet.Selection, &legs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan bet: %w", err)
+	}
+
+	parsed, err := parseTimestamp(timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse timestamp of bet %s: %w", bet.BetID, err)
+	}
+
+	bet.Timestamp = parsed
+
+	if err := json.Unmarshal([]byte(legs), &bet.Legs); err != nil {
+		return nil, fmt.Errorf("failed to decode legs of bet %s: %w", bet.BetID, err)
+	}
+
+	return &bet, nil
+}
+
+// GetUserTotals fetches the number of bets, the winnings, the losses, the payouts and the bets won and decided
+// of each of the given users that placed a bet, in every currency they bet in
+func (db DBInstance) GetUserTotals(ctx context.Context, userIDs []string) ([]User, error) {
+	ctx, span := tracer.Start(ctx, "GetUserTotals")
+	defer span.End()
+
+	var users []User
+
+	err := db.DB.WithContext(ctx).Model(&Bet{}).
+		Select(`user_id, currency, COUNT(*) as total_bets,
+			SUM(`+wonStake+`) as total_winnings,
+			SUM(`+lostStake+`) as total_losses,
+			SUM(payout) as total_payout,
+			SUM(`+wonShare+`) as won_bets,
+			SUM(`+decidedShare+`) as decided_bets`).
+		Where("user_id IN ?", userIDs).
+		Group("user_id, currency").
+		Order("user_id, currency").
+		Scan(&users).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to fetch user totals")
+		span.RecordError(err)
+
+		return nil, fmt.Errorf("failed to get user totals: %w", err)
+	}
+
+	return users, nil
+}
+
+// GetRecentBets fetches the latest bets of each of the given users, at most limit per user, newest first
+func (db DBInstance) GetRecentBets(ctx context.Context, userIDs []string, limit int) ([]Bet, error) {
+	ctx, span := tracer.Start(ctx, "GetRecentBets")
+	defer span.End()
+
+	rows, err := db.DB.WithContext(ctx).Raw(`SELECT `+betColumns+` FROM (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY timestamp DESC) AS recency
+			FROM bets WHERE user_id IN ?
+		) AS bets WHERE recency <= ? ORDER BY user_id, timestamp DESC`, userIDs, limit).
+		Rows()
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to query recent bets")
+		span.RecordError(err)
+
+		return nil, fmt.Errorf("failed to query recent bets: %w", err)
+	}
+
+	defer rows.Close()
+
+	var bets []Bet
+
+	for rows.Next() {
+		bet, err := scanBet(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		bets = append(bets, *bet)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read recent bets: %w", err)
+	}
+
+	return bets, nil
+}
+
+// GetLimitTotals fetches the amount lost and staked and the number of bets each of the given users placed over the
+// day, week and month before now, in every currency they bet in. Bets placed after now are counted in every period.
+func (db DBInstance) GetLimitTotals(ctx context.Context, userIDs []string, now time.Time) ([]LimitTotals, error) {
+	ctx, span := tracer.Start(ctx, "GetLimitTotals")
+	defer span.End()
+
+	columns := []string{"user_id", "currency"}
+	args := []any{}
+
+	for _, period := range enums.LimitPeriods {
+		columns = append(columns,
+			fmt.Sprintf("SUM(CASE WHEN timestamp >= ? THEN %s ELSE 0 END) as %s_loss", lostStake, period),
+			fmt.Sprintf("SUM(CASE WHEN timestamp >= ? THEN amount ELSE 0 END) as %s_stake", period),
+			fmt.Sprintf("SUM(CASE WHEN timestamp >= ? THEN 1 ELSE 0 END) as %s_bets", period),
+		)
+
+		from := now.Add(-period.Duration())
+		args = append(args, from, from, from)
+	}
+
+	var totals []LimitTotals
+
+	err := db.DB.WithContext(ctx).Model(&Bet{}).
+		Select(strings.Join(columns, ", "), args...).
+		Where("user_id IN ? AND timestamp >= ?", userIDs, now.Add(-enums.Month.Duration())).
+		Group("user_id, currency").
+		Order("user_id, currency").
+		Scan(&totals).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to fetch limit totals")
+		span.RecordError(err)
+
+		return nil, fmt.Errorf("failed to get limit totals: %w", err)
+	}
+
+	return totals, nil
+}
+
+// GetLimitBets fetches the bets each of the given users placed from a time on that the session length and stake trend
+// are worked out from: those placed since, when it is set, and the latest of them up to latest per user.
+// Bets are ordered by user, oldest first.
+func (db DBInstance) GetLimitBets(ctx context.Context, userIDs []string, from time.Time, since *time.Time, latest int) ([]Bet, error) {
+	ctx, span := tracer.Start(ctx, "GetLimitBets")
+	defer span.End()
+
+	condition, args := "recency <= ?", []any{userIDs, from, latest}
+	if since != nil {
+		condition, args = "(recency <= ? OR timestamp >= ?)", append(args, *since)
+	}
+
+	rows, err := db.DB.WithContext(ctx).Raw(`SELECT `+betColumns+` FROM (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY timestamp DESC) AS recency
+			FROM bets WHERE user_id IN ? AND timestamp >= ?
+		) AS bets WHERE `+condition+` ORDER BY user_id, timestamp`, args...).
+		Rows()
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to query limit bets")
+		span.RecordError(err)
+
+		return nil, fmt.Errorf("failed to query limit bets: %w", err)
+	}
+
+	defer rows.Close()
+
+	var bets []Bet
+
+	for rows.Next() {
+		bet, err := scanBet(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		bets = append(bets, *bet)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read limit bets: %w", err)
+	}
+
+	return bets, nil
+}
+
+// GetRecentAlerts fetches the latest alerts of each of the given users, at most limit per user, newest first
+func (db DBInstance) GetRecentAlerts(ctx context.Context, userIDs []string, limit int) ([]Alert, error) {
+	ctx, span := tracer.Start(ctx, "GetRecentAlerts")
+	defer span.End()
+
+	var alerts []Alert
+
+	err := db.DB.WithContext(ctx).Raw(`SELECT * FROM (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY created DESC) AS position
+			FROM alerts WHERE user_id IN ?
+		) WHERE position <= ? ORDER BY user_id, created DESC`, userIDs, limit).
+		Scan(&alerts).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to fetch recent alerts")
+		span.RecordError(err)
+
+		return nil, fmt.Errorf("failed to get recent alerts: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// ListUserLimits fetches the caps of the given users, ordered by user and period
+func (db DBInstance) ListUserLimits(ctx context.Context, userIDs []string) ([]UserLimits, error) {
+	ctx, span := tracer.Start(ctx, "ListUserLimits")
+	defer span.End()
+
+	var limits []UserLimits
+
+	err := db.DB.WithContext(ctx).Where("user_id IN ?", userIDs).Order("user_id, period").Find(&limits).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to list user limits")
+		span.RecordError(err)
+
+		return nil, fmt.Errorf("failed to list user limits: %w", err)
+	}
+
+	return limits, nil
+}
+
+// GetSelfExclusion fetches a self-exclusion by its ID
+func (db DBInstance) GetSelfExclusion(ctx context.Context, id string) (*SelfExclusion, error) {
+	ctx, span := tracer.Start(ctx, "GetSelfExclusion")
+	defer span.End()
+
+	var exclusion SelfExclusion
+
+	err := db.DB.WithContext(ctx).Where("id = ?", id).First(&exclusion).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to fetch self-exclusion")
+		span.RecordError(err)
+
+		return nil, fmt.Errorf("failed to get self-exclusion %s: %w", id, err)
+	}
+
+	return &exclusion, nil
+}
+
+// ListSelfExclusions fetches the self-exclusions of the given users, or of every user when none is given,
+// ordered by user and by start
+func (db DBInstance) ListSelfExclusions(ctx context.Context, userIDs []string) ([]SelfExclusion, error) {
+	ctx, span := tracer.Start(ctx, "ListSelfExclusions")
+	defer span.End()
+
+	query := db.DB.WithContext(ctx)
+	if len(userIDs) > 0 {
+		query = query.Where("user_id IN ?", userIDs)
+	}
+
+	var exclusions []SelfExclusion
+
+	err := query.Order("user_id, starts_at").Find(&exclusions).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to list self-exclusions")
+		span.RecordError(err)
+
+		return nil, fmt.Errorf("failed to list self-exclusions: %w", err)
+	}
+
+	return exclusions, nil
+}
+
+// ListExclusionViolations fetches the most recent exclusion violations, of a single user when one is given, newest first
+func (db DBInstance) ListExclusionViolations(ctx context.Context, userID string, limit int) ([]ExclusionViolation, error) {
+	ctx, span := tracer.Start(ctx, "ListExclusionViolations")
+	defer span.End()
+
+	query := db.DB.WithContext(ctx)
+	if userID != "" {
+		query = query.Where("user_id = ?", userID)
+	}
+
+	var violations []ExclusionViolation
+
+	err := query.Order("created DESC").Limit(limit).Find(&violations).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to list exclusion violations")
+		span.RecordError(err)
+
+		return nil, fmt.Errorf("failed to list exclusion violations: %w", err)
+	}
+
+	return violations, nil
+}
+
+// ListAlerts fetches the most recent alerts, newest first
+func (db DBInstance) ListAlerts(ctx context.Context, limit int) ([]Alert, error) {
+	ctx, span := tracer.Start(ctx, "ListAlerts")
+	defer span.End()
+
+	var alerts []Alert
+
+	err := db.DB.WithContext(ctx).Order("created DESC").Limit(limit).Find(&alerts).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to list alerts")
+		span.RecordError(err)
+
+		return nil, fmt.Errorf("failed to list alerts: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// ListAlertsByEvent fetches the most recent alerts of the given events, newest first
+func (db DBInstance) ListAlertsByEvent(ctx context.Context, events []string, limit int) ([]Alert, error) {
+	ctx, span := tracer.Start(ctx, "ListAlertsByEvent")
+	defer span.End()
+
+	var alerts []Alert
+
+	err := db.DB.WithContext(ctx).Where("event IN ?", events).Order("created DESC").Limit(limit).Find(&alerts).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to list alerts")
+		span.RecordError(err)
+
+		return nil, fmt.Errorf("failed to list alerts: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// ListUserAlertsSince fetches the alerts of an event raised for the given users since a time, oldest first
+func (db DBInstance) ListUserAlertsSince(ctx context.Context, event string, userIDs []string, since time.Time) ([]Alert, error) {
+	ctx, span := tracer.Start(ctx, "ListUserAlertsSince")
+	defer span.End()
+
+	var alerts []Alert
+
+	err := db.DB.WithContext(ctx).
+		Where("event = ? AND user_id IN ? AND created >= ?", event, userIDs, since).
+		Order("created").
+		Find(&alerts).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to list user alerts")
+		span.RecordError(err)
+
+		return nil, fmt.Errorf("failed to list user alerts: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// GetBet fetches a bet along with its legs
+func (db DBInstance) GetBet(ctx context.Context, betID string) (*Bet, error) {
+	ctx, span := tracer.Start(ctx, "GetBet")
+	defer span.End()
+
+	rows, err := db.DB.WithContext(ctx).Model(&Bet{}).
+		Where("bet_id = ?", betID).
+		Select(betColumns).
+		Rows()
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to query bet")
+		span.RecordError(err)
+
+		return nil, fmt.Errorf("failed to query bet %s: %w", betID, err)
+	}
+
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read bet %s: %w", betID, err)
+		}
+
+		return nil, fmt.Errorf("failed to get bet %s: %w", betID, gorm.ErrRecordNotFound)
+	}
+
+	return scanBet(rows)
+}
+
+// filterBets narrows a query on the bets down to the user, event, outcome and time range of the filter
+func filterBets(query *gorm.DB, filter domain.BetFilter) *gorm.DB {
+	if filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+
+	if filter.EventID != "" {
+		query = query.Where("event_id = ?", filter.EventID)
+	}
+
+	if filter.Outcome != "" {
+		query = query.Where("outcome = ?", filter.Outcome)
+	}
+
+	if filter.From != nil {
+		query = query.Where("timestamp >= ?", *filter.From)
+	}
+
+	if filter.To != nil {
+		query = query.Where("timestamp < ?", *filter.To)
+	}
+
+	return query
+}
+
+// GetBreakdown fetches the number of bets, the stakes and the payouts of the bets matching the filter, grouped by
+// the dimensions and by currency. The limit of the filter is ignored.
+func (db DBInstance) GetBreakdown(ctx context.Context, dimensions []enums.Dimension, filter domain.BetFilter) ([]Breakdown, error) {
+	ctx, span := tracer.Start(ctx, "GetBreakdown")
+	defer span.End()
+
+	// the dimensions are named after the columns holding them
+	columns := make([]string, 0, len(dimensions)+1)
+
+	for _, dimension := range dimensions {
+		if !dimension.IsValid() {
+			return nil, fmt.Errorf("invalid dimension %q", dimension)
+		}
+
+		columns = append(columns, dimension.String())
+	}
+
+	columns = append(columns, "currency")
+	grouping := strings.Join(columns, ", ")
+
+	var breakdown []Breakdown
+
+	err := filterBets(db.DB.WithContext(ctx).Model(&Bet{}), filter).
+		Select(grouping + `, COUNT(*) as bets, SUM(amount) as volume,
+			SUM(CASE outcome WHEN 'pending' THEN 0 ELSE amount END) as settled_stake,
+			SUM(payout) as payout`).
+		Group(grouping).
+		Order(grouping).
+		Scan(&breakdown).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to fetch breakdown")
+		span.RecordError(err)
+
+		return nil, fmt.Errorf("failed to get breakdown: %w", err)
+	}
+
+	return breakdown, nil
+}
+
+// StreamBets calls fn for every bet matching the filter, ordered by timestamp.
+// Rows are read one at a time so that large exports do not have to fit in memory.
+func (db DBInstance) StreamBets(ctx context.Context, filter domain.BetFilter, fn func(bet *Bet) error) error {
+	ctx, span := tracer.Start(ctx, "StreamBets")
+	defer span.End()
+
+	query := filterBets(db.DB.WithContext(ctx).Model(&Bet{}), filter)
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	rows, err := query.
+		Select(betColumns).
+		Order("timestamp").
+		Rows()
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to query bets")
+		span.RecordError(err)
+
+		return fmt.Errorf("failed to query bets: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		bet, err := scanBet(rows)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(bet); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		span.SetStatus(codes.Error, "Failed to read bets")
+		span.RecordError(err)
+
+		return fmt.Errorf("failed to read bets: %w", err)
+	}
+
+	return nil
+}
+
+// GetIngestJob fetches an ingest job by its ID
+func (db DBInstance) GetIngestJob(ctx context.Context, id string) (*IngestJob, error) {
+	ctx, span := tracer.Start(ctx, "GetIngestJob")
+	defer span.End()
+
+	var job IngestJob
+
+	err := db.DB.WithContext(ctx).Where("id = ?", id).First(&job).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to fetch ingest job")
+		span.RecordError(err)
+
+		return nil, fmt.Errorf("failed to get ingest job %s: %w", id, err)
+	}
+
+	return &job, nil
+}
+
+// FindIngestJob fetches the job that got furthest importing the file with the given hash and size:
+// a completed job if there is one, otherwise the job with the most committed records.
+// It returns nil when the file was never imported.
+func (db DBInstance) FindIngestJob(ctx context.Context, hash string, size int64) (*IngestJob, error) {
+	ctx, span := tracer.Start(ctx, "FindIngestJob")
+	defer span.End()
+
+	var jobs []IngestJob
+
+	err := db.DB.WithContext(ctx).
+		Where("hash = ? AND size = ?", hash, size).
+		Order("status = 'completed' DESC, committed_records DESC, created DESC").
+		Limit(1).
+		Find(&jobs).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to find ingest job")
+		span.RecordError(err)
+
+		return nil, fmt.Errorf("failed to find ingest job: %w", err)
+	}
+
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+
+	return &jobs[0], nil
+}
+
+// ListIngestJobs fetches the most recent ingest jobs, newest first
+func (db DBInstance) ListIngestJobs(ctx context.Context, limit int) ([]IngestJob, error) {
+	ctx, span := tracer.Start(ctx, "ListIngestJobs")
+	defer span.End()
+
+	var jobs []IngestJob
+
+	err := db.DB.WithContext(ctx).
+		Order("created DESC").
+		Limit(limit).
+		Find(&jobs).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to list ingest jobs")
+		span.RecordError(err)
+
+		return nil, fmt.Errorf("failed to list ingest jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// GetWebhookSubscription fetches a webhook subscription by its ID
+func (db DBInstance) GetWebhookSubscription(ctx context.Context, id string) (*WebhookSubscription, error) {
+	ctx, span := tracer.Start(ctx, "GetWebhookSubscription")
+	defer span.End()
+
+	var subscription WebhookSubscription
+
+	err := db.DB.WithContext(ctx).Where("id = ?", id).First(&subscription).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to fetch webhook subscription")
+		span.RecordError(err)
+
+		return nil, fmt.Errorf("failed to get webhook subscription %s: %w", id, err)
+	}
+
+	return &subscription, nil
+}
+
+// ListWebhookSubscriptions fetches every webhook subscription, oldest first
+func (db DBInstance) ListWebhookSubscriptions(ctx context.Context) ([]WebhookSubscription, error) {
+	ctx, span := tracer.Start(ctx, "ListWebhookSubscriptions")
+	defer span.End()
+
+	var subscriptions []WebhookSubscription
+
+	err := db.DB.WithContext(ctx).Order("created").Find(&subscriptions).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to list webhook subscriptions")
+		span.RecordError(err)
+
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// ListDueWebhookDeliveries fetches the deliveries whose next attempt is due at now, most overdue first,
+// together with their subscription
+func (db DBInstance) ListDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "ListDueWebhookDeliveries")
+	defer span.End()
+
+	var deliveries []WebhookDelivery
+
+	err := db.DB.WithContext(ctx).
+		Preload("Subscription").
+		Where("next_attempt_at <= ?", now.UTC()).
+		Order("next_attempt_at").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to list due webhook deliveries")
+		span.RecordError(err)
+
+		return nil, fmt.Errorf("failed to list due webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// ListWebhookDeadLetters fetches the most recent webhook dead letters, newest first
+func (db DBInstance) ListWebhookDeadLetters(ctx context.Context, limit int) ([]WebhookDeadLetter, error) {
+	ctx, span := tracer.Start(ctx, "ListWebhookDeadLetters")
+	defer span.End()
+
+	var deadLetters []WebhookDeadLetter
+
+	err := db.DB.WithContext(ctx).
+		Order("created DESC").
+		Limit(limit).
+		Find(&deadLetters).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to list webhook dead letters")
+		span.RecordError(err)
+
+		return nil, fmt.Errorf("failed to list webhook dead letters: %w", err)
+	}
+
+	return deadLetters, nil
+}
+
+// GetEvent fetches an event along with its markets and their selections, in the order they were created
+func (db DBInstance) GetEvent(ctx context.Context, id string) (*Event, error) {
+	ctx, span := tracer.Start(ctx, "GetEvent")
+	defer span.End()
+
+	var event Event
+
+	err := db.DB.WithContext(ctx).
+		Preload("Markets", func(tx *gorm.DB) *gorm.DB { return tx.Order("created") }).
+		Preload("Markets.Selections", func(tx *gorm.DB) *gorm.DB { return tx.Order("created") }).
+		Where("id = ?", id).
+		First(&event).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to fetch event")
+		span.RecordError(err)
+
+		return nil, fmt.Errorf("failed to get event %s: %w", id, err)
+	}
+
+	return &event, nil
+}
+
+// ListSelectionResults fetches every result submitted for a selection, newest first
+func (db DBInstance) ListSelectionResults(ctx context.Context, selectionID string) ([]SelectionResult, error) {
+	ctx, span := tracer.Start(ctx, "ListSelectionResults")
+	defer span.End()
+
+	var results []SelectionResult
+
+	err := db.DB.WithContext(ctx).
+		Where("selection_id = ?", selectionID).
+		Order("created DESC").
+		Find(&results).Error
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to list selection results")
+		span.RecordError(err)
+
+		return nil, fmt.Errorf("failed to list results of selection %s: %w", selectionID, err)
+	}
+
+	return results, nil
+}
@@ -2,10 +2,22 @@ package gorm_test
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"testing"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/database/postgres/gorm"
+	"github.com/brianvoe/gofakeit"
 )
 
 func TestDBInstance_GetTotalBets(t *testing.T) {
+	if err := prepareTestDatabase(); err != nil {
+		t.Fatalf("failed to prepare test database: %v", err)
+	}
+
 	type args struct {
 		ctx    context.Context
 		userID string
@@ -71,6 +83,10 @@ func TestDBInstance_GetTotalBets(t *testing.T) {
 }
 
 func TestDBInstance_GetTotalWinnings(t *testing.T) {
+	if err := prepareTestDatabase(); err != nil {
+		t.Fatalf("failed to prepare test database: %v", err)
+	}
+
 	type args struct {
 		ctx    context.Context
 		userID string
@@ -79,7 +95,7 @@ func TestDBInstance_GetTotalWinnings(t *testing.T) {
 	tests := []struct {
 		name    string
 		args    args
-		want    float64
+		want    []gorm.User
 		wantErr bool
 	}{
 		{
@@ -88,7 +104,7 @@ func TestDBInstance_GetTotalWinnings(t *testing.T) {
 				ctx:    context.Background(),
 				userID: userID,
 			},
-			want:    float64(169.00),
+			want:    []gorm.User{{UserID: userID, Currency: "USD", TotalWinnings: 1_690_000}},
 			wantErr: false,
 		},
 		{
@@ -97,7 +113,7 @@ func TestDBInstance_GetTotalWinnings(t *testing.T) {
 				ctx:    context.Background(),
 				userID: userID6,
 			},
-			want:    0,
+			want:    []gorm.User{{UserID: userID6, Currency: "USD"}},
 			wantErr: false,
 		},
 	}
@@ -109,7 +125,7 @@ func TestDBInstance_GetTotalWinnings(t *testing.T) {
 				return
 			}
 
-			if got != tt.want {
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
 				t.Errorf("DBInstance.GetTotalWinnings() = %v, want %v", got, tt.want)
 			}
 		})
@@ -117,6 +133,10 @@ func TestDBInstance_GetTotalWinnings(t *testing.T) {
 }
 
 func TestDBInstance_GetTopUsers(t *testing.T) {
+	if err := prepareTestDatabase(); err != nil {
+		t.Fatalf("failed to prepare test database: %v", err)
+	}
+
 	type args struct {
 		ctx   context.Context
 		limit int
@@ -173,6 +193,10 @@ func TestDBInstance_GetTopUsers(t *testing.T) {
 }
 
 func TestDBInstance_GetAnomalousUsers(t *testing.T) {
+	if err := prepareTestDatabase(); err != nil {
+		t.Fatalf("failed to prepare test database: %v", err)
+	}
+
 	type args struct {
 		ctx context.Context
 	}
@@ -207,3 +231,860 @@ func TestDBInstance_GetAnomalousUsers(t *testing.T) {
 		})
 	}
 }
+
+func TestDBInstance_StreamBets(t *testing.T) {
+	if err := prepareTestDatabase(); err != nil {
+		t.Fatalf("failed to prepare test database: %v", err)
+	}
+
+	after := time.Date(2024, 11, 23, 0, 0, 0, 0, time.UTC)
+
+	type args struct {
+		ctx    context.Context
+		filter domain.BetFilter
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "success: stream all bets",
+			args: args{
+				ctx: context.Background(),
+			},
+			want:    18,
+			wantErr: false,
+		},
+		{
+			name: "success: stream a user's bets",
+			args: args{
+				ctx:    context.Background(),
+				filter: domain.BetFilter{UserID: userID2},
+			},
+			want:    7,
+			wantErr: false,
+		},
+		{
+			name: "success: no bets in time range",
+			args: args{
+				ctx:    context.Background(),
+				filter: domain.BetFilter{From: &after},
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "success: stream a user's lost bets",
+			args: args{
+				ctx:    context.Background(),
+				filter: domain.BetFilter{UserID: userID2, Outcome: enums.Lose},
+			},
+			want:    5,
+			wantErr: false,
+		},
+		{
+			name: "success: no bets on the event",
+			args: args{
+				ctx:    context.Background(),
+				filter: domain.BetFilter{EventID: "ars-che"},
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "fail: callback error stops the stream",
+			args: args{
+				ctx: context.Background(),
+			},
+			want:    1,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := 0
+
+			err := testingDB.StreamBets(tt.args.ctx, tt.args.filter, func(_ *gorm.Bet) error {
+				got++
+
+				if tt.wantErr {
+					return fmt.Errorf("error")
+				}
+
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DBInstance.StreamBets() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("DBInstance.StreamBets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDBInstance_FindIngestJob(t *testing.T) {
+	tests := []struct {
+		name        string
+		jobs        []gorm.IngestJob
+		size        int64
+		wantStatus  string
+		wantRecords int64
+		wantNil     bool
+	}{
+		{
+			name: "success: job with the most committed records",
+			jobs: []gorm.IngestJob{
+				{Status: "failed", Records: 5000},
+				{Status: "failed", Records: 0},
+			},
+			size:        100,
+			wantStatus:  "failed",
+			wantRecords: 5000,
+		},
+		{
+			name: "success: completed job wins",
+			jobs: []gorm.IngestJob{
+				{Status: "running", Records: 5000},
+				{Status: "completed", Records: 3000},
+			},
+			size:        100,
+			wantStatus:  "completed",
+			wantRecords: 3000,
+		},
+		{
+			name: "success: same content with another size was never imported",
+			jobs: []gorm.IngestJob{
+				{Status: "completed", Records: 3000},
+			},
+			size:    101,
+			wantNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash := fmt.Sprintf("%x", time.Now().UnixNano())
+
+			for _, job := range tt.jobs {
+				job.Path, job.Size, job.Hash, job.Format = "bets.ndjson", 100, hash, "ndjson"
+
+				if err := testingDB.CreateIngestJob(context.Background(), &job); err != nil {
+					t.Fatalf("failed to create ingest job: %v", err)
+				}
+			}
+
+			got, err := testingDB.FindIngestJob(context.Background(), hash, tt.size)
+			if err != nil {
+				t.Fatalf("DBInstance.FindIngestJob() error = %v", err)
+			}
+
+			if (got == nil) != tt.wantNil {
+				t.Fatalf("DBInstance.FindIngestJob() = %v, wantNil %v", got, tt.wantNil)
+			}
+
+			if got != nil && (got.Status != tt.wantStatus || got.Records != tt.wantRecords) {
+				t.Errorf("DBInstance.FindIngestJob() = %s with %d records, want %s with %d",
+					got.Status, got.Records, tt.wantStatus, tt.wantRecords)
+			}
+		})
+	}
+}
+
+func TestDBInstance_ListIngestJobs(t *testing.T) {
+	for range 3 {
+		job := &gorm.IngestJob{Path: "bets.ndjson", Size: 100, Hash: "list", Format: "ndjson", Status: "completed"}
+		if err := testingDB.CreateIngestJob(context.Background(), job); err != nil {
+			t.Fatalf("failed to create ingest job: %v", err)
+		}
+	}
+
+	got, err := testingDB.ListIngestJobs(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("DBInstance.ListIngestJobs() error = %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("DBInstance.ListIngestJobs() returned %d jobs, want 2", len(got))
+	}
+
+	if got[0].CreatedAt.Before(got[1].CreatedAt) {
+		t.Errorf("DBInstance.ListIngestJobs() is not ordered newest first")
+	}
+}
+
+func TestDBInstance_GetUserLosses(t *testing.T) {
+	if err := prepareTestDatabase(); err != nil {
+		t.Fatalf("failed to prepare test database: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		userIDs []string
+		want    map[string]int64
+		wantErr bool
+	}{
+		{
+			name:    "success: sum the amount of lost bets",
+			userIDs: []string{userID, "no-bets"},
+			want:    map[string]int64{userID: 2_000_000},
+		},
+		{
+			name:    "success: no users",
+			userIDs: []string{},
+			want:    map[string]int64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetUserLosses(context.Background(), tt.userIDs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DBInstance.GetUserLosses() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			losses := map[string]int64{}
+			for _, user := range got {
+				losses[user.UserID] = user.TotalLosses
+			}
+
+			if fmt.Sprint(losses) != fmt.Sprint(tt.want) {
+				t.Errorf("DBInstance.GetUserLosses() = %v, want %v", losses, tt.want)
+			}
+		})
+	}
+}
+
+func TestDBInstance_ListDueWebhookDeliveries(t *testing.T) {
+	subscription := &gorm.WebhookSubscription{URL: "http://localhost:9000/hooks", Secret: "s3cret", Events: "user.loss_limit_crossed"}
+	if err := testingDB.CreateWebhookSubscription(context.Background(), subscription); err != nil {
+		t.Fatalf("failed to create webhook subscription: %v", err)
+	}
+
+	now := time.Now()
+	alertID := "due-deliveries-alert"
+
+	alert := &gorm.Alert{
+		AbstractBase: gorm.AbstractBase{ID: &alertID},
+		Event:        "user.loss_limit_crossed",
+		DedupeKey:    "user.loss_limit_crossed:due-deliveries",
+		UserID:       userID,
+		Value:        600,
+		Threshold:    500,
+	}
+
+	dueID, laterID := "due-delivery", "later-delivery"
+
+	deliveries := []gorm.WebhookDelivery{
+		{
+			AbstractBase:   gorm.AbstractBase{ID: &dueID},
+			SubscriptionID: *subscription.ID,
+			AlertID:        alertID,
+			Event:          alert.Event,
+			Payload:        "{}",
+			NextAttemptAt:  now.Add(-time.Minute).UTC(),
+		},
+		{
+			AbstractBase:   gorm.AbstractBase{ID: &laterID},
+			SubscriptionID: *subscription.ID,
+			AlertID:        alertID,
+			Event:          alert.Event,
+			Payload:        "{}",
+			NextAttemptAt:  now.Add(time.Hour).UTC(),
+		},
+	}
+
+	if _, err := testingDB.RecordAlert(context.Background(), alert, deliveries); err != nil {
+		t.Fatalf("failed to record alert: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		want    []string
+		notWant []string
+		wantErr bool
+	}{
+		{
+			name:    "success: only due deliveries are listed",
+			now:     now,
+			want:    []string{dueID},
+			notWant: []string{laterID},
+		},
+		{
+			name: "success: every delivery is due later",
+			now:  now.Add(2 * time.Hour),
+			want: []string{dueID, laterID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.ListDueWebhookDeliveries(context.Background(), tt.now, 100)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DBInstance.ListDueWebhookDeliveries() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			listed := map[string]gorm.WebhookDelivery{}
+			for _, delivery := range got {
+				listed[*delivery.ID] = delivery
+			}
+
+			for _, id := range tt.want {
+				delivery, ok := listed[id]
+				if !ok {
+					t.Errorf("delivery %s not listed", id)
+					continue
+				}
+
+				if delivery.Subscription == nil || delivery.Subscription.URL != subscription.URL {
+					t.Errorf("delivery %s subscription = %v, want %v", id, delivery.Subscription, subscription)
+				}
+			}
+
+			for _, id := range tt.notWant {
+				if _, ok := listed[id]; ok {
+					t.Errorf("delivery %s listed before it is due", id)
+				}
+			}
+		})
+	}
+}
+
+func TestDBInstance_GetUserTotals(t *testing.T) {
+	if err := prepareTestDatabase(); err != nil {
+		t.Fatalf("failed to prepare test database: %v", err)
+	}
+
+	// a user whose bets went through every state of their lifecycle
+	lifecycleUserID := "lifecycle-user"
+
+	err := testingDB.StoreBetData(context.Background(), []gorm.Bet{
+		{BetID: "lifecycle-win", UserID: lifecycleUserID, Amount: 10, Currency: "USD", Odds: 2, Outcome: "win", Payout: 20, Timestamp: time.Now()},
+		{BetID: "lifecycle-lose", UserID: lifecycleUserID, Amount: 10, Currency: "USD", Odds: 2, Outcome: "lose", Timestamp: time.Now()},
+		{BetID: "lifecycle-half-win", UserID: lifecycleUserID, Amount: 10, Currency: "USD", Odds: 2, Outcome: "half_win", Payout: 15, Timestamp: time.Now()},
+		{BetID: "lifecycle-half-lose", UserID: lifecycleUserID, Amount: 10, Currency: "USD", Odds: 2, Outcome: "half_lose", Payout: 5, Timestamp: time.Now()},
+		{BetID: "lifecycle-void", UserID: lifecycleUserID, Amount: 10, Currency: "USD", Odds: 2, Outcome: "void", Payout: 10, Timestamp: time.Now()},
+		{BetID: "lifecycle-push", UserID: lifecycleUserID, Amount: 10, Currency: "USD", Odds: 2, Outcome: "push", Payout: 10, Timestamp: time.Now()},
+		{BetID: "lifecycle-cashout", UserID: lifecycleUserID, Amount: 10, Currency: "USD", Odds: 2, Outcome: "cashout", Payout: 4, Timestamp: time.Now()},
+		{BetID: "lifecycle-pending", UserID: lifecycleUserID, Amount: 10, Currency: "USD", Odds: 2, Outcome: "pending", Timestamp: time.Now()},
+		{BetID: "currencies-usd", UserID: "currencies-user", Amount: 10, Currency: "USD", Odds: 2, Outcome: "win", Payout: 20, Timestamp: time.Now()},
+		{BetID: "currencies-kes", UserID: "currencies-user", Amount: 1000, Currency: "KES", Odds: 2, Outcome: "lose", Timestamp: time.Now()},
+	})
+	if err != nil {
+		t.Fatalf("failed to store lifecycle bets: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		userIDs []string
+		want    map[string]gorm.User
+		wantErr bool
+	}{
+		{
+			name:    "success: totals of the users that placed bets",
+			userIDs: []string{userID, "no-bets"},
+			want: map[string]gorm.User{
+				userID + ":USD": {
+					UserID: userID, Currency: "USD", TotalBets: 4, TotalWinnings: 1_690_000, TotalLosses: 2_000_000,
+					WonBets: 2, DecidedBets: 4,
+				},
+			},
+		},
+		{
+			name:    "success: stakes, payouts and win rate follow the state of each bet",
+			userIDs: []string{lifecycleUserID},
+			want: map[string]gorm.User{
+				lifecycleUserID + ":USD": {
+					UserID: lifecycleUserID, Currency: "USD", TotalBets: 8, TotalWinnings: 15, TotalLosses: 21, TotalPayout: 64,
+					// 1.5 of the 3 decided stakes were won
+					WonBets: 1.5, DecidedBets: 3,
+				},
+			},
+		},
+		{
+			name:    "success: totals kept apart for every currency",
+			userIDs: []string{"currencies-user"},
+			want: map[string]gorm.User{
+				"currencies-user:KES": {UserID: "currencies-user", Currency: "KES", TotalBets: 1, TotalLosses: 1000, DecidedBets: 1},
+				"currencies-user:USD": {
+					UserID: "currencies-user", Currency: "USD", TotalBets: 1, TotalWinnings: 10, TotalPayout: 20,
+					WonBets: 1, DecidedBets: 1,
+				},
+			},
+		},
+		{
+			name:    "success: no users",
+			userIDs: []string{},
+			want:    map[string]gorm.User{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetUserTotals(context.Background(), tt.userIDs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DBInstance.GetUserTotals() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			totals := map[string]gorm.User{}
+			for _, user := range got {
+				totals[user.UserID+":"+user.Currency] = user
+			}
+
+			if fmt.Sprint(totals) != fmt.Sprint(tt.want) {
+				t.Errorf("DBInstance.GetUserTotals() = %v, want %v", totals, tt.want)
+			}
+		})
+	}
+}
+
+func TestDBInstance_GetBreakdown(t *testing.T) {
+	if err := prepareTestDatabase(); err != nil {
+		t.Fatalf("failed to prepare test database: %v", err)
+	}
+
+	breakdownUserID := "breakdown-user"
+	placed := time.Date(2024, 11, 22, 12, 0, 0, 0, time.UTC)
+
+	err := testingDB.StoreBetData(context.Background(), []gorm.Bet{
+		{
+			BetID: "breakdown-1", UserID: breakdownUserID, Amount: 10, Currency: "USD", Odds: 2, Outcome: "win", Payout: 20,
+			Timestamp: placed, Sport: "football", EventID: "ars-che", Market: "result",
+		},
+		{
+			BetID: "breakdown-2", UserID: breakdownUserID, Amount: 30, Currency: "USD", Odds: 2, Outcome: "lose",
+			Timestamp: placed, Sport: "football", EventID: "ars-che", Market: "goals",
+		},
+		{
+			BetID: "breakdown-3", UserID: breakdownUserID, Amount: 5, Currency: "USD", Odds: 2, Outcome: "pending",
+			Timestamp: placed.Add(time.Hour), Sport: "football", EventID: "liv-mun", Market: "result",
+		},
+		{
+			BetID: "breakdown-4", UserID: breakdownUserID, Amount: 1000, Currency: "KES", Odds: 2, Outcome: "lose",
+			Timestamp: placed, Sport: "football", EventID: "ars-che", Market: "result",
+		},
+		{
+			BetID: "breakdown-5", UserID: breakdownUserID, Amount: 20, Currency: "USD", Odds: 2, Outcome: "void", Payout: 20,
+			Timestamp: placed,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to store breakdown bets: %v", err)
+	}
+
+	to := placed.Add(time.Minute)
+
+	tests := []struct {
+		name       string
+		dimensions []enums.Dimension
+		filter     domain.BetFilter
+		want       []gorm.Breakdown
+		wantErr    bool
+	}{
+		{
+			name:       "success: grouped by event and currency",
+			dimensions: []enums.Dimension{enums.EventID},
+			filter:     domain.BetFilter{UserID: breakdownUserID},
+			want: []gorm.Breakdown{
+				{Currency: "USD", Bets: 1, Volume: 20, SettledStake: 20, Payout: 20},
+				{EventID: "ars-che", Currency: "KES", Bets: 1, Volume: 1000, SettledStake: 1000},
+				{EventID: "ars-che", Currency: "USD", Bets: 2, Volume: 40, SettledStake: 40, Payout: 20},
+				{EventID: "liv-mun", Currency: "USD", Bets: 1, Volume: 5},
+			},
+		},
+		{
+			name:       "success: grouped by several dimensions within a time range",
+			dimensions: []enums.Dimension{enums.Sport, enums.Market},
+			filter:     domain.BetFilter{UserID: breakdownUserID, To: &to},
+			want: []gorm.Breakdown{
+				{Currency: "USD", Bets: 1, Volume: 20, SettledStake: 20, Payout: 20},
+				{Sport: "football", Market: "goals", Currency: "USD", Bets: 1, Volume: 30, SettledStake: 30},
+				{Sport: "football", Market: "result", Currency: "KES", Bets: 1, Volume: 1000, SettledStake: 1000},
+				{Sport: "football", Market: "result", Currency: "USD", Bets: 1, Volume: 10, SettledStake: 10, Payout: 20},
+			},
+		},
+		{
+			name:       "fail: unknown dimension",
+			dimensions: []enums.Dimension{"user_id"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetBreakdown(context.Background(), tt.dimensions, tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DBInstance.GetBreakdown() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("DBInstance.GetBreakdown() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDBInstance_GetRecentBets(t *testing.T) {
+	if err := prepareTestDatabase(); err != nil {
+		t.Fatalf("failed to prepare test database: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		userIDs []string
+		limit   int
+		want    map[string]int
+		wantErr bool
+	}{
+		{
+			name:    "success: at most limit bets per user",
+			userIDs: []string{userID, userID2, userID3},
+			limit:   3,
+			want:    map[string]int{userID: 3, userID2: 3, userID3: 2},
+		},
+		{
+			name:    "success: no bets for unknown users",
+			userIDs: []string{"no-bets"},
+			limit:   3,
+			want:    map[string]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetRecentBets(context.Background(), tt.userIDs, tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DBInstance.GetRecentBets() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			counts := map[string]int{}
+
+			for i, bet := range got {
+				counts[bet.UserID]++
+
+				if i > 0 && got[i-1].UserID == bet.UserID && got[i-1].Timestamp.Before(bet.Timestamp) {
+					t.Errorf("DBInstance.GetRecentBets() bets of %s are not newest first", bet.UserID)
+				}
+			}
+
+			if fmt.Sprint(counts) != fmt.Sprint(tt.want) {
+				t.Errorf("DBInstance.GetRecentBets() counts = %v, want %v", counts, tt.want)
+			}
+		})
+	}
+}
+
+func TestDBInstance_GetLimitTotals(t *testing.T) {
+	now := time.Now().UTC()
+	userID, otherUserID := gofakeit.UUID(), gofakeit.UUID()
+
+	err := testingDB.StoreBetData(context.Background(), []gorm.Bet{
+		{BetID: gofakeit.UUID(), UserID: userID, Amount: 10, Currency: "USD", Odds: 2, Outcome: "lose", Timestamp: now.Add(-time.Hour)},
+		{BetID: gofakeit.UUID(), UserID: userID, Amount: 20, Currency: "USD", Odds: 2, Outcome: "half_lose", Payout: 10, Timestamp: now.Add(-3 * 24 * time.Hour)},
+		{BetID: gofakeit.UUID(), UserID: userID, Amount: 40, Currency: "USD", Odds: 2, Outcome: "win", Payout: 80, Timestamp: now.Add(-10 * 24 * time.Hour)},
+		{BetID: gofakeit.UUID(), UserID: userID, Amount: 80, Currency: "USD", Odds: 2, Outcome: "lose", Timestamp: now.Add(-40 * 24 * time.Hour)},
+		{BetID: gofakeit.UUID(), UserID: userID, Amount: 1000, Currency: "KES", Odds: 2, Outcome: "pending", Timestamp: now.Add(-time.Minute)},
+		{BetID: gofakeit.UUID(), UserID: otherUserID, Amount: 5, Currency: "USD", Odds: 2, Outcome: "lose", Timestamp: now.Add(-time.Minute)},
+	})
+	if err != nil {
+		t.Fatalf("failed to store bets: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		userIDs []string
+		want    []gorm.LimitTotals
+		wantErr bool
+	}{
+		{
+			name:    "success: totals of every period by currency",
+			userIDs: []string{userID, "no-bets"},
+			want: []gorm.LimitTotals{
+				{UserID: userID, Currency: "KES", DayStake: 1000, DayBets: 1, WeekStake: 1000, WeekBets: 1, MonthStake: 1000, MonthBets: 1},
+				{
+					UserID: userID, Currency: "USD", DayLoss: 10, DayStake: 10, DayBets: 1, WeekLoss: 20, WeekStake: 30, WeekBets: 2,
+					MonthLoss: 20, MonthStake: 70, MonthBets: 3,
+				},
+			},
+		},
+		{
+			name:    "success: no users",
+			userIDs: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetLimitTotals(context.Background(), tt.userIDs, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DBInstance.GetLimitTotals() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("DBInstance.GetLimitTotals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDBInstance_GetLimitBets(t *testing.T) {
+	now := time.Now().UTC()
+	userID := gofakeit.UUID()
+
+	var bets []gorm.Bet
+
+	// a bet every 10 days, then every hour over the last 5 hours
+	for _, age := range []time.Duration{40 * 24 * time.Hour, 20 * 24 * time.Hour, 10 * 24 * time.Hour, 5 * time.Hour, 4 * time.Hour, 3 * time.Hour, 2 * time.Hour, time.Hour} {
+		bets = append(bets, gorm.Bet{
+			BetID: gofakeit.UUID(), UserID: userID, Amount: 10, Currency: "USD", Odds: 2, Outcome: "pending", Timestamp: now.Add(-age),
+		})
+	}
+
+	if err := testingDB.StoreBetData(context.Background(), bets); err != nil {
+		t.Fatalf("failed to store bets: %v", err)
+	}
+
+	from := now.Add(-30 * 24 * time.Hour)
+	since := now.Add(-150 * time.Minute)
+
+	tests := []struct {
+		name   string
+		since  *time.Time
+		latest int
+		want   int
+	}{
+		{
+			name:   "success: latest bets of the month",
+			latest: 7,
+			want:   7,
+		},
+		{
+			name:  "success: bets placed since a time",
+			since: &since,
+			want:  2,
+		},
+		{
+			name:   "success: latest bets along with those placed since a time",
+			since:  &since,
+			latest: 3,
+			want:   3,
+		},
+		{
+			name: "success: nothing asked for",
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetLimitBets(context.Background(), []string{userID}, from, tt.since, tt.latest)
+			if err != nil {
+				t.Fatalf("DBInstance.GetLimitBets() error = %v", err)
+			}
+
+			if len(got) != tt.want {
+				t.Fatalf("DBInstance.GetLimitBets() returned %d bets, want %d", len(got), tt.want)
+			}
+
+			for i := 1; i < len(got); i++ {
+				if got[i].Timestamp.Before(got[i-1].Timestamp) {
+					t.Errorf("DBInstance.GetLimitBets() bets are not oldest first")
+				}
+			}
+
+			if len(got) > 0 && got[len(got)-1].Timestamp.Before(since) {
+				t.Errorf("DBInstance.GetLimitBets() does not end with the latest bet")
+			}
+		})
+	}
+}
+
+func TestDBInstance_ListSelfExclusions(t *testing.T) {
+	userID, otherUserID := gofakeit.UUID(), gofakeit.UUID()
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(30 * 24 * time.Hour)
+
+	for _, exclusion := range []*gorm.SelfExclusion{
+		{UserID: userID, StartsAt: end, Reason: "again"},
+		{UserID: userID, StartsAt: start, EndsAt: &end, Reason: "taking a break"},
+		{UserID: otherUserID, StartsAt: start, Reason: "taking a break"},
+	} {
+		if err := testingDB.CreateSelfExclusion(context.Background(), exclusion); err != nil {
+			t.Fatalf("failed to create self-exclusion: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		userIDs []string
+		want    int
+	}{
+		{
+			name:    "success: self-exclusions of a user, earliest first",
+			userIDs: []string{userID},
+			want:    2,
+		},
+		{
+			name:    "success: self-exclusions of several users",
+			userIDs: []string{userID, otherUserID},
+			want:    3,
+		},
+		{
+			name:    "success: user without self-exclusions",
+			userIDs: []string{gofakeit.UUID()},
+			want:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.ListSelfExclusions(context.Background(), tt.userIDs)
+			if err != nil {
+				t.Fatalf("DBInstance.ListSelfExclusions() error = %v", err)
+			}
+
+			if len(got) != tt.want {
+				t.Fatalf("DBInstance.ListSelfExclusions() returned %d self-exclusions, want %d", len(got), tt.want)
+			}
+
+			if len(got) > 1 && got[0].UserID == userID && (!got[0].StartsAt.Equal(start) || got[0].EndsAt == nil || !got[0].EndsAt.Equal(end)) {
+				t.Errorf("DBInstance.ListSelfExclusions() first = %+v, want the one starting %v and ending %v", got[0], start, end)
+			}
+		})
+	}
+}
+
+func TestDBInstance_ListAlertsByEvent(t *testing.T) {
+	userID := gofakeit.UUID()
+
+	for _, event := range []string{"aml.large_transaction", "aml.structuring", "aml.structuring", "bet.large"} {
+		alert := &gorm.Alert{Event: event, DedupeKey: event + ":" + gofakeit.UUID(), UserID: userID, Value: 12000, Evidence: gofakeit.UUID()}
+		if _, err := testingDB.RecordAlert(context.Background(), alert, nil); err != nil {
+			t.Fatalf("failed to record alert: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		events []string
+		limit  int
+		want   int
+	}{
+		{
+			name:   "success: alerts of a single event",
+			events: []string{"aml.structuring"},
+			limit:  50,
+			want:   2,
+		},
+		{
+			name:   "success: alerts of several events up to the limit",
+			events: []string{"aml.large_transaction", "aml.structuring"},
+			limit:  2,
+			want:   2,
+		},
+		{
+			name:   "success: event without alerts",
+			events: []string{"user.limit_breached"},
+			limit:  50,
+			want:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.ListAlertsByEvent(context.Background(), tt.events, tt.limit)
+			if err != nil {
+				t.Fatalf("DBInstance.ListAlertsByEvent() error = %v", err)
+			}
+
+			var matching int
+
+			for _, alert := range got {
+				if alert.UserID == userID && slices.Contains(tt.events, alert.Event) && alert.Evidence != "" {
+					matching++
+				}
+			}
+
+			if matching != tt.want {
+				t.Errorf("DBInstance.ListAlertsByEvent() returned %d alerts of the user, want %d", matching, tt.want)
+			}
+		})
+	}
+}
+
+func TestDBInstance_ListUserAlertsSince(t *testing.T) {
+	userID, otherUserID := gofakeit.UUID(), gofakeit.UUID()
+	since := time.Now().Add(-time.Minute)
+
+	for _, alert := range []*gorm.Alert{
+		{Event: "aml.structuring", UserID: userID, Evidence: "b1,b2,b3"},
+		{Event: "aml.structuring", UserID: otherUserID, Evidence: "b4,b5,b6"},
+		{Event: "aml.large_transaction", UserID: userID, Evidence: "b7"},
+	} {
+		alert.DedupeKey = alert.Event + ":" + gofakeit.UUID()
+		alert.Value = 28500
+
+		if _, err := testingDB.RecordAlert(context.Background(), alert, nil); err != nil {
+			t.Fatalf("failed to record alert: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		event   string
+		userIDs []string
+		since   time.Time
+		want    []string
+	}{
+		{
+			name:    "success: alerts of an event raised for the users",
+			event:   "aml.structuring",
+			userIDs: []string{userID, otherUserID},
+			since:   since,
+			want:    []string{"b1,b2,b3", "b4,b5,b6"},
+		},
+		{
+			name:    "success: alerts of another user left out",
+			event:   "aml.structuring",
+			userIDs: []string{userID},
+			since:   since,
+			want:    []string{"b1,b2,b3"},
+		},
+		{
+			name:    "success: alerts raised before the time left out",
+			event:   "aml.structuring",
+			userIDs: []string{userID},
+			since:   time.Now().Add(time.Minute),
+			want:    []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.ListUserAlertsSince(context.Background(), tt.event, tt.userIDs, tt.since)
+			if err != nil {
+				t.Fatalf("DBInstance.ListUserAlertsSince() error = %v", err)
+			}
+
+			evidence := []string{}
+			for _, alert := range got {
+				evidence = append(evidence, alert.Evidence)
+			}
+
+			if !slices.Equal(evidence, tt.want) {
+				t.Errorf("DBInstance.ListUserAlertsSince() = %v, want %v", evidence, tt.want)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/database/postgres/gorm"
 )
 
@@ -11,19 +13,62 @@ import (
 type Cache interface {
 	Get(ctx context.Context, key string, valueType interface{}) (interface{}, error)
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
 }
 
 // Query holds the method signatures used to query the database
 type Query interface {
 	GetTotalBets(ctx context.Context, userID string) (int64, error)
-	GetTotalWinnings(ctx context.Context, userID string) (float64, error)
+	GetTotalWinnings(ctx context.Context, userID string) ([]gorm.User, error)
 	GetTopUsers(ctx context.Context, limit int) ([]gorm.User, error)
 	GetAnomalousUsers(ctx context.Context) ([]gorm.User, error)
+	GetBet(ctx context.Context, betID string) (*gorm.Bet, error)
+	StreamBets(ctx context.Context, filter domain.BetFilter, fn func(bet *gorm.Bet) error) error
+	GetEvent(ctx context.Context, id string) (*gorm.Event, error)
+	ListSelectionResults(ctx context.Context, selectionID string) ([]gorm.SelectionResult, error)
+	GetIngestJob(ctx context.Context, id string) (*gorm.IngestJob, error)
+	FindIngestJob(ctx context.Context, hash string, size int64) (*gorm.IngestJob, error)
+	ListIngestJobs(ctx context.Context, limit int) ([]gorm.IngestJob, error)
+	GetUserLosses(ctx context.Context, userIDs []string) ([]gorm.User, error)
+	GetUserTotals(ctx context.Context, userIDs []string) ([]gorm.User, error)
+	GetBreakdown(ctx context.Context, dimensions []enums.Dimension, filter domain.BetFilter) ([]gorm.Breakdown, error)
+	GetRecentBets(ctx context.Context, userIDs []string, limit int) ([]gorm.Bet, error)
+	GetLimitTotals(ctx context.Context, userIDs []string, now time.Time) ([]gorm.LimitTotals, error)
+	GetLimitBets(ctx context.Context, userIDs []string, from time.Time, since *time.Time, latest int) ([]gorm.Bet, error)
+	GetRecentAlerts(ctx context.Context, userIDs []string, limit int) ([]gorm.Alert, error)
+	ListAlerts(ctx context.Context, limit int) ([]gorm.Alert, error)
+	ListAlertsByEvent(ctx context.Context, events []string, limit int) ([]gorm.Alert, error)
+	ListUserAlertsSince(ctx context.Context, event string, userIDs []string, since time.Time) ([]gorm.Alert, error)
+	ListUserLimits(ctx context.Context, userIDs []string) ([]gorm.UserLimits, error)
+	GetSelfExclusion(ctx context.Context, id string) (*gorm.SelfExclusion, error)
+	ListSelfExclusions(ctx context.Context, userIDs []string) ([]gorm.SelfExclusion, error)
+	ListExclusionViolations(ctx context.Context, userID string, limit int) ([]gorm.ExclusionViolation, error)
+	GetWebhookSubscription(ctx context.Context, id string) (*gorm.WebhookSubscription, error)
+	ListWebhookSubscriptions(ctx context.Context) ([]gorm.WebhookSubscription, error)
+	ListDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]gorm.WebhookDelivery, error)
+	ListWebhookDeadLetters(ctx context.Context, limit int) ([]gorm.WebhookDeadLetter, error)
 }
 
 // Create contains the method signatures used to create a new record in the database
 type Create interface {
 	StoreBetData(ctx context.Context, bet []gorm.Bet) error
+	StoreNewBetData(ctx context.Context, bets []gorm.Bet) (int64, error)
+	SettleBet(ctx context.Context, bet *gorm.Bet) error
+	SaveEvent(ctx context.Context, event *gorm.Event) error
+	SaveUserLimits(ctx context.Context, limits *gorm.UserLimits) error
+	CreateSelfExclusion(ctx context.Context, exclusion *gorm.SelfExclusion) error
+	LiftSelfExclusion(ctx context.Context, exclusion *gorm.SelfExclusion) error
+	RecordExclusionViolations(ctx context.Context, violations []gorm.ExclusionViolation) error
+	SettleSelection(ctx context.Context, result *gorm.SelectionResult, settle func(bet *gorm.Bet) (bool, error)) error
+	CreateIngestJob(ctx context.Context, job *gorm.IngestJob) error
+	UpdateIngestJob(ctx context.Context, job *gorm.IngestJob) error
+	CommitIngestBatch(ctx context.Context, job *gorm.IngestJob, bets []gorm.Bet, violations []gorm.ExclusionViolation) error
+	CreateWebhookSubscription(ctx context.Context, subscription *gorm.WebhookSubscription) error
+	DeleteWebhookSubscription(ctx context.Context, id string) error
+	RecordAlert(ctx context.Context, alert *gorm.Alert, deliveries []gorm.WebhookDelivery) (bool, error)
+	UpdateWebhookDelivery(ctx context.Context, delivery *gorm.WebhookDelivery) error
+	DeleteWebhookDelivery(ctx context.Context, id string) error
+	DeadLetterWebhookDelivery(ctx context.Context, deadLetter *gorm.WebhookDeadLetter) error
 }
 
 // MaybetsDB struct implements the service's business specific calls to the database
@@ -31,13 +76,16 @@ type MaybetsDB struct {
 	cache  Cache
 	query  Query
 	create Create
+	// exchange converts the totals kept per currency into the reporting currency
+	exchange *domain.Exchange
 }
 
 // NewMaybetsDB initializes a new instance of the MaybetsDB struct
-func NewMaybetsDB(c Cache, q Query, cr Create) *MaybetsDB {
+func NewMaybetsDB(c Cache, q Query, cr Create, exchange *domain.Exchange) *MaybetsDB {
 	return &MaybetsDB{
-		cache:  c,
-		query:  q,
-		create: cr,
+		cache:    c,
+		query:    q,
+		create:   cr,
+		exchange: exchange,
 	}
 }
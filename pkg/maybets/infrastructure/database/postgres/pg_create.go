@@ -2,23 +2,518 @@ package postgres
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"strings"
 
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/database/postgres/gorm"
-	"github.com/mitchellh/mapstructure"
 )
 
+// StoreBetData stores bets, recording the ones that do not name a currency in the default currency
 func (db MaybetsDB) StoreBetData(ctx context.Context, bets []*domain.Bet) error {
-	var betData []gorm.Bet
+	if err := db.denominate(bets); err != nil {
+		return err
+	}
+
+	err := db.create.StoreBetData(ctx, toGormBets(bets))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// StoreNewBets stores the bets that are not stored yet, skipping the bets whose bet_id is, and returns the number stored
+func (db MaybetsDB) StoreNewBets(ctx context.Context, bets []*domain.Bet) (int64, error) {
+	if err := db.denominate(bets); err != nil {
+		return 0, err
+	}
+
+	return db.create.StoreNewBetData(ctx, toGormBets(bets))
+}
+
+// CreateIngestJob stores a new ingest job and fills in its ID and timestamps
+func (db MaybetsDB) CreateIngestJob(ctx context.Context, job *domain.IngestJob) error {
+	record := toGormIngestJob(job)
+
+	if err := db.create.CreateIngestJob(ctx, record); err != nil {
+		return err
+	}
+
+	*job = *toDomainIngestJob(record)
+
+	return nil
+}
+
+// UpdateIngestJob saves the status, error and progress of an ingest job
+func (db MaybetsDB) UpdateIngestJob(ctx context.Context, job *domain.IngestJob) error {
+	return db.create.UpdateIngestJob(ctx, toGormIngestJob(job))
+}
 
-	if err := mapstructure.Decode(bets, &betData); err != nil {
+// CommitIngestBatch stores a batch of bets and the exclusion violations found in it together with the job checkpoint
+// that follows them
+func (db MaybetsDB) CommitIngestBatch(
+	ctx context.Context, job *domain.IngestJob, bets []*domain.Bet, violations []domain.ExclusionViolation,
+) error {
+	if err := db.denominate(bets); err != nil {
 		return err
 	}
 
-	err := db.create.StoreBetData(ctx, betData)
+	return db.create.CommitIngestBatch(ctx, toGormIngestJob(job), toGormBets(bets), toGormExclusionViolations(violations))
+}
+
+// denominate records the bets that do not name a currency in the default currency and rejects the bets
+// in currencies that cannot be converted into the reporting currency
+func (db MaybetsDB) denominate(bets []*domain.Bet) error {
+	for _, bet := range bets {
+		if bet.Currency == "" {
+			bet.Currency = db.exchange.Default
+		}
+
+		if !db.exchange.Accepts(bet.Currency) {
+			return fmt.Errorf("%w %s: unsupported currency %q: no exchange rate is configured for it", domain.ErrInvalidBet, bet.BetID, bet.Currency)
+		}
+
+		if err := bet.ValidateCurrency(); err != nil {
+			return fmt.Errorf("%w %s: %w", domain.ErrInvalidBet, bet.BetID, err)
+		}
+	}
+
+	return nil
+}
+
+// SettleBet saves the new state of a bet, recording who settled it
+func (db MaybetsDB) SettleBet(ctx context.Context, bet *domain.Bet, settledBy string) error {
+	record := toGormBet(bet)
+	record.UpdatedBy = &settledBy
+
+	if err := db.create.SettleBet(ctx, &record); err != nil {
+		return err
+	}
+
+	db.dropCachedWinnings(ctx, []string{bet.UserID})
+
+	return nil
+}
+
+// SaveEvent stores an event with its markets and selections, or adds to the event when it exists,
+// and fills in the IDs that were generated
+func (db MaybetsDB) SaveEvent(ctx context.Context, event *domain.Event) error {
+	record := toGormEvent(event)
+
+	if err := db.create.SaveEvent(ctx, record); err != nil {
+		return err
+	}
+
+	event.ID = *record.ID
+
+	for i, market := range record.Markets {
+		event.Markets[i].ID = *market.ID
+
+		for j, selection := range market.Selections {
+			event.Markets[i].Selections[j].ID = *selection.ID
+		}
+	}
+
+	return nil
+}
+
+// SettleSelection records a result submitted for a selection and saves the bets settle changed with it, in a single transaction.
+// The previous result of the selection is set on the result before settle is called and the summary once the bets are saved,
+// with the stakes and payouts of the settled bets added up in the reporting currency.
+// The cached winnings of the owners of the settled bets are dropped.
+func (db MaybetsDB) SettleSelection(ctx context.Context, result *domain.SelectionResult, settle func(bet *domain.Bet) (bool, error)) error {
+	record := &gorm.SelectionResult{
+		AbstractBase: gorm.AbstractBase{CreatedBy: &result.SubmittedBy},
+		SelectionID:  result.SelectionID,
+		Result:       result.Result.String(),
+		Currency:     string(db.exchange.Reporting),
+	}
+
+	var userIDs []string
+
+	stakes, payouts := map[domain.Currency]domain.Money{}, map[domain.Currency]domain.Money{}
+
+	err := db.create.SettleSelection(ctx, record, func(bet *gorm.Bet) (bool, error) {
+		result.PreviousResult = ""
+		if record.PreviousResult != nil {
+			result.PreviousResult = enums.Outcome(*record.PreviousResult)
+		}
+
+		mapped := toDomainBet(bet)
+
+		changed, err := settle(mapped)
+		if err != nil || !changed {
+			return false, err
+		}
+
+		*bet = toGormBet(mapped)
+		userIDs = append(userIDs, mapped.UserID)
+
+		stakes[mapped.Currency] += mapped.Amount
+		payouts[mapped.Currency] += mapped.Payout()
+
+		stake, err := db.exchange.Total(stakes)
+		if err != nil {
+			return false, fmt.Errorf("bet %s: %w", mapped.BetID, err)
+		}
+
+		payout, err := db.exchange.Total(payouts)
+		if err != nil {
+			return false, fmt.Errorf("bet %s: %w", mapped.BetID, err)
+		}
+
+		record.Stake, record.Payout = int64(stake), int64(payout)
+
+		return true, nil
+	})
 	if err != nil {
 		return err
 	}
 
+	*result = *toDomainSelectionResult(record)
+
+	db.dropCachedWinnings(ctx, userIDs)
+
+	return nil
+}
+
+// dropCachedWinnings removes the cached winnings of users whose bets were settled.
+// Failures are only logged since the cached values expire on their own.
+func (db MaybetsDB) dropCachedWinnings(ctx context.Context, userIDs []string) {
+	if len(userIDs) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		keys = append(keys, winningsCacheKey(userID))
+	}
+
+	if err := db.cache.Delete(ctx, keys...); err != nil {
+		slog.WarnContext(ctx, "failed to drop cached winnings", "users", len(userIDs), "error", err)
+	}
+}
+
+func toGormEvent(event *domain.Event) *gorm.Event {
+	record := &gorm.Event{
+		Name:     event.Name,
+		StartsAt: event.StartsAt,
+	}
+
+	if event.ID != "" {
+		record.ID = &event.ID
+	}
+
+	for i, market := range event.Markets {
+		mappedMarket := gorm.Market{Name: market.Name}
+
+		if market.ID != "" {
+			mappedMarket.ID = &event.Markets[i].ID
+		}
+
+		for j, selection := range market.Selections {
+			mappedSelection := gorm.Selection{Name: selection.Name}
+
+			if selection.ID != "" {
+				mappedSelection.ID = &event.Markets[i].Selections[j].ID
+			}
+
+			mappedMarket.Selections = append(mappedMarket.Selections, mappedSelection)
+		}
+
+		record.Markets = append(record.Markets, mappedMarket)
+	}
+
+	return record
+}
+
+func toGormBets(bets []*domain.Bet) []gorm.Bet {
+	records := make([]gorm.Bet, 0, len(bets))
+
+	for _, bet := range bets {
+		records = append(records, toGormBet(bet))
+	}
+
+	return records
+}
+
+func toGormBet(bet *domain.Bet) gorm.Bet {
+	record := gorm.Bet{
+		BetID:       bet.BetID,
+		UserID:      bet.UserID,
+		Amount:      int64(bet.Amount),
+		Currency:    string(bet.Currency),
+		Odds:        bet.Odds,
+		OddsFormat:  bet.OddsFormat.String(),
+		QuotedOdds:  bet.QuotedOdds,
+		Outcome:     bet.Outcome.String(),
+		Timestamp:   bet.Timestamp,
+		Sport:       bet.Sport,
+		Competition: bet.Competition,
+		EventID:     bet.EventID,
+		Market:      bet.Market,
+		Selection:   bet.Selection,
+		Payout:      int64(bet.Payout()),
+	}
+
+	for i, leg := range bet.Legs {
+		record.Legs = append(record.Legs, gorm.BetLeg{
+			BetID:     bet.BetID,
+			Position:  i + 1,
+			Selection: leg.Selection,
+			Odds:      leg.Odds,
+			Outcome:   leg.Outcome.String(),
+		})
+	}
+
+	return record
+}
+
+func toGormIngestJob(job *domain.IngestJob) *gorm.IngestJob {
+	record := &gorm.IngestJob{
+		AbstractBase: gorm.AbstractBase{
+			CreatedAt: job.CreatedAt,
+			UpdatedAt: job.UpdatedAt,
+		},
+		Path:        job.Path,
+		Size:        job.Size,
+		Hash:        job.Hash,
+		Format:      job.Format.String(),
+		Status:      job.Status.String(),
+		Offset:      job.Offset,
+		Records:     job.Records,
+		Error:       job.Error,
+		CompletedAt: job.CompletedAt,
+	}
+
+	if job.ID != "" {
+		record.ID = &job.ID
+	}
+
+	return record
+}
+
+// CreateWebhookSubscription stores a new webhook subscription and fills in its ID and timestamps
+func (db MaybetsDB) CreateWebhookSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	record := toGormWebhookSubscription(subscription)
+
+	if err := db.create.CreateWebhookSubscription(ctx, record); err != nil {
+		return err
+	}
+
+	*subscription = *toDomainWebhookSubscription(record)
+
+	return nil
+}
+
+// DeleteWebhookSubscription deletes a webhook subscription along with its pending deliveries
+func (db MaybetsDB) DeleteWebhookSubscription(ctx context.Context, id string) error {
+	return db.create.DeleteWebhookSubscription(ctx, id)
+}
+
+// SaveUserLimits stores the caps of a user over a period and sets when they were saved
+func (db MaybetsDB) SaveUserLimits(ctx context.Context, limits *domain.Limits) error {
+	record := &gorm.UserLimits{
+		UserID:   limits.UserID,
+		Period:   limits.Period.String(),
+		LossCap:  int64(limits.LossCap),
+		StakeCap: int64(limits.StakeCap),
+		BetCap:   limits.BetCap,
+	}
+
+	if err := db.create.SaveUserLimits(ctx, record); err != nil {
+		return err
+	}
+
+	limits.UpdatedAt = record.UpdatedAt
+
 	return nil
 }
+
+// CreateSelfExclusion stores a new self-exclusion and fills in its ID and when it was created
+func (db MaybetsDB) CreateSelfExclusion(ctx context.Context, exclusion *domain.Exclusion) error {
+	record := toGormSelfExclusion(exclusion)
+
+	if err := db.create.CreateSelfExclusion(ctx, record); err != nil {
+		return err
+	}
+
+	exclusion.ID = *record.ID
+	exclusion.CreatedAt = record.CreatedAt
+
+	return nil
+}
+
+// LiftSelfExclusion saves when a self-exclusion was lifted and by whom
+func (db MaybetsDB) LiftSelfExclusion(ctx context.Context, exclusion *domain.Exclusion) error {
+	record := toGormSelfExclusion(exclusion)
+
+	var liftedBy *string
+	if exclusion.LiftedBy != "" {
+		liftedBy = &exclusion.LiftedBy
+	}
+
+	record.LiftedBy = liftedBy
+	record.UpdatedBy = liftedBy
+
+	return db.create.LiftSelfExclusion(ctx, record)
+}
+
+// RecordExclusionViolations stores the bets received from self-excluded users, skipping those already recorded
+// for the same exclusion
+func (db MaybetsDB) RecordExclusionViolations(ctx context.Context, violations []domain.ExclusionViolation) error {
+	return db.create.RecordExclusionViolations(ctx, toGormExclusionViolations(violations))
+}
+
+// toGormExclusionViolations converts exclusion violations into their records
+func toGormExclusionViolations(violations []domain.ExclusionViolation) []gorm.ExclusionViolation {
+	records := make([]gorm.ExclusionViolation, 0, len(violations))
+
+	for _, violation := range violations {
+		record := gorm.ExclusionViolation{
+			ExclusionID: violation.ExclusionID,
+			UserID:      violation.UserID,
+			BetID:       violation.BetID,
+			Amount:      int64(violation.Amount),
+			Currency:    string(violation.Currency),
+			PlacedAt:    violation.PlacedAt.UTC(),
+			Action:      violation.Action.String(),
+		}
+
+		if violation.ID != "" {
+			record.ID = &violation.ID
+		}
+
+		records = append(records, record)
+	}
+
+	return records
+}
+
+// toGormSelfExclusion maps a self-exclusion, storing its times in UTC
+func toGormSelfExclusion(exclusion *domain.Exclusion) *gorm.SelfExclusion {
+	record := &gorm.SelfExclusion{
+		AbstractBase: gorm.AbstractBase{
+			CreatedAt: exclusion.CreatedAt,
+		},
+		UserID:   exclusion.UserID,
+		StartsAt: exclusion.StartsAt.UTC(),
+		Reason:   exclusion.Reason,
+	}
+
+	if exclusion.ID != "" {
+		record.ID = &exclusion.ID
+	}
+
+	if exclusion.EndsAt != nil {
+		endsAt := exclusion.EndsAt.UTC()
+		record.EndsAt = &endsAt
+	}
+
+	if exclusion.LiftedAt != nil {
+		liftedAt := exclusion.LiftedAt.UTC()
+		record.LiftedAt = &liftedAt
+	}
+
+	return record
+}
+
+// RecordAlert stores an alert with its deliveries unless it was already raised, and reports whether it is new
+func (db MaybetsDB) RecordAlert(ctx context.Context, alert *domain.Alert, deliveries []domain.WebhookDelivery) (bool, error) {
+	record := &gorm.Alert{
+		Event:     alert.Event.String(),
+		DedupeKey: alert.Key(),
+		UserID:    alert.UserID,
+		BetID:     alert.BetID,
+		Value:     alert.Value,
+		Threshold: alert.Threshold,
+		LimitKind: alert.Limit.String(),
+		Period:    alert.Period.String(),
+		Evidence:  strings.Join(alert.Evidence, ","),
+	}
+
+	if alert.ID != "" {
+		record.ID = &alert.ID
+	}
+
+	records := make([]gorm.WebhookDelivery, 0, len(deliveries))
+	for i := range deliveries {
+		records = append(records, *toGormWebhookDelivery(&deliveries[i]))
+	}
+
+	return db.create.RecordAlert(ctx, record, records)
+}
+
+// UpdateWebhookDelivery saves the outcome of a failed attempt and when the next one is due
+func (db MaybetsDB) UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return db.create.UpdateWebhookDelivery(ctx, toGormWebhookDelivery(delivery))
+}
+
+// DeleteWebhookDelivery deletes a delivery once it succeeded
+func (db MaybetsDB) DeleteWebhookDelivery(ctx context.Context, id string) error {
+	return db.create.DeleteWebhookDelivery(ctx, id)
+}
+
+// DeadLetterWebhookDelivery moves a delivery that failed its last attempt to the dead letters
+func (db MaybetsDB) DeadLetterWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return db.create.DeadLetterWebhookDelivery(ctx, &gorm.WebhookDeadLetter{
+		DeliveryID:     delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		URL:            delivery.URL,
+		AlertID:        delivery.AlertID,
+		Event:          delivery.Event.String(),
+		Payload:        string(delivery.Payload),
+		Attempts:       delivery.Attempts,
+		LastStatus:     delivery.LastStatus,
+		LastError:      delivery.LastError,
+	})
+}
+
+func toGormWebhookSubscription(subscription *domain.WebhookSubscription) *gorm.WebhookSubscription {
+	events := make([]string, 0, len(subscription.Events))
+	for _, event := range subscription.Events {
+		events = append(events, event.String())
+	}
+
+	record := &gorm.WebhookSubscription{
+		AbstractBase: gorm.AbstractBase{
+			CreatedAt: subscription.CreatedAt,
+			UpdatedAt: subscription.UpdatedAt,
+		},
+		URL:    subscription.URL,
+		Secret: subscription.Secret,
+		Events: strings.Join(events, ","),
+	}
+
+	if subscription.ID != "" {
+		record.ID = &subscription.ID
+	}
+
+	return record
+}
+
+// toGormWebhookDelivery maps a delivery, storing its next attempt time in UTC so that due deliveries can be compared as text
+func toGormWebhookDelivery(delivery *domain.WebhookDelivery) *gorm.WebhookDelivery {
+	record := &gorm.WebhookDelivery{
+		AbstractBase: gorm.AbstractBase{
+			CreatedAt: delivery.CreatedAt,
+		},
+		SubscriptionID: delivery.SubscriptionID,
+		AlertID:        delivery.AlertID,
+		Event:          delivery.Event.String(),
+		Payload:        string(delivery.Payload),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt.UTC(),
+		LastStatus:     delivery.LastStatus,
+		LastError:      delivery.LastError,
+	}
+
+	if delivery.ID != "" {
+		record.ID = &delivery.ID
+	}
+
+	return record
+}
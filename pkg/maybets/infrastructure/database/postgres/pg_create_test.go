@@ -2,7 +2,9 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/database/postgres/gorm"
 	gormMock "github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/database/postgres/gorm/mock"
 	"github.com/brianvoe/gofakeit"
+	"github.com/google/uuid"
 )
 
 func TestMaybetsDB_StoreBetData(t *testing.T) {
@@ -30,7 +33,7 @@ func TestMaybetsDB_StoreBetData(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				bets: []*domain.Bet{
-					{BetID: gofakeit.UUID(), UserID: gofakeit.UUID(), Amount: 198, Odds: 3.2, Outcome: enums.Win, Timestamp: time.Now()},
+					{BetID: gofakeit.UUID(), UserID: gofakeit.UUID(), Amount: 1_980_000, Odds: 3.2, Outcome: enums.Win, Timestamp: time.Now()},
 				},
 			},
 			wantErr: false,
@@ -40,7 +43,7 @@ func TestMaybetsDB_StoreBetData(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				bets: []*domain.Bet{
-					{BetID: gofakeit.UUID(), UserID: gofakeit.UUID(), Amount: 198, Odds: 3.2, Outcome: enums.Win, Timestamp: time.Now()},
+					{BetID: gofakeit.UUID(), UserID: gofakeit.UUID(), Amount: 1_980_000, Odds: 3.2, Outcome: enums.Win, Timestamp: time.Now()},
 				},
 			},
 			wantErr: true,
@@ -51,7 +54,19 @@ func TestMaybetsDB_StoreBetData(t *testing.T) {
 			fakeGorm := gormMock.NewGormMock()
 			fakeCache := cacheMock.NewStoreCacheMock()
 
-			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm)
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
+
+			if tt.name == "success: store bets in db" {
+				fakeGorm.MockStoreBetDataFn = func(_ context.Context, bets []gorm.Bet) error {
+					for i, bet := range bets {
+						if want := tt.args.bets[i]; bet.BetID != want.BetID || !bet.Timestamp.Equal(want.Timestamp) {
+							t.Errorf("MaybetsDB.StoreBetData() stored %+v, want %+v", bet, want)
+						}
+					}
+
+					return nil
+				}
+			}
 
 			if tt.name == "sad: unable to store bets in db" {
 				fakeGorm.MockStoreBetDataFn = func(_ context.Context, _ []gorm.Bet) error {
@@ -65,3 +80,515 @@ func TestMaybetsDB_StoreBetData(t *testing.T) {
 		})
 	}
 }
+
+func TestMaybetsDB_StoreNewBets(t *testing.T) {
+	type args struct {
+		ctx  context.Context
+		bets []*domain.Bet
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "success: skip bets that are already stored",
+			args: args{
+				ctx: context.Background(),
+				bets: []*domain.Bet{
+					{BetID: gofakeit.UUID(), UserID: gofakeit.UUID(), Amount: 1_980_000, Odds: 3.2, Outcome: enums.Win, Timestamp: time.Now()},
+					{BetID: gofakeit.UUID(), UserID: gofakeit.UUID(), Amount: 500, Odds: 1.2, Outcome: enums.Lose, Timestamp: time.Now()},
+				},
+			},
+			want: 1,
+		},
+		{
+			name: "fail: bet in a currency without a rate",
+			args: args{
+				ctx: context.Background(),
+				bets: []*domain.Bet{
+					{BetID: gofakeit.UUID(), UserID: gofakeit.UUID(), Amount: 500, Currency: "JPY", Odds: 1.2, Outcome: enums.Lose, Timestamp: time.Now()},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad: unable to store bets in db",
+			args: args{
+				ctx: context.Background(),
+				bets: []*domain.Bet{
+					{BetID: gofakeit.UUID(), UserID: gofakeit.UUID(), Amount: 1_980_000, Odds: 3.2, Outcome: enums.Win, Timestamp: time.Now()},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			fakeCache := cacheMock.NewStoreCacheMock()
+
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
+
+			if tt.name == "success: skip bets that are already stored" {
+				fakeGorm.MockStoreNewBetDataFn = func(_ context.Context, bets []gorm.Bet) (int64, error) {
+					return int64(len(bets) - 1), nil
+				}
+			}
+
+			if tt.name == "sad: unable to store bets in db" {
+				fakeGorm.MockStoreNewBetDataFn = func(_ context.Context, _ []gorm.Bet) (int64, error) {
+					return 0, fmt.Errorf("error")
+				}
+			}
+
+			got, err := db.StoreNewBets(tt.args.ctx, tt.args.bets)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MaybetsDB.StoreNewBets() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if invalid := tt.name == "fail: bet in a currency without a rate"; errors.Is(err, domain.ErrInvalidBet) != invalid {
+				t.Errorf("MaybetsDB.StoreNewBets() error = %v, want it marked as an invalid bet: %v", err, invalid)
+			}
+
+			if got != tt.want {
+				t.Errorf("MaybetsDB.StoreNewBets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaybetsDB_SettleBet(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		bet       *domain.Bet
+		settledBy string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success: settle a cashed out bet",
+			args: args{
+				ctx:       context.Background(),
+				bet:       &domain.Bet{BetID: gofakeit.UUID(), Amount: 100_000, Currency: "USD", Odds: 3, Outcome: enums.Cashout, Cashout: 120_000},
+				settledBy: "trader-1",
+			},
+			wantErr: false,
+		},
+		{
+			name: "sad: unable to settle bet",
+			args: args{
+				ctx:       context.Background(),
+				bet:       &domain.Bet{BetID: gofakeit.UUID(), Amount: 100_000, Currency: "USD", Odds: 3, Outcome: enums.Win},
+				settledBy: "trader-1",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			fakeCache := cacheMock.NewStoreCacheMock()
+
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
+
+			if tt.name == "success: settle a cashed out bet" {
+				fakeGorm.MockSettleBetFn = func(_ context.Context, bet *gorm.Bet) error {
+					if bet.Outcome != "cashout" || bet.Payout != 120_000 || bet.UpdatedBy == nil || *bet.UpdatedBy != tt.args.settledBy {
+						t.Errorf("MaybetsDB.SettleBet() saved %+v, want the cashout as payout, settled by %s", bet, tt.args.settledBy)
+					}
+
+					return nil
+				}
+			}
+
+			if tt.name == "sad: unable to settle bet" {
+				fakeGorm.MockSettleBetFn = func(_ context.Context, _ *gorm.Bet) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			if err := db.SettleBet(tt.args.ctx, tt.args.bet, tt.args.settledBy); (err != nil) != tt.wantErr {
+				t.Errorf("MaybetsDB.SettleBet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMaybetsDB_SaveEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   *domain.Event
+		wantErr bool
+	}{
+		{
+			name: "success: save an event and fill in its IDs",
+			event: &domain.Event{Name: "ARS-CHE", StartsAt: time.Now(), Markets: []domain.Market{
+				{Name: "Match result", Selections: []domain.Selection{{ID: "ars-che-home", Name: "Home"}, {Name: "Away"}}},
+			}},
+		},
+		{
+			name:    "sad: unable to save event",
+			event:   &domain.Event{Name: "ARS-CHE", StartsAt: time.Now()},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			fakeCache := cacheMock.NewStoreCacheMock()
+
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
+
+			if tt.wantErr {
+				fakeGorm.MockSaveEventFn = func(_ context.Context, _ *gorm.Event) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			err := db.SaveEvent(context.Background(), tt.event)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MaybetsDB.SaveEvent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			selections := tt.event.Markets[0].Selections
+			if tt.event.ID == "" || tt.event.Markets[0].ID == "" || selections[0].ID != "ars-che-home" || selections[1].ID == "" {
+				t.Errorf("MaybetsDB.SaveEvent() = %+v, want every ID filled in and the given ones kept", tt.event)
+			}
+		})
+	}
+}
+
+func TestMaybetsDB_SettleSelection(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name: "success: settle a selection and drop the cached winnings",
+		},
+		{
+			name:    "sad: unable to settle selection",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			fakeCache := cacheMock.NewStoreCacheMock()
+
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
+
+			previous := "lose"
+			userID := uuid.NewString()
+
+			fakeGorm.MockSettleSelectionFn = func(_ context.Context, result *gorm.SelectionResult, settle func(bet *gorm.Bet) (bool, error)) error {
+				if tt.wantErr {
+					return fmt.Errorf("error")
+				}
+
+				result.PreviousResult = &previous
+				bet := &gorm.Bet{BetID: "b1", UserID: userID, Amount: 100_000, Currency: "USD", Odds: 2, Outcome: "lose", Selection: result.SelectionID}
+
+				changed, err := settle(bet)
+				if err != nil || !changed || bet.Outcome != "win" || bet.Payout != 200_000 {
+					return fmt.Errorf("settle() = %v, %v leaving %+v, want the bet won", changed, err, bet)
+				}
+
+				id := uuid.NewString()
+				result.ID, result.BetsSettled, result.Stake, result.Payout = &id, 1, bet.Amount, bet.Payout
+
+				return nil
+			}
+
+			var dropped []string
+
+			fakeCache.MockDeleteFn = func(_ context.Context, keys ...string) error {
+				dropped = append(dropped, keys...)
+				return nil
+			}
+
+			result := &domain.SelectionResult{SelectionID: "s1", Result: enums.Win, SubmittedBy: "feed"}
+
+			err := db.SettleSelection(context.Background(), result, func(bet *domain.Bet) (bool, error) {
+				return bet.ApplyResult(result.SelectionID, result.Result, result.PreviousResult)
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MaybetsDB.SettleSelection() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if result.PreviousResult != enums.Lose || result.BetsSettled != 1 || result.Payout != 200_000 || result.SubmittedBy != "feed" {
+				t.Errorf("MaybetsDB.SettleSelection() summary = %+v, want one bet resettled from lose", result)
+			}
+
+			if len(dropped) != 1 || dropped[0] != winningsCacheKey(userID) {
+				t.Errorf("MaybetsDB.SettleSelection() dropped %v, want the winnings of %s", dropped, userID)
+			}
+		})
+	}
+}
+
+func TestMaybetsDB_CommitIngestBatch(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		job        *domain.IngestJob
+		bets       []*domain.Bet
+		violations []domain.ExclusionViolation
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success: commit batch with checkpoint",
+			args: args{
+				ctx: context.Background(),
+				job: &domain.IngestJob{ID: gofakeit.UUID(), Status: enums.IngestRunning, Offset: 1024, Records: 1},
+				bets: []*domain.Bet{
+					{BetID: gofakeit.UUID(), UserID: gofakeit.UUID(), Amount: 1_980_000, Odds: 3.2, Outcome: enums.Win, Timestamp: time.Now()},
+				},
+				violations: []domain.ExclusionViolation{
+					{ExclusionID: gofakeit.UUID(), UserID: gofakeit.UUID(), BetID: gofakeit.UUID(), Amount: 10_000, Currency: "USD", PlacedAt: time.Now(), Action: enums.Reject},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "sad: unable to commit batch",
+			args: args{
+				ctx: context.Background(),
+				job: &domain.IngestJob{ID: gofakeit.UUID(), Status: enums.IngestRunning, Offset: 1024, Records: 1},
+				bets: []*domain.Bet{
+					{BetID: gofakeit.UUID(), UserID: gofakeit.UUID(), Amount: 1_980_000, Odds: 3.2, Outcome: enums.Win, Timestamp: time.Now()},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			fakeCache := cacheMock.NewStoreCacheMock()
+
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
+
+			fakeGorm.MockCommitIngestBatchFn = func(
+				_ context.Context, job *gorm.IngestJob, bets []gorm.Bet, violations []gorm.ExclusionViolation,
+			) error {
+				if tt.name == "sad: unable to commit batch" {
+					return fmt.Errorf("error")
+				}
+
+				if *job.ID != tt.args.job.ID || job.Offset != tt.args.job.Offset || len(bets) != len(tt.args.bets) ||
+					len(violations) != len(tt.args.violations) {
+					return fmt.Errorf("unexpected checkpoint %+v with %d bets and %d violations", job, len(bets), len(violations))
+				}
+
+				return nil
+			}
+
+			err := db.CommitIngestBatch(tt.args.ctx, tt.args.job, tt.args.bets, tt.args.violations)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MaybetsDB.CommitIngestBatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMaybetsDB_CreateIngestJob(t *testing.T) {
+	tests := []struct {
+		name    string
+		job     *domain.IngestJob
+		wantErr bool
+	}{
+		{
+			name: "success: create ingest job",
+			job:  &domain.IngestJob{Path: "bets.ndjson", Size: 10, Hash: "hash", Format: enums.NDJSON, Status: enums.IngestRunning},
+		},
+		{
+			name:    "sad: unable to create ingest job",
+			job:     &domain.IngestJob{Path: "bets.ndjson", Size: 10, Hash: "hash", Format: enums.NDJSON, Status: enums.IngestRunning},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			fakeCache := cacheMock.NewStoreCacheMock()
+
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
+
+			if tt.name == "sad: unable to create ingest job" {
+				fakeGorm.MockCreateIngestJobFn = func(_ context.Context, _ *gorm.IngestJob) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			err := db.CreateIngestJob(context.Background(), tt.job)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MaybetsDB.CreateIngestJob() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && tt.job.ID == "" {
+				t.Errorf("MaybetsDB.CreateIngestJob() did not set the job ID")
+			}
+		})
+	}
+}
+
+func TestMaybetsDB_RecordAlert(t *testing.T) {
+	tests := []struct {
+		name    string
+		alert   *domain.Alert
+		wantKey string
+		wantNew bool
+		wantErr bool
+	}{
+		{
+			name:    "success: record large bet alert",
+			alert:   &domain.Alert{ID: "a1", Event: enums.LargeBet, UserID: "u1", BetID: "b1", Value: 5000, Threshold: 1000},
+			wantKey: "bet.large:b1",
+			wantNew: true,
+		},
+		{
+			name:    "success: record loss limit alert",
+			alert:   &domain.Alert{ID: "a2", Event: enums.LossLimitCrossed, UserID: "u1", Value: 5200, Threshold: 5000},
+			wantKey: "user.loss_limit_crossed:u1",
+			wantNew: true,
+		},
+		{
+			name: "success: record responsible gambling limit alert",
+			alert: &domain.Alert{
+				ID: "a4", Event: enums.LimitBreached, UserID: "u1", Limit: enums.StakeCap, Period: enums.Day, Value: 120, Threshold: 100,
+				CreatedAt: time.Date(2026, 10, 19, 23, 30, 0, 0, time.FixedZone("EAT", 3*60*60)),
+			},
+			wantKey: "user.limit_breached:u1:stake_cap:day:2026-10-19",
+			wantNew: true,
+		},
+		{
+			name: "success: record structuring alert with its evidence",
+			alert: &domain.Alert{
+				ID: "a5", Event: enums.AMLStructuring, UserID: "u1", BetID: "b1", Value: 28500, Threshold: 10000, Evidence: []string{"b1", "b2", "b3"},
+			},
+			wantKey: "aml.structuring:u1:b1",
+			wantNew: true,
+		},
+		{
+			name:    "sad: unable to record alert",
+			alert:   &domain.Alert{ID: "a3", Event: enums.AnomalyDetected, UserID: "u1", Value: 40},
+			wantKey: "anomaly.detected:u1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			fakeCache := cacheMock.NewStoreCacheMock()
+
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
+
+			fakeGorm.MockRecordAlertFn = func(_ context.Context, alert *gorm.Alert, deliveries []gorm.WebhookDelivery) (bool, error) {
+				if tt.name == "sad: unable to record alert" {
+					return false, fmt.Errorf("error")
+				}
+
+				if alert.DedupeKey != tt.wantKey || *alert.ID != tt.alert.ID || len(deliveries) != 1 ||
+					alert.Evidence != strings.Join(tt.alert.Evidence, ",") {
+					return false, fmt.Errorf("unexpected alert %+v with %d deliveries", alert, len(deliveries))
+				}
+
+				return true, nil
+			}
+
+			deliveries := []domain.WebhookDelivery{
+				{SubscriptionID: "s1", AlertID: tt.alert.ID, Event: tt.alert.Event, Payload: []byte(`{}`), NextAttemptAt: time.Now()},
+			}
+
+			got, err := db.RecordAlert(context.Background(), tt.alert, deliveries)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MaybetsDB.RecordAlert() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.wantNew {
+				t.Errorf("MaybetsDB.RecordAlert() = %v, want %v", got, tt.wantNew)
+			}
+		})
+	}
+}
+
+func TestMaybetsDB_RecordExclusionViolations(t *testing.T) {
+	placedAt := time.Date(2026, 10, 19, 9, 0, 0, 0, time.FixedZone("EAT", 3*60*60))
+
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name: "success: record the bets of a self-excluded user",
+		},
+		{
+			name:    "sad: unable to record exclusion violations",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			fakeCache := cacheMock.NewStoreCacheMock()
+
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
+
+			var recorded []gorm.ExclusionViolation
+
+			fakeGorm.MockRecordExclusionViolationsFn = func(_ context.Context, violations []gorm.ExclusionViolation) error {
+				if tt.wantErr {
+					return fmt.Errorf("error")
+				}
+
+				recorded = violations
+
+				return nil
+			}
+
+			err := db.RecordExclusionViolations(context.Background(), []domain.ExclusionViolation{
+				{ExclusionID: "e1", UserID: "u1", BetID: "b1", Amount: domain.MoneyFromFloat(50), Currency: "KES", PlacedAt: placedAt, Action: enums.Flag},
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MaybetsDB.RecordExclusionViolations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			want := gorm.ExclusionViolation{
+				ExclusionID: "e1", UserID: "u1", BetID: "b1", Amount: 500_000, Currency: "KES", PlacedAt: placedAt.UTC(), Action: "flag",
+			}
+			if len(recorded) != 1 || recorded[0] != want {
+				t.Errorf("MaybetsDB.RecordExclusionViolations() recorded %+v, want %+v", recorded, want)
+			}
+		})
+	}
+}
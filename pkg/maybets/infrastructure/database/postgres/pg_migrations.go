@@ -3,7 +3,6 @@ package postgres
 import (
 	"errors"
 	"fmt"
-	"os"
 
 	"github.com/golang-migrate/migrate/v4"
 
@@ -14,29 +13,103 @@ import (
 	assets "github.com/KathurimaKimathi/maybets/db"
 )
 
-// RunMigrations applies all pending migrations
-func RunMigrations() error {
+// Migrator manages the schema of a SQLite database using the embedded migrations
+type Migrator struct {
+	m *migrate.Migrate
+}
+
+// NewMigrator initializes a new Migrator for the SQLite database at dbPath.
+// Make sure to call Close once done.
+func NewMigrator(dbPath string) (*Migrator, error) {
 	driver, err := iofs.New(assets.DBMigrations, "migrations")
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	path := os.Getenv("SQLITE_URL")
-	if path == "" {
-		path = "../../../../../.."
+	m, err := migrate.NewWithSourceInstance("iofs", driver, "sqlite3://"+dbPath)
+	if err != nil {
+		return nil, err
 	}
 
-	dbPath := fmt.Sprintf("%s/bets.db", path)
+	return &Migrator{m: m}, nil
+}
 
-	m, err := migrate.NewWithSourceInstance("iofs", driver, "sqlite3://"+dbPath)
-	if err != nil {
-		return err
+// Up applies all pending migrations
+func (mg *Migrator) Up() error {
+	err := mg.m.Up()
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+
+	return nil
+}
+
+// Down rolls back the given number of applied migrations
+func (mg *Migrator) Down(steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("invalid number of steps %d: must be at least 1", steps)
 	}
 
-	err = m.Up()
+	err := mg.m.Steps(-steps)
 	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
-		return err
+		return fmt.Errorf("failed to roll back migrations: %w", err)
+	}
+
+	return nil
+}
+
+// Goto migrates up or down to the given version
+func (mg *Migrator) Goto(version uint) error {
+	err := mg.m.Migrate(version)
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to migrate to version %d: %w", version, err)
 	}
 
 	return nil
 }
+
+// Version returns the currently applied version and whether the last migration failed halfway.
+// A version of 0 means no migration has been applied.
+func (mg *Migrator) Version() (uint, bool, error) {
+	version, dirty, err := mg.m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read migration version: %w", err)
+	}
+
+	return version, dirty, nil
+}
+
+// Force sets the migration version without running any migration and clears the dirty flag.
+// It is used to recover after a migration failed halfway and the schema was fixed by hand.
+func (mg *Migrator) Force(version int) error {
+	if err := mg.m.Force(version); err != nil {
+		return fmt.Errorf("failed to force version %d: %w", version, err)
+	}
+
+	return nil
+}
+
+// Close releases the database and source connections
+func (mg *Migrator) Close() error {
+	sourceErr, databaseErr := mg.m.Close()
+
+	return errors.Join(sourceErr, databaseErr)
+}
+
+// RunMigrations applies all pending migrations to the SQLite database at dbPath
+func RunMigrations(dbPath string) (err error) {
+	migrator, err := NewMigrator(dbPath)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = errors.Join(err, migrator.Close())
+	}()
+
+	return migrator.Up()
+}
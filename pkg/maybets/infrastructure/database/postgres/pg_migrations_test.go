@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/database/postgres/gorm"
+)
+
+func TestMigrator_UpDownUp(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "bets.db")
+
+	db, err := gorm.NewDBInstance(dbPath)
+	if err != nil {
+		t.Fatalf("failed to initialize db: %v", err)
+	}
+
+	migrator, err := NewMigrator(dbPath)
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+
+	defer migrator.Close()
+
+	if err := migrator.Up(); err != nil {
+		t.Fatalf("Migrator.Up() error = %v", err)
+	}
+
+	latest, dirty, err := migrator.Version()
+	if err != nil || dirty || latest == 0 {
+		t.Fatalf("Migrator.Version() = %v, %v, %v after up", latest, dirty, err)
+	}
+
+	if !db.DB.Migrator().HasTable("bets") {
+		t.Fatalf("bets table missing after up")
+	}
+
+	if err := migrator.Goto(1); err != nil {
+		t.Fatalf("Migrator.Goto() error = %v", err)
+	}
+
+	if err := migrator.Down(1); err != nil {
+		t.Fatalf("Migrator.Down() error = %v", err)
+	}
+
+	version, _, err := migrator.Version()
+	if err != nil || version != 0 {
+		t.Fatalf("Migrator.Version() = %v, %v after down", version, err)
+	}
+
+	if db.DB.Migrator().HasTable("bets") {
+		t.Fatalf("bets table still present after down")
+	}
+
+	if err := migrator.Up(); err != nil {
+		t.Fatalf("Migrator.Up() error = %v", err)
+	}
+
+	version, _, err = migrator.Version()
+	if err != nil || version != latest {
+		t.Fatalf("Migrator.Version() = %v, %v after second up, want %v", version, err, latest)
+	}
+
+	if !db.DB.Migrator().HasTable("bets") {
+		t.Fatalf("bets table missing after second up")
+	}
+}
+
+func TestMigrator_Force(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "bets.db")
+
+	migrator, err := NewMigrator(dbPath)
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+
+	defer migrator.Close()
+
+	if err := migrator.Force(1); err != nil {
+		t.Fatalf("Migrator.Force() error = %v", err)
+	}
+
+	version, dirty, err := migrator.Version()
+	if err != nil || dirty || version != 1 {
+		t.Errorf("Migrator.Version() = %v, %v, %v, want 1, false, nil", version, dirty, err)
+	}
+
+	if err := migrator.Down(0); err == nil {
+		t.Errorf("Migrator.Down(0) error = nil, want error")
+	}
+}
@@ -1,12 +1,18 @@
 package postgres
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/database/postgres/gorm"
 	"go.opentelemetry.io/otel"
 )
 
@@ -14,7 +20,7 @@ var tracer = otel.Tracer("github.com/KathurimaKimathi/maybets/pkg/maybets/infras
 
 // GetTotalBets fetches the total number of bets placed by a user.
 func (db MaybetsDB) GetTotalBets(ctx context.Context, userID string) (int64, error) {
-	_, span := tracer.Start(ctx, "GetTotalBets")
+	ctx, span := tracer.Start(ctx, "GetTotalBets")
 	defer span.End()
 
 	cacheKey := fmt.Sprintf("total-bets-%s", userID)
@@ -36,37 +42,52 @@ func (db MaybetsDB) GetTotalBets(ctx context.Context, userID string) (int64, err
 
 	err = db.cache.Set(ctx, cacheKey, &fetchedTotal, time.Minute)
 	if err != nil {
-		log.Println(err.Error())
+		slog.WarnContext(ctx, "failed to cache query result", "key", cacheKey, "error", err)
 	}
 
 	return fetchedTotal, nil
 }
 
-// GetTotalWinnings calculates the total winnings of a user.
-func (db MaybetsDB) GetTotalWinnings(ctx context.Context, userID string) (float64, error) {
-	_, span := tracer.Start(ctx, "GetTotalWinnings")
+// winningsCacheKey is the key the total winnings of a user are cached under
+func winningsCacheKey(userID string) string {
+	return fmt.Sprintf("total-winnings-%s", userID)
+}
+
+// GetTotalWinnings calculates the total winnings of a user in the reporting currency.
+func (db MaybetsDB) GetTotalWinnings(ctx context.Context, userID string) (domain.Money, error) {
+	ctx, span := tracer.Start(ctx, "GetTotalWinnings")
 	defer span.End()
 
-	cacheKey := fmt.Sprintf("total-winnings-%s", userID)
+	cacheKey := winningsCacheKey(userID)
 
-	cachedTotal, err := db.cache.Get(ctx, cacheKey, new(*float64))
+	cachedTotal, err := db.cache.Get(ctx, cacheKey, new(*domain.Money))
 	if err == nil {
-		totalFloat, ok := cachedTotal.(*float64)
+		total, ok := cachedTotal.(*domain.Money)
 		if !ok {
-			return 0, fmt.Errorf("cannot cast interface to float64 pointer type")
+			return 0, fmt.Errorf("cannot cast interface to money pointer type")
 		}
 
-		return *totalFloat, nil
+		return *total, nil
+	}
+
+	totals, err := db.query.GetTotalWinnings(ctx, userID)
+	if err != nil {
+		return 0, err
 	}
 
-	fetchedTotal, err := db.query.GetTotalWinnings(ctx, userID)
+	users, err := db.toReportedUsers(totals)
 	if err != nil {
 		return 0, err
 	}
 
+	var fetchedTotal domain.Money
+	if len(users) > 0 {
+		fetchedTotal = users[0].TotalWinnings
+	}
+
 	err = db.cache.Set(ctx, cacheKey, &fetchedTotal, time.Minute)
 	if err != nil {
-		log.Println(err.Error())
+		slog.WarnContext(ctx, "failed to cache query result", "key", cacheKey, "error", err)
 	}
 
 	return fetchedTotal, nil
@@ -74,7 +95,7 @@ func (db MaybetsDB) GetTotalWinnings(ctx context.Context, userID string) (float6
 
 // GetTopUsers fetches the top users with the highest betting volume.
 func (db MaybetsDB) GetTopUsers(ctx context.Context, limit int) ([]domain.User, error) {
-	_, span := tracer.Start(ctx, "GetTopUsers")
+	ctx, span := tracer.Start(ctx, "GetTopUsers")
 	defer span.End()
 
 	cacheKey := fmt.Sprintf("total-winnings-%v", limit)
@@ -107,7 +128,7 @@ func (db MaybetsDB) GetTopUsers(ctx context.Context, limit int) ([]domain.User,
 
 	err = db.cache.Set(ctx, cacheKey, mappedUsers, time.Minute)
 	if err != nil {
-		log.Println(err.Error())
+		slog.WarnContext(ctx, "failed to cache query result", "key", cacheKey, "error", err)
 	}
 
 	return mappedUsers, nil
@@ -115,7 +136,7 @@ func (db MaybetsDB) GetTopUsers(ctx context.Context, limit int) ([]domain.User,
 
 // GetAnomalousUsers fetches users with significantly higher betting activity than the average.
 func (db MaybetsDB) GetAnomalousUsers(ctx context.Context) ([]domain.User, error) {
-	_, span := tracer.Start(ctx, "GetTopUsers")
+	ctx, span := tracer.Start(ctx, "GetTopUsers")
 	defer span.End()
 
 	cacheKey := "anomalous-users"
@@ -148,8 +169,855 @@ func (db MaybetsDB) GetAnomalousUsers(ctx context.Context) ([]domain.User, error
 
 	err = db.cache.Set(ctx, cacheKey, mappedUsers, time.Minute)
 	if err != nil {
-		log.Println(err.Error())
+		slog.WarnContext(ctx, "failed to cache query result", "key", cacheKey, "error", err)
+	}
+
+	return mappedUsers, nil
+}
+
+// GetBet fetches a bet along with its legs
+func (db MaybetsDB) GetBet(ctx context.Context, betID string) (*domain.Bet, error) {
+	ctx, span := tracer.Start(ctx, "GetBet")
+	defer span.End()
+
+	bet, err := db.query.GetBet(ctx, betID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainBet(bet), nil
+}
+
+// StreamBets calls fn for every bet matching the filter without loading them all in memory
+func (db MaybetsDB) StreamBets(ctx context.Context, filter domain.BetFilter, fn func(bet *domain.Bet) error) error {
+	ctx, span := tracer.Start(ctx, "StreamBets")
+	defer span.End()
+
+	return db.query.StreamBets(ctx, filter, func(bet *gorm.Bet) error {
+		return fn(toDomainBet(bet))
+	})
+}
+
+// GetIngestJob fetches an ingest job by its ID
+func (db MaybetsDB) GetIngestJob(ctx context.Context, id string) (*domain.IngestJob, error) {
+	ctx, span := tracer.Start(ctx, "GetIngestJob")
+	defer span.End()
+
+	job, err := db.query.GetIngestJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainIngestJob(job), nil
+}
+
+// FindIngestJob fetches the job that got furthest importing a file, or nil if it was never imported
+func (db MaybetsDB) FindIngestJob(ctx context.Context, hash string, size int64) (*domain.IngestJob, error) {
+	ctx, span := tracer.Start(ctx, "FindIngestJob")
+	defer span.End()
+
+	job, err := db.query.FindIngestJob(ctx, hash, size)
+	if err != nil || job == nil {
+		return nil, err
+	}
+
+	return toDomainIngestJob(job), nil
+}
+
+// ListIngestJobs fetches the most recent ingest jobs, newest first
+func (db MaybetsDB) ListIngestJobs(ctx context.Context, limit int) ([]domain.IngestJob, error) {
+	ctx, span := tracer.Start(ctx, "ListIngestJobs")
+	defer span.End()
+
+	jobs, err := db.query.ListIngestJobs(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedJobs := make([]domain.IngestJob, 0, len(jobs))
+
+	for i := range jobs {
+		mappedJobs = append(mappedJobs, *toDomainIngestJob(&jobs[i]))
+	}
+
+	return mappedJobs, nil
+}
+
+// GetUserLosses fetches the total amount lost by each of the given users that lost at least one bet.
+// It is not cached since it is used to detect users crossing the loss limit as their bets are stored.
+func (db MaybetsDB) GetUserLosses(ctx context.Context, userIDs []string) ([]domain.User, error) {
+	ctx, span := tracer.Start(ctx, "GetUserLosses")
+	defer span.End()
+
+	users, err := db.query.GetUserLosses(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.toReportedUsers(users)
+}
+
+// GetUserTotals fetches the number of bets, the winnings and the losses of each of the given users that placed a bet.
+// It is not cached since the users asked for vary from one query to the next.
+func (db MaybetsDB) GetUserTotals(ctx context.Context, userIDs []string) ([]domain.User, error) {
+	ctx, span := tracer.Start(ctx, "GetUserTotals")
+	defer span.End()
+
+	users, err := db.query.GetUserTotals(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.toReportedUsers(users)
+}
+
+// toReportedUsers merges the totals kept for every currency a user bet in into a single user, in the order
+// the users first appear. Each amount is converted into the reporting currency and rounded once added up,
+// so that the totals are exact to its minor unit.
+func (db MaybetsDB) toReportedUsers(users []gorm.User) ([]domain.User, error) {
+	type totals struct {
+		winnings, losses, payouts map[domain.Currency]domain.Money
+		won, decided              float64
+	}
+
+	mappedUsers := make([]domain.User, 0, len(users))
+
+	positions := map[string]int{}
+	amounts := map[string]*totals{}
+
+	for _, user := range users {
+		position, ok := positions[user.UserID]
+		if !ok {
+			position = len(mappedUsers)
+			positions[user.UserID] = position
+
+			mappedUsers = append(mappedUsers, domain.User{ID: user.UserID, Currency: db.exchange.Reporting})
+			amounts[user.UserID] = &totals{
+				winnings: map[domain.Currency]domain.Money{},
+				losses:   map[domain.Currency]domain.Money{},
+				payouts:  map[domain.Currency]domain.Money{},
+			}
+		}
+
+		currency := domain.Currency(user.Currency)
+		userTotals := amounts[user.UserID]
+
+		mappedUsers[position].TotalBets += user.TotalBets
+		userTotals.winnings[currency] += domain.Money(user.TotalWinnings)
+		userTotals.losses[currency] += domain.Money(user.TotalLosses)
+		userTotals.payouts[currency] += domain.Money(user.TotalPayout)
+		userTotals.won += user.WonBets
+		userTotals.decided += user.DecidedBets
+	}
+
+	for i := range mappedUsers {
+		user := &mappedUsers[i]
+		userTotals := amounts[user.ID]
+
+		var err error
+
+		if user.TotalWinnings, err = db.exchange.Total(userTotals.winnings); err != nil {
+			return nil, fmt.Errorf("user %s: %w", user.ID, err)
+		}
+
+		if user.TotalLosses, err = db.exchange.Total(userTotals.losses); err != nil {
+			return nil, fmt.Errorf("user %s: %w", user.ID, err)
+		}
+
+		if user.TotalPayout, err = db.exchange.Total(userTotals.payouts); err != nil {
+			return nil, fmt.Errorf("user %s: %w", user.ID, err)
+		}
+
+		if userTotals.decided > 0 {
+			user.WinRate = userTotals.won / userTotals.decided
+		}
 	}
 
 	return mappedUsers, nil
 }
+
+// GetBreakdown fetches the number of bets, the volume, the payouts and the margin of the bets matching the filter,
+// grouped by the dimensions and ordered by volume, largest first. The amounts of every currency are converted into
+// the reporting currency and rounded once added up. Up to filter.Limit groups are returned, all of them when it is 0.
+func (db MaybetsDB) GetBreakdown(ctx context.Context, dimensions []enums.Dimension, filter domain.BetFilter) ([]domain.Breakdown, error) {
+	ctx, span := tracer.Start(ctx, "GetBreakdown")
+	defer span.End()
+
+	rows, err := db.query.GetBreakdown(ctx, dimensions, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	type totals struct {
+		volumes, settled, payouts map[domain.Currency]domain.Money
+	}
+
+	var breakdown []domain.Breakdown
+
+	positions := map[string]int{}
+	amounts := []*totals{}
+
+	for _, row := range rows {
+		values := map[enums.Dimension]string{
+			enums.Sport:       row.Sport,
+			enums.Competition: row.Competition,
+			enums.EventID:     row.EventID,
+			enums.Market:      row.Market,
+			enums.Selection:   row.Selection,
+		}
+
+		group := make(map[enums.Dimension]string, len(dimensions))
+		parts := make([]string, 0, len(dimensions))
+
+		for _, dimension := range dimensions {
+			group[dimension] = values[dimension]
+			parts = append(parts, values[dimension])
+		}
+
+		// the values are joined with a NUL byte, which they do not hold, so that distinct groups never share a key
+		key := strings.Join(parts, "\x00")
+
+		position, ok := positions[key]
+		if !ok {
+			position = len(breakdown)
+			positions[key] = position
+
+			breakdown = append(breakdown, domain.Breakdown{Dimensions: group, Currency: db.exchange.Reporting})
+			amounts = append(amounts, &totals{
+				volumes: map[domain.Currency]domain.Money{},
+				settled: map[domain.Currency]domain.Money{},
+				payouts: map[domain.Currency]domain.Money{},
+			})
+		}
+
+		currency := domain.Currency(row.Currency)
+
+		breakdown[position].Bets += row.Bets
+		amounts[position].volumes[currency] += domain.Money(row.Volume)
+		amounts[position].settled[currency] += domain.Money(row.SettledStake)
+		amounts[position].payouts[currency] += domain.Money(row.Payout)
+	}
+
+	for i := range breakdown {
+		group := &breakdown[i]
+
+		if group.Volume, err = db.exchange.Total(amounts[i].volumes); err != nil {
+			return nil, err
+		}
+
+		if group.Payout, err = db.exchange.Total(amounts[i].payouts); err != nil {
+			return nil, err
+		}
+
+		settled, err := db.exchange.Total(amounts[i].settled)
+		if err != nil {
+			return nil, err
+		}
+
+		group.Margin = settled - group.Payout
+		if settled > 0 {
+			group.MarginRate = float64(group.Margin) / float64(settled)
+		}
+	}
+
+	// the rows come ordered by their dimensions, which breaks ties between groups of the same volume
+	slices.SortStableFunc(breakdown, func(a, b domain.Breakdown) int {
+		return cmp.Compare(b.Volume, a.Volume)
+	})
+
+	if filter.Limit > 0 && len(breakdown) > filter.Limit {
+		breakdown = breakdown[:filter.Limit]
+	}
+
+	return breakdown, nil
+}
+
+// GetRecentBets fetches the latest bets of each of the given users, at most limit per user, newest first
+func (db MaybetsDB) GetRecentBets(ctx context.Context, userIDs []string, limit int) ([]domain.Bet, error) {
+	ctx, span := tracer.Start(ctx, "GetRecentBets")
+	defer span.End()
+
+	bets, err := db.query.GetRecentBets(ctx, userIDs, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedBets := make([]domain.Bet, 0, len(bets))
+
+	for i := range bets {
+		mappedBets = append(mappedBets, *toDomainBet(&bets[i]))
+	}
+
+	return mappedBets, nil
+}
+
+// GetLimitTotals fetches the amount lost and staked and the number of bets each of the given users placed over every
+// limit period before now, one per user that placed a bet and period, in the order the users first appear.
+// The amounts of every currency are converted into the reporting currency and rounded once added up.
+func (db MaybetsDB) GetLimitTotals(ctx context.Context, userIDs []string, now time.Time) ([]domain.LimitTotals, error) {
+	ctx, span := tracer.Start(ctx, "GetLimitTotals")
+	defer span.End()
+
+	rows, err := db.query.GetLimitTotals(ctx, userIDs, now)
+	if err != nil {
+		return nil, err
+	}
+
+	type amounts struct {
+		loss, stake map[domain.Currency]domain.Money
+		bets        int64
+	}
+
+	var order []string
+
+	users := map[string]map[enums.LimitPeriod]*amounts{}
+
+	for _, row := range rows {
+		periods, ok := users[row.UserID]
+		if !ok {
+			order = append(order, row.UserID)
+
+			periods = make(map[enums.LimitPeriod]*amounts, len(enums.LimitPeriods))
+			for _, period := range enums.LimitPeriods {
+				periods[period] = &amounts{loss: map[domain.Currency]domain.Money{}, stake: map[domain.Currency]domain.Money{}}
+			}
+
+			users[row.UserID] = periods
+		}
+
+		currency := domain.Currency(row.Currency)
+
+		for period, totals := range map[enums.LimitPeriod][3]int64{
+			enums.Day:   {row.DayLoss, row.DayStake, row.DayBets},
+			enums.Week:  {row.WeekLoss, row.WeekStake, row.WeekBets},
+			enums.Month: {row.MonthLoss, row.MonthStake, row.MonthBets},
+		} {
+			periods[period].loss[currency] += domain.Money(totals[0])
+			periods[period].stake[currency] += domain.Money(totals[1])
+			periods[period].bets += totals[2]
+		}
+	}
+
+	limitTotals := make([]domain.LimitTotals, 0, len(order)*len(enums.LimitPeriods))
+
+	for _, userID := range order {
+		for _, period := range enums.LimitPeriods {
+			totals := users[userID][period]
+
+			loss, err := db.exchange.Total(totals.loss)
+			if err != nil {
+				return nil, fmt.Errorf("user %s: %w", userID, err)
+			}
+
+			stake, err := db.exchange.Total(totals.stake)
+			if err != nil {
+				return nil, fmt.Errorf("user %s: %w", userID, err)
+			}
+
+			limitTotals = append(limitTotals, domain.LimitTotals{
+				UserID: userID, Period: period, Loss: loss, Stake: stake, Bets: totals.bets,
+			})
+		}
+	}
+
+	return limitTotals, nil
+}
+
+// GetLimitBets fetches the bets each of the given users placed from a time on that the session length and stake trend
+// are worked out from: those placed since, when it is set, and the latest of them up to latest per user.
+// Bets are ordered by user, oldest first.
+func (db MaybetsDB) GetLimitBets(
+	ctx context.Context, userIDs []string, from time.Time, since *time.Time, latest int,
+) ([]domain.Bet, error) {
+	ctx, span := tracer.Start(ctx, "GetLimitBets")
+	defer span.End()
+
+	bets, err := db.query.GetLimitBets(ctx, userIDs, from, since, latest)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedBets := make([]domain.Bet, 0, len(bets))
+
+	for i := range bets {
+		mappedBets = append(mappedBets, *toDomainBet(&bets[i]))
+	}
+
+	return mappedBets, nil
+}
+
+// GetRecentAlerts fetches the latest alerts of each of the given users, at most limit per user, newest first
+func (db MaybetsDB) GetRecentAlerts(ctx context.Context, userIDs []string, limit int) ([]domain.Alert, error) {
+	ctx, span := tracer.Start(ctx, "GetRecentAlerts")
+	defer span.End()
+
+	alerts, err := db.query.GetRecentAlerts(ctx, userIDs, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainAlerts(alerts), nil
+}
+
+// ListAlerts fetches the most recent alerts, newest first
+func (db MaybetsDB) ListAlerts(ctx context.Context, limit int) ([]domain.Alert, error) {
+	ctx, span := tracer.Start(ctx, "ListAlerts")
+	defer span.End()
+
+	alerts, err := db.query.ListAlerts(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainAlerts(alerts), nil
+}
+
+// ListAlertsByEvent fetches the most recent alerts of the given events, newest first
+func (db MaybetsDB) ListAlertsByEvent(ctx context.Context, events []enums.WebhookEvent, limit int) ([]domain.Alert, error) {
+	ctx, span := tracer.Start(ctx, "ListAlertsByEvent")
+	defer span.End()
+
+	names := make([]string, 0, len(events))
+	for _, event := range events {
+		names = append(names, event.String())
+	}
+
+	alerts, err := db.query.ListAlertsByEvent(ctx, names, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainAlerts(alerts), nil
+}
+
+// ListUserAlertsSince fetches the alerts of an event raised for the given users since a time, oldest first
+func (db MaybetsDB) ListUserAlertsSince(
+	ctx context.Context, event enums.WebhookEvent, userIDs []string, since time.Time,
+) ([]domain.Alert, error) {
+	ctx, span := tracer.Start(ctx, "ListUserAlertsSince")
+	defer span.End()
+
+	alerts, err := db.query.ListUserAlertsSince(ctx, event.String(), userIDs, since)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainAlerts(alerts), nil
+}
+
+// GetWebhookSubscription fetches a webhook subscription by its ID
+func (db MaybetsDB) GetWebhookSubscription(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	ctx, span := tracer.Start(ctx, "GetWebhookSubscription")
+	defer span.End()
+
+	subscription, err := db.query.GetWebhookSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainWebhookSubscription(subscription), nil
+}
+
+// ListWebhookSubscriptions fetches every webhook subscription, oldest first
+func (db MaybetsDB) ListWebhookSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	ctx, span := tracer.Start(ctx, "ListWebhookSubscriptions")
+	defer span.End()
+
+	subscriptions, err := db.query.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedSubscriptions := make([]domain.WebhookSubscription, 0, len(subscriptions))
+
+	for i := range subscriptions {
+		mappedSubscriptions = append(mappedSubscriptions, *toDomainWebhookSubscription(&subscriptions[i]))
+	}
+
+	return mappedSubscriptions, nil
+}
+
+// ListDueWebhookDeliveries fetches the deliveries whose next attempt is due at now, along with the URL and secret to use
+func (db MaybetsDB) ListDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "ListDueWebhookDeliveries")
+	defer span.End()
+
+	deliveries, err := db.query.ListDueWebhookDeliveries(ctx, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedDeliveries := make([]domain.WebhookDelivery, 0, len(deliveries))
+
+	for _, delivery := range deliveries {
+		mapped := domain.WebhookDelivery{
+			SubscriptionID: delivery.SubscriptionID,
+			AlertID:        delivery.AlertID,
+			Event:          enums.WebhookEvent(delivery.Event),
+			Payload:        json.RawMessage(delivery.Payload),
+			Attempts:       delivery.Attempts,
+			NextAttemptAt:  delivery.NextAttemptAt,
+			LastStatus:     delivery.LastStatus,
+			LastError:      delivery.LastError,
+			CreatedAt:      delivery.CreatedAt,
+		}
+
+		if delivery.ID != nil {
+			mapped.ID = *delivery.ID
+		}
+
+		if delivery.Subscription != nil {
+			mapped.URL = delivery.Subscription.URL
+			mapped.Secret = delivery.Subscription.Secret
+		}
+
+		mappedDeliveries = append(mappedDeliveries, mapped)
+	}
+
+	return mappedDeliveries, nil
+}
+
+// ListWebhookDeadLetters fetches the most recent webhook dead letters, newest first
+func (db MaybetsDB) ListWebhookDeadLetters(ctx context.Context, limit int) ([]domain.WebhookDeadLetter, error) {
+	ctx, span := tracer.Start(ctx, "ListWebhookDeadLetters")
+	defer span.End()
+
+	deadLetters, err := db.query.ListWebhookDeadLetters(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedDeadLetters := make([]domain.WebhookDeadLetter, 0, len(deadLetters))
+
+	for _, deadLetter := range deadLetters {
+		mapped := domain.WebhookDeadLetter{
+			DeliveryID:     deadLetter.DeliveryID,
+			SubscriptionID: deadLetter.SubscriptionID,
+			URL:            deadLetter.URL,
+			AlertID:        deadLetter.AlertID,
+			Event:          enums.WebhookEvent(deadLetter.Event),
+			Payload:        json.RawMessage(deadLetter.Payload),
+			Attempts:       deadLetter.Attempts,
+			LastStatus:     deadLetter.LastStatus,
+			LastError:      deadLetter.LastError,
+			FailedAt:       deadLetter.CreatedAt,
+		}
+
+		if deadLetter.ID != nil {
+			mapped.ID = *deadLetter.ID
+		}
+
+		mappedDeadLetters = append(mappedDeadLetters, mapped)
+	}
+
+	return mappedDeadLetters, nil
+}
+
+func toDomainBet(bet *gorm.Bet) *domain.Bet {
+	mapped := &domain.Bet{
+		BetID:       bet.BetID,
+		UserID:      bet.UserID,
+		Amount:      domain.Money(bet.Amount),
+		Currency:    domain.Currency(bet.Currency),
+		Odds:        bet.Odds,
+		OddsFormat:  enums.OddsFormat(bet.OddsFormat),
+		QuotedOdds:  bet.QuotedOdds,
+		Outcome:     enums.Outcome(bet.Outcome),
+		Timestamp:   bet.Timestamp,
+		Sport:       bet.Sport,
+		Competition: bet.Competition,
+		EventID:     bet.EventID,
+		Market:      bet.Market,
+		Selection:   bet.Selection,
+	}
+
+	// the payout of a cashed out bet is the amount it was cashed out for
+	if mapped.Outcome == enums.Cashout {
+		mapped.Cashout = domain.Money(bet.Payout)
+	}
+
+	for _, leg := range bet.Legs {
+		mapped.Legs = append(mapped.Legs, domain.BetLeg{
+			Selection: leg.Selection,
+			Odds:      leg.Odds,
+			Outcome:   enums.Outcome(leg.Outcome),
+		})
+	}
+
+	return mapped
+}
+
+// ListUserLimits fetches the caps of the given users over every period they have caps for
+func (db MaybetsDB) ListUserLimits(ctx context.Context, userIDs []string) ([]domain.Limits, error) {
+	ctx, span := tracer.Start(ctx, "ListUserLimits")
+	defer span.End()
+
+	records, err := db.query.ListUserLimits(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := make([]domain.Limits, 0, len(records))
+
+	for _, record := range records {
+		limits = append(limits, domain.Limits{
+			UserID:    record.UserID,
+			Period:    enums.LimitPeriod(record.Period),
+			LossCap:   domain.Money(record.LossCap),
+			StakeCap:  domain.Money(record.StakeCap),
+			BetCap:    record.BetCap,
+			UpdatedAt: record.UpdatedAt,
+		})
+	}
+
+	return limits, nil
+}
+
+// GetSelfExclusion fetches a self-exclusion by its ID
+func (db MaybetsDB) GetSelfExclusion(ctx context.Context, id string) (*domain.Exclusion, error) {
+	ctx, span := tracer.Start(ctx, "GetSelfExclusion")
+	defer span.End()
+
+	exclusion, err := db.query.GetSelfExclusion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainExclusion(exclusion), nil
+}
+
+// ListSelfExclusions fetches the self-exclusions of the given users, or of every user when none is given
+func (db MaybetsDB) ListSelfExclusions(ctx context.Context, userIDs []string) ([]domain.Exclusion, error) {
+	ctx, span := tracer.Start(ctx, "ListSelfExclusions")
+	defer span.End()
+
+	records, err := db.query.ListSelfExclusions(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	exclusions := make([]domain.Exclusion, 0, len(records))
+
+	for i := range records {
+		exclusions = append(exclusions, *toDomainExclusion(&records[i]))
+	}
+
+	return exclusions, nil
+}
+
+// ListExclusionViolations fetches the most recent exclusion violations, of a single user when one is given
+func (db MaybetsDB) ListExclusionViolations(ctx context.Context, userID string, limit int) ([]domain.ExclusionViolation, error) {
+	ctx, span := tracer.Start(ctx, "ListExclusionViolations")
+	defer span.End()
+
+	records, err := db.query.ListExclusionViolations(ctx, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := make([]domain.ExclusionViolation, 0, len(records))
+
+	for _, record := range records {
+		var id string
+		if record.ID != nil {
+			id = *record.ID
+		}
+
+		violations = append(violations, domain.ExclusionViolation{
+			ID:          id,
+			ExclusionID: record.ExclusionID,
+			UserID:      record.UserID,
+			BetID:       record.BetID,
+			Amount:      domain.Money(record.Amount),
+			Currency:    domain.Currency(record.Currency),
+			PlacedAt:    record.PlacedAt,
+			Action:      enums.ExclusionMode(record.Action),
+			CreatedAt:   record.CreatedAt,
+		})
+	}
+
+	return violations, nil
+}
+
+func toDomainExclusion(exclusion *gorm.SelfExclusion) *domain.Exclusion {
+	var id string
+	if exclusion.ID != nil {
+		id = *exclusion.ID
+	}
+
+	var liftedBy string
+	if exclusion.LiftedBy != nil {
+		liftedBy = *exclusion.LiftedBy
+	}
+
+	return &domain.Exclusion{
+		ID:        id,
+		UserID:    exclusion.UserID,
+		StartsAt:  exclusion.StartsAt,
+		EndsAt:    exclusion.EndsAt,
+		Reason:    exclusion.Reason,
+		LiftedAt:  exclusion.LiftedAt,
+		LiftedBy:  liftedBy,
+		CreatedAt: exclusion.CreatedAt,
+	}
+}
+
+func toDomainAlerts(alerts []gorm.Alert) []domain.Alert {
+	mappedAlerts := make([]domain.Alert, 0, len(alerts))
+
+	for _, alert := range alerts {
+		mapped := domain.Alert{
+			Event:     enums.WebhookEvent(alert.Event),
+			UserID:    alert.UserID,
+			BetID:     alert.BetID,
+			Value:     alert.Value,
+			Threshold: alert.Threshold,
+			Limit:     enums.LimitKind(alert.LimitKind),
+			Period:    enums.LimitPeriod(alert.Period),
+			CreatedAt: alert.CreatedAt,
+		}
+
+		if alert.Evidence != "" {
+			mapped.Evidence = strings.Split(alert.Evidence, ",")
+		}
+
+		if alert.ID != nil {
+			mapped.ID = *alert.ID
+		}
+
+		mappedAlerts = append(mappedAlerts, mapped)
+	}
+
+	return mappedAlerts
+}
+
+func toDomainWebhookSubscription(subscription *gorm.WebhookSubscription) *domain.WebhookSubscription {
+	var id string
+	if subscription.ID != nil {
+		id = *subscription.ID
+	}
+
+	var events []enums.WebhookEvent
+	for _, event := range strings.Split(subscription.Events, ",") {
+		if event != "" {
+			events = append(events, enums.WebhookEvent(event))
+		}
+	}
+
+	return &domain.WebhookSubscription{
+		ID:        id,
+		URL:       subscription.URL,
+		Secret:    subscription.Secret,
+		Events:    events,
+		CreatedAt: subscription.CreatedAt,
+		UpdatedAt: subscription.UpdatedAt,
+	}
+}
+
+func toDomainIngestJob(job *gorm.IngestJob) *domain.IngestJob {
+	var id string
+	if job.ID != nil {
+		id = *job.ID
+	}
+
+	return &domain.IngestJob{
+		ID:          id,
+		Path:        job.Path,
+		Size:        job.Size,
+		Hash:        job.Hash,
+		Format:      enums.FileFormat(job.Format),
+		Status:      enums.IngestJobStatus(job.Status),
+		Offset:      job.Offset,
+		Records:     job.Records,
+		Error:       job.Error,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.UpdatedAt,
+		CompletedAt: job.CompletedAt,
+	}
+}
+
+// GetEvent fetches an event along with its markets and selections
+func (db MaybetsDB) GetEvent(ctx context.Context, id string) (*domain.Event, error) {
+	event, err := db.query.GetEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	mapped := &domain.Event{
+		Name:     event.Name,
+		StartsAt: event.StartsAt,
+		Markets:  []domain.Market{},
+	}
+
+	if event.ID != nil {
+		mapped.ID = *event.ID
+	}
+
+	for _, market := range event.Markets {
+		mappedMarket := domain.Market{Name: market.Name}
+
+		if market.ID != nil {
+			mappedMarket.ID = *market.ID
+		}
+
+		for _, selection := range market.Selections {
+			mappedSelection := domain.Selection{Name: selection.Name}
+
+			if selection.ID != nil {
+				mappedSelection.ID = *selection.ID
+			}
+
+			if selection.Result != nil {
+				mappedSelection.Result = enums.Outcome(*selection.Result)
+			}
+
+			mappedMarket.Selections = append(mappedMarket.Selections, mappedSelection)
+		}
+
+		mapped.Markets = append(mapped.Markets, mappedMarket)
+	}
+
+	return mapped, nil
+}
+
+// ListSelectionResults fetches every result submitted for a selection, newest first
+func (db MaybetsDB) ListSelectionResults(ctx context.Context, selectionID string) ([]domain.SelectionResult, error) {
+	results, err := db.query.ListSelectionResults(ctx, selectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	mappedResults := make([]domain.SelectionResult, 0, len(results))
+
+	for i := range results {
+		mappedResults = append(mappedResults, *toDomainSelectionResult(&results[i]))
+	}
+
+	return mappedResults, nil
+}
+
+func toDomainSelectionResult(result *gorm.SelectionResult) *domain.SelectionResult {
+	mapped := &domain.SelectionResult{
+		SelectionID: result.SelectionID,
+		Result:      enums.Outcome(result.Result),
+		SubmittedAt: result.CreatedAt,
+		BetsSettled: result.BetsSettled,
+		Stake:       domain.Money(result.Stake),
+		Payout:      domain.Money(result.Payout),
+		Currency:    domain.Currency(result.Currency),
+	}
+
+	if result.ID != nil {
+		mapped.ID = *result.ID
+	}
+
+	if result.PreviousResult != nil {
+		mapped.PreviousResult = enums.Outcome(*result.PreviousResult)
+	}
+
+	if result.CreatedBy != nil {
+		mapped.SubmittedBy = *result.CreatedBy
+	}
+
+	return mapped
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
 	cacheMock "github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/cache/mock"
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/database/postgres/gorm"
@@ -13,6 +14,16 @@ import (
 	"github.com/google/uuid"
 )
 
+// testExchange reports in USD and accepts bets in KES
+var testExchange = func() *domain.Exchange {
+	exchange, err := domain.NewExchange("USD", "USD", map[domain.Currency]string{"KES": "0.0077"})
+	if err != nil {
+		panic(err)
+	}
+
+	return exchange
+}()
+
 func TestMaybetsDB_GetTotalBets(t *testing.T) {
 	type args struct {
 		ctx    context.Context
@@ -71,7 +82,7 @@ func TestMaybetsDB_GetTotalBets(t *testing.T) {
 			fakeGorm := gormMock.NewGormMock()
 			fakeCache := cacheMock.NewStoreCacheMock()
 
-			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm)
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
 
 			if tt.name == "success: get bets from db" {
 				fakeCache.MockGetFn = func(_ context.Context, _ string, _ interface{}) (interface{}, error) {
@@ -125,6 +136,7 @@ func TestMaybetsDB_GetTotalWinnings(t *testing.T) {
 	tests := []struct {
 		name    string
 		args    args
+		want    domain.Money
 		wantErr bool
 	}{
 		{
@@ -133,6 +145,7 @@ func TestMaybetsDB_GetTotalWinnings(t *testing.T) {
 				ctx:    context.Background(),
 				userID: uuid.NewString(),
 			},
+			want:    1_000_000,
 			wantErr: false,
 		},
 		{
@@ -141,8 +154,27 @@ func TestMaybetsDB_GetTotalWinnings(t *testing.T) {
 				ctx:    context.Background(),
 				userID: uuid.NewString(),
 			},
+			want:    50_000,
+			wantErr: false,
+		},
+		{
+			name: "success: winnings in several currencies converted into the reporting currency",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.NewString(),
+			},
+			// 100 USD and 100 KES at 0.0077 USD
+			want:    1_007_700,
 			wantErr: false,
 		},
+		{
+			name: "fail: winnings in a currency without a rate",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.NewString(),
+			},
+			wantErr: true,
+		},
 		{
 			name: "fail: invalid type in cache",
 			args: args{
@@ -165,6 +197,7 @@ func TestMaybetsDB_GetTotalWinnings(t *testing.T) {
 				ctx:    context.Background(),
 				userID: uuid.NewString(),
 			},
+			want:    1_000_000,
 			wantErr: false,
 		},
 	}
@@ -174,7 +207,7 @@ func TestMaybetsDB_GetTotalWinnings(t *testing.T) {
 			fakeGorm := gormMock.NewGormMock()
 			fakeCache := cacheMock.NewStoreCacheMock()
 
-			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm)
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
 
 			if tt.name == "success: get bets from db" {
 				fakeCache.MockGetFn = func(_ context.Context, _ string, _ interface{}) (interface{}, error) {
@@ -184,7 +217,7 @@ func TestMaybetsDB_GetTotalWinnings(t *testing.T) {
 
 			if tt.name == "success: get bets from cache" {
 				fakeCache.MockGetFn = func(_ context.Context, _ string, _ interface{}) (interface{}, error) {
-					total := float64(5)
+					total := domain.Money(50_000)
 
 					return &total, nil
 				}
@@ -205,16 +238,39 @@ func TestMaybetsDB_GetTotalWinnings(t *testing.T) {
 					return nil, fmt.Errorf("error")
 				}
 
-				fakeGorm.MockGetTotalWinningsFn = func(_ context.Context, _ string) (float64, error) {
-					return 0, fmt.Errorf("error")
+				fakeGorm.MockGetTotalWinningsFn = func(_ context.Context, _ string) ([]gorm.User, error) {
+					return nil, fmt.Errorf("error")
 				}
 			}
 
-			_, err := db.GetTotalWinnings(tt.args.ctx, tt.args.userID)
+			if tt.name == "success: winnings in several currencies converted into the reporting currency" ||
+				tt.name == "fail: winnings in a currency without a rate" {
+				fakeCache.MockGetFn = func(_ context.Context, _ string, _ interface{}) (interface{}, error) {
+					return nil, fmt.Errorf("error")
+				}
+
+				currency := "KES"
+				if tt.wantErr {
+					currency = "EUR"
+				}
+
+				fakeGorm.MockGetTotalWinningsFn = func(_ context.Context, userID string) ([]gorm.User, error) {
+					return []gorm.User{
+						{UserID: userID, Currency: "USD", TotalWinnings: 1_000_000},
+						{UserID: userID, Currency: currency, TotalWinnings: 1_000_000},
+					}, nil
+				}
+			}
+
+			got, err := db.GetTotalWinnings(tt.args.ctx, tt.args.userID)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("MaybetsDB.GetTotalWinnings() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+
+			if got != tt.want {
+				t.Errorf("MaybetsDB.GetTotalWinnings() = %v, want %v", got, tt.want)
+			}
 		})
 	}
 }
@@ -277,7 +333,7 @@ func TestMaybetsDB_GetTopUsers(t *testing.T) {
 			fakeGorm := gormMock.NewGormMock()
 			fakeCache := cacheMock.NewStoreCacheMock()
 
-			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm)
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
 
 			if tt.name == "success: get bets from db" {
 				fakeCache.MockGetFn = func(_ context.Context, _ string, _ interface{}) (interface{}, error) {
@@ -377,7 +433,7 @@ func TestMaybetsDB_GetAnomalousUsers(t *testing.T) {
 			fakeGorm := gormMock.NewGormMock()
 			fakeCache := cacheMock.NewStoreCacheMock()
 
-			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm)
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
 
 			if tt.name == "success: get bets from db" {
 				fakeCache.MockGetFn = func(_ context.Context, _ string, _ interface{}) (interface{}, error) {
@@ -424,3 +480,669 @@ func TestMaybetsDB_GetAnomalousUsers(t *testing.T) {
 		})
 	}
 }
+
+func TestMaybetsDB_StreamBets(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		filter domain.BetFilter
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success: stream bets",
+			args: args{
+				ctx:    context.Background(),
+				filter: domain.BetFilter{UserID: uuid.NewString()},
+			},
+			wantErr: false,
+		},
+		{
+			name: "fail: fail to stream from db",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			fakeCache := cacheMock.NewStoreCacheMock()
+
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
+
+			if tt.name == "fail: fail to stream from db" {
+				fakeGorm.MockStreamBetsFn = func(_ context.Context, _ domain.BetFilter, _ func(bet *gorm.Bet) error) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			var streamed []*domain.Bet
+
+			err := db.StreamBets(tt.args.ctx, tt.args.filter, func(bet *domain.Bet) error {
+				streamed = append(streamed, bet)
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MaybetsDB.StreamBets() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && (len(streamed) != 1 || !streamed[0].Outcome.IsValid()) {
+				t.Errorf("MaybetsDB.StreamBets() streamed = %v, want one mapped bet", streamed)
+			}
+		})
+	}
+}
+
+func TestMaybetsDB_FindIngestJob(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantNil bool
+		wantErr bool
+	}{
+		{
+			name: "success: file was imported before",
+		},
+		{
+			name:    "success: file was never imported",
+			wantNil: true,
+		},
+		{
+			name:    "sad: unable to find ingest job",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			fakeCache := cacheMock.NewStoreCacheMock()
+
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
+
+			fakeGorm.MockFindIngestJobFn = func(_ context.Context, hash string, size int64) (*gorm.IngestJob, error) {
+				switch tt.name {
+				case "success: file was never imported":
+					return nil, nil
+				case "sad: unable to find ingest job":
+					return nil, fmt.Errorf("error")
+				default:
+					id := uuid.NewString()
+
+					return &gorm.IngestJob{AbstractBase: gorm.AbstractBase{ID: &id}, Hash: hash, Size: size, Status: "failed"}, nil
+				}
+			}
+
+			got, err := db.FindIngestJob(context.Background(), "hash", 10)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MaybetsDB.FindIngestJob() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && (got == nil) != tt.wantNil {
+				t.Errorf("MaybetsDB.FindIngestJob() = %v, wantNil %v", got, tt.wantNil)
+			}
+
+			if got != nil && (got.ID == "" || got.Status != enums.IngestFailed) {
+				t.Errorf("MaybetsDB.FindIngestJob() = %+v, want a mapped failed job", got)
+			}
+		})
+	}
+}
+
+func TestMaybetsDB_ListDueWebhookDeliveries(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name: "success: list due deliveries with their subscription",
+		},
+		{
+			name:    "sad: unable to list due deliveries",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			fakeCache := cacheMock.NewStoreCacheMock()
+
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
+
+			if tt.name == "sad: unable to list due deliveries" {
+				fakeGorm.MockListDueWebhookDeliveriesFn = func(_ context.Context, _ time.Time, _ int) ([]gorm.WebhookDelivery, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			got, err := db.ListDueWebhookDeliveries(context.Background(), time.Now(), 100)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MaybetsDB.ListDueWebhookDeliveries() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			for _, delivery := range got {
+				if delivery.ID == "" || delivery.URL == "" || delivery.Secret == "" || delivery.Event != enums.LargeBet {
+					t.Errorf("MaybetsDB.ListDueWebhookDeliveries() = %+v, want a delivery mapped with its subscription", delivery)
+				}
+			}
+		})
+	}
+}
+
+func TestMaybetsDB_GetBet(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name: "success: get a cashed out bet",
+		},
+		{
+			name:    "sad: unable to get bet",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			fakeCache := cacheMock.NewStoreCacheMock()
+
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
+
+			fakeGorm.MockGetBetFn = func(_ context.Context, betID string) (*gorm.Bet, error) {
+				if tt.wantErr {
+					return nil, fmt.Errorf("error")
+				}
+
+				return &gorm.Bet{BetID: betID, Amount: 100_000, Currency: "USD", Odds: 3, Outcome: "cashout", Payout: 120_000}, nil
+			}
+
+			got, err := db.GetBet(context.Background(), "b1")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MaybetsDB.GetBet() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && (got.Cashout != 120_000 || got.Payout() != 120_000) {
+				t.Errorf("MaybetsDB.GetBet() = %+v, want the payout as cashout amount", got)
+			}
+		})
+	}
+}
+
+func TestMaybetsDB_GetUserTotals(t *testing.T) {
+	userIDs := []string{uuid.NewString(), uuid.NewString()}
+
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name: "success: get the totals of users",
+		},
+		{
+			name:    "sad: unable to get the totals of users",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			fakeCache := cacheMock.NewStoreCacheMock()
+
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
+
+			if tt.name == "sad: unable to get the totals of users" {
+				fakeGorm.MockGetUserTotalsFn = func(_ context.Context, _ []string) ([]gorm.User, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			got, err := db.GetUserTotals(context.Background(), userIDs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MaybetsDB.GetUserTotals() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if len(got) != len(userIDs) {
+				t.Fatalf("MaybetsDB.GetUserTotals() returned %d users, want %d", len(got), len(userIDs))
+			}
+
+			for i, user := range got {
+				if user.ID != userIDs[i] || user.TotalWinnings == 0 || user.TotalLosses == 0 {
+					t.Errorf("MaybetsDB.GetUserTotals() = %+v, want the totals of %s", user, userIDs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMaybetsDB_GetLimitTotals(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		rows    []gorm.LimitTotals
+		rowsErr error
+		want    []domain.LimitTotals
+		wantErr bool
+	}{
+		{
+			name: "success: totals of every currency converted into the reporting currency",
+			rows: []gorm.LimitTotals{
+				{UserID: "u1", Currency: "KES", DayStake: 13_000_000, DayBets: 1, WeekStake: 13_000_000, WeekBets: 1, MonthStake: 13_000_000, MonthBets: 1},
+				{UserID: "u1", Currency: "USD", DayLoss: 100_000, DayStake: 100_000, DayBets: 1, WeekLoss: 300_000, WeekStake: 300_000, WeekBets: 2,
+					MonthLoss: 300_000, MonthStake: 300_000, MonthBets: 2},
+				{UserID: "u2", Currency: "USD", MonthLoss: 50_000, MonthStake: 50_000, MonthBets: 1},
+			},
+			want: []domain.LimitTotals{
+				{UserID: "u1", Period: enums.Day, Loss: 100_000, Stake: 200_100, Bets: 2},
+				{UserID: "u1", Period: enums.Week, Loss: 300_000, Stake: 400_100, Bets: 3},
+				{UserID: "u1", Period: enums.Month, Loss: 300_000, Stake: 400_100, Bets: 3},
+				{UserID: "u2", Period: enums.Day},
+				{UserID: "u2", Period: enums.Week},
+				{UserID: "u2", Period: enums.Month, Loss: 50_000, Stake: 50_000, Bets: 1},
+			},
+		},
+		{
+			name:    "fail: currency without an exchange rate",
+			rows:    []gorm.LimitTotals{{UserID: "u1", Currency: "JPY", DayStake: 100_000, DayBets: 1}},
+			wantErr: true,
+		},
+		{
+			name:    "sad: unable to get the totals",
+			rowsErr: fmt.Errorf("error"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			fakeCache := cacheMock.NewStoreCacheMock()
+
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
+
+			fakeGorm.MockGetLimitTotalsFn = func(_ context.Context, _ []string, _ time.Time) ([]gorm.LimitTotals, error) {
+				return tt.rows, tt.rowsErr
+			}
+
+			got, err := db.GetLimitTotals(context.Background(), []string{"u1", "u2"}, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MaybetsDB.GetLimitTotals() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("MaybetsDB.GetLimitTotals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaybetsDB_GetBreakdown(t *testing.T) {
+	rows := []gorm.Breakdown{
+		{EventID: "e1", Currency: "KES", Bets: 1, Volume: 10_000_000, SettledStake: 10_000_000},
+		{EventID: "e1", Currency: "USD", Bets: 2, Volume: 100_000, SettledStake: 100_000, Payout: 50_000},
+		{EventID: "e2", Currency: "USD", Bets: 1, Volume: 500_000},
+	}
+
+	e1 := domain.Breakdown{
+		Dimensions: map[enums.Dimension]string{enums.EventID: "e1"}, Bets: 3,
+		// 1000 KES are worth 7.70 USD
+		Volume: 177_000, Payout: 50_000, Margin: 127_000, MarginRate: 127_000.0 / 177_000, Currency: "USD",
+	}
+	e2 := domain.Breakdown{
+		Dimensions: map[enums.Dimension]string{enums.EventID: "e2"}, Bets: 1, Volume: 500_000, Currency: "USD",
+	}
+
+	tests := []struct {
+		name    string
+		filter  domain.BetFilter
+		want    []domain.Breakdown
+		wantErr bool
+	}{
+		{
+			name: "success: currencies merged and groups ordered by volume",
+			want: []domain.Breakdown{e2, e1},
+		},
+		{
+			name:   "success: groups with the largest volume up to the limit",
+			filter: domain.BetFilter{Limit: 1},
+			want:   []domain.Breakdown{e2},
+		},
+		{
+			name:    "sad: unable to get the breakdown",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			fakeCache := cacheMock.NewStoreCacheMock()
+
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
+
+			fakeGorm.MockGetBreakdownFn = func(_ context.Context, _ []enums.Dimension, _ domain.BetFilter) ([]gorm.Breakdown, error) {
+				if tt.wantErr {
+					return nil, fmt.Errorf("error")
+				}
+
+				return rows, nil
+			}
+
+			got, err := db.GetBreakdown(context.Background(), []enums.Dimension{enums.EventID}, tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MaybetsDB.GetBreakdown() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("MaybetsDB.GetBreakdown() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaybetsDB_GetRecentAlerts(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name: "success: get the latest alerts of users",
+		},
+		{
+			name:    "sad: unable to get the latest alerts of users",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			fakeCache := cacheMock.NewStoreCacheMock()
+
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
+
+			if tt.name == "sad: unable to get the latest alerts of users" {
+				fakeGorm.MockGetRecentAlertsFn = func(_ context.Context, _ []string, _ int) ([]gorm.Alert, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			got, err := db.GetRecentAlerts(context.Background(), []string{uuid.NewString()}, 5)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MaybetsDB.GetRecentAlerts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			for _, alert := range got {
+				if alert.ID == "" || alert.Event != enums.LossLimitCrossed {
+					t.Errorf("MaybetsDB.GetRecentAlerts() = %+v, want a mapped alert", alert)
+				}
+			}
+		})
+	}
+}
+
+func TestMaybetsDB_ListAlertsByEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name: "success: list the alerts of an event with their evidence",
+		},
+		{
+			name:    "sad: unable to list the alerts of an event",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			fakeCache := cacheMock.NewStoreCacheMock()
+
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
+
+			fakeGorm.MockListAlertsByEventFn = func(_ context.Context, events []string, _ int) ([]gorm.Alert, error) {
+				if tt.wantErr {
+					return nil, fmt.Errorf("error")
+				}
+
+				id := uuid.NewString()
+
+				return []gorm.Alert{
+					{AbstractBase: gorm.AbstractBase{ID: &id}, Event: events[0], UserID: "u1", Value: 28500, Evidence: "b1,b2,b3"},
+				}, nil
+			}
+
+			got, err := db.ListAlertsByEvent(context.Background(), []enums.WebhookEvent{enums.AMLStructuring}, 5)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MaybetsDB.ListAlertsByEvent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			for _, alert := range got {
+				if alert.Event != enums.AMLStructuring || len(alert.Evidence) != 3 {
+					t.Errorf("MaybetsDB.ListAlertsByEvent() = %+v, want a structuring alert with 3 evidence bets", alert)
+				}
+			}
+		})
+	}
+}
+
+func TestMaybetsDB_ListUserAlertsSince(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name: "success: list the alerts of an event raised for users with their evidence",
+		},
+		{
+			name:    "sad: unable to list the alerts of users",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			fakeCache := cacheMock.NewStoreCacheMock()
+
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
+
+			if tt.wantErr {
+				fakeGorm.MockListUserAlertsSinceFn = func(_ context.Context, _ string, _ []string, _ time.Time) ([]gorm.Alert, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			got, err := db.ListUserAlertsSince(context.Background(), enums.AMLStructuring, []string{"u1"}, time.Now().Add(-time.Hour))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MaybetsDB.ListUserAlertsSince() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			for _, alert := range got {
+				if alert.Event != enums.AMLStructuring || alert.UserID != "u1" || len(alert.Evidence) != 3 {
+					t.Errorf("MaybetsDB.ListUserAlertsSince() = %+v, want a structuring alert of u1 with 3 evidence bets", alert)
+				}
+			}
+		})
+	}
+}
+
+func TestMaybetsDB_GetEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name: "success: get an event with a settled selection",
+		},
+		{
+			name:    "sad: unable to get event",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			fakeCache := cacheMock.NewStoreCacheMock()
+
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
+
+			if tt.wantErr {
+				fakeGorm.MockGetEventFn = func(_ context.Context, _ string) (*gorm.Event, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			got, err := db.GetEvent(context.Background(), "e1")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MaybetsDB.GetEvent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && (got.ID != "e1" || got.Markets[0].Selections[0].Result != enums.Win) {
+				t.Errorf("MaybetsDB.GetEvent() = %+v, want e1 with a won selection", got)
+			}
+		})
+	}
+}
+
+func TestMaybetsDB_ListSelectionResults(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name: "success: list the results of a selection",
+		},
+		{
+			name:    "sad: unable to list the results of a selection",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			fakeCache := cacheMock.NewStoreCacheMock()
+
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
+
+			if tt.wantErr {
+				fakeGorm.MockListSelectionResultsFn = func(_ context.Context, _ string) ([]gorm.SelectionResult, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			got, err := db.ListSelectionResults(context.Background(), "s1")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MaybetsDB.ListSelectionResults() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && (len(got) != 1 || got[0].SubmittedBy != "feed" || got[0].SelectionID != "s1") {
+				t.Errorf("MaybetsDB.ListSelectionResults() = %+v, want one result submitted by feed", got)
+			}
+		})
+	}
+}
+
+func TestMaybetsDB_ListUserLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name: "success: list the caps of users",
+		},
+		{
+			name:    "sad: unable to list the caps of users",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			fakeCache := cacheMock.NewStoreCacheMock()
+
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
+
+			if tt.name == "sad: unable to list the caps of users" {
+				fakeGorm.MockListUserLimitsFn = func(_ context.Context, _ []string) ([]gorm.UserLimits, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			got, err := db.ListUserLimits(context.Background(), []string{"u1"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MaybetsDB.ListUserLimits() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			want := domain.Limits{UserID: "u1", Period: enums.Day, LossCap: domain.MoneyFromFloat(100), BetCap: 20}
+			if len(got) != 1 || got[0] != want {
+				t.Errorf("MaybetsDB.ListUserLimits() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestMaybetsDB_ListSelfExclusions(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name: "success: list the self-exclusions of users",
+		},
+		{
+			name:    "sad: unable to list the self-exclusions of users",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			fakeCache := cacheMock.NewStoreCacheMock()
+
+			db := NewMaybetsDB(fakeCache, fakeGorm, fakeGorm, testExchange)
+
+			if tt.wantErr {
+				fakeGorm.MockListSelfExclusionsFn = func(_ context.Context, _ []string) ([]gorm.SelfExclusion, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			got, err := db.ListSelfExclusions(context.Background(), []string{"u1"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MaybetsDB.ListSelfExclusions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if len(got) != 1 || got[0].ID == "" || got[0].UserID != "u1" || got[0].EndsAt != nil || got[0].LiftedBy != "" ||
+				!got[0].Covers(time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)) {
+				t.Errorf("MaybetsDB.ListSelfExclusions() = %+v, want a standing self-exclusion of u1", got)
+			}
+		})
+	}
+}
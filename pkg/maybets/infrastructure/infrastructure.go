@@ -4,37 +4,101 @@ import (
 	"context"
 	"time"
 
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/pubsub"
 )
 
 // Database holds the methods of interacting with the database
 type Database interface {
 	GetTotalBets(ctx context.Context, userID string) (int64, error)
-	GetTotalWinnings(ctx context.Context, userID string) (float64, error)
+	GetTotalWinnings(ctx context.Context, userID string) (domain.Money, error)
 	GetTopUsers(ctx context.Context, limit int) ([]domain.User, error)
 	GetAnomalousUsers(ctx context.Context) ([]domain.User, error)
 	StoreBetData(ctx context.Context, bets []*domain.Bet) error
+	StoreNewBets(ctx context.Context, bets []*domain.Bet) (int64, error)
+	GetBet(ctx context.Context, betID string) (*domain.Bet, error)
+	SettleBet(ctx context.Context, bet *domain.Bet, settledBy string) error
+	SaveEvent(ctx context.Context, event *domain.Event) error
+	GetEvent(ctx context.Context, id string) (*domain.Event, error)
+	SettleSelection(ctx context.Context, result *domain.SelectionResult, settle func(bet *domain.Bet) (bool, error)) error
+	ListSelectionResults(ctx context.Context, selectionID string) ([]domain.SelectionResult, error)
+	StreamBets(ctx context.Context, filter domain.BetFilter, fn func(bet *domain.Bet) error) error
+	CreateIngestJob(ctx context.Context, job *domain.IngestJob) error
+	UpdateIngestJob(ctx context.Context, job *domain.IngestJob) error
+	CommitIngestBatch(ctx context.Context, job *domain.IngestJob, bets []*domain.Bet, violations []domain.ExclusionViolation) error
+	GetIngestJob(ctx context.Context, id string) (*domain.IngestJob, error)
+	FindIngestJob(ctx context.Context, hash string, size int64) (*domain.IngestJob, error)
+	ListIngestJobs(ctx context.Context, limit int) ([]domain.IngestJob, error)
+	GetUserLosses(ctx context.Context, userIDs []string) ([]domain.User, error)
+	GetUserTotals(ctx context.Context, userIDs []string) ([]domain.User, error)
+	GetBreakdown(ctx context.Context, dimensions []enums.Dimension, filter domain.BetFilter) ([]domain.Breakdown, error)
+	GetRecentBets(ctx context.Context, userIDs []string, limit int) ([]domain.Bet, error)
+	GetLimitTotals(ctx context.Context, userIDs []string, now time.Time) ([]domain.LimitTotals, error)
+	GetLimitBets(ctx context.Context, userIDs []string, from time.Time, since *time.Time, latest int) ([]domain.Bet, error)
+	GetRecentAlerts(ctx context.Context, userIDs []string, limit int) ([]domain.Alert, error)
+	ListAlerts(ctx context.Context, limit int) ([]domain.Alert, error)
+	ListAlertsByEvent(ctx context.Context, events []enums.WebhookEvent, limit int) ([]domain.Alert, error)
+	ListUserAlertsSince(ctx context.Context, event enums.WebhookEvent, userIDs []string, since time.Time) ([]domain.Alert, error)
+	SaveUserLimits(ctx context.Context, limits *domain.Limits) error
+	ListUserLimits(ctx context.Context, userIDs []string) ([]domain.Limits, error)
+	CreateSelfExclusion(ctx context.Context, exclusion *domain.Exclusion) error
+	LiftSelfExclusion(ctx context.Context, exclusion *domain.Exclusion) error
+	GetSelfExclusion(ctx context.Context, id string) (*domain.Exclusion, error)
+	ListSelfExclusions(ctx context.Context, userIDs []string) ([]domain.Exclusion, error)
+	RecordExclusionViolations(ctx context.Context, violations []domain.ExclusionViolation) error
+	ListExclusionViolations(ctx context.Context, userID string, limit int) ([]domain.ExclusionViolation, error)
+	CreateWebhookSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error
+	GetWebhookSubscription(ctx context.Context, id string) (*domain.WebhookSubscription, error)
+	ListWebhookSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, id string) error
+	RecordAlert(ctx context.Context, alert *domain.Alert, deliveries []domain.WebhookDelivery) (bool, error)
+	ListDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+	DeleteWebhookDelivery(ctx context.Context, id string) error
+	DeadLetterWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+	ListWebhookDeadLetters(ctx context.Context, limit int) ([]domain.WebhookDeadLetter, error)
 }
 
 // Cache interface holds methods for interacting with the caching service
 type Cache interface {
 	Get(ctx context.Context, key string, valueType interface{}) (interface{}, error)
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// Webhooks holds the methods of notifying webhook subscriptions
+type Webhooks interface {
+	// Send attempts a delivery and returns the HTTP status it was answered with, if any
+	Send(ctx context.Context, delivery domain.WebhookDelivery) (int, error)
+}
+
+// Live holds the methods of fanning events out to the live feed clients of this process
+type Live interface {
+	Publish(event domain.LiveEvent)
+	Subscribe(filter domain.LiveFilter) *pubsub.Subscription
+	Subscribers() int
 }
 
 // Infrastructure implements the infrastructure interface(s)
 type Infrastructure struct {
 	Cache    Cache
 	Database Database
+	Webhooks Webhooks
+	Live     Live
 }
 
 // NewInfrastructureInteractor initializes a new Infrastructure
 func NewInfrastructureInteractor(
 	cache Cache,
 	database Database,
+	webhooks Webhooks,
+	live Live,
 ) *Infrastructure {
 	return &Infrastructure{
 		Cache:    cache,
 		Database: database,
+		Webhooks: webhooks,
+		Live:     live,
 	}
 }
@@ -0,0 +1,97 @@
+// Package pubsub fans live events out to the subscribers of this process
+package pubsub
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+)
+
+// Broker delivers every published event to the subscribers whose filter matches it.
+// Publishing never blocks: a subscriber whose buffer is full misses the event, which is counted in Dropped.
+type Broker struct {
+	buffer int
+
+	mu          sync.RWMutex
+	subscribers map[*Subscription]struct{}
+}
+
+// NewBroker initializes a new Broker giving every subscriber a buffer of the given number of events
+func NewBroker(buffer int) *Broker {
+	return &Broker{
+		buffer:      buffer,
+		subscribers: map[*Subscription]struct{}{},
+	}
+}
+
+// Publish delivers the event to every matching subscriber
+func (b *Broker) Publish(event domain.LiveEvent) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for subscription := range b.subscribers {
+		if !subscription.filter.Matches(event) {
+			continue
+		}
+
+		select {
+		case subscription.events <- event:
+		default:
+			subscription.dropped.Add(1)
+		}
+	}
+}
+
+// Subscribe registers a subscriber receiving the events matching filter until it is closed
+func (b *Broker) Subscribe(filter domain.LiveFilter) *Subscription {
+	subscription := &Subscription{
+		broker: b,
+		filter: filter,
+		events: make(chan domain.LiveEvent, b.buffer),
+	}
+
+	b.mu.Lock()
+	b.subscribers[subscription] = struct{}{}
+	b.mu.Unlock()
+
+	return subscription
+}
+
+// Subscribers returns the number of open subscriptions
+func (b *Broker) Subscribers() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return len(b.subscribers)
+}
+
+// Subscription receives the events of a broker matching its filter
+type Subscription struct {
+	broker  *Broker
+	filter  domain.LiveFilter
+	events  chan domain.LiveEvent
+	dropped atomic.Int64
+	once    sync.Once
+}
+
+// Events returns the channel the events are received on. It is closed once the subscription is closed.
+func (s *Subscription) Events() <-chan domain.LiveEvent {
+	return s.events
+}
+
+// Dropped returns the number of events missed because the buffer was full since the previous call
+func (s *Subscription) Dropped() int64 {
+	return s.dropped.Swap(0)
+}
+
+// Close unregisters the subscription. It is safe to call more than once.
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.broker.mu.Lock()
+		delete(s.broker.subscribers, s)
+		s.broker.mu.Unlock()
+
+		close(s.events)
+	})
+}
@@ -0,0 +1,86 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+)
+
+func TestBroker_Publish(t *testing.T) {
+	events := []domain.LiveEvent{
+		{Topic: enums.BetsTopic, UserID: "u1", Data: "bet 1"},
+		{Topic: enums.BetsTopic, UserID: "u2", Data: "bet 2"},
+		{Topic: enums.LeaderboardTopic, Data: "leaderboard"},
+		{Topic: enums.AlertsTopic, UserID: "u1", Data: "alert"},
+	}
+
+	tests := []struct {
+		name        string
+		buffer      int
+		filter      domain.LiveFilter
+		want        []any
+		wantDropped int64
+	}{
+		{
+			name:   "success: every event without a filter",
+			buffer: 10,
+			want:   []any{"bet 1", "bet 2", "leaderboard", "alert"},
+		},
+		{
+			name:   "success: events of a user and of every user",
+			buffer: 10,
+			filter: domain.LiveFilter{UserID: "u1"},
+			want:   []any{"bet 1", "leaderboard", "alert"},
+		},
+		{
+			name:   "success: events of the subscribed topics",
+			buffer: 10,
+			filter: domain.LiveFilter{Topics: []enums.LiveTopic{enums.BetsTopic}, UserID: "u2"},
+			want:   []any{"bet 2"},
+		},
+		{
+			name:        "success: slow subscriber drops the events that do not fit its buffer",
+			buffer:      2,
+			want:        []any{"bet 1", "bet 2"},
+			wantDropped: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broker := NewBroker(tt.buffer)
+
+			subscription := broker.Subscribe(tt.filter)
+
+			for _, event := range events {
+				broker.Publish(event)
+			}
+
+			subscription.Close()
+
+			var got []any
+			for event := range subscription.Events() {
+				got = append(got, event.Data)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("Broker.Publish() delivered %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Broker.Publish() delivered %v, want %v", got, tt.want)
+				}
+			}
+
+			if dropped := subscription.Dropped(); dropped != tt.wantDropped {
+				t.Errorf("Subscription.Dropped() = %v, want %v", dropped, tt.wantDropped)
+			}
+
+			if broker.Subscribers() != 0 {
+				t.Errorf("Broker.Subscribers() = %v after close, want 0", broker.Subscribers())
+			}
+		})
+	}
+}
@@ -0,0 +1,367 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/codec"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// KafkaOptions controls how a Kafka topic is consumed
+type KafkaOptions struct {
+	// Brokers are the addresses used to bootstrap the connection to the cluster
+	Brokers []string
+	// Topic is the topic bets are published on, each message value holding a JSON encoded bet
+	Topic string
+	// Group is the consumer group shared by every consumer instance
+	Group string
+	// DeadLetter is the topic that receives the messages that cannot be decoded or that storage turns down as invalid
+	DeadLetter string
+	// BatchSize is the maximum number of messages stored together
+	BatchSize int
+	// BatchWait is how long a batch waits to fill up once its first message has arrived
+	BatchWait time.Duration
+	// MaxAttempts is the number of times a message is attempted before the consumer stops on a storage failure
+	MaxAttempts int
+	// RetryBackoff is the wait before the first retry of a message, doubled on every further attempt
+	RetryBackoff time.Duration
+	// StatsInterval is how often the consumer lag is reported
+	StatsInterval time.Duration
+}
+
+// errDeadLetter marks the failures to dead letter a message. The consumer stops on them: the message was neither
+// stored nor dead lettered, and committing a later offset of its partition would skip it.
+var errDeadLetter = errors.New("failed to dead letter message")
+
+// errNotStored marks the messages that could not be stored for a reason other than what they hold. The consumer
+// stops on them rather than dead lettering them, so that they are redelivered once storage is back.
+var errNotStored = errors.New("failed to store message")
+
+// KafkaConsumer reads bets from a Kafka topic through a consumer group.
+// Offsets are committed only once the messages they cover have been stored or dead lettered.
+type KafkaConsumer struct {
+	reader  *kafka.Reader
+	writer  *kafka.Writer
+	options KafkaOptions
+	handler Handler
+	// lag is the lag last reported by the reader, -1 until the first report
+	lag atomic.Int64
+}
+
+// NewKafkaConsumer initializes a new KafkaConsumer.
+// A new group starts from the beginning of the topic so that bets published before it existed are stored too.
+func NewKafkaConsumer(options KafkaOptions, handler Handler) *KafkaConsumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     options.Brokers,
+		GroupID:     options.Group,
+		Topic:       options.Topic,
+		StartOffset: kafka.FirstOffset,
+		MaxWait:     options.BatchWait,
+		ErrorLogger: kafka.LoggerFunc(func(message string, args ...interface{}) {
+			slog.Error(fmt.Sprintf(message, args...), "topic", options.Topic)
+		}),
+	})
+
+	writer := &kafka.Writer{
+		Addr:                   kafka.TCP(options.Brokers...),
+		Topic:                  options.DeadLetter,
+		RequiredAcks:           kafka.RequireAll,
+		AllowAutoTopicCreation: true,
+	}
+
+	consumer := &KafkaConsumer{
+		reader:  reader,
+		writer:  writer,
+		options: options,
+		handler: handler,
+	}
+
+	consumer.lag.Store(-1)
+
+	return consumer
+}
+
+// Run consumes the topic until the context is canceled or a message can neither be stored nor dead lettered.
+// On shutdown the batch already fetched is stored and committed before the consumer leaves the group,
+// so that the partitions it owned are handed over without redelivering or losing messages.
+// When a message still fails to be stored after the retries, or cannot be dead lettered, the consumer leaves the
+// group without committing it, so that it is redelivered to the next owner of its partition.
+func (c *KafkaConsumer) Run(ctx context.Context) error {
+	slog.InfoContext(ctx, "consuming kafka topic", "topic", c.options.Topic, "group", c.options.Group)
+
+	unregister, err := c.registerLag()
+	if err != nil {
+		return errors.Join(err, c.Close())
+	}
+
+	defer func() {
+		if err := unregister(); err != nil {
+			slog.ErrorContext(ctx, "failed to unregister kafka consumer lag", "topic", c.options.Topic, "error", err)
+		}
+	}()
+
+	statsCtx, stopStats := context.WithCancel(ctx)
+	defer stopStats()
+
+	go c.reportStats(statsCtx)
+
+	for ctx.Err() == nil {
+		messages, err := c.fetch(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "failed to consume kafka topic", "topic", c.options.Topic, "error", err)
+
+			select {
+			case <-ctx.Done():
+			case <-time.After(c.options.BatchWait):
+			}
+		}
+
+		if len(messages) == 0 {
+			continue
+		}
+
+		if err := c.handle(ctx, messages); err != nil {
+			if errors.Is(err, errDeadLetter) || errors.Is(err, errNotStored) {
+				return errors.Join(fmt.Errorf("stopped consuming kafka topic %s: %w", c.options.Topic, err), c.Close())
+			}
+
+			slog.ErrorContext(ctx, "failed to handle kafka messages", "topic", c.options.Topic, "error", err)
+		}
+	}
+
+	return c.Close()
+}
+
+// Close leaves the consumer group and releases the connections to the cluster
+func (c *KafkaConsumer) Close() error {
+	return errors.Join(c.reader.Close(), c.writer.Close())
+}
+
+// fetch blocks until a message is available, then gathers more until the batch is full or BatchWait has elapsed.
+// The messages fetched before the context is canceled are returned so that they can still be stored.
+func (c *KafkaConsumer) fetch(ctx context.Context) ([]kafka.Message, error) {
+	message, err := c.reader.FetchMessage(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch message: %w", err)
+	}
+
+	messages := []kafka.Message{message}
+
+	waitCtx, cancel := context.WithTimeout(ctx, c.options.BatchWait)
+	defer cancel()
+
+	for len(messages) < c.options.BatchSize {
+		message, err := c.reader.FetchMessage(waitCtx)
+		if err != nil {
+			break
+		}
+
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
+
+// handle stores a batch of messages and commits their offsets.
+// When the batch is rejected its messages are stored one by one, so that a single invalid message does not hold
+// back the others. Messages that cannot be decoded or that storage turns down as invalid are dead lettered.
+// Other failures are retried up to MaxAttempts times, after which the messages stored so far are committed and
+// errNotStored is returned, leaving the failing message and the ones after it uncommitted.
+// Writes in progress are not interrupted by a shutdown, but no new retry is started once the context is canceled.
+func (c *KafkaConsumer) handle(ctx context.Context, messages []kafka.Message) error {
+	ctx, span := tracer.Start(ctx, "HandleKafkaMessages")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("topic", c.options.Topic), attribute.Int("messages", len(messages)))
+
+	writeCtx := context.WithoutCancel(ctx)
+
+	var (
+		bets    []*domain.Bet
+		decoded []kafka.Message
+	)
+
+	for _, message := range messages {
+		bet, err := codec.UnmarshalBet(message.Value)
+		if err != nil {
+			if err := c.deadLetter(writeCtx, message, err); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		bets = append(bets, bet)
+		decoded = append(decoded, message)
+	}
+
+	if len(bets) > 0 {
+		err := c.handler(writeCtx, bets)
+		if err != nil {
+			span.SetStatus(codes.Error, "failed to store batch")
+			span.RecordError(err)
+
+			slog.WarnContext(ctx, "failed to store batch, storing messages one by one",
+				"topic", c.options.Topic, "messages", len(bets), "error", err)
+
+			for i, bet := range bets {
+				stored, err := c.storeOne(ctx, bet)
+				if !stored && ctx.Err() != nil {
+					// the remaining messages are left uncommitted and redelivered to the next owner of their partitions
+					return c.commit(writeCtx, decoded[:i]...)
+				}
+
+				if err == nil {
+					continue
+				}
+
+				if !errors.Is(err, domain.ErrInvalidBet) {
+					span.SetStatus(codes.Error, "failed to store message")
+
+					return errors.Join(fmt.Errorf("%w %d/%d: %w", errNotStored, decoded[i].Partition, decoded[i].Offset, err),
+						c.commit(writeCtx, decoded[:i]...))
+				}
+
+				if err := c.deadLetter(writeCtx, decoded[i], err); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return c.commit(writeCtx, messages...)
+}
+
+// storeOne attempts to store a single bet up to MaxAttempts times, backing off between attempts.
+// A bet turned down as invalid is not retried since it would be turned down again.
+// It reports whether the bet was stored and the last error otherwise.
+func (c *KafkaConsumer) storeOne(ctx context.Context, bet *domain.Bet) (bool, error) {
+	backoff := c.options.RetryBackoff
+
+	var err error
+
+	for attempt := 1; attempt <= c.options.MaxAttempts; attempt++ {
+		if err = c.handler(context.WithoutCancel(ctx), []*domain.Bet{bet}); err == nil {
+			return true, nil
+		}
+
+		if errors.Is(err, domain.ErrInvalidBet) {
+			return false, err
+		}
+
+		if attempt == c.options.MaxAttempts {
+			break
+		}
+
+		slog.WarnContext(ctx, "failed to store message, retrying",
+			"topic", c.options.Topic, "bet_id", bet.BetID, "attempt", attempt, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+
+	return false, fmt.Errorf("not stored after %d attempts: %w", c.options.MaxAttempts, err)
+}
+
+// deadLetter copies a message to the dead letter topic along with the reason it failed and where it came from
+func (c *KafkaConsumer) deadLetter(ctx context.Context, message kafka.Message, reason error) error {
+	headers := append([]kafka.Header{}, message.Headers...)
+	headers = append(headers,
+		kafka.Header{Key: "error", Value: []byte(reason.Error())},
+		kafka.Header{Key: "source_topic", Value: []byte(message.Topic)},
+		kafka.Header{Key: "source_partition", Value: []byte(strconv.Itoa(message.Partition))},
+		kafka.Header{Key: "source_offset", Value: []byte(strconv.FormatInt(message.Offset, 10))},
+	)
+
+	err := c.writer.WriteMessages(ctx, kafka.Message{Key: message.Key, Value: message.Value, Headers: headers})
+	if err != nil {
+		return fmt.Errorf("%w %d/%d: %w", errDeadLetter, message.Partition, message.Offset, err)
+	}
+
+	slog.WarnContext(ctx, "dead lettered message",
+		"topic", message.Topic, "partition", message.Partition, "offset", message.Offset, "error", reason)
+
+	return nil
+}
+
+func (c *KafkaConsumer) commit(ctx context.Context, messages ...kafka.Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	if err := c.reader.CommitMessages(ctx, messages...); err != nil {
+		return fmt.Errorf("failed to commit offsets: %w", err)
+	}
+
+	return nil
+}
+
+// registerLag publishes the consumer lag as the maybets.kafka.consumer.lag gauge, refreshed every StatsInterval.
+// It returns the function that stops publishing it.
+func (c *KafkaConsumer) registerLag() (func() error, error) {
+	gauge, err := meter.Int64ObservableGauge("maybets.kafka.consumer.lag",
+		metric.WithDescription("Number of messages of the topic the consumer group has not read yet"),
+		metric.WithUnit("{message}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka consumer lag gauge: %w", err)
+	}
+
+	attributes := metric.WithAttributes(attribute.String("topic", c.options.Topic), attribute.String("group", c.options.Group))
+
+	registration, err := meter.RegisterCallback(func(_ context.Context, observer metric.Observer) error {
+		if lag := c.lag.Load(); lag >= 0 {
+			observer.ObserveInt64(gauge, lag, attributes)
+		}
+
+		return nil
+	}, gauge)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register kafka consumer lag gauge: %w", err)
+	}
+
+	return registration.Unregister, nil
+}
+
+// reportStats logs the consumer lag and throughput every StatsInterval until the context is canceled.
+// The lag is kept for the maybets.kafka.consumer.lag gauge.
+func (c *KafkaConsumer) reportStats(ctx context.Context) {
+	ticker := time.NewTicker(c.options.StatsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			stats := c.reader.Stats()
+
+			c.lag.Store(stats.Lag)
+
+			slog.InfoContext(ctx, "kafka consumer stats",
+				"topic", c.options.Topic,
+				"group", c.options.Group,
+				"lag", stats.Lag,
+				"offset", stats.Offset,
+				"messages", stats.Messages,
+				"rebalances", stats.Rebalances,
+				"errors", stats.Errors,
+			)
+		}
+	}
+}
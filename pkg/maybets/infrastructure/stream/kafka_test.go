@@ -0,0 +1,385 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// testKafkaBrokers returns the brokers at KAFKA_BROKERS, or a local one, and skips the test when none is reachable.
+// A single node Redpanda is enough to run these tests, see the README.
+func testKafkaBrokers(t *testing.T) []string {
+	t.Helper()
+
+	brokers := strings.Split(os.Getenv("KAFKA_BROKERS"), ",")
+	if brokers[0] == "" {
+		brokers = []string{"localhost:9092"}
+	}
+
+	conn, err := net.DialTimeout("tcp", brokers[0], time.Second)
+	if err != nil {
+		t.Skipf("kafka is not reachable at %s: %v", brokers[0], err)
+	}
+
+	conn.Close()
+
+	return brokers
+}
+
+// createTopic creates a single partition topic so that messages are consumed in the order they were produced
+func createTopic(t *testing.T, brokers []string, topic string) {
+	t.Helper()
+
+	client := &kafka.Client{Addr: kafka.TCP(brokers...)}
+
+	response, err := client.CreateTopics(context.Background(), &kafka.CreateTopicsRequest{
+		Topics: []kafka.TopicConfig{{Topic: topic, NumPartitions: 1, ReplicationFactor: 1}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+
+	if err := response.Errors[topic]; err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+}
+
+func produce(t *testing.T, brokers []string, topic string, payloads ...string) {
+	t.Helper()
+
+	createTopic(t, brokers, topic)
+
+	writer := &kafka.Writer{
+		Addr:  kafka.TCP(brokers...),
+		Topic: topic,
+	}
+	defer writer.Close()
+
+	messages := make([]kafka.Message, len(payloads))
+	for i, payload := range payloads {
+		messages[i] = kafka.Message{Value: []byte(payload)}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// the first write may fail while the topic leader is being elected
+	var err error
+	for ctx.Err() == nil {
+		if err = writer.WriteMessages(ctx, messages...); err == nil {
+			return
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	t.Fatalf("failed to produce messages: %v", err)
+}
+
+// committedOffset returns the offset committed by the group on the single partition of the topic
+func committedOffset(t *testing.T, brokers []string, group, topic string) int64 {
+	t.Helper()
+
+	client := &kafka.Client{Addr: kafka.TCP(brokers...)}
+
+	response, err := client.OffsetFetch(context.Background(), &kafka.OffsetFetchRequest{
+		GroupID: group,
+		Topics:  map[string][]int{topic: {0}},
+	})
+	if err != nil {
+		t.Fatalf("failed to fetch committed offset: %v", err)
+	}
+
+	partitions := response.Topics[topic]
+	if len(partitions) == 0 {
+		return -1
+	}
+
+	return partitions[0].CommittedOffset
+}
+
+// deadLettered reads every message of the dead letter topic
+func deadLettered(t *testing.T, brokers []string, topic string, want int) []kafka.Message {
+	t.Helper()
+
+	if want == 0 {
+		return nil
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{Brokers: brokers, Topic: topic, MaxWait: 100 * time.Millisecond})
+	defer reader.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var messages []kafka.Message
+
+	for len(messages) < want {
+		message, err := reader.ReadMessage(ctx)
+		if err != nil {
+			break
+		}
+
+		messages = append(messages, message)
+	}
+
+	return messages
+}
+
+func TestKafkaConsumer_Run(t *testing.T) {
+	brokers := testKafkaBrokers(t)
+
+	tests := []struct {
+		name           string
+		payloads       []string
+		invalid        map[string]bool
+		wantStored     []string
+		wantDeadLetter int
+	}{
+		{
+			name:       "success: batch stored and committed",
+			payloads:   []string{betPayload(t, "b1"), betPayload(t, "b2"), betPayload(t, "b3")},
+			wantStored: []string{"b1", "b2", "b3"},
+		},
+		{
+			name:           "success: undecodable message is dead lettered right away",
+			payloads:       []string{betPayload(t, "b1"), `{"bet_id": "b2", "outcome": "draw"}`, "not json"},
+			wantStored:     []string{"b1"},
+			wantDeadLetter: 2,
+		},
+		{
+			name:           "fail: invalid message is dead lettered while the rest of the batch is stored",
+			payloads:       []string{betPayload(t, "b1"), betPayload(t, "b2"), betPayload(t, "b3")},
+			invalid:        map[string]bool{"b2": true},
+			wantStored:     []string{"b1", "b3"},
+			wantDeadLetter: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			topic := fmt.Sprintf("test.bets.%d", time.Now().UnixNano())
+			options := KafkaOptions{
+				Brokers:       brokers,
+				Topic:         topic,
+				Group:         topic + ".maybets",
+				DeadLetter:    topic + ".dead-letter",
+				BatchSize:     10,
+				BatchWait:     100 * time.Millisecond,
+				MaxAttempts:   3,
+				RetryBackoff:  time.Millisecond,
+				StatsInterval: time.Second,
+			}
+
+			createTopic(t, brokers, options.DeadLetter)
+			produce(t, brokers, topic, tt.payloads...)
+
+			handler := &recordingHandler{stored: map[string]int{}, invalid: tt.invalid}
+			consumer := NewKafkaConsumer(options, handler.handle)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			done := make(chan error)
+
+			go func() {
+				done <- consumer.Run(ctx)
+			}()
+
+			deadline := time.Now().Add(30 * time.Second)
+			for committedOffset(t, brokers, options.Group, topic) < int64(len(tt.payloads)) {
+				if time.Now().After(deadline) {
+					t.Fatalf("offsets were not committed in time")
+				}
+
+				time.Sleep(100 * time.Millisecond)
+			}
+
+			cancel()
+
+			if err := <-done; err != nil {
+				t.Errorf("KafkaConsumer.Run() error = %v", err)
+			}
+
+			handler.mu.Lock()
+			defer handler.mu.Unlock()
+
+			for _, id := range tt.wantStored {
+				if handler.stored[id] != 1 {
+					t.Errorf("bet %s stored %d times, want 1", id, handler.stored[id])
+				}
+			}
+
+			if len(handler.stored) != len(tt.wantStored) {
+				t.Errorf("stored %d bets, want %d", len(handler.stored), len(tt.wantStored))
+			}
+
+			messages := deadLettered(t, brokers, options.DeadLetter, tt.wantDeadLetter)
+			if len(messages) != tt.wantDeadLetter {
+				t.Errorf("dead lettered %d messages, want %d", len(messages), tt.wantDeadLetter)
+			}
+
+			for _, message := range messages {
+				headers := map[string]string{}
+				for _, header := range message.Headers {
+					headers[header.Key] = string(header.Value)
+				}
+
+				if headers["source_topic"] != topic || headers["error"] == "" {
+					t.Errorf("dead lettered message headers = %v, want the source topic and the error", headers)
+				}
+			}
+		})
+	}
+}
+
+func TestKafkaConsumer_Run_DeadLetterFailure(t *testing.T) {
+	brokers := testKafkaBrokers(t)
+
+	topic := fmt.Sprintf("test.bets.%d", time.Now().UnixNano())
+	options := KafkaOptions{
+		Brokers: brokers,
+		Topic:   topic,
+		Group:   topic + ".maybets",
+		// kafka rejects topic names with spaces, so no message can be dead lettered
+		DeadLetter:    topic + " dead letter",
+		BatchSize:     10,
+		BatchWait:     100 * time.Millisecond,
+		MaxAttempts:   3,
+		RetryBackoff:  time.Millisecond,
+		StatsInterval: time.Second,
+	}
+
+	produce(t, brokers, topic, "not json", betPayload(t, "b1"))
+
+	handler := &recordingHandler{stored: map[string]int{}}
+	consumer := NewKafkaConsumer(options, handler.handle)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	err := consumer.Run(ctx)
+	if !errors.Is(err, errDeadLetter) {
+		t.Fatalf("KafkaConsumer.Run() error = %v, want a dead letter failure", err)
+	}
+
+	if offset := committedOffset(t, brokers, options.Group, topic); offset > 0 {
+		t.Errorf("committed offset = %d, want the message that was not dead lettered left uncommitted", offset)
+	}
+}
+
+func TestKafkaConsumer_Run_StoreFailure(t *testing.T) {
+	brokers := testKafkaBrokers(t)
+
+	topic := fmt.Sprintf("test.bets.%d", time.Now().UnixNano())
+	options := KafkaOptions{
+		Brokers:       brokers,
+		Topic:         topic,
+		Group:         topic + ".maybets",
+		DeadLetter:    topic + ".dead-letter",
+		BatchSize:     10,
+		BatchWait:     100 * time.Millisecond,
+		MaxAttempts:   3,
+		RetryBackoff:  time.Millisecond,
+		StatsInterval: time.Second,
+	}
+
+	createTopic(t, brokers, options.DeadLetter)
+	produce(t, brokers, topic, betPayload(t, "b1"), betPayload(t, "b2"), betPayload(t, "b3"))
+
+	handler := &recordingHandler{stored: map[string]int{}, failing: map[string]bool{"b2": true}}
+	consumer := NewKafkaConsumer(options, handler.handle)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	err := consumer.Run(ctx)
+	if !errors.Is(err, errNotStored) {
+		t.Fatalf("KafkaConsumer.Run() error = %v, want a storage failure", err)
+	}
+
+	if offset := committedOffset(t, brokers, options.Group, topic); offset != 1 {
+		t.Errorf("committed offset = %d, want only the message stored before the failure committed", offset)
+	}
+
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+
+	if len(handler.stored) != 1 || handler.stored["b1"] != 1 {
+		t.Errorf("stored bets = %v, want only b1", handler.stored)
+	}
+
+	if messages := deadLettered(t, brokers, options.DeadLetter, 1); len(messages) != 0 {
+		t.Errorf("dead lettered %d messages, want none", len(messages))
+	}
+}
+
+func TestKafkaConsumer_registerLag(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	tests := []struct {
+		name    string
+		lag     int64
+		wantLag []int64
+	}{
+		{
+			name:    "success: lag reported by the reader",
+			lag:     42,
+			wantLag: []int64{42},
+		},
+		{
+			name: "success: nothing published before the first report",
+			lag:  -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consumer := NewKafkaConsumer(KafkaOptions{Brokers: []string{"localhost:9092"}, Topic: "bets"}, nil)
+			defer consumer.Close()
+
+			unregister, err := consumer.registerLag()
+			if err != nil {
+				t.Fatalf("KafkaConsumer.registerLag() error = %v", err)
+			}
+			defer unregister()
+
+			consumer.lag.Store(tt.lag)
+
+			var metrics metricdata.ResourceMetrics
+			if err := reader.Collect(context.Background(), &metrics); err != nil {
+				t.Fatalf("ManualReader.Collect() error = %v", err)
+			}
+
+			var got []int64
+
+			for _, scope := range metrics.ScopeMetrics {
+				for _, m := range scope.Metrics {
+					if m.Name != "maybets.kafka.consumer.lag" {
+						continue
+					}
+
+					for _, point := range m.Data.(metricdata.Gauge[int64]).DataPoints {
+						got = append(got, point.Value)
+					}
+				}
+			}
+
+			if !reflect.DeepEqual(got, tt.wantLag) {
+				t.Errorf("maybets.kafka.consumer.lag = %v, want %v", got, tt.wantLag)
+			}
+		})
+	}
+}
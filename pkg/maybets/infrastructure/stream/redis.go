@@ -0,0 +1,292 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/codec"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+	"github.com/go-redis/redis"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// BetField is the stream entry field holding the JSON encoded bet
+const BetField = "bet"
+
+// RedisOptions controls how a Redis stream is consumed
+type RedisOptions struct {
+	// Stream is the key of the stream bets are published on
+	Stream string
+	// Group is the consumer group shared by every consumer instance
+	Group string
+	// Consumer identifies this instance within the group
+	Consumer string
+	// DeadLetter is the key of the stream that receives the messages that cannot be stored
+	DeadLetter string
+	// BatchSize is the maximum number of messages stored together
+	BatchSize int
+	// BatchWait is how long a read waits for new messages
+	BatchWait time.Duration
+	// MaxDeliveries is the number of times a message is attempted before it is dead lettered
+	MaxDeliveries int64
+	// RetryIdle is how long a message stays unacknowledged before it is attempted again
+	RetryIdle time.Duration
+}
+
+// RedisConsumer reads bets from a Redis stream through a consumer group
+type RedisConsumer struct {
+	client    *redis.Client
+	options   RedisOptions
+	handler   Handler
+	lastRetry time.Time
+}
+
+// NewRedisConsumer initializes a new RedisConsumer, creating the stream and the consumer group if needed.
+// A new group starts from the beginning of the stream so that bets published before it existed are stored too.
+func NewRedisConsumer(client *redis.Client, options RedisOptions, handler Handler) (*RedisConsumer, error) {
+	err := client.XGroupCreateMkStream(options.Stream, options.Group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return nil, fmt.Errorf("failed to create consumer group %s on stream %s: %w", options.Group, options.Stream, err)
+	}
+
+	return &RedisConsumer{
+		client:  client,
+		options: options,
+		handler: handler,
+	}, nil
+}
+
+// Run consumes the stream until the context is canceled.
+// Messages left unacknowledged, by this or a crashed consumer, are retried once they have been idle for RetryIdle.
+func (c *RedisConsumer) Run(ctx context.Context) error {
+	slog.InfoContext(ctx, "consuming redis stream",
+		"stream", c.options.Stream, "group", c.options.Group, "consumer", c.options.Consumer)
+
+	for ctx.Err() == nil {
+		if err := c.Poll(ctx); err != nil {
+			slog.ErrorContext(ctx, "failed to consume redis stream", "stream", c.options.Stream, "error", err)
+
+			select {
+			case <-ctx.Done():
+			case <-time.After(c.options.BatchWait):
+			}
+		}
+	}
+
+	return nil
+}
+
+// Poll retries the idle pending messages when they are due, then stores the next batch of new messages
+func (c *RedisConsumer) Poll(ctx context.Context) error {
+	if time.Since(c.lastRetry) >= c.options.RetryIdle {
+		if err := c.retryPending(ctx); err != nil {
+			return err
+		}
+
+		c.lastRetry = time.Now()
+	}
+
+	streams, err := c.client.XReadGroup(&redis.XReadGroupArgs{
+		Group:    c.options.Group,
+		Consumer: c.options.Consumer,
+		Streams:  []string{c.options.Stream, ">"},
+		Count:    int64(c.options.BatchSize),
+		Block:    c.options.BatchWait,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to read stream: %w", err)
+	}
+
+	for _, stream := range streams {
+		if err := c.handle(ctx, stream.Messages); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// handle stores a batch of messages and acknowledges the ones that were stored.
+// When the batch is rejected its messages are stored one by one so that a single bad message does not hold back the others.
+// Messages that still fail stay pending and are retried later.
+func (c *RedisConsumer) handle(ctx context.Context, messages []redis.XMessage) error {
+	ctx, span := tracer.Start(ctx, "HandleRedisMessages")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("stream", c.options.Stream), attribute.Int("messages", len(messages)))
+
+	var (
+		bets []*domain.Bet
+		ids  []string
+	)
+
+	for _, message := range messages {
+		bet, err := decodeMessage(message)
+		if err != nil {
+			if err := c.deadLetter(ctx, message, err); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		bets = append(bets, bet)
+		ids = append(ids, message.ID)
+	}
+
+	if len(bets) == 0 {
+		return nil
+	}
+
+	err := c.handler(ctx, bets)
+	if err == nil {
+		return c.ack(ids...)
+	}
+
+	span.SetStatus(codes.Error, "failed to store batch")
+	span.RecordError(err)
+
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	slog.WarnContext(ctx, "failed to store batch, storing messages one by one",
+		"stream", c.options.Stream, "messages", len(bets), "error", err)
+
+	for i, bet := range bets {
+		if err := c.handler(ctx, []*domain.Bet{bet}); err != nil {
+			slog.WarnContext(ctx, "failed to store message, it will be retried",
+				"stream", c.options.Stream, "id", ids[i], "error", err)
+
+			continue
+		}
+
+		if err := c.ack(ids[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// retryPending claims the messages that have been pending for RetryIdle and attempts them again.
+// Messages that were already delivered MaxDeliveries times are dead lettered instead.
+func (c *RedisConsumer) retryPending(ctx context.Context) error {
+	pending, err := c.client.XPendingExt(&redis.XPendingExtArgs{
+		Stream: c.options.Stream,
+		Group:  c.options.Group,
+		Start:  "-",
+		End:    "+",
+		Count:  int64(c.options.BatchSize),
+	}).Result()
+	if err != nil {
+		return fmt.Errorf("failed to list pending messages: %w", err)
+	}
+
+	var retry []string
+
+	for _, entry := range pending {
+		if entry.Idle < c.options.RetryIdle {
+			continue
+		}
+
+		if entry.RetryCount < c.options.MaxDeliveries {
+			retry = append(retry, entry.Id)
+			continue
+		}
+
+		messages, err := c.client.XRangeN(c.options.Stream, entry.Id, entry.Id, 1).Result()
+		if err != nil {
+			return fmt.Errorf("failed to read pending message %s: %w", entry.Id, err)
+		}
+
+		// the message was trimmed from the stream, there is nothing left to store
+		if len(messages) == 0 {
+			if err := c.ack(entry.Id); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		err = c.deadLetter(ctx, messages[0], fmt.Errorf("not stored after %d deliveries", entry.RetryCount))
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(retry) == 0 {
+		return nil
+	}
+
+	claimed, err := c.client.XClaim(&redis.XClaimArgs{
+		Stream:   c.options.Stream,
+		Group:    c.options.Group,
+		Consumer: c.options.Consumer,
+		MinIdle:  c.options.RetryIdle,
+		Messages: retry,
+	}).Result()
+	if err != nil {
+		return fmt.Errorf("failed to claim pending messages: %w", err)
+	}
+
+	return c.handle(ctx, claimed)
+}
+
+// deadLetter copies a message to the dead letter stream along with the reason it failed, then acknowledges it
+func (c *RedisConsumer) deadLetter(ctx context.Context, message redis.XMessage, reason error) error {
+	values := make(map[string]interface{}, len(message.Values)+3)
+	for field, value := range message.Values {
+		values[field] = value
+	}
+
+	values["error"] = reason.Error()
+	values["source_stream"] = c.options.Stream
+	values["source_id"] = message.ID
+
+	_, err := c.client.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.XAdd(&redis.XAddArgs{Stream: c.options.DeadLetter, Values: values})
+		pipe.XAck(c.options.Stream, c.options.Group, message.ID)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to dead letter message %s: %w", message.ID, err)
+	}
+
+	slog.WarnContext(ctx, "dead lettered message", "stream", c.options.Stream, "id", message.ID, "error", reason)
+
+	return nil
+}
+
+func (c *RedisConsumer) ack(ids ...string) error {
+	if err := c.client.XAck(c.options.Stream, c.options.Group, ids...).Err(); err != nil {
+		return fmt.Errorf("failed to acknowledge messages: %w", err)
+	}
+
+	return nil
+}
+
+// decodeMessage decodes the bet carried by a stream entry
+func decodeMessage(message redis.XMessage) (*domain.Bet, error) {
+	value, ok := message.Values[BetField]
+	if !ok {
+		return nil, fmt.Errorf("message has no %q field", BetField)
+	}
+
+	data, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %q field type %T", BetField, value)
+	}
+
+	return codec.UnmarshalBet([]byte(data))
+}
@@ -0,0 +1,226 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+	"github.com/go-redis/redis"
+)
+
+// testRedisClient connects to the redis server at REDIS_URL, or a local one, and skips the test when none is reachable
+func testRedisClient(t *testing.T) *redis.Client {
+	t.Helper()
+
+	url := os.Getenv("REDIS_URL")
+	if url == "" {
+		url = "redis://localhost:6379/0"
+	}
+
+	options, err := redis.ParseURL(url)
+	if err != nil {
+		t.Fatalf("invalid REDIS_URL: %v", err)
+	}
+
+	client := redis.NewClient(options)
+	if err := client.Ping().Err(); err != nil {
+		t.Skipf("redis is not reachable at %s: %v", url, err)
+	}
+
+	t.Cleanup(func() {
+		client.Close()
+	})
+
+	return client
+}
+
+func publish(t *testing.T, client *redis.Client, stream string, payloads ...string) {
+	t.Helper()
+
+	for _, payload := range payloads {
+		err := client.XAdd(&redis.XAddArgs{Stream: stream, Values: map[string]interface{}{BetField: payload}}).Err()
+		if err != nil {
+			t.Fatalf("failed to publish message: %v", err)
+		}
+	}
+}
+
+func betPayload(t *testing.T, betID string) string {
+	t.Helper()
+
+	data, err := json.Marshal(domain.Bet{
+		BetID: betID, UserID: "u1", Amount: 10, Odds: 2, Outcome: enums.Win, Timestamp: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("failed to encode bet: %v", err)
+	}
+
+	return string(data)
+}
+
+// recordingHandler stores bets in memory and rejects any batch holding a bet listed in failing
+type recordingHandler struct {
+	mu      sync.Mutex
+	stored  map[string]int
+	failing map[string]bool
+	// invalid holds the bets turned down as invalid, as opposed to failing ones that hit a storage error
+	invalid map[string]bool
+}
+
+func (h *recordingHandler) handle(_ context.Context, bets []*domain.Bet) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, bet := range bets {
+		if h.failing[bet.BetID] {
+			return fmt.Errorf("bet %s rejected", bet.BetID)
+		}
+
+		if h.invalid[bet.BetID] {
+			return fmt.Errorf("%w %s: unsupported currency", domain.ErrInvalidBet, bet.BetID)
+		}
+	}
+
+	for _, bet := range bets {
+		h.stored[bet.BetID]++
+	}
+
+	return nil
+}
+
+func TestRedisConsumer_Poll(t *testing.T) {
+	client := testRedisClient(t)
+
+	tests := []struct {
+		name           string
+		payloads       []string
+		failing        map[string]bool
+		recover        bool
+		polls          int
+		wantStored     []string
+		wantDeadLetter int
+		wantPending    int64
+	}{
+		{
+			name:       "success: batch stored and acknowledged",
+			payloads:   []string{betPayload(t, "b1"), betPayload(t, "b2"), betPayload(t, "b3")},
+			polls:      1,
+			wantStored: []string{"b1", "b2", "b3"},
+		},
+		{
+			name:           "success: undecodable message is dead lettered right away",
+			payloads:       []string{betPayload(t, "b1"), `{"bet_id": "b2", "outcome": "draw"}`, "not json"},
+			polls:          1,
+			wantStored:     []string{"b1"},
+			wantDeadLetter: 2,
+		},
+		{
+			name:        "success: rejected message stays pending while the rest of the batch is stored",
+			payloads:    []string{betPayload(t, "b1"), betPayload(t, "b2")},
+			failing:     map[string]bool{"b2": true},
+			polls:       1,
+			wantStored:  []string{"b1"},
+			wantPending: 1,
+		},
+		{
+			name:       "success: pending message is stored once the failure is resolved",
+			payloads:   []string{betPayload(t, "b1"), betPayload(t, "b2")},
+			failing:    map[string]bool{"b2": true},
+			recover:    true,
+			polls:      3,
+			wantStored: []string{"b1", "b2"},
+		},
+		{
+			name:           "fail: message that keeps failing is dead lettered",
+			payloads:       []string{betPayload(t, "b1")},
+			failing:        map[string]bool{"b1": true},
+			polls:          6,
+			wantDeadLetter: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := fmt.Sprintf("test:bets:%d", time.Now().UnixNano())
+			options := RedisOptions{
+				Stream:        key,
+				Group:         "maybets",
+				Consumer:      "test",
+				DeadLetter:    key + ":dead-letter",
+				BatchSize:     10,
+				BatchWait:     10 * time.Millisecond,
+				MaxDeliveries: 3,
+				RetryIdle:     time.Millisecond,
+			}
+
+			t.Cleanup(func() {
+				client.Del(options.Stream, options.DeadLetter)
+			})
+
+			publish(t, client, key, tt.payloads...)
+
+			failing := map[string]bool{}
+			for id := range tt.failing {
+				failing[id] = true
+			}
+
+			handler := &recordingHandler{stored: map[string]int{}, failing: failing}
+
+			consumer, err := NewRedisConsumer(client, options, handler.handle)
+			if err != nil {
+				t.Fatalf("NewRedisConsumer() error = %v", err)
+			}
+
+			ctx := context.Background()
+
+			for i := range tt.polls {
+				if i == 1 && tt.recover {
+					handler.mu.Lock()
+					handler.failing = map[string]bool{}
+					handler.mu.Unlock()
+				}
+
+				time.Sleep(2 * options.RetryIdle)
+
+				if err := consumer.Poll(ctx); err != nil {
+					t.Fatalf("RedisConsumer.Poll() error = %v", err)
+				}
+			}
+
+			for _, id := range tt.wantStored {
+				if handler.stored[id] != 1 {
+					t.Errorf("bet %s stored %d times, want 1", id, handler.stored[id])
+				}
+			}
+
+			if len(handler.stored) != len(tt.wantStored) {
+				t.Errorf("stored %d bets, want %d", len(handler.stored), len(tt.wantStored))
+			}
+
+			deadLettered, err := client.XLen(options.DeadLetter).Result()
+			if err != nil && !errors.Is(err, redis.Nil) {
+				t.Fatalf("failed to read dead letter stream: %v", err)
+			}
+
+			if deadLettered != int64(tt.wantDeadLetter) {
+				t.Errorf("dead lettered %d messages, want %d", deadLettered, tt.wantDeadLetter)
+			}
+
+			pending, err := client.XPending(options.Stream, options.Group).Result()
+			if err != nil {
+				t.Fatalf("failed to read pending messages: %v", err)
+			}
+
+			if pending.Count != tt.wantPending {
+				t.Errorf("%d messages pending, want %d", pending.Count, tt.wantPending)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+// Package stream consumes bets published on message transports and hands them to storage in micro-batches
+package stream
+
+import (
+	"context"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+	"go.opentelemetry.io/otel"
+)
+
+var (
+	tracer = otel.Tracer("github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/stream")
+	meter  = otel.Meter("github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/stream")
+)
+
+// Handler stores a batch of decoded bets.
+// Messages are only acknowledged once the handler has returned without an error.
+type Handler func(ctx context.Context, bets []*domain.Bet) error
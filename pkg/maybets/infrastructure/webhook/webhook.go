@@ -0,0 +1,123 @@
+// Package webhook delivers signed alert notifications over HTTP
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+var tracer = otel.Tracer("github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/webhook")
+
+// Headers sent with every delivery
+const (
+	// EventHeader holds the event of the alert
+	EventHeader = "X-Maybets-Event"
+	// DeliveryHeader holds the delivery ID, identical across the attempts of a delivery
+	DeliveryHeader = "X-Maybets-Delivery"
+	// TimestampHeader holds the unix time the attempt was signed at
+	TimestampHeader = "X-Maybets-Timestamp"
+	// SignatureHeader holds "sha256=" followed by the hex encoded signature of the attempt, see Sign
+	SignatureHeader = "X-Maybets-Signature"
+)
+
+// signaturePrefix names the algorithm of the signature
+const signaturePrefix = "sha256="
+
+// Client sends webhook deliveries
+type Client struct {
+	http *http.Client
+}
+
+// NewClient initializes a new Client whose requests are aborted after timeout
+func NewClient(timeout time.Duration) *Client {
+	return &Client{
+		http: &http.Client{Timeout: timeout},
+	}
+}
+
+// Send posts the delivery payload to the subscription URL, signed with the subscription secret.
+// Any status outside of 2xx is reported as an error along with the status.
+func (c *Client) Send(ctx context.Context, delivery domain.WebhookDelivery) (int, error) {
+	ctx, span := tracer.Start(ctx, "SendWebhook")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("delivery_id", delivery.ID),
+		attribute.String("event", delivery.Event.String()),
+		attribute.Int("attempt", delivery.Attempts+1),
+	)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "maybets-webhooks")
+	request.Header.Set(EventHeader, delivery.Event.String())
+	request.Header.Set(DeliveryHeader, delivery.ID)
+	request.Header.Set(TimestampHeader, timestamp)
+	request.Header.Set(SignatureHeader, signaturePrefix+Sign(delivery.Secret, timestamp, delivery.Payload))
+
+	response, err := c.http.Do(request)
+	if err != nil {
+		span.SetStatus(codes.Error, "Failed to send webhook")
+		span.RecordError(err)
+
+		return 0, fmt.Errorf("failed to send webhook: %w", err)
+	}
+
+	defer response.Body.Close()
+
+	span.SetAttributes(attribute.Int("status", response.StatusCode))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		// a short excerpt of the answer helps the subscriber understand why the delivery failed
+		excerpt, _ := io.ReadAll(io.LimitReader(response.Body, 256))
+
+		err := fmt.Errorf("unexpected status %d: %s", response.StatusCode, strings.TrimSpace(string(excerpt)))
+
+		span.SetStatus(codes.Error, "Webhook rejected")
+		span.RecordError(err)
+
+		return response.StatusCode, err
+	}
+
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	return response.StatusCode, nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the timestamp, a dot and the payload, keyed with the secret.
+// Including the timestamp lets subscribers reject replayed deliveries.
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature header of a delivery, comparing in constant time
+func Verify(secret, timestamp string, payload []byte, signature string) bool {
+	expected := signaturePrefix + Sign(secret, timestamp, payload)
+
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
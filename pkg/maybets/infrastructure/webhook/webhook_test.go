@@ -0,0 +1,125 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+)
+
+func TestClient_Send(t *testing.T) {
+	payload := []byte(`{"id":"a1","event":"bet.large","user_id":"u1","bet_id":"b1","value":5000,"threshold":1000}`)
+
+	tests := []struct {
+		name       string
+		status     int
+		secret     string
+		wantStatus int
+		wantErr    bool
+	}{
+		{
+			name:       "success: signed delivery accepted",
+			status:     http.StatusNoContent,
+			secret:     "s3cret",
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "fail: receiver rejects the delivery",
+			status:     http.StatusInternalServerError,
+			secret:     "s3cret",
+			wantStatus: http.StatusInternalServerError,
+			wantErr:    true,
+		},
+		{
+			name:       "fail: receiver does not recognise the signature",
+			status:     http.StatusNoContent,
+			secret:     "another secret",
+			wantStatus: http.StatusUnauthorized,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Errorf("failed to read delivery: %v", err)
+				}
+
+				if r.Header.Get(EventHeader) != enums.LargeBet.String() || r.Header.Get(DeliveryHeader) != "d1" {
+					t.Errorf("delivery headers = %v", r.Header)
+				}
+
+				if !Verify("s3cret", r.Header.Get(TimestampHeader), body, r.Header.Get(SignatureHeader)) {
+					http.Error(w, "invalid signature", http.StatusUnauthorized)
+					return
+				}
+
+				w.WriteHeader(tt.status)
+			}))
+			defer receiver.Close()
+
+			client := NewClient(time.Second)
+
+			got, err := client.Send(context.Background(), domain.WebhookDelivery{
+				ID:      "d1",
+				URL:     receiver.URL,
+				Secret:  tt.secret,
+				Event:   enums.LargeBet,
+				Payload: payload,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.wantStatus {
+				t.Errorf("Client.Send() = %v, want %v", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	payload := []byte(`{"event":"anomaly.detected"}`)
+	signature := "sha256=" + Sign("s3cret", "1700000000", payload)
+
+	tests := []struct {
+		name      string
+		timestamp string
+		payload   []byte
+		want      bool
+	}{
+		{
+			name:      "success: matching signature",
+			timestamp: "1700000000",
+			payload:   payload,
+			want:      true,
+		},
+		{
+			name:      "fail: tampered payload",
+			timestamp: "1700000000",
+			payload:   []byte(`{"event":"bet.large"}`),
+			want:      false,
+		},
+		{
+			name:      "fail: replayed with another timestamp",
+			timestamp: "1700000600",
+			payload:   payload,
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Verify("s3cret", tt.timestamp, tt.payload, signature); got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
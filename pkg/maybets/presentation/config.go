@@ -2,20 +2,24 @@ package presentation
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
 	"net/http"
-	"os"
 	"regexp"
 	"time"
 
-	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/helpers"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/config"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure"
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/cache"
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/database/postgres"
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/database/postgres/gorm"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/pubsub"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/webhook"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/presentation/graphql"
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/presentation/rest"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/presentation/rpc"
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/usecases"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -50,30 +54,50 @@ func isAllowedOrigin(origin string, compiledPatterns []*regexp.Regexp) bool {
 	return false
 }
 
-// StartServer sets up gin
-func StartServer(ctx context.Context, port int) error {
-	otelShutdown, err := helpers.SetupOTelSDK(ctx)
+// StartServer sets up gin. Tracing is set up by the caller.
+func StartServer(ctx context.Context, cfg *config.Config) error {
+	maybetUsecases, err := ConfigureStartUpDependencies(cfg)
 	if err != nil {
 		return err
 	}
 
-	defer func() {
-		err = errors.Join(err, otelShutdown(ctx))
-	}()
+	// alerts raised by every command are delivered by the server
+	go maybetUsecases.RunWebhooks(ctx)
+	go maybetUsecases.RunLeaderboard(ctx)
+	go maybetUsecases.RunExposure(ctx)
+	go maybetUsecases.RunAML(ctx)
 
-	maybetUsecases, err := ConfigureStartUpDependencies()
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPCPort))
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to listen for grpc: %w", err)
 	}
 
-	r := gin.Default()
+	grpcServer := rpc.NewGRPCServer(maybetUsecases)
+	defer grpcServer.Stop()
+
+	go func() {
+		slog.InfoContext(ctx, "starting grpc server", "addr", listener.Addr().String())
+
+		if err := grpcServer.Serve(listener); err != nil {
+			slog.ErrorContext(ctx, "grpc server stopped", "error", err)
+		}
+	}()
+
+	if cfg.Environment != enums.Local {
+		gin.SetMode(gin.ReleaseMode)
+	}
 
-	SetupRoutes(r, *maybetUsecases)
+	r := gin.New()
+	r.Use(gin.Recovery())
 
-	addr := fmt.Sprintf(":%d", port)
+	SetupRoutes(r, cfg, *maybetUsecases)
+
+	addr := fmt.Sprintf(":%d", cfg.Port)
+
+	slog.InfoContext(ctx, "starting server", "addr", addr)
 
 	if err := r.Run(addr); err != nil {
-		log.Println(err.Error())
+		slog.ErrorContext(ctx, "server stopped", "error", err)
 		return err
 	}
 
@@ -81,15 +105,13 @@ func StartServer(ctx context.Context, port int) error {
 }
 
 // ConfigureStartUpDependencies is used to initialize all the constructors required for the application to start
-func ConfigureStartUpDependencies() (*usecases.UsecaseMayBets, error) {
-	db, err := gorm.NewDBInstance()
+func ConfigureStartUpDependencies(cfg *config.Config) (*usecases.UsecaseMayBets, error) {
+	db, err := gorm.NewDBInstance(cfg.SQLite.Path)
 	if err != nil {
 		return nil, err
 	}
 
-	redisURL := os.Getenv("REDIS_URL")
-
-	opt, err := redis.ParseURL(redisURL)
+	opt, err := redis.ParseURL(cfg.Redis.URL)
 	if err != nil {
 		return nil, err
 	}
@@ -102,16 +124,23 @@ func ConfigureStartUpDependencies() (*usecases.UsecaseMayBets, error) {
 		return nil, err
 	}
 
-	err = postgres.RunMigrations()
+	err = postgres.RunMigrations(cfg.SQLite.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	exchange, err := cfg.Money.Exchange()
 	if err != nil {
 		return nil, err
 	}
 
-	database := postgres.NewMaybetsDB(cacheSvc, db, db)
+	database := postgres.NewMaybetsDB(cacheSvc, db, db, exchange)
 
-	infra := infrastructure.NewInfrastructureInteractor(cacheSvc, database)
+	infra := infrastructure.NewInfrastructureInteractor(
+		cacheSvc, database, webhook.NewClient(cfg.Webhooks.Timeout), pubsub.NewBroker(cfg.Live.Buffer),
+	)
 
-	maybetUsecases, err := usecases.NewUsecaseMayBetsImpl(*infra)
+	maybetUsecases, err := usecases.NewUsecaseMayBetsImpl(*infra, cfg.Webhooks, cfg.Live, cfg.Limits, cfg.AML, cfg.Linked, exchange)
 	if err != nil {
 		return nil, fmt.Errorf("can't instantiate service : %w", err)
 	}
@@ -119,7 +148,7 @@ func ConfigureStartUpDependencies() (*usecases.UsecaseMayBets, error) {
 	return maybetUsecases, nil
 }
 
-func SetupRoutes(r *gin.Engine, usecases usecases.UsecaseMayBets) {
+func SetupRoutes(r *gin.Engine, cfg *config.Config, usecases usecases.UsecaseMayBets) {
 	compiledPatterns := compilePatterns(allowedOriginPatterns)
 
 	r.Use(cors.New(cors.Config{
@@ -153,7 +182,8 @@ func SetupRoutes(r *gin.Engine, usecases usecases.UsecaseMayBets) {
 		AllowWebSockets: true,
 	}))
 
-	r.Use(otelgin.Middleware(fmt.Sprintf("maybets-%v", os.Getenv("ENVIRONMENT"))))
+	r.Use(otelgin.Middleware(cfg.ServiceName()))
+	r.Use(rest.RequestLogger())
 
 	handlers := rest.NewHandlersInterfaces(&usecases)
 
@@ -166,4 +196,53 @@ func SetupRoutes(r *gin.Engine, usecases usecases.UsecaseMayBets) {
 	analytics.GET("/total_winnings", handlers.GetUserTotalWinnings)
 	analytics.GET("/top_users", handlers.GetTopFiveUsers)
 	analytics.GET("/anomalies", handlers.GetAllAnomalousUsers)
+	analytics.GET("/anomalies/linked", handlers.GetLinkedAccounts)
+	analytics.GET("/breakdown", handlers.GetBreakdown)
+	analytics.GET("/exposure", handlers.GetExposure)
+
+	// group bet data apis
+	bets := apiV1RoutesGroup.Group("/bets")
+	bets.GET("/export", handlers.ExportBets)
+	bets.POST("", handlers.CreateBets)
+	bets.POST("/:bet_id/settlement", handlers.SettleBet)
+
+	// group event and settlement apis
+	events := apiV1RoutesGroup.Group("/events")
+	events.POST("", handlers.SaveEvent)
+	events.GET("/:id", handlers.GetEvent)
+
+	selections := apiV1RoutesGroup.Group("/selections")
+	selections.POST("/:id/results", handlers.SettleSelection)
+	selections.GET("/:id/results", handlers.ListSelectionResults)
+
+	// group responsible gambling apis
+	users := apiV1RoutesGroup.Group("/users")
+	users.GET("/:user_id/limits", handlers.GetLimitStanding)
+	users.PUT("/:user_id/limits/:period", handlers.SaveUserLimits)
+
+	exclusions := apiV1RoutesGroup.Group("/exclusions")
+	exclusions.POST("", handlers.CreateSelfExclusion)
+	exclusions.GET("", handlers.ListSelfExclusions)
+	exclusions.GET("/violations", handlers.ListExclusionViolations)
+	exclusions.GET("/report", handlers.GetExclusionReport)
+	exclusions.POST("/:id/lift", handlers.LiftSelfExclusion)
+
+	// group anti-money laundering apis
+	aml := apiV1RoutesGroup.Group("/aml")
+	aml.GET("/report", handlers.GetAMLReport)
+	aml.GET("/alerts", handlers.ListAMLAlerts)
+
+	// live feed over SSE or WebSocket
+	apiV1RoutesGroup.GET("/stream", handlers.Stream)
+
+	// flexible analytics queries
+	apiV1RoutesGroup.POST("/graphql", graphql.Handler(&usecases, cfg.GraphQL))
+
+	// group webhook apis
+	webhooks := apiV1RoutesGroup.Group("/webhooks")
+	webhooks.POST("", handlers.CreateWebhookSubscription)
+	webhooks.GET("", handlers.ListWebhookSubscriptions)
+	webhooks.GET("/dead_letters", handlers.ListWebhookDeadLetters)
+	webhooks.GET("/:id", handlers.GetWebhookSubscription)
+	webhooks.DELETE("/:id", handlers.DeleteWebhookSubscription)
 }
@@ -0,0 +1,94 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// unboundedListSize is the size assumed for lists that take neither a limit nor ids
+const unboundedListSize = 100.0
+
+// complexity estimates the cost of running the operation of a query before any resolver runs.
+// Every field costs 1 and the cost of the fields selected under a list is multiplied by its size,
+// taken from its limit or ids argument. The cost is a float so that absurd sizes cannot overflow it.
+// Queries that fail to parse or validate, or that do not name the operation to run, are rejected with an error
+// rather than costed, since nothing can be told of what running them would take.
+func complexity(query, operationName string, variables map[string]interface{}) (cost float64, err error) {
+	document, errs := gqlparser.LoadQuery(analysisSchema, query)
+	if len(errs) > 0 {
+		// every error of the list is written on a line of its own
+		return 0, fmt.Errorf("invalid query: %s", strings.TrimSpace(errs.Error()))
+	}
+
+	operation := document.Operations.ForName(operationName)
+	if operationName == "" && len(document.Operations) == 1 {
+		operation = document.Operations[0]
+	}
+
+	if operation == nil {
+		if operationName == "" {
+			return 0, fmt.Errorf("invalid query: an operation name is required when the query holds several operations")
+		}
+
+		return 0, fmt.Errorf("invalid query: no operation named %q", operationName)
+	}
+
+	// argument values that do not match the variable definitions panic while being read
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			cost, err = 0, fmt.Errorf("invalid variables: %v", recovered)
+		}
+	}()
+
+	return selectionCost(operation.SelectionSet, variables), nil
+}
+
+func selectionCost(selections ast.SelectionSet, variables map[string]interface{}) float64 {
+	var cost float64
+
+	for _, selection := range selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if selection.Definition == nil {
+				continue
+			}
+
+			cost += 1 + listSize(selection, variables)*selectionCost(selection.SelectionSet, variables)
+		case *ast.InlineFragment:
+			cost += selectionCost(selection.SelectionSet, variables)
+		case *ast.FragmentSpread:
+			// validation rejects fragment cycles so the recursion ends
+			if selection.Definition != nil {
+				cost += selectionCost(selection.Definition.SelectionSet, variables)
+			}
+		}
+	}
+
+	return cost
+}
+
+// listSize returns the number of items a field resolves to at most
+func listSize(field *ast.Field, variables map[string]interface{}) float64 {
+	if field.Definition.Type.Elem == nil {
+		return 1
+	}
+
+	args := field.ArgumentMap(variables)
+
+	if ids, ok := args["ids"].([]interface{}); ok {
+		return float64(len(ids))
+	}
+
+	// negative limits are rejected by the resolvers, they must not lower the cost of the rest of the query
+	switch limit := args["limit"].(type) {
+	case int64:
+		return max(float64(limit), 0)
+	case float64:
+		return max(limit, 0)
+	}
+
+	return unboundedListSize
+}
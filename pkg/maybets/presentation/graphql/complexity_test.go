@@ -0,0 +1,77 @@
+package graphql
+
+import (
+	"testing"
+)
+
+func TestComplexity(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		want      float64
+		wantErr   bool
+	}{
+		{
+			name:  "success: scalar fields of a single user",
+			query: `{ user(id: "u1") { id totalBets } }`,
+			want:  3,
+		},
+		{
+			name:  "success: default limits multiply the nested fields",
+			query: `{ leaderboard { id recentBets { id amount } } }`,
+			want:  1 + 5*(1+1+10*2),
+		},
+		{
+			name:  "success: ids size the list of users",
+			query: `{ users(ids: ["u1", "u2", "u3"]) { id } }`,
+			want:  1 + 3*1,
+		},
+		{
+			name:      "success: limit taken from the variables",
+			query:     `query Bets($limit: Int) { bets(limit: $limit) { id user { id } } }`,
+			variables: map[string]interface{}{"limit": float64(20)},
+			want:      1 + 20*(1+1+1),
+		},
+		{
+			name: "success: fields selected through fragments",
+			query: `{ alerts(limit: 2) { ...alert } }
+				fragment alert on Alert { id event }`,
+			want: 1 + 2*2,
+		},
+		{
+			name:  "success: negative limits do not lower the cost",
+			query: `{ a: bets(limit: -100) { id } b: bets(limit: 1) { id } }`,
+			want:  1 + 1 + 1,
+		},
+		{
+			name:    "fail: query that does not parse",
+			query:   `{ users(ids: ["u1"] { id } }`,
+			wantErr: true,
+		},
+		{
+			name:    "fail: query selecting unknown fields",
+			query:   `{ unknown }`,
+			wantErr: true,
+		},
+		{
+			name:    "fail: several operations and none named",
+			query:   `query A { user(id: "u1") { id } } query B { user(id: "u2") { id } }`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := complexity(tt.query, "", tt.variables)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("complexity() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("complexity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
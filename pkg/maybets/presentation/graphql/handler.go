@@ -0,0 +1,59 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/config"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/usecases"
+	"github.com/gin-gonic/gin"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/errors"
+)
+
+// maxQueryBody bounds the size of a request body holding a query
+const maxQueryBody = 1 << 20
+
+// request is the body of a GraphQL request
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Handler answers GraphQL queries. Queries estimated to cost more than the configured complexity are rejected
+// before any resolver runs, and every request gets its own loaders so that lookups are batched within it.
+func Handler(usecase *usecases.UsecaseMayBets, cfg config.GraphQLConfig) gin.HandlerFunc {
+	schema := NewSchema(usecase, cfg)
+
+	return func(c *gin.Context) {
+		var body request
+
+		if err := json.NewDecoder(http.MaxBytesReader(c.Writer, c.Request.Body, maxQueryBody)).Decode(&body); err != nil {
+			c.JSON(http.StatusBadRequest, map[string]interface{}{
+				"error": fmt.Sprintf("invalid request body: %v", err),
+			})
+
+			return
+		}
+
+		cost, err := complexity(body.Query, body.OperationName, body.Variables)
+		if err != nil {
+			c.JSON(http.StatusOK, &graphqlgo.Response{Errors: []*errors.QueryError{errors.Errorf("%v", err)}})
+			return
+		}
+
+		if cost > float64(cfg.MaxComplexity) {
+			c.JSON(http.StatusOK, &graphqlgo.Response{Errors: []*errors.QueryError{
+				errors.Errorf("query complexity %.0f exceeds the maximum of %d", cost, cfg.MaxComplexity),
+			}})
+
+			return
+		}
+
+		ctx := withLoaders(c.Request.Context(), usecase)
+
+		c.JSON(http.StatusOK, schema.Exec(ctx, body.Query, body.OperationName, body.Variables))
+	}
+}
@@ -0,0 +1,214 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/usecases"
+)
+
+// loadersKey is the context key of the loaders of a request
+type loadersKey struct{}
+
+// batch loads a value per user. Loading one user fetches every user the request knows of
+// that was not loaded yet in a single call, so resolving a field over N users costs one query rather than N.
+type batch[V any] struct {
+	mu     sync.Mutex
+	fetch  func(ctx context.Context, userIDs []string) (map[string]V, error)
+	loaded map[string]V
+}
+
+func newBatch[V any](fetch func(ctx context.Context, userIDs []string) (map[string]V, error)) *batch[V] {
+	return &batch[V]{
+		fetch:  fetch,
+		loaded: map[string]V{},
+	}
+}
+
+// load returns the value of userID, fetching it along with every known user that was not loaded yet.
+// Users missing from the fetch result get the zero value.
+func (b *batch[V]) load(ctx context.Context, userID string, known []string) (V, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if value, ok := b.loaded[userID]; ok {
+		return value, nil
+	}
+
+	userIDs := []string{userID}
+
+	for _, id := range known {
+		if _, ok := b.loaded[id]; !ok && id != userID {
+			userIDs = append(userIDs, id)
+		}
+	}
+
+	values, err := b.fetch(ctx, userIDs)
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+
+	for _, id := range userIDs {
+		b.loaded[id] = values[id]
+	}
+
+	return b.loaded[userID], nil
+}
+
+// loaders batches the per user lookups of a single request.
+// Resolvers register the users they return so that their fields are later loaded together.
+type loaders struct {
+	usecase *usecases.UsecaseMayBets
+
+	mu    sync.Mutex
+	known map[string]struct{}
+	order []string
+
+	totals       *batch[domain.User]
+	recentBets   map[int]*batch[[]domain.Bet]
+	recentAlerts map[int]*batch[[]domain.Alert]
+
+	anomalousMu     sync.Mutex
+	anomalous       map[string]bool
+	anomalousLoaded bool
+}
+
+func newLoaders(usecase *usecases.UsecaseMayBets) *loaders {
+	l := &loaders{
+		usecase:      usecase,
+		known:        map[string]struct{}{},
+		recentBets:   map[int]*batch[[]domain.Bet]{},
+		recentAlerts: map[int]*batch[[]domain.Alert]{},
+	}
+
+	l.totals = newBatch(func(ctx context.Context, userIDs []string) (map[string]domain.User, error) {
+		users, err := usecase.GetUsersTotals(ctx, userIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		totals := make(map[string]domain.User, len(users))
+		for _, user := range users {
+			totals[user.ID] = user
+		}
+
+		return totals, nil
+	})
+
+	return l
+}
+
+// withLoaders returns a context carrying fresh loaders for a request
+func withLoaders(ctx context.Context, usecase *usecases.UsecaseMayBets) context.Context {
+	return context.WithValue(ctx, loadersKey{}, newLoaders(usecase))
+}
+
+// loadersFrom returns the loaders of the request
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// register makes the users known to the request so that they are loaded in the same batches
+func (l *loaders) register(userIDs ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, userID := range userIDs {
+		if _, ok := l.known[userID]; ok {
+			continue
+		}
+
+		l.known[userID] = struct{}{}
+		l.order = append(l.order, userID)
+	}
+}
+
+func (l *loaders) knownUsers() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.order
+}
+
+// userTotals loads the number of bets, the winnings and the losses of a user
+func (l *loaders) userTotals(ctx context.Context, userID string) (domain.User, error) {
+	return l.totals.load(ctx, userID, l.knownUsers())
+}
+
+// userRecentBets loads the latest bets of a user, at most limit
+func (l *loaders) userRecentBets(ctx context.Context, userID string, limit int) ([]domain.Bet, error) {
+	l.mu.Lock()
+
+	loader, ok := l.recentBets[limit]
+	if !ok {
+		loader = newBatch(func(ctx context.Context, userIDs []string) (map[string][]domain.Bet, error) {
+			bets, err := l.usecase.GetRecentBets(ctx, userIDs, limit)
+			if err != nil {
+				return nil, err
+			}
+
+			byUser := map[string][]domain.Bet{}
+			for _, bet := range bets {
+				byUser[bet.UserID] = append(byUser[bet.UserID], bet)
+			}
+
+			return byUser, nil
+		})
+		l.recentBets[limit] = loader
+	}
+
+	l.mu.Unlock()
+
+	return loader.load(ctx, userID, l.knownUsers())
+}
+
+// userRecentAlerts loads the latest alerts of a user, at most limit
+func (l *loaders) userRecentAlerts(ctx context.Context, userID string, limit int) ([]domain.Alert, error) {
+	l.mu.Lock()
+
+	loader, ok := l.recentAlerts[limit]
+	if !ok {
+		loader = newBatch(func(ctx context.Context, userIDs []string) (map[string][]domain.Alert, error) {
+			alerts, err := l.usecase.GetRecentAlerts(ctx, userIDs, limit)
+			if err != nil {
+				return nil, err
+			}
+
+			byUser := map[string][]domain.Alert{}
+			for _, alert := range alerts {
+				byUser[alert.UserID] = append(byUser[alert.UserID], alert)
+			}
+
+			return byUser, nil
+		})
+		l.recentAlerts[limit] = loader
+	}
+
+	l.mu.Unlock()
+
+	return loader.load(ctx, userID, l.knownUsers())
+}
+
+// isAnomalous reports whether a user is anomalous. The anomalous users are fetched once per request.
+func (l *loaders) isAnomalous(ctx context.Context, userID string) (bool, error) {
+	l.anomalousMu.Lock()
+	defer l.anomalousMu.Unlock()
+
+	if !l.anomalousLoaded {
+		users, err := l.usecase.GetAllAnomalousUsers(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		l.anomalous = make(map[string]bool, len(users))
+		for _, user := range users {
+			l.anomalous[user.ID] = true
+		}
+
+		l.anomalousLoaded = true
+	}
+
+	return l.anomalous[userID], nil
+}
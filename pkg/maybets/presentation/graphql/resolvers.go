@@ -0,0 +1,486 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/usecases"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+const (
+	// maxUsers caps the number of users fetched by ids at once
+	maxUsers = 100
+	// maxLimit caps the limit of the top level lists
+	maxLimit = 500
+	// maxUserLimit caps the limit of the lists nested under a user
+	maxUserLimit = 100
+)
+
+// checkLimit rejects list sizes outside 1..maximum
+func checkLimit(name string, limit int32, maximum int) error {
+	if limit < 1 || int(limit) > maximum {
+		return fmt.Errorf("%s: invalid value %d: must be between 1 and %d", name, limit, maximum)
+	}
+
+	return nil
+}
+
+// oddsFormat reads an optional odds format argument, empty when it is not given
+func oddsFormat(value *string) (enums.OddsFormat, error) {
+	if value == nil {
+		return "", nil
+	}
+
+	format := enums.OddsFormat(*value)
+	if !format.IsValid() {
+		return "", fmt.Errorf("format: invalid value %q: must be one of %v", format, enums.OddsFormats)
+	}
+
+	return format, nil
+}
+
+// Resolver resolves the root queries through the use cases
+type Resolver struct {
+	usecase *usecases.UsecaseMayBets
+}
+
+// User resolves a single user
+func (r *Resolver) User(ctx context.Context, args struct{ ID graphqlgo.ID }) *userResolver {
+	return newUserResolver(ctx, string(args.ID))
+}
+
+// Users resolves several users at once
+func (r *Resolver) Users(ctx context.Context, args struct{ IDs []graphqlgo.ID }) ([]*userResolver, error) {
+	if len(args.IDs) > maxUsers {
+		return nil, fmt.Errorf("ids: %d given: at most %d users can be fetched at once", len(args.IDs), maxUsers)
+	}
+
+	userIDs := make([]string, 0, len(args.IDs))
+	for _, id := range args.IDs {
+		userIDs = append(userIDs, string(id))
+	}
+
+	return newUserResolvers(ctx, userIDs), nil
+}
+
+// Leaderboard resolves the users with the highest betting volume
+func (r *Resolver) Leaderboard(ctx context.Context, args struct{ Limit int32 }) ([]*userResolver, error) {
+	if err := checkLimit("limit", args.Limit, maxLimit); err != nil {
+		return nil, err
+	}
+
+	users, err := r.usecase.GetTopUsers(ctx, int(args.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return newUserResolvers(ctx, userIDsOf(users)), nil
+}
+
+// AnomalousUsers resolves the users with significantly higher betting activity than the average
+func (r *Resolver) AnomalousUsers(ctx context.Context) ([]*userResolver, error) {
+	users, err := r.usecase.GetAllAnomalousUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newUserResolvers(ctx, userIDsOf(users)), nil
+}
+
+// Bets resolves the bets matching the filter
+func (r *Resolver) Bets(ctx context.Context, args struct {
+	UserID *graphqlgo.ID
+	From   *graphqlgo.Time
+	To     *graphqlgo.Time
+	Limit  int32
+}) ([]*betResolver, error) {
+	if err := checkLimit("limit", args.Limit, maxLimit); err != nil {
+		return nil, err
+	}
+
+	filter := domain.BetFilter{Limit: int(args.Limit)}
+
+	if args.UserID != nil {
+		filter.UserID = string(*args.UserID)
+	}
+
+	if args.From != nil {
+		from := args.From.Time
+		filter.From = &from
+	}
+
+	if args.To != nil {
+		to := args.To.Time
+		filter.To = &to
+	}
+
+	bets, err := r.usecase.ListBets(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return newBetResolvers(ctx, bets), nil
+}
+
+// Alerts resolves the most recent alerts
+func (r *Resolver) Alerts(ctx context.Context, args struct{ Limit int32 }) ([]*alertResolver, error) {
+	if err := checkLimit("limit", args.Limit, maxLimit); err != nil {
+		return nil, err
+	}
+
+	alerts, err := r.usecase.ListAlerts(ctx, int(args.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return newAlertResolvers(ctx, alerts), nil
+}
+
+// userResolver resolves the fields of a user through the loaders of the request
+type userResolver struct {
+	id string
+}
+
+func newUserResolver(ctx context.Context, userID string) *userResolver {
+	loadersFrom(ctx).register(userID)
+
+	return &userResolver{id: userID}
+}
+
+func newUserResolvers(ctx context.Context, userIDs []string) []*userResolver {
+	loadersFrom(ctx).register(userIDs...)
+
+	resolvers := make([]*userResolver, 0, len(userIDs))
+	for _, userID := range userIDs {
+		resolvers = append(resolvers, &userResolver{id: userID})
+	}
+
+	return resolvers
+}
+
+func userIDsOf(users []domain.User) []string {
+	userIDs := make([]string, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.ID)
+	}
+
+	return userIDs
+}
+
+// ID resolves the ID of the user
+func (u *userResolver) ID() graphqlgo.ID {
+	return graphqlgo.ID(u.id)
+}
+
+// TotalBets resolves the number of bets placed by the user
+func (u *userResolver) TotalBets(ctx context.Context) (int32, error) {
+	user, err := loadersFrom(ctx).userTotals(ctx, u.id)
+
+	return int32(user.TotalBets), err
+}
+
+// TotalWinnings resolves the amount won by the user
+func (u *userResolver) TotalWinnings(ctx context.Context) (float64, error) {
+	user, err := loadersFrom(ctx).userTotals(ctx, u.id)
+
+	return user.TotalWinnings.Float64(), err
+}
+
+// TotalLosses resolves the amount lost by the user
+func (u *userResolver) TotalLosses(ctx context.Context) (float64, error) {
+	user, err := loadersFrom(ctx).userTotals(ctx, u.id)
+
+	return user.TotalLosses.Float64(), err
+}
+
+// TotalPayout resolves the amount returned on the bets won by the user
+func (u *userResolver) TotalPayout(ctx context.Context) (float64, error) {
+	user, err := loadersFrom(ctx).userTotals(ctx, u.id)
+
+	return user.TotalPayout.Float64(), err
+}
+
+// Currency resolves the currency the amounts of the user are reported in
+func (u *userResolver) Currency(ctx context.Context) string {
+	return string(loadersFrom(ctx).usecase.Exchange.Reporting)
+}
+
+// WinRate resolves the share of the decided stakes of the user that was won
+func (u *userResolver) WinRate(ctx context.Context) (float64, error) {
+	user, err := loadersFrom(ctx).userTotals(ctx, u.id)
+
+	return user.WinRate, err
+}
+
+// Anomalous resolves whether the user bets significantly more than the average
+func (u *userResolver) Anomalous(ctx context.Context) (bool, error) {
+	return loadersFrom(ctx).isAnomalous(ctx, u.id)
+}
+
+// RecentBets resolves the latest bets of the user
+func (u *userResolver) RecentBets(ctx context.Context, args struct{ Limit int32 }) ([]*betResolver, error) {
+	if err := checkLimit("limit", args.Limit, maxUserLimit); err != nil {
+		return nil, err
+	}
+
+	bets, err := loadersFrom(ctx).userRecentBets(ctx, u.id, int(args.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return newBetResolvers(ctx, bets), nil
+}
+
+// RecentAlerts resolves the latest alerts raised for the user
+func (u *userResolver) RecentAlerts(ctx context.Context, args struct{ Limit int32 }) ([]*alertResolver, error) {
+	if err := checkLimit("limit", args.Limit, maxUserLimit); err != nil {
+		return nil, err
+	}
+
+	alerts, err := loadersFrom(ctx).userRecentAlerts(ctx, u.id, int(args.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return newAlertResolvers(ctx, alerts), nil
+}
+
+// betResolver resolves the fields of a bet
+type betResolver struct {
+	bet domain.Bet
+}
+
+func newBetResolvers(ctx context.Context, bets []domain.Bet) []*betResolver {
+	resolvers := make([]*betResolver, 0, len(bets))
+	userIDs := make([]string, 0, len(bets))
+
+	for _, bet := range bets {
+		resolvers = append(resolvers, &betResolver{bet: bet})
+		userIDs = append(userIDs, bet.UserID)
+	}
+
+	loadersFrom(ctx).register(userIDs...)
+
+	return resolvers
+}
+
+// ID resolves the ID of the bet
+func (b *betResolver) ID() graphqlgo.ID {
+	return graphqlgo.ID(b.bet.BetID)
+}
+
+// User resolves the user that placed the bet
+func (b *betResolver) User() *userResolver {
+	return &userResolver{id: b.bet.UserID}
+}
+
+// Amount resolves the amount staked
+func (b *betResolver) Amount() float64 {
+	return b.bet.Amount.Float64()
+}
+
+// Currency resolves the currency the bet was placed in
+func (b *betResolver) Currency() string {
+	return string(b.bet.Currency)
+}
+
+// Odds resolves the odds of the bet
+func (b *betResolver) Odds() float64 {
+	return b.bet.Odds
+}
+
+// OddsFormat resolves the format the odds of the bet were placed in
+func (b *betResolver) OddsFormat() string {
+	if b.bet.OddsFormat == "" {
+		return enums.Decimal.String()
+	}
+
+	return b.bet.OddsFormat.String()
+}
+
+// QuotedOdds resolves the odds of the bet quoted in a format, the one they were placed in when none is given
+func (b *betResolver) QuotedOdds(args struct{ Format *string }) (string, error) {
+	format, err := oddsFormat(args.Format)
+	if err != nil {
+		return "", err
+	}
+
+	if format == "" {
+		format = b.bet.OddsFormat
+	}
+
+	return b.bet.OddsIn(format), nil
+}
+
+// Outcome resolves the state of the bet
+func (b *betResolver) Outcome() string {
+	return b.bet.Outcome.String()
+}
+
+// Payout resolves the amount returned for the bet
+func (b *betResolver) Payout() float64 {
+	return b.bet.Payout().Float64()
+}
+
+// Selection resolves the ID of the selection a single bet was placed on, null when it was not placed on one
+func (b *betResolver) Selection() *string {
+	return optional(b.bet.Selection)
+}
+
+// Sport resolves the sport the bet was placed on, null when the bet does not say
+func (b *betResolver) Sport() *string {
+	return optional(b.bet.Sport)
+}
+
+// Competition resolves the competition the bet was placed on, null when the bet does not say
+func (b *betResolver) Competition() *string {
+	return optional(b.bet.Competition)
+}
+
+// EventID resolves the event the bet was placed on, null when the bet does not say
+func (b *betResolver) EventID() *string {
+	return optional(b.bet.EventID)
+}
+
+// Market resolves the market the bet was placed on, null when the bet does not say
+func (b *betResolver) Market() *string {
+	return optional(b.bet.Market)
+}
+
+// optional maps an empty string to null
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
+
+// Timestamp resolves when the bet was placed
+func (b *betResolver) Timestamp() graphqlgo.Time {
+	return graphqlgo.Time{Time: b.bet.Timestamp}
+}
+
+// Legs resolves the selections of a multi-leg bet
+func (b *betResolver) Legs() []*betLegResolver {
+	resolvers := make([]*betLegResolver, 0, len(b.bet.Legs))
+	for _, leg := range b.bet.Legs {
+		resolvers = append(resolvers, &betLegResolver{leg: leg})
+	}
+
+	return resolvers
+}
+
+// betLegResolver resolves the fields of a leg of a multi-leg bet
+type betLegResolver struct {
+	leg domain.BetLeg
+}
+
+// Selection resolves what the leg was placed on
+func (l *betLegResolver) Selection() string {
+	return l.leg.Selection
+}
+
+// Odds resolves the odds of the leg
+func (l *betLegResolver) Odds() float64 {
+	return l.leg.Odds
+}
+
+// QuotedOdds resolves the odds of the leg quoted in a format, decimal when none is given
+func (l *betLegResolver) QuotedOdds(args struct{ Format *string }) (string, error) {
+	format, err := oddsFormat(args.Format)
+	if err != nil {
+		return "", err
+	}
+
+	return domain.FormatOdds(l.leg.Odds, format), nil
+}
+
+// Outcome resolves the state of the leg
+func (l *betLegResolver) Outcome() string {
+	return l.leg.Outcome.String()
+}
+
+// alertResolver resolves the fields of an alert
+type alertResolver struct {
+	alert domain.Alert
+}
+
+func newAlertResolvers(ctx context.Context, alerts []domain.Alert) []*alertResolver {
+	resolvers := make([]*alertResolver, 0, len(alerts))
+	userIDs := make([]string, 0, len(alerts))
+
+	for _, alert := range alerts {
+		resolvers = append(resolvers, &alertResolver{alert: alert})
+		userIDs = append(userIDs, alert.UserID)
+	}
+
+	loadersFrom(ctx).register(userIDs...)
+
+	return resolvers
+}
+
+// ID resolves the ID of the alert
+func (a *alertResolver) ID() graphqlgo.ID {
+	return graphqlgo.ID(a.alert.ID)
+}
+
+// Event resolves what raised the alert
+func (a *alertResolver) Event() string {
+	return a.alert.Event.String()
+}
+
+// User resolves the user the alert is about
+func (a *alertResolver) User() *userResolver {
+	return &userResolver{id: a.alert.UserID}
+}
+
+// BetID resolves the bet that raised the alert, if any
+func (a *alertResolver) BetID() *string {
+	if a.alert.BetID == "" {
+		return nil
+	}
+
+	return &a.alert.BetID
+}
+
+// Value resolves the value that raised the alert
+func (a *alertResolver) Value() float64 {
+	return a.alert.Value
+}
+
+// Threshold resolves the limit the value reached, if any
+func (a *alertResolver) Threshold() *float64 {
+	if a.alert.Threshold == 0 {
+		return nil
+	}
+
+	return &a.alert.Threshold
+}
+
+// Limit resolves the responsible gambling limit the user breached, if any
+func (a *alertResolver) Limit() *string {
+	return optional(a.alert.Limit.String())
+}
+
+// Period resolves the period of the responsible gambling cap the user breached, if any
+func (a *alertResolver) Period() *string {
+	return optional(a.alert.Period.String())
+}
+
+// Evidence resolves the IDs of the bets reported by an anti-money laundering rule
+func (a *alertResolver) Evidence() []string {
+	if a.alert.Evidence == nil {
+		return []string{}
+	}
+
+	return a.alert.Evidence
+}
+
+// CreatedAt resolves when the alert was raised
+func (a *alertResolver) CreatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: a.alert.CreatedAt}
+}
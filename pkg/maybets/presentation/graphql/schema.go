@@ -0,0 +1,121 @@
+// Package graphql serves flexible analytics queries over users, bets, the leaderboard and alerts
+package graphql
+
+import (
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/config"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/usecases"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	gqlotel "github.com/graph-gophers/graphql-go/trace/otel"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"go.opentelemetry.io/otel"
+)
+
+// schema describes the queries that can be made.
+// List sizes are bounded by their limit or ids arguments, which is what the query complexity is estimated from.
+const schema = `
+schema {
+	query: Query
+}
+
+scalar Time
+
+type Query {
+	# user fetches the totals of a single user
+	user(id: ID!): User!
+	# users fetches several users at once, at most 100
+	users(ids: [ID!]!): [User!]!
+	# leaderboard fetches the users with the highest betting volume
+	leaderboard(limit: Int = 5): [User!]!
+	# anomalousUsers fetches the users with significantly higher betting activity than the average
+	anomalousUsers: [User!]!
+	# bets fetches the bets matching the filter ordered by timestamp, at most 500
+	bets(userId: ID, from: Time, to: Time, limit: Int = 50): [Bet!]!
+	# alerts fetches the most recent alerts, newest first, at most 500
+	alerts(limit: Int = 50): [Alert!]!
+}
+
+type User {
+	id: ID!
+	totalBets: Int!
+	# the amounts of the user are converted into the reporting currency
+	totalWinnings: Float!
+	totalLosses: Float!
+	# totalPayout is the amount returned on the bets won by the user, stakes times odds
+	totalPayout: Float!
+	# winRate is the share of the decided stakes that was won, half results counting half
+	winRate: Float!
+	# currency is the reporting currency the amounts are in
+	currency: String!
+	anomalous: Boolean!
+	# recentBets fetches the latest bets of the user, newest first, at most 100
+	recentBets(limit: Int = 10): [Bet!]!
+	# recentAlerts fetches the latest alerts raised for the user, newest first, at most 100
+	recentAlerts(limit: Int = 10): [Alert!]!
+}
+
+type Bet {
+	id: ID!
+	user: User!
+	amount: Float!
+	# currency is the ISO 4217 code of the currency the amount and payout are in
+	currency: String!
+	# odds and outcome of a multi-leg bet are derived from its legs, odds are decimal
+	odds: Float!
+	# oddsFormat is the format the odds were placed in: decimal, fractional, american, hongkong or malay
+	oddsFormat: String!
+	# quotedOdds are the odds quoted in a format, the one they were placed in by default
+	quotedOdds(format: String): String!
+	# outcome is the state of the bet: pending, win, lose, void, push, cashout, half_win or half_lose
+	outcome: String!
+	# payout is the amount returned for the bet, the stake times the odds when it is won
+	payout: Float!
+	timestamp: Time!
+	# sport, competition, eventId and market describe what the bet was placed on, when it says
+	sport: String
+	competition: String
+	eventId: String
+	market: String
+	# selection is the ID of the selection a single bet is placed on
+	selection: String
+	# legs holds the selections of a multi-leg bet, it is empty for a single bet
+	legs: [BetLeg!]!
+}
+
+type BetLeg {
+	selection: String!
+	odds: Float!
+	# quotedOdds are the odds quoted in a format, decimal by default
+	quotedOdds(format: String): String!
+	outcome: String!
+}
+
+type Alert {
+	id: ID!
+	event: String!
+	user: User!
+	betId: String
+	value: Float!
+	threshold: Float
+	# limit and period name the responsible gambling limit a user breached
+	limit: String
+	period: String
+	# evidence holds the IDs of the bets reported by an anti-money laundering rule
+	evidence: [String!]!
+	createdAt: Time!
+}
+`
+
+// NewSchema parses the schema and binds it to the resolvers.
+// Every resolver that is not a plain field read is traced, and queries nested deeper than cfg.MaxDepth are rejected.
+func NewSchema(usecase *usecases.UsecaseMayBets, cfg config.GraphQLConfig) *graphqlgo.Schema {
+	return graphqlgo.MustParseSchema(schema, &Resolver{usecase: usecase},
+		graphqlgo.MaxDepth(cfg.MaxDepth),
+		graphqlgo.Tracer(&gqlotel.Tracer{
+			Tracer: otel.Tracer("github.com/KathurimaKimathi/maybets/pkg/maybets/presentation/graphql/"),
+		}),
+	)
+}
+
+// analysisSchema is the schema loaded for estimating the complexity of queries
+var analysisSchema = gqlparser.MustLoadSchema(&ast.Source{Name: "schema.graphql", Input: schema})
@@ -1,8 +1,17 @@
 package rest
 
 import (
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/codec"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/usecases"
 	"github.com/gin-gonic/gin"
 )
@@ -85,3 +94,758 @@ func (h HandlersInterfacesImpl) GetAllAnomalousUsers(c *gin.Context) {
 		"result": users,
 	})
 }
+
+// GetLinkedAccounts endpoint to get the groups of users who bet the same selections at the same odds within window
+// of each other, over at least min_events events, with their similarity score and the bets they shared.
+// The bets are optionally filtered by time range, and only the groups of user_id are returned when it is given.
+func (h HandlersInterfacesImpl) GetLinkedAccounts(c *gin.Context) {
+	filter, err := parseBetFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	var rules domain.LinkRules
+
+	if value := c.Query("window"); value != "" {
+		if rules.Window, err = time.ParseDuration(value); err != nil || rules.Window <= 0 {
+			c.JSON(http.StatusBadRequest, map[string]interface{}{
+				"error": fmt.Sprintf("invalid window %q: must be a positive duration such as 10s", value),
+			})
+
+			return
+		}
+	}
+
+	if value := c.Query("min_events"); value != "" {
+		if rules.MinEvents, err = strconv.Atoi(value); err != nil || rules.MinEvents < 1 {
+			c.JSON(http.StatusBadRequest, map[string]interface{}{
+				"error": fmt.Sprintf("invalid min_events %q: must be a positive integer", value),
+			})
+
+			return
+		}
+	}
+
+	report, err := h.usecase.GetLinkedAccounts(c.Request.Context(), filter, rules)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"result": report,
+	})
+}
+
+// parseBetFilter reads the user_id, from and to query parameters.
+// from and to are RFC3339 timestamps and bound the bet timestamp as [from, to).
+func parseBetFilter(c *gin.Context) (domain.BetFilter, error) {
+	filter := domain.BetFilter{
+		UserID: c.Query("user_id"),
+	}
+
+	for _, param := range []struct {
+		name   string
+		target **time.Time
+	}{
+		{"from", &filter.From},
+		{"to", &filter.To},
+	} {
+		value := c.Query(param.name)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid %s timestamp %q: must be RFC3339", param.name, value)
+		}
+
+		*param.target = &parsed
+	}
+
+	return filter, nil
+}
+
+// GetBreakdown endpoint to get the volume, payouts and margin of bets grouped by the comma separated dimensions
+// given in by, optionally filtered by user and time range
+func (h HandlersInterfacesImpl) GetBreakdown(c *gin.Context) {
+	var dimensions []enums.Dimension
+
+	for _, dimension := range strings.Split(c.Query("by"), ",") {
+		if dimension = strings.TrimSpace(dimension); dimension != "" {
+			dimensions = append(dimensions, enums.Dimension(dimension))
+		}
+	}
+
+	filter, err := parseBetFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	filter.Limit, err = strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if err != nil || filter.Limit < 1 {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": fmt.Sprintf("invalid limit %q: must be a positive integer", c.Query("limit")),
+		})
+
+		return
+	}
+
+	breakdown, err := h.usecase.GetBreakdown(c.Request.Context(), dimensions, filter)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"result": breakdown,
+	})
+}
+
+// GetExposure endpoint to get the stake, potential payout and worst case liability of the pending bets
+// per event, market and selection, optionally for the event given in event_id
+func (h HandlersInterfacesImpl) GetExposure(c *gin.Context) {
+	exposure, err := h.usecase.GetExposure(c.Request.Context(), c.Query("event_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"result": exposure,
+	})
+}
+
+// parseOddsFormat reads the odds_format query parameter, empty when it is missing
+func parseOddsFormat(c *gin.Context) (enums.OddsFormat, error) {
+	format := enums.OddsFormat(c.Query("odds_format"))
+	if format != "" && !format.IsValid() {
+		return format, fmt.Errorf("invalid odds_format %q: must be one of %v", format, enums.OddsFormats)
+	}
+
+	return format, nil
+}
+
+// ExportBets endpoint to stream bets as CSV or NDJSON, optionally filtered by user and time range.
+// Odds are quoted in the format given by odds_format, decimal by default.
+func (h HandlersInterfacesImpl) ExportBets(c *gin.Context) {
+	format := enums.FileFormat(c.DefaultQuery("format", enums.NDJSON.String()))
+	if !format.IsValid() {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": fmt.Sprintf("invalid format %q: must be %s or %s", format, enums.NDJSON, enums.CSV),
+		})
+
+		return
+	}
+
+	filter, err := parseBetFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	odds, err := parseOddsFormat(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	writer, err := codec.NewWriter(c.Writer, format, codec.CSVOptions{}, odds)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	contentType := "application/x-ndjson"
+	if format == enums.CSV {
+		contentType = "text/csv"
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=bets.%s", format))
+	c.Status(http.StatusOK)
+
+	// the status is already sent once the first row is written so failures can only be logged
+	written, err := h.usecase.ExportBets(c.Request.Context(), filter, writer)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "bet export failed", "written", written, "error", err)
+		_ = c.Error(err)
+	}
+}
+
+// maxBetsBody bounds the size of a request body holding bets
+const maxBetsBody = 32 << 20
+
+// CreateBets endpoint to store bets sent as NDJSON, or CSV with format=csv.
+// The odds of CSV bets are read in the format given by odds_format, or guessed from each value.
+// The bets are stored before the response is sent and published to the live feed once committed. Bets whose bet_id
+// is already stored are skipped, so that a request can be sent again after a failure.
+// Bets storage turns down are answered with 400 and storage failures with 500, in which case none of the bets is stored.
+// The bets of self-excluded users are turned down when limits.exclusion_mode is reject, and with limits.reject set
+// so are the bets that would take their owner over a responsible gambling cap. Both are listed in rejected
+// along with the reason.
+func (h HandlersInterfacesImpl) CreateBets(c *gin.Context) {
+	format := enums.FileFormat(c.DefaultQuery("format", enums.NDJSON.String()))
+	if !format.IsValid() {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": fmt.Sprintf("invalid format %q: must be %s or %s", format, enums.NDJSON, enums.CSV),
+		})
+
+		return
+	}
+
+	odds, err := parseOddsFormat(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	reader, err := codec.NewReader(
+		http.MaxBytesReader(c.Writer, c.Request.Body, maxBetsBody), format, codec.CSVOptions{OddsFormat: odds},
+	)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	bets, err := codec.ReadAll(reader)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	rejected, err := h.usecase.AcceptBets(c.Request.Context(), bets)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrInvalidBet) {
+			code = http.StatusBadRequest
+		}
+
+		c.JSON(code, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusAccepted, map[string]interface{}{
+		"result": map[string]interface{}{"received": len(bets), "rejected": rejected},
+	})
+}
+
+// SettleBet endpoint to change the state of a placed bet.
+// The body holds the new outcome of a single bet, or the outcome of every leg of a multi-leg bet.
+// The settled bet is returned with its odds quoted in the format given by odds_format, decimal by default.
+func (h HandlersInterfacesImpl) SettleBet(c *gin.Context) {
+	odds, err := parseOddsFormat(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	var settlement domain.Settlement
+	if err := c.ShouldBindJSON(&settlement); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	settlement.BetID = c.Param("bet_id")
+
+	bet, err := h.usecase.SettleBet(c.Request.Context(), settlement)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"result": bet.Quoted(odds),
+	})
+}
+
+// SaveEvent endpoint to store an event with its markets and selections, or to add to an event that exists
+func (h HandlersInterfacesImpl) SaveEvent(c *gin.Context) {
+	var event domain.Event
+	if err := c.ShouldBindJSON(&event); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	saved, err := h.usecase.SaveEvent(c.Request.Context(), event)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, map[string]interface{}{
+		"result": saved,
+	})
+}
+
+// SaveUserLimits endpoint to set the caps a user is held to over a period, replacing those they had over it
+func (h HandlersInterfacesImpl) SaveUserLimits(c *gin.Context) {
+	var limits domain.Limits
+	if err := c.ShouldBindJSON(&limits); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	limits.UserID = c.Param("user_id")
+	limits.Period = enums.LimitPeriod(c.Param("period"))
+
+	saved, err := h.usecase.SaveUserLimits(c.Request.Context(), limits)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"result": saved,
+	})
+}
+
+// GetLimitStanding endpoint to get where a user stands against every responsible gambling limit they are held to
+func (h HandlersInterfacesImpl) GetLimitStanding(c *gin.Context) {
+	standing, err := h.usecase.GetLimitStanding(c.Request.Context(), c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"result": standing,
+	})
+}
+
+// CreateSelfExclusion endpoint to register a period a user excluded themselves from betting over
+func (h HandlersInterfacesImpl) CreateSelfExclusion(c *gin.Context) {
+	var exclusion domain.Exclusion
+	if err := c.ShouldBindJSON(&exclusion); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	created, err := h.usecase.CreateSelfExclusion(c.Request.Context(), exclusion)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, map[string]interface{}{
+		"result": created,
+	})
+}
+
+// ListSelfExclusions endpoint to get the self-exclusions of the user given in user_id, or of every user
+func (h HandlersInterfacesImpl) ListSelfExclusions(c *gin.Context) {
+	exclusions, err := h.usecase.ListSelfExclusions(c.Request.Context(), c.Query("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"result": exclusions,
+	})
+}
+
+// exclusionLiftInput is the body accepted when lifting a self-exclusion
+type exclusionLiftInput struct {
+	LiftedBy string `json:"lifted_by"`
+}
+
+// LiftSelfExclusion endpoint to end a self-exclusion now
+func (h HandlersInterfacesImpl) LiftSelfExclusion(c *gin.Context) {
+	var input exclusionLiftInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	exclusion, err := h.usecase.LiftSelfExclusion(c.Request.Context(), c.Param("id"), input.LiftedBy)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"result": exclusion,
+	})
+}
+
+// ListExclusionViolations endpoint to get the most recent bets received from self-excluded users, newest first,
+// optionally those of the user given in user_id
+func (h HandlersInterfacesImpl) ListExclusionViolations(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": fmt.Sprintf("invalid limit %q: must be a positive integer", c.Query("limit")),
+		})
+
+		return
+	}
+
+	violations, err := h.usecase.ListExclusionViolations(c.Request.Context(), c.Query("user_id"), limit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"result": violations,
+	})
+}
+
+// GetExclusionReport endpoint to list the bets accepted from users during one of their self-exclusions,
+// optionally filtered by user and time range
+func (h HandlersInterfacesImpl) GetExclusionReport(c *gin.Context) {
+	filter, err := parseBetFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	report, err := h.usecase.GetExclusionReport(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"result": report,
+	})
+}
+
+// GetAMLReport endpoint to screen the bets against the anti-money laundering rules, optionally filtered by user
+// and time range, and list the findings with their evidence bets. format=csv exports the report as a CSV file
+// with a row for every evidence bet.
+func (h HandlersInterfacesImpl) GetAMLReport(c *gin.Context) {
+	format := enums.ReportFormat(c.DefaultQuery("format", enums.JSONReport.String()))
+	if !format.IsValid() {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": fmt.Sprintf("invalid format %q: must be one of %v", format, enums.ReportFormats),
+		})
+
+		return
+	}
+
+	filter, err := parseBetFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	report, err := h.usecase.GetAMLReport(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	if format == enums.JSONReport {
+		c.JSON(http.StatusOK, map[string]interface{}{
+			"result": report,
+		})
+
+		return
+	}
+
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", "attachment; filename=aml-report.csv")
+	c.Status(http.StatusOK)
+
+	if err := codec.WriteAMLReport(c.Writer, report, format); err != nil {
+		slog.ErrorContext(c.Request.Context(), "AML report export failed", "error", err)
+		_ = c.Error(err)
+	}
+}
+
+// ListAMLAlerts endpoint to get the most recent alerts raised by the scheduled anti-money laundering screening,
+// newest first, with the IDs of their evidence bets
+func (h HandlersInterfacesImpl) ListAMLAlerts(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": fmt.Sprintf("invalid limit %q: must be a positive integer", c.Query("limit")),
+		})
+
+		return
+	}
+
+	alerts, err := h.usecase.ListAMLAlerts(c.Request.Context(), limit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"result": alerts,
+	})
+}
+
+// GetEvent endpoint to get an event with its markets and the results of its selections
+func (h HandlersInterfacesImpl) GetEvent(c *gin.Context) {
+	event, err := h.usecase.GetEvent(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"result": event,
+	})
+}
+
+// selectionResultInput is the body accepted when submitting the result of a selection
+type selectionResultInput struct {
+	Result      enums.Outcome `json:"result" binding:"required"`
+	SubmittedBy string        `json:"submitted_by" binding:"required"`
+}
+
+// SettleSelection endpoint to submit the result of a selection, settling every bet on it.
+// The response holds the summary of the settlement.
+func (h HandlersInterfacesImpl) SettleSelection(c *gin.Context) {
+	var input selectionResultInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	summary, err := h.usecase.SettleSelection(c.Request.Context(), domain.SelectionResult{
+		SelectionID: c.Param("id"),
+		Result:      input.Result,
+		SubmittedBy: input.SubmittedBy,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"result": summary,
+	})
+}
+
+// ListSelectionResults endpoint to get every result submitted for a selection, newest first
+func (h HandlersInterfacesImpl) ListSelectionResults(c *gin.Context) {
+	results, err := h.usecase.ListSelectionResults(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"result": results,
+	})
+}
+
+// webhookSubscriptionInput is the body accepted when creating a webhook subscription
+type webhookSubscriptionInput struct {
+	URL    string               `json:"url" binding:"required"`
+	Events []enums.WebhookEvent `json:"events" binding:"required"`
+	// Secret is optional, one is generated when it is omitted
+	Secret string `json:"secret"`
+}
+
+// CreateWebhookSubscription endpoint to subscribe a URL to alert events.
+// The response holds the signing secret, which is not shown again.
+func (h HandlersInterfacesImpl) CreateWebhookSubscription(c *gin.Context) {
+	var input webhookSubscriptionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	subscription, err := h.usecase.CreateWebhookSubscription(c.Request.Context(), domain.WebhookSubscription{
+		URL:    input.URL,
+		Events: input.Events,
+		Secret: input.Secret,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, map[string]interface{}{
+		"result": subscription,
+	})
+}
+
+// ListWebhookSubscriptions endpoint to get every webhook subscription
+func (h HandlersInterfacesImpl) ListWebhookSubscriptions(c *gin.Context) {
+	subscriptions, err := h.usecase.ListWebhookSubscriptions(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"result": subscriptions,
+	})
+}
+
+// GetWebhookSubscription endpoint to get a webhook subscription by its ID
+func (h HandlersInterfacesImpl) GetWebhookSubscription(c *gin.Context) {
+	subscription, err := h.usecase.GetWebhookSubscription(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"result": subscription,
+	})
+}
+
+// DeleteWebhookSubscription endpoint to delete a webhook subscription and its pending deliveries
+func (h HandlersInterfacesImpl) DeleteWebhookSubscription(c *gin.Context) {
+	if err := h.usecase.DeleteWebhookSubscription(c.Request.Context(), c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListWebhookDeadLetters endpoint to get the most recent webhook deliveries that exhausted their attempts
+func (h HandlersInterfacesImpl) ListWebhookDeadLetters(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": fmt.Sprintf("invalid limit %q: must be a positive integer", c.Query("limit")),
+		})
+
+		return
+	}
+
+	deadLetters, err := h.usecase.ListWebhookDeadLetters(c.Request.Context(), limit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"result": deadLetters,
+	})
+}
@@ -0,0 +1,231 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// laggedEvent tells a client how many events it missed because it could not keep up
+const laggedEvent = "lagged"
+
+var upgrader = websocket.Upgrader{
+	// the CORS middleware rejects the origins that are not allowed before the upgrade
+	CheckOrigin: func(_ *http.Request) bool { return true },
+}
+
+// liveWriter sends live feed events to a client over SSE or a WebSocket
+type liveWriter interface {
+	write(event string, data any) error
+	ping() error
+}
+
+// parseLiveFilter reads the topics and user_id query parameters.
+// topics is a comma separated list of topics, every topic is sent when it is omitted.
+func parseLiveFilter(c *gin.Context) (domain.LiveFilter, error) {
+	filter := domain.LiveFilter{
+		UserID: c.Query("user_id"),
+	}
+
+	if topics := c.Query("topics"); topics != "" {
+		for _, name := range strings.Split(topics, ",") {
+			topic := enums.LiveTopic(strings.TrimSpace(name))
+			if !topic.IsValid() {
+				return filter, fmt.Errorf("invalid topic %q: must be one of %v", topic, enums.LiveTopics)
+			}
+
+			filter.Topics = append(filter.Topics, topic)
+		}
+	}
+
+	return filter, nil
+}
+
+// Stream endpoint to follow new bets, leaderboard changes, alerts and exposure as they happen.
+// WebSocket upgrade requests are answered over a WebSocket, any other request with Server-Sent Events.
+func (h HandlersInterfacesImpl) Stream(c *gin.Context) {
+	filter, err := parseLiveFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return
+	}
+
+	if websocket.IsWebSocketUpgrade(c.Request) {
+		h.streamWebSocket(c, filter)
+		return
+	}
+
+	h.streamSSE(c, filter)
+}
+
+// streamSSE sends the live feed as Server-Sent Events
+func (h HandlersInterfacesImpl) streamSSE(c *gin.Context, filter domain.LiveFilter) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// keep reverse proxies from buffering the events
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	h.serveLive(c.Request.Context(), filter, &sseWriter{
+		writer:     c.Writer,
+		controller: http.NewResponseController(c.Writer),
+		timeout:    h.usecase.LiveConfig.WriteTimeout,
+	})
+}
+
+// streamWebSocket sends the live feed as WebSocket text messages
+func (h HandlersInterfacesImpl) streamWebSocket(c *gin.Context, filter domain.LiveFilter) {
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// the upgrader already answered the request
+		slog.WarnContext(c.Request.Context(), "websocket upgrade failed", "error", err)
+		return
+	}
+
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	// the request context does not end with a hijacked connection, reading is how a disconnect is noticed
+	go func() {
+		defer cancel()
+
+		conn.SetReadLimit(512)
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	h.serveLive(ctx, filter, &webSocketWriter{
+		conn:    conn,
+		timeout: h.usecase.LiveConfig.WriteTimeout,
+	})
+}
+
+// serveLive sends the events matching the filter until the client goes away or a write fails.
+// A client that falls behind misses events and is told how many with a lagged event.
+func (h HandlersInterfacesImpl) serveLive(ctx context.Context, filter domain.LiveFilter, writer liveWriter) {
+	subscription := h.usecase.SubscribeLive(filter)
+	defer subscription.Close()
+
+	// new clients start from the current leaderboard rather than waiting for it to change
+	if len(filter.Topics) == 0 || slices.Contains(filter.Topics, enums.LeaderboardTopic) {
+		users, err := h.usecase.GetTopFiveUsers(ctx)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to fetch the leaderboard for a live client", "error", err)
+		} else if err := writer.write(enums.LeaderboardTopic.String(), users); err != nil {
+			return
+		}
+	}
+
+	// as well as from the current exposure, when they asked for it
+	if slices.Contains(filter.Topics, enums.ExposureTopic) {
+		exposure, err := h.usecase.GetExposure(ctx, "")
+		if err != nil {
+			slog.WarnContext(ctx, "failed to work out the exposure for a live client", "error", err)
+		} else if err := writer.write(enums.ExposureTopic.String(), exposure); err != nil {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(h.usecase.LiveConfig.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if err := writer.ping(); err != nil {
+				return
+			}
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return
+			}
+
+			if dropped := subscription.Dropped(); dropped > 0 {
+				if err := writer.write(laggedEvent, map[string]int64{"dropped": dropped}); err != nil {
+					return
+				}
+			}
+
+			if err := writer.write(event.Topic.String(), event.Data); err != nil {
+				slog.InfoContext(ctx, "live client disconnected", "error", err)
+				return
+			}
+		}
+	}
+}
+
+// sseWriter writes Server-Sent Events
+type sseWriter struct {
+	writer     gin.ResponseWriter
+	controller *http.ResponseController
+	timeout    time.Duration
+}
+
+func (w *sseWriter) write(event string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", event, err)
+	}
+
+	return w.send(fmt.Sprintf("event: %s\ndata: %s\n\n", event, payload))
+}
+
+func (w *sseWriter) ping() error {
+	return w.send(": ping\n\n")
+}
+
+func (w *sseWriter) send(message string) error {
+	if err := w.controller.SetWriteDeadline(time.Now().Add(w.timeout)); err != nil {
+		return err
+	}
+
+	if _, err := w.writer.WriteString(message); err != nil {
+		return err
+	}
+
+	return w.controller.Flush()
+}
+
+// webSocketWriter writes WebSocket messages holding the topic and data of an event
+type webSocketWriter struct {
+	conn    *websocket.Conn
+	timeout time.Duration
+}
+
+func (w *webSocketWriter) write(event string, data any) error {
+	if err := w.conn.SetWriteDeadline(time.Now().Add(w.timeout)); err != nil {
+		return err
+	}
+
+	return w.conn.WriteJSON(map[string]any{
+		"topic": event,
+		"data":  data,
+	})
+}
+
+func (w *webSocketWriter) ping() error {
+	return w.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(w.timeout))
+}
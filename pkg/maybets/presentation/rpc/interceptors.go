@@ -0,0 +1,65 @@
+package rpc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryRequestLogger logs every handled unary call with its status code and latency, like rest.RequestLogger.
+// The tracing stats handler runs first so that the trace IDs are part of the record.
+func UnaryRequestLogger() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		response, err := handler(ctx, request)
+
+		logCall(ctx, info.FullMethod, start, err)
+
+		return response, err
+	}
+}
+
+// StreamRequestLogger logs every handled streaming call with its status code and latency
+func StreamRequestLogger() grpc.StreamServerInterceptor {
+	return func(server any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		err := handler(server, stream)
+
+		logCall(stream.Context(), info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+
+	level := slog.LevelInfo
+
+	switch code {
+	case codes.OK:
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		level = slog.LevelError
+	default:
+		level = slog.LevelWarn
+	}
+
+	var client string
+	if p, ok := peer.FromContext(ctx); ok {
+		client = p.Addr.String()
+	}
+
+	slog.Log(ctx, level, "rpc handled",
+		"method", method,
+		"code", code.String(),
+		"latency", time.Since(start),
+		"client", client,
+	)
+}
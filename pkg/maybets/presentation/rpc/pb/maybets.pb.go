@@ -0,0 +1,1046 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.3
+// 	protoc        v5.29.3
+// source: maybets.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Outcome int32
+
+const (
+	Outcome_OUTCOME_UNSPECIFIED Outcome = 0
+	Outcome_OUTCOME_WIN         Outcome = 1
+	Outcome_OUTCOME_LOSE        Outcome = 2
+	Outcome_OUTCOME_PENDING     Outcome = 3
+	Outcome_OUTCOME_VOID        Outcome = 4
+	Outcome_OUTCOME_PUSH        Outcome = 5
+	Outcome_OUTCOME_CASHOUT     Outcome = 6
+	Outcome_OUTCOME_HALF_WIN    Outcome = 7
+	Outcome_OUTCOME_HALF_LOSE   Outcome = 8
+)
+
+// Enum value maps for Outcome.
+var (
+	Outcome_name = map[int32]string{
+		0: "OUTCOME_UNSPECIFIED",
+		1: "OUTCOME_WIN",
+		2: "OUTCOME_LOSE",
+		3: "OUTCOME_PENDING",
+		4: "OUTCOME_VOID",
+		5: "OUTCOME_PUSH",
+		6: "OUTCOME_CASHOUT",
+		7: "OUTCOME_HALF_WIN",
+		8: "OUTCOME_HALF_LOSE",
+	}
+	Outcome_value = map[string]int32{
+		"OUTCOME_UNSPECIFIED": 0,
+		"OUTCOME_WIN":         1,
+		"OUTCOME_LOSE":        2,
+		"OUTCOME_PENDING":     3,
+		"OUTCOME_VOID":        4,
+		"OUTCOME_PUSH":        5,
+		"OUTCOME_CASHOUT":     6,
+		"OUTCOME_HALF_WIN":    7,
+		"OUTCOME_HALF_LOSE":   8,
+	}
+)
+
+func (x Outcome) Enum() *Outcome {
+	p := new(Outcome)
+	*p = x
+	return p
+}
+
+func (x Outcome) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Outcome) Descriptor() protoreflect.EnumDescriptor {
+	return file_maybets_proto_enumTypes[0].Descriptor()
+}
+
+func (Outcome) Type() protoreflect.EnumType {
+	return &file_maybets_proto_enumTypes[0]
+}
+
+func (x Outcome) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Outcome.Descriptor instead.
+func (Outcome) EnumDescriptor() ([]byte, []int) {
+	return file_maybets_proto_rawDescGZIP(), []int{0}
+}
+
+type Bet struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	BetId  string                 `protobuf:"bytes,1,opt,name=bet_id,json=betId,proto3" json:"bet_id,omitempty"`
+	UserId string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Odds   float64                `protobuf:"fixed64,4,opt,name=odds,proto3" json:"odds,omitempty"`
+	// outcome and odds are derived from the legs of a multi-leg bet and may be left unset
+	Outcome   Outcome                `protobuf:"varint,5,opt,name=outcome,proto3,enum=maybets.v1.Outcome" json:"outcome,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// legs holds the selections of a multi-leg (accumulator) bet, it is empty for a single bet
+	Legs []*BetLeg `protobuf:"bytes,7,rep,name=legs,proto3" json:"legs,omitempty"`
+	// cashout is the amount a cashed out bet was settled for
+	Cashout float64 `protobuf:"fixed64,8,opt,name=cashout,proto3" json:"cashout,omitempty"`
+	// selection is the ID of the selection a single bet is placed on, if any
+	Selection string `protobuf:"bytes,9,opt,name=selection,proto3" json:"selection,omitempty"`
+	// currency is the ISO 4217 code of the currency of the amount, the default currency when unset
+	Currency string `protobuf:"bytes,10,opt,name=currency,proto3" json:"currency,omitempty"`
+	// odds_format is the format odds is quoted in: decimal when unset, fractional, american, hongkong or malay
+	OddsFormat string `protobuf:"bytes,11,opt,name=odds_format,json=oddsFormat,proto3" json:"odds_format,omitempty"`
+	// quoted_odds are the odds as quoted, such as 5/2 or +150, and take precedence over odds when set
+	QuotedOdds string `protobuf:"bytes,12,opt,name=quoted_odds,json=quotedOdds,proto3" json:"quoted_odds,omitempty"`
+	// sport, competition, event_id and market describe what the bet is placed on, they are optional
+	Sport         string `protobuf:"bytes,13,opt,name=sport,proto3" json:"sport,omitempty"`
+	Competition   string `protobuf:"bytes,14,opt,name=competition,proto3" json:"competition,omitempty"`
+	EventId       string `protobuf:"bytes,15,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Market        string `protobuf:"bytes,16,opt,name=market,proto3" json:"market,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bet) Reset() {
+	*x = Bet{}
+	mi := &file_maybets_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bet) ProtoMessage() {}
+
+func (x *Bet) ProtoReflect() protoreflect.Message {
+	mi := &file_maybets_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bet.ProtoReflect.Descriptor instead.
+func (*Bet) Descriptor() ([]byte, []int) {
+	return file_maybets_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Bet) GetBetId() string {
+	if x != nil {
+		return x.BetId
+	}
+	return ""
+}
+
+func (x *Bet) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Bet) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Bet) GetOdds() float64 {
+	if x != nil {
+		return x.Odds
+	}
+	return 0
+}
+
+func (x *Bet) GetOutcome() Outcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return Outcome_OUTCOME_UNSPECIFIED
+}
+
+func (x *Bet) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Bet) GetLegs() []*BetLeg {
+	if x != nil {
+		return x.Legs
+	}
+	return nil
+}
+
+func (x *Bet) GetCashout() float64 {
+	if x != nil {
+		return x.Cashout
+	}
+	return 0
+}
+
+func (x *Bet) GetSelection() string {
+	if x != nil {
+		return x.Selection
+	}
+	return ""
+}
+
+func (x *Bet) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Bet) GetOddsFormat() string {
+	if x != nil {
+		return x.OddsFormat
+	}
+	return ""
+}
+
+func (x *Bet) GetQuotedOdds() string {
+	if x != nil {
+		return x.QuotedOdds
+	}
+	return ""
+}
+
+func (x *Bet) GetSport() string {
+	if x != nil {
+		return x.Sport
+	}
+	return ""
+}
+
+func (x *Bet) GetCompetition() string {
+	if x != nil {
+		return x.Competition
+	}
+	return ""
+}
+
+func (x *Bet) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *Bet) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+type BetLeg struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Selection string                 `protobuf:"bytes,1,opt,name=selection,proto3" json:"selection,omitempty"`
+	// odds are decimal
+	Odds          float64 `protobuf:"fixed64,2,opt,name=odds,proto3" json:"odds,omitempty"`
+	Outcome       Outcome `protobuf:"varint,3,opt,name=outcome,proto3,enum=maybets.v1.Outcome" json:"outcome,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BetLeg) Reset() {
+	*x = BetLeg{}
+	mi := &file_maybets_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BetLeg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BetLeg) ProtoMessage() {}
+
+func (x *BetLeg) ProtoReflect() protoreflect.Message {
+	mi := &file_maybets_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BetLeg.ProtoReflect.Descriptor instead.
+func (*BetLeg) Descriptor() ([]byte, []int) {
+	return file_maybets_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BetLeg) GetSelection() string {
+	if x != nil {
+		return x.Selection
+	}
+	return ""
+}
+
+func (x *BetLeg) GetOdds() float64 {
+	if x != nil {
+		return x.Odds
+	}
+	return 0
+}
+
+func (x *BetLeg) GetOutcome() Outcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return Outcome_OUTCOME_UNSPECIFIED
+}
+
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TotalBets     int64                  `protobuf:"varint,2,opt,name=total_bets,json=totalBets,proto3" json:"total_bets,omitempty"`
+	TotalWinnings float64                `protobuf:"fixed64,3,opt,name=total_winnings,json=totalWinnings,proto3" json:"total_winnings,omitempty"`
+	// currency is the reporting currency total_winnings is in
+	Currency      string `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_maybets_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_maybets_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_maybets_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *User) GetTotalBets() int64 {
+	if x != nil {
+		return x.TotalBets
+	}
+	return 0
+}
+
+func (x *User) GetTotalWinnings() float64 {
+	if x != nil {
+		return x.TotalWinnings
+	}
+	return 0
+}
+
+func (x *User) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type GetUserTotalBetsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserTotalBetsRequest) Reset() {
+	*x = GetUserTotalBetsRequest{}
+	mi := &file_maybets_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserTotalBetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserTotalBetsRequest) ProtoMessage() {}
+
+func (x *GetUserTotalBetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_maybets_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserTotalBetsRequest.ProtoReflect.Descriptor instead.
+func (*GetUserTotalBetsRequest) Descriptor() ([]byte, []int) {
+	return file_maybets_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetUserTotalBetsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetUserTotalBetsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserTotalBetsResponse) Reset() {
+	*x = GetUserTotalBetsResponse{}
+	mi := &file_maybets_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserTotalBetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserTotalBetsResponse) ProtoMessage() {}
+
+func (x *GetUserTotalBetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_maybets_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserTotalBetsResponse.ProtoReflect.Descriptor instead.
+func (*GetUserTotalBetsResponse) Descriptor() ([]byte, []int) {
+	return file_maybets_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetUserTotalBetsResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type GetUserTotalWinningsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserTotalWinningsRequest) Reset() {
+	*x = GetUserTotalWinningsRequest{}
+	mi := &file_maybets_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserTotalWinningsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserTotalWinningsRequest) ProtoMessage() {}
+
+func (x *GetUserTotalWinningsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_maybets_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserTotalWinningsRequest.ProtoReflect.Descriptor instead.
+func (*GetUserTotalWinningsRequest) Descriptor() ([]byte, []int) {
+	return file_maybets_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetUserTotalWinningsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetUserTotalWinningsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserTotalWinningsResponse) Reset() {
+	*x = GetUserTotalWinningsResponse{}
+	mi := &file_maybets_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserTotalWinningsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserTotalWinningsResponse) ProtoMessage() {}
+
+func (x *GetUserTotalWinningsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_maybets_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserTotalWinningsResponse.ProtoReflect.Descriptor instead.
+func (*GetUserTotalWinningsResponse) Descriptor() ([]byte, []int) {
+	return file_maybets_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetUserTotalWinningsResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type GetTopUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTopUsersRequest) Reset() {
+	*x = GetTopUsersRequest{}
+	mi := &file_maybets_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopUsersRequest) ProtoMessage() {}
+
+func (x *GetTopUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_maybets_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopUsersRequest.ProtoReflect.Descriptor instead.
+func (*GetTopUsersRequest) Descriptor() ([]byte, []int) {
+	return file_maybets_proto_rawDescGZIP(), []int{7}
+}
+
+type GetTopUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTopUsersResponse) Reset() {
+	*x = GetTopUsersResponse{}
+	mi := &file_maybets_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopUsersResponse) ProtoMessage() {}
+
+func (x *GetTopUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_maybets_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopUsersResponse.ProtoReflect.Descriptor instead.
+func (*GetTopUsersResponse) Descriptor() ([]byte, []int) {
+	return file_maybets_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetTopUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type GetAnomalousUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAnomalousUsersRequest) Reset() {
+	*x = GetAnomalousUsersRequest{}
+	mi := &file_maybets_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAnomalousUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAnomalousUsersRequest) ProtoMessage() {}
+
+func (x *GetAnomalousUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_maybets_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAnomalousUsersRequest.ProtoReflect.Descriptor instead.
+func (*GetAnomalousUsersRequest) Descriptor() ([]byte, []int) {
+	return file_maybets_proto_rawDescGZIP(), []int{9}
+}
+
+type GetAnomalousUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAnomalousUsersResponse) Reset() {
+	*x = GetAnomalousUsersResponse{}
+	mi := &file_maybets_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAnomalousUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAnomalousUsersResponse) ProtoMessage() {}
+
+func (x *GetAnomalousUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_maybets_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAnomalousUsersResponse.ProtoReflect.Descriptor instead.
+func (*GetAnomalousUsersResponse) Descriptor() ([]byte, []int) {
+	return file_maybets_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetAnomalousUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type IngestBetsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bets          []*Bet                 `protobuf:"bytes,1,rep,name=bets,proto3" json:"bets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestBetsRequest) Reset() {
+	*x = IngestBetsRequest{}
+	mi := &file_maybets_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestBetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestBetsRequest) ProtoMessage() {}
+
+func (x *IngestBetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_maybets_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestBetsRequest.ProtoReflect.Descriptor instead.
+func (*IngestBetsRequest) Descriptor() ([]byte, []int) {
+	return file_maybets_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *IngestBetsRequest) GetBets() []*Bet {
+	if x != nil {
+		return x.Bets
+	}
+	return nil
+}
+
+type IngestBetsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// received is the number of bets read from the stream
+	Received int64 `protobuf:"varint,1,opt,name=received,proto3" json:"received,omitempty"`
+	// batches is the number of batches handed over for storage
+	Batches       int64 `protobuf:"varint,2,opt,name=batches,proto3" json:"batches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestBetsResponse) Reset() {
+	*x = IngestBetsResponse{}
+	mi := &file_maybets_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestBetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestBetsResponse) ProtoMessage() {}
+
+func (x *IngestBetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_maybets_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestBetsResponse.ProtoReflect.Descriptor instead.
+func (*IngestBetsResponse) Descriptor() ([]byte, []int) {
+	return file_maybets_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *IngestBetsResponse) GetReceived() int64 {
+	if x != nil {
+		return x.Received
+	}
+	return 0
+}
+
+func (x *IngestBetsResponse) GetBatches() int64 {
+	if x != nil {
+		return x.Batches
+	}
+	return 0
+}
+
+var File_maybets_proto protoreflect.FileDescriptor
+
+var file_maybets_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x6d, 0x61, 0x79, 0x62, 0x65, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x0a, 0x6d, 0x61, 0x79, 0x62, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf3, 0x03, 0x0a,
+	0x03, 0x42, 0x65, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x65, 0x74, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x6f, 0x64, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x6f, 0x64, 0x64, 0x73,
+	0x12, 0x2d, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x13, 0x2e, 0x6d, 0x61, 0x79, 0x62, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f,
+	0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x12,
+	0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x26, 0x0a, 0x04, 0x6c, 0x65, 0x67,
+	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x61, 0x79, 0x62, 0x65, 0x74,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x65, 0x74, 0x4c, 0x65, 0x67, 0x52, 0x04, 0x6c, 0x65, 0x67,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x61, 0x73, 0x68, 0x6f, 0x75, 0x74, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x07, 0x63, 0x61, 0x73, 0x68, 0x6f, 0x75, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x64, 0x64, 0x73, 0x5f, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x64, 0x64, 0x73,
+	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x64,
+	0x5f, 0x6f, 0x64, 0x64, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x71, 0x75, 0x6f,
+	0x74, 0x65, 0x64, 0x4f, 0x64, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x6f, 0x72, 0x74,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x20, 0x0a,
+	0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x65, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x19, 0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61,
+	0x72, 0x6b, 0x65, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x72, 0x6b,
+	0x65, 0x74, 0x22, 0x69, 0x0a, 0x06, 0x42, 0x65, 0x74, 0x4c, 0x65, 0x67, 0x12, 0x1c, 0x0a, 0x09,
+	0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6f, 0x64,
+	0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x6f, 0x64, 0x64, 0x73, 0x12, 0x2d,
+	0x0a, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x13, 0x2e, 0x6d, 0x61, 0x79, 0x62, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x75, 0x74,
+	0x63, 0x6f, 0x6d, 0x65, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x22, 0x78, 0x0a,
+	0x04, 0x55, 0x73, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x62,
+	0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x42, 0x65, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x77, 0x69,
+	0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x57, 0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x32, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x55, 0x73,
+	0x65, 0x72, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x40, 0x0a, 0x18, 0x47,
+	0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x65, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x61, 0x79, 0x62, 0x65, 0x74, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x22, 0x36, 0x0a,
+	0x1b, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x57, 0x69, 0x6e,
+	0x6e, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x44, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72,
+	0x54, 0x6f, 0x74, 0x61, 0x6c, 0x57, 0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x61, 0x79, 0x62, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x22, 0x14, 0x0a, 0x12, 0x47,
+	0x65, 0x74, 0x54, 0x6f, 0x70, 0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x3d, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x70, 0x55, 0x73, 0x65, 0x72, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x75, 0x73, 0x65, 0x72,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x61, 0x79, 0x62, 0x65, 0x74,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73,
+	0x22, 0x1a, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x41, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x6f, 0x75, 0x73,
+	0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x43, 0x0a, 0x19,
+	0x47, 0x65, 0x74, 0x41, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x6f, 0x75, 0x73, 0x55, 0x73, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x75, 0x73, 0x65,
+	0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x61, 0x79, 0x62, 0x65,
+	0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72,
+	0x73, 0x22, 0x38, 0x0a, 0x11, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x42, 0x65, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x04, 0x62, 0x65, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x61, 0x79, 0x62, 0x65, 0x74, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x42, 0x65, 0x74, 0x52, 0x04, 0x62, 0x65, 0x74, 0x73, 0x22, 0x4a, 0x0a, 0x12, 0x49,
+	0x6e, 0x67, 0x65, 0x73, 0x74, 0x42, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x62, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
+	0x62, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x2a, 0xc0, 0x01, 0x0a, 0x07, 0x4f, 0x75, 0x74, 0x63,
+	0x6f, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x13, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b,
+	0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x57, 0x49, 0x4e, 0x10, 0x01, 0x12, 0x10, 0x0a,
+	0x0c, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x4c, 0x4f, 0x53, 0x45, 0x10, 0x02, 0x12,
+	0x13, 0x0a, 0x0f, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49,
+	0x4e, 0x47, 0x10, 0x03, 0x12, 0x10, 0x0a, 0x0c, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f,
+	0x56, 0x4f, 0x49, 0x44, 0x10, 0x04, 0x12, 0x10, 0x0a, 0x0c, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d,
+	0x45, 0x5f, 0x50, 0x55, 0x53, 0x48, 0x10, 0x05, 0x12, 0x13, 0x0a, 0x0f, 0x4f, 0x55, 0x54, 0x43,
+	0x4f, 0x4d, 0x45, 0x5f, 0x43, 0x41, 0x53, 0x48, 0x4f, 0x55, 0x54, 0x10, 0x06, 0x12, 0x14, 0x0a,
+	0x10, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x48, 0x41, 0x4c, 0x46, 0x5f, 0x57, 0x49,
+	0x4e, 0x10, 0x07, 0x12, 0x15, 0x0a, 0x11, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x48,
+	0x41, 0x4c, 0x46, 0x5f, 0x4c, 0x4f, 0x53, 0x45, 0x10, 0x08, 0x32, 0xdb, 0x03, 0x0a, 0x0e, 0x4d,
+	0x61, 0x79, 0x62, 0x65, 0x74, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5d, 0x0a,
+	0x10, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x65, 0x74,
+	0x73, 0x12, 0x23, 0x2e, 0x6d, 0x61, 0x79, 0x62, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x65, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x6d, 0x61, 0x79, 0x62, 0x65, 0x74, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x54, 0x6f, 0x74, 0x61, 0x6c,
+	0x42, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x69, 0x0a, 0x14,
+	0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x57, 0x69, 0x6e, 0x6e,
+	0x69, 0x6e, 0x67, 0x73, 0x12, 0x27, 0x2e, 0x6d, 0x61, 0x79, 0x62, 0x65, 0x74, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x57, 0x69,
+	0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e,
+	0x6d, 0x61, 0x79, 0x62, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73,
+	0x65, 0x72, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x57, 0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x54, 0x6f,
+	0x70, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x1e, 0x2e, 0x6d, 0x61, 0x79, 0x62, 0x65, 0x74, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x70, 0x55, 0x73, 0x65, 0x72, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6d, 0x61, 0x79, 0x62, 0x65, 0x74, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x70, 0x55, 0x73, 0x65, 0x72, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x41, 0x6e,
+	0x6f, 0x6d, 0x61, 0x6c, 0x6f, 0x75, 0x73, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x24, 0x2e, 0x6d,
+	0x61, 0x79, 0x62, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6e, 0x6f,
+	0x6d, 0x61, 0x6c, 0x6f, 0x75, 0x73, 0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x25, 0x2e, 0x6d, 0x61, 0x79, 0x62, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x41, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x6f, 0x75, 0x73, 0x55, 0x73, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0a, 0x49, 0x6e, 0x67,
+	0x65, 0x73, 0x74, 0x42, 0x65, 0x74, 0x73, 0x12, 0x1d, 0x2e, 0x6d, 0x61, 0x79, 0x62, 0x65, 0x74,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x42, 0x65, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x6d, 0x61, 0x79, 0x62, 0x65, 0x74, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x42, 0x65, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x42, 0x45, 0x5a, 0x43, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4b, 0x61, 0x74, 0x68, 0x75, 0x72, 0x69, 0x6d, 0x61,
+	0x4b, 0x69, 0x6d, 0x61, 0x74, 0x68, 0x69, 0x2f, 0x6d, 0x61, 0x79, 0x62, 0x65, 0x74, 0x73, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x6d, 0x61, 0x79, 0x62, 0x65, 0x74, 0x73, 0x2f, 0x70, 0x72, 0x65, 0x73,
+	0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x62, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_maybets_proto_rawDescOnce sync.Once
+	file_maybets_proto_rawDescData = file_maybets_proto_rawDesc
+)
+
+func file_maybets_proto_rawDescGZIP() []byte {
+	file_maybets_proto_rawDescOnce.Do(func() {
+		file_maybets_proto_rawDescData = protoimpl.X.CompressGZIP(file_maybets_proto_rawDescData)
+	})
+	return file_maybets_proto_rawDescData
+}
+
+var file_maybets_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_maybets_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_maybets_proto_goTypes = []any{
+	(Outcome)(0),                         // 0: maybets.v1.Outcome
+	(*Bet)(nil),                          // 1: maybets.v1.Bet
+	(*BetLeg)(nil),                       // 2: maybets.v1.BetLeg
+	(*User)(nil),                         // 3: maybets.v1.User
+	(*GetUserTotalBetsRequest)(nil),      // 4: maybets.v1.GetUserTotalBetsRequest
+	(*GetUserTotalBetsResponse)(nil),     // 5: maybets.v1.GetUserTotalBetsResponse
+	(*GetUserTotalWinningsRequest)(nil),  // 6: maybets.v1.GetUserTotalWinningsRequest
+	(*GetUserTotalWinningsResponse)(nil), // 7: maybets.v1.GetUserTotalWinningsResponse
+	(*GetTopUsersRequest)(nil),           // 8: maybets.v1.GetTopUsersRequest
+	(*GetTopUsersResponse)(nil),          // 9: maybets.v1.GetTopUsersResponse
+	(*GetAnomalousUsersRequest)(nil),     // 10: maybets.v1.GetAnomalousUsersRequest
+	(*GetAnomalousUsersResponse)(nil),    // 11: maybets.v1.GetAnomalousUsersResponse
+	(*IngestBetsRequest)(nil),            // 12: maybets.v1.IngestBetsRequest
+	(*IngestBetsResponse)(nil),           // 13: maybets.v1.IngestBetsResponse
+	(*timestamppb.Timestamp)(nil),        // 14: google.protobuf.Timestamp
+}
+var file_maybets_proto_depIdxs = []int32{
+	0,  // 0: maybets.v1.Bet.outcome:type_name -> maybets.v1.Outcome
+	14, // 1: maybets.v1.Bet.timestamp:type_name -> google.protobuf.Timestamp
+	2,  // 2: maybets.v1.Bet.legs:type_name -> maybets.v1.BetLeg
+	0,  // 3: maybets.v1.BetLeg.outcome:type_name -> maybets.v1.Outcome
+	3,  // 4: maybets.v1.GetUserTotalBetsResponse.user:type_name -> maybets.v1.User
+	3,  // 5: maybets.v1.GetUserTotalWinningsResponse.user:type_name -> maybets.v1.User
+	3,  // 6: maybets.v1.GetTopUsersResponse.users:type_name -> maybets.v1.User
+	3,  // 7: maybets.v1.GetAnomalousUsersResponse.users:type_name -> maybets.v1.User
+	1,  // 8: maybets.v1.IngestBetsRequest.bets:type_name -> maybets.v1.Bet
+	4,  // 9: maybets.v1.MaybetsService.GetUserTotalBets:input_type -> maybets.v1.GetUserTotalBetsRequest
+	6,  // 10: maybets.v1.MaybetsService.GetUserTotalWinnings:input_type -> maybets.v1.GetUserTotalWinningsRequest
+	8,  // 11: maybets.v1.MaybetsService.GetTopUsers:input_type -> maybets.v1.GetTopUsersRequest
+	10, // 12: maybets.v1.MaybetsService.GetAnomalousUsers:input_type -> maybets.v1.GetAnomalousUsersRequest
+	12, // 13: maybets.v1.MaybetsService.IngestBets:input_type -> maybets.v1.IngestBetsRequest
+	5,  // 14: maybets.v1.MaybetsService.GetUserTotalBets:output_type -> maybets.v1.GetUserTotalBetsResponse
+	7,  // 15: maybets.v1.MaybetsService.GetUserTotalWinnings:output_type -> maybets.v1.GetUserTotalWinningsResponse
+	9,  // 16: maybets.v1.MaybetsService.GetTopUsers:output_type -> maybets.v1.GetTopUsersResponse
+	11, // 17: maybets.v1.MaybetsService.GetAnomalousUsers:output_type -> maybets.v1.GetAnomalousUsersResponse
+	13, // 18: maybets.v1.MaybetsService.IngestBets:output_type -> maybets.v1.IngestBetsResponse
+	14, // [14:19] is the sub-list for method output_type
+	9,  // [9:14] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_maybets_proto_init() }
+func file_maybets_proto_init() {
+	if File_maybets_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_maybets_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_maybets_proto_goTypes,
+		DependencyIndexes: file_maybets_proto_depIdxs,
+		EnumInfos:         file_maybets_proto_enumTypes,
+		MessageInfos:      file_maybets_proto_msgTypes,
+	}.Build()
+	File_maybets_proto = out.File
+	file_maybets_proto_rawDesc = nil
+	file_maybets_proto_goTypes = nil
+	file_maybets_proto_depIdxs = nil
+}
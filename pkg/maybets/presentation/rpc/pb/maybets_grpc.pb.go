@@ -0,0 +1,283 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: maybets.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MaybetsService_GetUserTotalBets_FullMethodName     = "/maybets.v1.MaybetsService/GetUserTotalBets"
+	MaybetsService_GetUserTotalWinnings_FullMethodName = "/maybets.v1.MaybetsService/GetUserTotalWinnings"
+	MaybetsService_GetTopUsers_FullMethodName          = "/maybets.v1.MaybetsService/GetTopUsers"
+	MaybetsService_GetAnomalousUsers_FullMethodName    = "/maybets.v1.MaybetsService/GetAnomalousUsers"
+	MaybetsService_IngestBets_FullMethodName           = "/maybets.v1.MaybetsService/IngestBets"
+)
+
+// MaybetsServiceClient is the client API for MaybetsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MaybetsService mirrors the analytics and ingest endpoints of the REST API
+type MaybetsServiceClient interface {
+	// GetUserTotalBets fetches the total number of bets placed by a user
+	GetUserTotalBets(ctx context.Context, in *GetUserTotalBetsRequest, opts ...grpc.CallOption) (*GetUserTotalBetsResponse, error)
+	// GetUserTotalWinnings calculates the total winnings of a user
+	GetUserTotalWinnings(ctx context.Context, in *GetUserTotalWinningsRequest, opts ...grpc.CallOption) (*GetUserTotalWinningsResponse, error)
+	// GetTopUsers fetches the 5 users with the highest betting volume
+	GetTopUsers(ctx context.Context, in *GetTopUsersRequest, opts ...grpc.CallOption) (*GetTopUsersResponse, error)
+	// GetAnomalousUsers fetches the users with significantly higher betting activity than the average
+	GetAnomalousUsers(ctx context.Context, in *GetAnomalousUsersRequest, opts ...grpc.CallOption) (*GetAnomalousUsersResponse, error)
+	// IngestBets stores the bets streamed by the client.
+	// The bets are stored in batches as they arrive and the response acknowledges the whole stream.
+	IngestBets(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[IngestBetsRequest, IngestBetsResponse], error)
+}
+
+type maybetsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMaybetsServiceClient(cc grpc.ClientConnInterface) MaybetsServiceClient {
+	return &maybetsServiceClient{cc}
+}
+
+func (c *maybetsServiceClient) GetUserTotalBets(ctx context.Context, in *GetUserTotalBetsRequest, opts ...grpc.CallOption) (*GetUserTotalBetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserTotalBetsResponse)
+	err := c.cc.Invoke(ctx, MaybetsService_GetUserTotalBets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *maybetsServiceClient) GetUserTotalWinnings(ctx context.Context, in *GetUserTotalWinningsRequest, opts ...grpc.CallOption) (*GetUserTotalWinningsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserTotalWinningsResponse)
+	err := c.cc.Invoke(ctx, MaybetsService_GetUserTotalWinnings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *maybetsServiceClient) GetTopUsers(ctx context.Context, in *GetTopUsersRequest, opts ...grpc.CallOption) (*GetTopUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTopUsersResponse)
+	err := c.cc.Invoke(ctx, MaybetsService_GetTopUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *maybetsServiceClient) GetAnomalousUsers(ctx context.Context, in *GetAnomalousUsersRequest, opts ...grpc.CallOption) (*GetAnomalousUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAnomalousUsersResponse)
+	err := c.cc.Invoke(ctx, MaybetsService_GetAnomalousUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *maybetsServiceClient) IngestBets(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[IngestBetsRequest, IngestBetsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MaybetsService_ServiceDesc.Streams[0], MaybetsService_IngestBets_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[IngestBetsRequest, IngestBetsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MaybetsService_IngestBetsClient = grpc.ClientStreamingClient[IngestBetsRequest, IngestBetsResponse]
+
+// MaybetsServiceServer is the server API for MaybetsService service.
+// All implementations must embed UnimplementedMaybetsServiceServer
+// for forward compatibility.
+//
+// MaybetsService mirrors the analytics and ingest endpoints of the REST API
+type MaybetsServiceServer interface {
+	// GetUserTotalBets fetches the total number of bets placed by a user
+	GetUserTotalBets(context.Context, *GetUserTotalBetsRequest) (*GetUserTotalBetsResponse, error)
+	// GetUserTotalWinnings calculates the total winnings of a user
+	GetUserTotalWinnings(context.Context, *GetUserTotalWinningsRequest) (*GetUserTotalWinningsResponse, error)
+	// GetTopUsers fetches the 5 users with the highest betting volume
+	GetTopUsers(context.Context, *GetTopUsersRequest) (*GetTopUsersResponse, error)
+	// GetAnomalousUsers fetches the users with significantly higher betting activity than the average
+	GetAnomalousUsers(context.Context, *GetAnomalousUsersRequest) (*GetAnomalousUsersResponse, error)
+	// IngestBets stores the bets streamed by the client.
+	// The bets are stored in batches as they arrive and the response acknowledges the whole stream.
+	IngestBets(grpc.ClientStreamingServer[IngestBetsRequest, IngestBetsResponse]) error
+	mustEmbedUnimplementedMaybetsServiceServer()
+}
+
+// UnimplementedMaybetsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMaybetsServiceServer struct{}
+
+func (UnimplementedMaybetsServiceServer) GetUserTotalBets(context.Context, *GetUserTotalBetsRequest) (*GetUserTotalBetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserTotalBets not implemented")
+}
+func (UnimplementedMaybetsServiceServer) GetUserTotalWinnings(context.Context, *GetUserTotalWinningsRequest) (*GetUserTotalWinningsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserTotalWinnings not implemented")
+}
+func (UnimplementedMaybetsServiceServer) GetTopUsers(context.Context, *GetTopUsersRequest) (*GetTopUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTopUsers not implemented")
+}
+func (UnimplementedMaybetsServiceServer) GetAnomalousUsers(context.Context, *GetAnomalousUsersRequest) (*GetAnomalousUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAnomalousUsers not implemented")
+}
+func (UnimplementedMaybetsServiceServer) IngestBets(grpc.ClientStreamingServer[IngestBetsRequest, IngestBetsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method IngestBets not implemented")
+}
+func (UnimplementedMaybetsServiceServer) mustEmbedUnimplementedMaybetsServiceServer() {}
+func (UnimplementedMaybetsServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeMaybetsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MaybetsServiceServer will
+// result in compilation errors.
+type UnsafeMaybetsServiceServer interface {
+	mustEmbedUnimplementedMaybetsServiceServer()
+}
+
+func RegisterMaybetsServiceServer(s grpc.ServiceRegistrar, srv MaybetsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMaybetsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MaybetsService_ServiceDesc, srv)
+}
+
+func _MaybetsService_GetUserTotalBets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserTotalBetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MaybetsServiceServer).GetUserTotalBets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MaybetsService_GetUserTotalBets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MaybetsServiceServer).GetUserTotalBets(ctx, req.(*GetUserTotalBetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MaybetsService_GetUserTotalWinnings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserTotalWinningsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MaybetsServiceServer).GetUserTotalWinnings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MaybetsService_GetUserTotalWinnings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MaybetsServiceServer).GetUserTotalWinnings(ctx, req.(*GetUserTotalWinningsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MaybetsService_GetTopUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MaybetsServiceServer).GetTopUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MaybetsService_GetTopUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MaybetsServiceServer).GetTopUsers(ctx, req.(*GetTopUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MaybetsService_GetAnomalousUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAnomalousUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MaybetsServiceServer).GetAnomalousUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MaybetsService_GetAnomalousUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MaybetsServiceServer).GetAnomalousUsers(ctx, req.(*GetAnomalousUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MaybetsService_IngestBets_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MaybetsServiceServer).IngestBets(&grpc.GenericServerStream[IngestBetsRequest, IngestBetsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MaybetsService_IngestBetsServer = grpc.ClientStreamingServer[IngestBetsRequest, IngestBetsResponse]
+
+// MaybetsService_ServiceDesc is the grpc.ServiceDesc for MaybetsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MaybetsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "maybets.v1.MaybetsService",
+	HandlerType: (*MaybetsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetUserTotalBets",
+			Handler:    _MaybetsService_GetUserTotalBets_Handler,
+		},
+		{
+			MethodName: "GetUserTotalWinnings",
+			Handler:    _MaybetsService_GetUserTotalWinnings_Handler,
+		},
+		{
+			MethodName: "GetTopUsers",
+			Handler:    _MaybetsService_GetTopUsers_Handler,
+		},
+		{
+			MethodName: "GetAnomalousUsers",
+			Handler:    _MaybetsService_GetAnomalousUsers_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "IngestBets",
+			Handler:       _MaybetsService_IngestBets_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "maybets.proto",
+}
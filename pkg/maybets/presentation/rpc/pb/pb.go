@@ -0,0 +1,4 @@
+// Package pb holds the protobuf messages and gRPC stubs of the maybets API generated from maybets.proto
+package pb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative maybets.proto
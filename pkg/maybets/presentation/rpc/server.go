@@ -0,0 +1,252 @@
+// Package rpc serves the maybets API over gRPC
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/presentation/rpc/pb"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/usecases"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ingestBatchSize is the number of streamed bets handed over for storage at once
+const ingestBatchSize = 1000
+
+// Server implements the MaybetsService on top of the use cases
+type Server struct {
+	pb.UnimplementedMaybetsServiceServer
+
+	usecase *usecases.UsecaseMayBets
+}
+
+// NewServer initializes a new gRPC service implementation
+func NewServer(usecase *usecases.UsecaseMayBets) *Server {
+	return &Server{usecase: usecase}
+}
+
+// NewGRPCServer returns a gRPC server with tracing and request logging serving the MaybetsService
+func NewGRPCServer(usecase *usecases.UsecaseMayBets) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(UnaryRequestLogger()),
+		grpc.ChainStreamInterceptor(StreamRequestLogger()),
+	)
+
+	pb.RegisterMaybetsServiceServer(server, NewServer(usecase))
+
+	return server
+}
+
+// GetUserTotalBets fetches the total number of bets placed by a user
+func (s *Server) GetUserTotalBets(ctx context.Context, request *pb.GetUserTotalBetsRequest) (*pb.GetUserTotalBetsResponse, error) {
+	if request.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id: must not be empty")
+	}
+
+	user, err := s.usecase.GetUserTotalBets(ctx, request.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.GetUserTotalBetsResponse{User: toProtoUser(*user)}, nil
+}
+
+// GetUserTotalWinnings calculates the total winnings of a user
+func (s *Server) GetUserTotalWinnings(
+	ctx context.Context, request *pb.GetUserTotalWinningsRequest,
+) (*pb.GetUserTotalWinningsResponse, error) {
+	if request.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id: must not be empty")
+	}
+
+	user, err := s.usecase.GetUserTotalWinnings(ctx, request.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.GetUserTotalWinningsResponse{User: toProtoUser(*user)}, nil
+}
+
+// GetTopUsers fetches the 5 users with the highest betting volume
+func (s *Server) GetTopUsers(ctx context.Context, _ *pb.GetTopUsersRequest) (*pb.GetTopUsersResponse, error) {
+	users, err := s.usecase.GetTopFiveUsers(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.GetTopUsersResponse{Users: toProtoUsers(users)}, nil
+}
+
+// GetAnomalousUsers fetches the users with significantly higher betting activity than the average
+func (s *Server) GetAnomalousUsers(ctx context.Context, _ *pb.GetAnomalousUsersRequest) (*pb.GetAnomalousUsersResponse, error) {
+	users, err := s.usecase.GetAllAnomalousUsers(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.GetAnomalousUsersResponse{Users: toProtoUsers(users)}, nil
+}
+
+// IngestBets stores the bets streamed by the client in batches as they arrive.
+// The response acknowledges the whole stream once the client closes it and the last batch is committed.
+// Bets whose bet_id is already stored are skipped, so that a stream can be sent again after a failure.
+// An invalid bet or a batch that cannot be stored aborts the stream; the batches committed before it are kept.
+func (s *Server) IngestBets(stream pb.MaybetsService_IngestBetsServer) error {
+	ctx := stream.Context()
+
+	var received, batches int64
+
+	batch := make([]*domain.Bet, 0, ingestBatchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if err := s.usecase.StoreBets(ctx, batch); err != nil {
+			code := codes.Internal
+			if errors.Is(err, domain.ErrInvalidBet) {
+				code = codes.InvalidArgument
+			}
+
+			return status.Errorf(code, "%v: %d batches were stored before it", err, batches)
+		}
+
+		batches++
+		batch = make([]*domain.Bet, 0, ingestBatchSize)
+
+		return nil
+	}
+
+	for {
+		request, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			if err := flush(); err != nil {
+				return err
+			}
+
+			return stream.SendAndClose(&pb.IngestBetsResponse{Received: received, Batches: batches})
+		}
+
+		if err != nil {
+			return err
+		}
+
+		for _, bet := range request.GetBets() {
+			mapped, err := toDomainBet(bet)
+			if err != nil {
+				return status.Errorf(codes.InvalidArgument, "bet %d: %v: %d batches were stored before it",
+					received+1, err, batches)
+			}
+
+			batch = append(batch, mapped)
+			received++
+
+			if len(batch) == ingestBatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+func toDomainBet(bet *pb.Bet) (*domain.Bet, error) {
+	if bet.GetTimestamp() == nil {
+		return nil, errors.New("timestamp: must be set")
+	}
+
+	mapped := &domain.Bet{
+		BetID:       bet.GetBetId(),
+		UserID:      bet.GetUserId(),
+		Amount:      domain.MoneyFromFloat(bet.GetAmount()),
+		Currency:    domain.Currency(bet.GetCurrency()),
+		Odds:        bet.GetOdds(),
+		Outcome:     toDomainOutcome(bet.GetOutcome()),
+		Timestamp:   bet.GetTimestamp().AsTime(),
+		Cashout:     domain.MoneyFromFloat(bet.GetCashout()),
+		Sport:       bet.GetSport(),
+		Competition: bet.GetCompetition(),
+		EventID:     bet.GetEventId(),
+		Market:      bet.GetMarket(),
+		Selection:   bet.GetSelection(),
+	}
+
+	for _, leg := range bet.GetLegs() {
+		mapped.Legs = append(mapped.Legs, domain.BetLeg{
+			Selection: leg.GetSelection(),
+			Odds:      leg.GetOdds(),
+			Outcome:   toDomainOutcome(leg.GetOutcome()),
+		})
+	}
+
+	// the odds of a single bet are read in their format, the odds of a multi-leg bet are derived from its legs
+	if len(mapped.Legs) == 0 {
+		odds := bet.GetQuotedOdds()
+		if odds == "" {
+			odds = strconv.FormatFloat(bet.GetOdds(), 'f', -1, 64)
+		}
+
+		if err := mapped.SetOdds(odds, enums.OddsFormat(bet.GetOddsFormat())); err != nil {
+			return nil, err
+		}
+	}
+
+	// the outcome of a multi-leg bet is derived from its legs so it may be left unspecified
+	if err := mapped.Derive(); err != nil {
+		return nil, err
+	}
+
+	return mapped, nil
+}
+
+// toDomainOutcome maps an outcome, an unspecified outcome maps to an invalid one
+func toDomainOutcome(outcome pb.Outcome) enums.Outcome {
+	switch outcome {
+	case pb.Outcome_OUTCOME_WIN:
+		return enums.Win
+	case pb.Outcome_OUTCOME_LOSE:
+		return enums.Lose
+	case pb.Outcome_OUTCOME_PENDING:
+		return enums.Pending
+	case pb.Outcome_OUTCOME_VOID:
+		return enums.Void
+	case pb.Outcome_OUTCOME_PUSH:
+		return enums.Push
+	case pb.Outcome_OUTCOME_CASHOUT:
+		return enums.Cashout
+	case pb.Outcome_OUTCOME_HALF_WIN:
+		return enums.HalfWin
+	case pb.Outcome_OUTCOME_HALF_LOSE:
+		return enums.HalfLose
+	default:
+		return enums.Outcome(outcome.String())
+	}
+}
+
+func toProtoUser(user domain.User) *pb.User {
+	return &pb.User{
+		Id:            user.ID,
+		TotalBets:     user.TotalBets,
+		TotalWinnings: user.TotalWinnings.Float64(),
+		Currency:      string(user.Currency),
+	}
+}
+
+func toProtoUsers(users []domain.User) []*pb.User {
+	mapped := make([]*pb.User, 0, len(users))
+
+	for _, user := range users {
+		mapped = append(mapped, toProtoUser(user))
+	}
+
+	return mapped
+}
@@ -0,0 +1,240 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/config"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/pubsub"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/presentation/rpc/pb"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/usecases"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeDatabase stores bets in memory. The methods it does not override panic when called.
+type fakeDatabase struct {
+	infrastructure.Database
+
+	totalBets    int64
+	totalBetsErr error
+	// storeErr fails every batch from the one numbered failBatch, counting from 1
+	storeErr  error
+	failBatch int
+
+	batches [][]*domain.Bet
+}
+
+func (f *fakeDatabase) GetTotalBets(_ context.Context, _ string) (int64, error) {
+	return f.totalBets, f.totalBetsErr
+}
+
+func (f *fakeDatabase) ListSelfExclusions(_ context.Context, _ []string) ([]domain.Exclusion, error) {
+	return nil, nil
+}
+
+func (f *fakeDatabase) ListUserLimits(_ context.Context, _ []string) ([]domain.Limits, error) {
+	return nil, nil
+}
+
+func (f *fakeDatabase) StoreNewBets(_ context.Context, bets []*domain.Bet) (int64, error) {
+	if f.storeErr != nil && len(f.batches)+1 >= f.failBatch {
+		return 0, f.storeErr
+	}
+
+	f.batches = append(f.batches, bets)
+
+	return int64(len(bets)), nil
+}
+
+// newTestClient serves the MaybetsService on top of the database over an in-memory connection
+func newTestClient(t *testing.T, db *fakeDatabase) pb.MaybetsServiceClient {
+	t.Helper()
+
+	exchange, err := domain.NewExchange("USD", "USD", nil)
+	if err != nil {
+		t.Fatalf("NewExchange() error = %v", err)
+	}
+
+	infra := infrastructure.NewInfrastructureInteractor(nil, db, nil, pubsub.NewBroker(1))
+
+	usecase, err := usecases.NewUsecaseMayBetsImpl(
+		*infra, config.WebhookConfig{}, config.LiveConfig{}, config.LimitsConfig{}, config.AMLConfig{},
+		config.LinkedAccountsConfig{}, exchange,
+	)
+	if err != nil {
+		t.Fatalf("NewUsecaseMayBetsImpl() error = %v", err)
+	}
+
+	listener := bufconn.Listen(1 << 20)
+
+	server := NewGRPCServer(usecase)
+	go server.Serve(listener)
+
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewMaybetsServiceClient(conn)
+}
+
+func testBets(n int) []*pb.Bet {
+	bets := make([]*pb.Bet, n)
+
+	for i := range bets {
+		bets[i] = &pb.Bet{
+			BetId:     fmt.Sprintf("b%d", i+1),
+			UserId:    "u1",
+			Amount:    10,
+			Odds:      2.5,
+			Outcome:   pb.Outcome_OUTCOME_WIN,
+			Timestamp: timestamppb.New(time.Date(2026, 10, 1, 18, 0, 0, 0, time.UTC)),
+		}
+	}
+
+	return bets
+}
+
+func TestServer_GetUserTotalBets(t *testing.T) {
+	tests := []struct {
+		name     string
+		db       *fakeDatabase
+		userID   string
+		want     int64
+		wantCode codes.Code
+	}{
+		{
+			name:     "success: total bets of a user",
+			db:       &fakeDatabase{totalBets: 5},
+			userID:   "u1",
+			want:     5,
+			wantCode: codes.OK,
+		},
+		{
+			name:     "fail: no user_id",
+			db:       &fakeDatabase{},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "sad: unable to count the bets",
+			db:       &fakeDatabase{totalBetsErr: errors.New("database is locked")},
+			userID:   "u1",
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, tt.db)
+
+			response, err := client.GetUserTotalBets(context.Background(), &pb.GetUserTotalBetsRequest{UserId: tt.userID})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("Server.GetUserTotalBets() code = %v, want %v: %v", code, tt.wantCode, err)
+			}
+
+			if err == nil && response.GetUser().GetTotalBets() != tt.want {
+				t.Errorf("Server.GetUserTotalBets() = %v, want %v total bets", response.GetUser(), tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_IngestBets(t *testing.T) {
+	tests := []struct {
+		name        string
+		db          *fakeDatabase
+		requests    [][]*pb.Bet
+		want        *pb.IngestBetsResponse
+		wantCode    codes.Code
+		wantBatches int
+	}{
+		{
+			name:        "success: bets over several requests acknowledged once stored",
+			db:          &fakeDatabase{},
+			requests:    [][]*pb.Bet{testBets(2), testBets(1)},
+			want:        &pb.IngestBetsResponse{Received: 3, Batches: 1},
+			wantCode:    codes.OK,
+			wantBatches: 1,
+		},
+		{
+			name:        "success: bets stored in batches of 1000",
+			db:          &fakeDatabase{},
+			requests:    [][]*pb.Bet{testBets(ingestBatchSize + 1)},
+			want:        &pb.IngestBetsResponse{Received: ingestBatchSize + 1, Batches: 2},
+			wantCode:    codes.OK,
+			wantBatches: 2,
+		},
+		{
+			name:        "fail: bet without a timestamp",
+			db:          &fakeDatabase{},
+			requests:    [][]*pb.Bet{{{BetId: "b1", UserId: "u1", Amount: 10, Odds: 2.5, Outcome: pb.Outcome_OUTCOME_WIN}}},
+			wantCode:    codes.InvalidArgument,
+			wantBatches: 0,
+		},
+		{
+			name:        "fail: bet turned down by storage",
+			db:          &fakeDatabase{storeErr: fmt.Errorf("%w b1: unsupported currency \"JPY\"", domain.ErrInvalidBet), failBatch: 1},
+			requests:    [][]*pb.Bet{testBets(1)},
+			wantCode:    codes.InvalidArgument,
+			wantBatches: 0,
+		},
+		{
+			name:        "sad: batch that cannot be stored is not acknowledged",
+			db:          &fakeDatabase{storeErr: errors.New("database is locked"), failBatch: 2},
+			requests:    [][]*pb.Bet{testBets(ingestBatchSize + 1)},
+			wantCode:    codes.Internal,
+			wantBatches: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, tt.db)
+
+			stream, err := client.IngestBets(context.Background())
+			if err != nil {
+				t.Fatalf("Client.IngestBets() error = %v", err)
+			}
+
+			for _, bets := range tt.requests {
+				// the server may abort the stream before every request is sent
+				if err := stream.Send(&pb.IngestBetsRequest{Bets: bets}); err != nil {
+					break
+				}
+			}
+
+			response, err := stream.CloseAndRecv()
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("Server.IngestBets() code = %v, want %v: %v", code, tt.wantCode, err)
+			}
+
+			if err == nil && (response.GetReceived() != tt.want.GetReceived() || response.GetBatches() != tt.want.GetBatches()) {
+				t.Errorf("Server.IngestBets() = %v, want %v", response, tt.want)
+			}
+
+			if len(tt.db.batches) != tt.wantBatches {
+				t.Errorf("Server.IngestBets() stored %d batches, want %d", len(tt.db.batches), tt.wantBatches)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+	"github.com/google/uuid"
+)
+
+// DetectAnomalies raises an alert for every anomalous user that was not alerted on before.
+// It returns the number of new alerts.
+func (u *UsecaseMayBets) DetectAnomalies(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "DetectAnomalies")
+	defer span.End()
+
+	users, err := u.Infrastructure.Database.GetAnomalousUsers(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	alerts := make([]domain.Alert, 0, len(users))
+
+	for _, user := range users {
+		alerts = append(alerts, domain.Alert{
+			Event:  enums.AnomalyDetected,
+			UserID: user.ID,
+			Value:  float64(user.TotalBets),
+		})
+	}
+
+	return u.raiseAlerts(ctx, alerts)
+}
+
+// raiseBetAlerts raises the alerts caused by a batch of bets that was just stored: one for every large bet,
+// one for every user whose losses crossed the loss limit with this batch and one for every responsible gambling limit
+// the owners of the bets stand in breach of.
+// The bets are already stored at this point and must not be stored again, so failures are logged rather than returned.
+func (u *UsecaseMayBets) raiseBetAlerts(ctx context.Context, bets []*domain.Bet) {
+	ctx, span := tracer.Start(ctx, "RaiseBetAlerts")
+	defer span.End()
+
+	var alerts []domain.Alert
+
+	// batchLosses holds the amount each user lost in this batch, in the reporting currency
+	batchLosses := map[string]domain.Money{}
+
+	largeBet := domain.MoneyFromFloat(u.WebhookConfig.LargeBetAmount)
+
+	for _, bet := range bets {
+		amount, err := u.Exchange.Convert(bet.Amount, bet.Currency)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to check bet for alerts", "bet_id", bet.BetID, "error", err)
+			continue
+		}
+
+		if largeBet > 0 && amount >= largeBet {
+			alerts = append(alerts, domain.Alert{
+				Event:     enums.LargeBet,
+				UserID:    bet.UserID,
+				BetID:     bet.BetID,
+				Value:     amount.Float64(),
+				Threshold: u.WebhookConfig.LargeBetAmount,
+			})
+		}
+
+		if loss := u.reportedLoss(ctx, bet, 0); loss > 0 {
+			batchLosses[bet.UserID] += loss
+		}
+	}
+
+	alerts = append(alerts, u.lossLimitAlerts(ctx, batchLosses)...)
+	alerts = append(alerts, u.limitAlerts(ctx, betUserIDs(bets))...)
+
+	if _, err := u.raiseAlerts(ctx, alerts); err != nil {
+		slog.ErrorContext(ctx, "failed to raise bet alerts", "alerts", len(alerts), "error", err)
+	}
+}
+
+// reportedLoss returns the loss of a bet beyond the loss it had before, in the reporting currency.
+// Failures are logged and count as no loss since the bet is already stored.
+func (u *UsecaseMayBets) reportedLoss(ctx context.Context, bet *domain.Bet, previousLoss domain.Money) domain.Money {
+	loss, err := u.Exchange.Convert(bet.Loss()-previousLoss, bet.Currency)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to convert bet loss", "bet_id", bet.BetID, "error", err)
+		return 0
+	}
+
+	return loss
+}
+
+// lossLimitAlerts returns an alert for every user whose losses crossed the loss limit with the given new losses,
+// both in the reporting currency. Failures are logged since the losses are already stored.
+func (u *UsecaseMayBets) lossLimitAlerts(ctx context.Context, newLosses map[string]domain.Money) []domain.Alert {
+	if u.WebhookConfig.LossLimit <= 0 || len(newLosses) == 0 {
+		return nil
+	}
+
+	userIDs := make([]string, 0, len(newLosses))
+	for userID := range newLosses {
+		userIDs = append(userIDs, userID)
+	}
+
+	users, err := u.Infrastructure.Database.GetUserLosses(ctx, userIDs)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to check loss limits", "users", len(userIDs), "error", err)
+	}
+
+	var alerts []domain.Alert
+
+	for _, user := range users {
+		// only the losses that take the user over the limit raise the alert
+		limit := domain.MoneyFromFloat(u.WebhookConfig.LossLimit)
+		if user.TotalLosses < limit || user.TotalLosses-newLosses[user.ID] >= limit {
+			continue
+		}
+
+		alerts = append(alerts, domain.Alert{
+			Event:     enums.LossLimitCrossed,
+			UserID:    user.ID,
+			Value:     user.TotalLosses.Float64(),
+			Threshold: u.WebhookConfig.LossLimit,
+		})
+	}
+
+	return alerts
+}
+
+// raiseAlerts records the alerts that were not raised before and queues a delivery for every subscription of their event.
+// It returns the number of new alerts.
+func (u *UsecaseMayBets) raiseAlerts(ctx context.Context, alerts []domain.Alert) (int, error) {
+	if len(alerts) == 0 {
+		return 0, nil
+	}
+
+	subscriptions, err := u.Infrastructure.Database.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var raised int
+
+	for _, alert := range alerts {
+		now := time.Now()
+
+		alert.ID = uuid.NewString()
+		alert.CreatedAt = now
+
+		payload, err := json.Marshal(alert)
+		if err != nil {
+			return raised, fmt.Errorf("failed to encode alert: %w", err)
+		}
+
+		var deliveries []domain.WebhookDelivery
+
+		for _, subscription := range subscriptions {
+			if !subscription.Subscribes(alert.Event) {
+				continue
+			}
+
+			deliveries = append(deliveries, domain.WebhookDelivery{
+				SubscriptionID: subscription.ID,
+				AlertID:        alert.ID,
+				Event:          alert.Event,
+				Payload:        payload,
+				NextAttemptAt:  now,
+			})
+		}
+
+		created, err := u.Infrastructure.Database.RecordAlert(ctx, &alert, deliveries)
+		if err != nil {
+			return raised, err
+		}
+
+		if !created {
+			continue
+		}
+
+		raised++
+
+		u.Infrastructure.Live.Publish(domain.LiveEvent{Topic: enums.AlertsTopic, UserID: alert.UserID, Data: alert})
+
+		slog.InfoContext(ctx, "raised alert",
+			"alert_id", alert.ID, "event", alert.Event, "user_id", alert.UserID, "bet_id", alert.BetID,
+			"value", alert.Value, "deliveries", len(deliveries))
+	}
+
+	return raised, nil
+}
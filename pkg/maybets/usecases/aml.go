@@ -0,0 +1,143 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+)
+
+// GetAMLReport screens the bets placed over the period of the filter, only those of its user when it names one,
+// against the anti-money laundering rules and returns their findings with the bets as evidence.
+// Runs of bets reaching over the start of the period are only reported from it.
+func (u *UsecaseMayBets) GetAMLReport(ctx context.Context, filter domain.BetFilter) (*domain.AMLReport, error) {
+	ctx, span := tracer.Start(ctx, "GetAMLReport")
+	defer span.End()
+
+	rules := u.AMLConfig.Rules()
+	if !rules.Enabled() {
+		return nil, errors.New("aml.large_transaction_amount: must be set to screen bets")
+	}
+
+	scanner := domain.NewAMLScanner(rules, u.Exchange)
+
+	if err := u.Infrastructure.Database.StreamBets(ctx, filter, scanner.Add); err != nil {
+		return nil, err
+	}
+
+	return &domain.AMLReport{
+		From:      filter.From,
+		To:        filter.To,
+		Currency:  u.Exchange.Reporting,
+		Threshold: rules.Threshold,
+		Findings:  scanner.Findings(),
+	}, nil
+}
+
+// ScreenAML screens the bets placed over the lookback against the anti-money laundering rules and raises an alert
+// for every finding that was not alerted on before, leaving out the structuring runs whose every bet was reported by
+// a structuring alert raised over the lookback. It returns the number of new alerts.
+func (u *UsecaseMayBets) ScreenAML(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "ScreenAML")
+	defer span.End()
+
+	from := time.Now().Add(-u.AMLConfig.Lookback)
+
+	report, err := u.GetAMLReport(ctx, domain.BetFilter{From: &from})
+	if err != nil {
+		return 0, err
+	}
+
+	reported, err := u.structuredBets(ctx, report.Findings, from)
+	if err != nil {
+		return 0, err
+	}
+
+	alerts := make([]domain.Alert, 0, len(report.Findings))
+
+	for _, finding := range report.Findings {
+		if alert, ok := finding.Alert(report.Threshold, reported); ok {
+			alerts = append(alerts, alert)
+		}
+	}
+
+	return u.raiseAlerts(ctx, alerts)
+}
+
+// structuredBets returns the IDs of the bets reported by the structuring alerts raised since from for the users
+// of the structuring findings
+func (u *UsecaseMayBets) structuredBets(ctx context.Context, findings []domain.AMLFinding, from time.Time) (map[string]bool, error) {
+	seen := map[string]bool{}
+
+	var userIDs []string
+
+	for _, finding := range findings {
+		if finding.Rule == enums.Structuring && !seen[finding.UserID] {
+			seen[finding.UserID] = true
+			userIDs = append(userIDs, finding.UserID)
+		}
+	}
+
+	reported := map[string]bool{}
+
+	if len(userIDs) == 0 {
+		return reported, nil
+	}
+
+	alerts, err := u.Infrastructure.Database.ListUserAlertsSince(ctx, enums.AMLStructuring, userIDs, from)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, alert := range alerts {
+		for _, betID := range alert.Evidence {
+			reported[betID] = true
+		}
+	}
+
+	return reported, nil
+}
+
+// ListAMLAlerts fetches the most recent alerts raised for anti-money laundering findings, newest first
+func (u *UsecaseMayBets) ListAMLAlerts(ctx context.Context, limit int) ([]domain.Alert, error) {
+	ctx, span := tracer.Start(ctx, "ListAMLAlerts")
+	defer span.End()
+
+	events := make([]enums.WebhookEvent, 0, len(enums.AMLRules))
+	for _, rule := range enums.AMLRules {
+		events = append(events, rule.Event())
+	}
+
+	return u.Infrastructure.Database.ListAlertsByEvent(ctx, events, limit)
+}
+
+// RunAML screens the bets against the anti-money laundering rules on its interval until ctx is canceled.
+// It returns at once when the rules or the scheduled screening are disabled.
+func (u *UsecaseMayBets) RunAML(ctx context.Context) {
+	if u.AMLConfig.Interval <= 0 || !u.AMLConfig.Rules().Enabled() {
+		return
+	}
+
+	ticker := time.NewTicker(u.AMLConfig.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			raised, err := u.ScreenAML(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to screen bets against the anti-money laundering rules", "error", err)
+				continue
+			}
+
+			if raised > 0 {
+				slog.WarnContext(ctx, "raised anti-money laundering alerts", "alerts", raised)
+			}
+		}
+	}
+}
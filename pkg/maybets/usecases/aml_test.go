@@ -0,0 +1,143 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/config"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+)
+
+// testAMLBet returns a bet of the user placed the given time before now
+func testAMLBet(betID, userID string, amount float64, placed time.Duration) domain.Bet {
+	return domain.Bet{
+		BetID: betID, UserID: userID, Amount: domain.MoneyFromFloat(amount), Currency: "USD", Odds: 2,
+		Outcome: enums.Pending, Timestamp: time.Now().Add(-placed),
+	}
+}
+
+// testAMLBets returns a large bet of u1, four bets of u2 just below the 1000 USD threshold within half an hour, and
+// a large bet of u3 placed before a day of lookback
+func testAMLBets() []domain.Bet {
+	return []domain.Bet{
+		testAMLBet("b1", "u3", 5000, 48*time.Hour),
+		testAMLBet("b2", "u2", 950, 3*time.Hour),
+		testAMLBet("b3", "u2", 960, 3*time.Hour-10*time.Minute),
+		testAMLBet("b4", "u2", 970, 3*time.Hour-20*time.Minute),
+		testAMLBet("b6", "u2", 980, 3*time.Hour-30*time.Minute),
+		testAMLBet("b5", "u1", 1500, time.Hour),
+	}
+}
+
+func TestUsecaseMayBets_ScreenAML(t *testing.T) {
+	rules := config.AMLConfig{
+		LargeTransactionAmount: 1000,
+		StructuringMargin:      0.1,
+		StructuringBets:        3,
+		StructuringWindow:      time.Hour,
+		Lookback:               24 * time.Hour,
+	}
+
+	disabled := rules
+	disabled.LargeTransactionAmount = 0
+
+	tests := []struct {
+		name       string
+		aml        config.AMLConfig
+		want       int
+		wantAlerts []string
+		wantErr    bool
+		// lookback and placed are the lookback of the second screening and the bets placed before it
+		lookback       time.Duration
+		placed         []domain.Bet
+		wantAgain      int
+		wantAgainAlert string
+	}{
+		{
+			name:       "success: alerts raised for the findings over the lookback, once",
+			aml:        rules,
+			want:       2,
+			wantAlerts: []string{"aml.large_transaction:u1", "aml.structuring:u2"},
+			lookback:   rules.Lookback,
+		},
+		{
+			name:       "success: run regrouped as its first bet leaves the lookback not alerted on again",
+			aml:        rules,
+			want:       2,
+			wantAlerts: []string{"aml.large_transaction:u1", "aml.structuring:u2"},
+			lookback:   3*time.Hour - 5*time.Minute,
+		},
+		{
+			name:           "success: bets joining a run already alerted on reported",
+			aml:            rules,
+			want:           2,
+			wantAlerts:     []string{"aml.large_transaction:u1", "aml.structuring:u2"},
+			lookback:       rules.Lookback,
+			placed:         []domain.Bet{testAMLBet("b7", "u2", 990, 3*time.Hour-40*time.Minute)},
+			wantAgain:      1,
+			wantAgainAlert: "aml.structuring:u2:b7",
+		},
+		{
+			name:       "fail: rules disabled",
+			aml:        disabled,
+			wantAlerts: []string{},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &fakeDatabase{bets: testAMLBets()}
+			usecase := newTestUsecase(t, db, config.LimitsConfig{}, tt.aml)
+
+			screened := time.Now()
+
+			got, err := usecase.ScreenAML(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UsecaseMayBets.ScreenAML() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("UsecaseMayBets.ScreenAML() = %v, want %v", got, tt.want)
+			}
+
+			alerts := alertEvents(db.alerts)
+			slices.Sort(alerts)
+
+			if fmt.Sprint(alerts) != fmt.Sprint(tt.wantAlerts) {
+				t.Errorf("UsecaseMayBets.ScreenAML() raised %v, want %v", alerts, tt.wantAlerts)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if len(db.filters) != 1 || db.filters[0].From == nil ||
+				db.filters[0].From.Sub(screened.Add(-tt.aml.Lookback)).Abs() > time.Second {
+				t.Errorf("UsecaseMayBets.ScreenAML() read the bets with filters %v, want them from %v back", db.filters, tt.aml.Lookback)
+			}
+
+			usecase.AMLConfig.Lookback = tt.lookback
+			db.bets = append(db.bets, tt.placed...)
+
+			again, err := usecase.ScreenAML(context.Background())
+			if err != nil {
+				t.Fatalf("UsecaseMayBets.ScreenAML() error = %v", err)
+			}
+
+			if again != tt.wantAgain || len(db.alerts) != tt.want+tt.wantAgain {
+				t.Fatalf("UsecaseMayBets.ScreenAML() raised %d alerts again, want %d", again, tt.wantAgain)
+			}
+
+			if tt.wantAgain > 0 {
+				if alert := db.alerts[len(db.alerts)-1]; alert.Key() != tt.wantAgainAlert || !slices.Contains(alert.Evidence, "b7") {
+					t.Errorf("UsecaseMayBets.ScreenAML() raised %+v, want %v holding the new bet", alert, tt.wantAgainAlert)
+				}
+			}
+		})
+	}
+}
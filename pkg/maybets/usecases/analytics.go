@@ -0,0 +1,127 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+)
+
+// GetTopUsers fetches the users with the highest betting volume, at most limit of them.
+func (u *UsecaseMayBets) GetTopUsers(ctx context.Context, limit int) ([]domain.User, error) {
+	ctx, span := tracer.Start(ctx, "GetTopUsers")
+	defer span.End()
+
+	return u.Infrastructure.Database.GetTopUsers(ctx, limit)
+}
+
+// GetUsersTotals fetches the number of bets, the winnings and the losses of several users at once.
+// Users that never placed a bet are left out.
+func (u *UsecaseMayBets) GetUsersTotals(ctx context.Context, userIDs []string) ([]domain.User, error) {
+	ctx, span := tracer.Start(ctx, "GetUsersTotals")
+	defer span.End()
+
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	return u.Infrastructure.Database.GetUserTotals(ctx, userIDs)
+}
+
+// GetBreakdown fetches the number of bets, the volume, the payouts and the margin of the bets matching the filter,
+// grouped by one or more dimensions and ordered by volume, largest first.
+func (u *UsecaseMayBets) GetBreakdown(ctx context.Context, dimensions []enums.Dimension, filter domain.BetFilter) ([]domain.Breakdown, error) {
+	ctx, span := tracer.Start(ctx, "GetBreakdown")
+	defer span.End()
+
+	if len(dimensions) == 0 {
+		return nil, fmt.Errorf("at least one dimension must be given, one of %v", enums.Dimensions)
+	}
+
+	for i, dimension := range dimensions {
+		if !dimension.IsValid() {
+			return nil, fmt.Errorf("invalid dimension %q: must be one of %v", dimension, enums.Dimensions)
+		}
+
+		if slices.Contains(dimensions[:i], dimension) {
+			return nil, fmt.Errorf("dimension %q is given more than once", dimension)
+		}
+	}
+
+	return u.Infrastructure.Database.GetBreakdown(ctx, dimensions, filter)
+}
+
+// GetExposure works out the open liability of the pending bets per event, market and selection, and of the pending
+// multi-leg bets, narrowed down to a single event when eventID is given. Multi-leg bets do not name an event,
+// so they are only reported over every event.
+func (u *UsecaseMayBets) GetExposure(ctx context.Context, eventID string) (domain.Exposure, error) {
+	ctx, span := tracer.Start(ctx, "GetExposure")
+	defer span.End()
+
+	calculator := domain.NewExposureCalculator(u.Exchange)
+
+	err := u.Infrastructure.Database.StreamBets(ctx, domain.BetFilter{EventID: eventID, Outcome: enums.Pending}, func(bet *domain.Bet) error {
+		calculator.Add(bet)
+
+		return nil
+	})
+	if err != nil {
+		return domain.Exposure{}, err
+	}
+
+	return calculator.Exposure()
+}
+
+// GetRecentBets fetches the latest bets of several users at once, at most limit per user, newest first.
+func (u *UsecaseMayBets) GetRecentBets(ctx context.Context, userIDs []string, limit int) ([]domain.Bet, error) {
+	ctx, span := tracer.Start(ctx, "GetRecentBets")
+	defer span.End()
+
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	return u.Infrastructure.Database.GetRecentBets(ctx, userIDs, limit)
+}
+
+// GetRecentAlerts fetches the latest alerts of several users at once, at most limit per user, newest first.
+func (u *UsecaseMayBets) GetRecentAlerts(ctx context.Context, userIDs []string, limit int) ([]domain.Alert, error) {
+	ctx, span := tracer.Start(ctx, "GetRecentAlerts")
+	defer span.End()
+
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	return u.Infrastructure.Database.GetRecentAlerts(ctx, userIDs, limit)
+}
+
+// ListAlerts fetches the most recent alerts, newest first
+func (u *UsecaseMayBets) ListAlerts(ctx context.Context, limit int) ([]domain.Alert, error) {
+	ctx, span := tracer.Start(ctx, "ListAlerts")
+	defer span.End()
+
+	return u.Infrastructure.Database.ListAlerts(ctx, limit)
+}
+
+// ListBets fetches the bets matching the filter, ordered by timestamp.
+// The filter should carry a limit since every matching bet is held in memory.
+func (u *UsecaseMayBets) ListBets(ctx context.Context, filter domain.BetFilter) ([]domain.Bet, error) {
+	ctx, span := tracer.Start(ctx, "ListBets")
+	defer span.End()
+
+	var bets []domain.Bet
+
+	err := u.Infrastructure.Database.StreamBets(ctx, filter, func(bet *domain.Bet) error {
+		bets = append(bets, *bet)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return bets, nil
+}
@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+)
+
+// SaveEvent validates and stores an event with its markets and selections.
+// Saving an event that exists updates its name and start and adds the markets and selections it does not have yet.
+func (u *UsecaseMayBets) SaveEvent(ctx context.Context, event domain.Event) (*domain.Event, error) {
+	ctx, span := tracer.Start(ctx, "SaveEvent")
+	defer span.End()
+
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := u.Infrastructure.Database.SaveEvent(ctx, &event); err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// GetEvent fetches an event along with its markets and the results of its selections
+func (u *UsecaseMayBets) GetEvent(ctx context.Context, id string) (*domain.Event, error) {
+	ctx, span := tracer.Start(ctx, "GetEvent")
+	defer span.End()
+
+	return u.Infrastructure.Database.GetEvent(ctx, id)
+}
@@ -0,0 +1,205 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+)
+
+// CreateSelfExclusion registers a period a user excluded themselves from betting over, starting now unless a start is given
+func (u *UsecaseMayBets) CreateSelfExclusion(ctx context.Context, exclusion domain.Exclusion) (*domain.Exclusion, error) {
+	ctx, span := tracer.Start(ctx, "CreateSelfExclusion")
+	defer span.End()
+
+	if exclusion.StartsAt.IsZero() {
+		exclusion.StartsAt = time.Now()
+	}
+
+	exclusion.ID, exclusion.LiftedAt, exclusion.LiftedBy = "", nil, ""
+
+	if err := exclusion.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := u.Infrastructure.Database.CreateSelfExclusion(ctx, &exclusion); err != nil {
+		return nil, err
+	}
+
+	return &exclusion, nil
+}
+
+// LiftSelfExclusion ends a self-exclusion now, so that the bets the user places from then on are accepted
+func (u *UsecaseMayBets) LiftSelfExclusion(ctx context.Context, id, liftedBy string) (*domain.Exclusion, error) {
+	ctx, span := tracer.Start(ctx, "LiftSelfExclusion")
+	defer span.End()
+
+	exclusion, err := u.Infrastructure.Database.GetSelfExclusion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := exclusion.Lift(time.Now(), liftedBy); err != nil {
+		return nil, err
+	}
+
+	if err := u.Infrastructure.Database.LiftSelfExclusion(ctx, exclusion); err != nil {
+		return nil, err
+	}
+
+	return exclusion, nil
+}
+
+// ListSelfExclusions fetches the self-exclusions of a user, or of every user when none is given
+func (u *UsecaseMayBets) ListSelfExclusions(ctx context.Context, userID string) ([]domain.Exclusion, error) {
+	ctx, span := tracer.Start(ctx, "ListSelfExclusions")
+	defer span.End()
+
+	var userIDs []string
+	if userID != "" {
+		userIDs = []string{userID}
+	}
+
+	return u.Infrastructure.Database.ListSelfExclusions(ctx, userIDs)
+}
+
+// ListExclusionViolations fetches the most recent bets received from self-excluded users, of a single user
+// when one is given, newest first
+func (u *UsecaseMayBets) ListExclusionViolations(ctx context.Context, userID string, limit int) ([]domain.ExclusionViolation, error) {
+	ctx, span := tracer.Start(ctx, "ListExclusionViolations")
+	defer span.End()
+
+	return u.Infrastructure.Database.ListExclusionViolations(ctx, userID, limit)
+}
+
+// GetExclusionReport lists the stored bets that were placed by users during one of their self-exclusions,
+// within the period of the filter and only those of its user when it names one
+func (u *UsecaseMayBets) GetExclusionReport(ctx context.Context, filter domain.BetFilter) (*domain.ExclusionReport, error) {
+	ctx, span := tracer.Start(ctx, "GetExclusionReport")
+	defer span.End()
+
+	exclusions, err := u.ListSelfExclusions(ctx, filter.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.ExclusionReport{From: filter.From, To: filter.To, Bets: []domain.ExcludedBet{}}
+
+	// a bet falling within overlapping exclusions of its user is reported once, under the earliest of them
+	reported := map[string]bool{}
+
+	for _, exclusion := range exclusions {
+		if !exclusion.Overlaps(filter.From, filter.To) {
+			continue
+		}
+
+		// the bets are read over the part of the period the exclusion covers
+		from, to := exclusion.StartsAt, exclusion.Until()
+		if filter.From != nil && filter.From.After(from) {
+			from = *filter.From
+		}
+
+		if filter.To != nil && (to == nil || filter.To.Before(*to)) {
+			to = filter.To
+		}
+
+		err := u.Infrastructure.Database.StreamBets(ctx, domain.BetFilter{UserID: exclusion.UserID, From: &from, To: to},
+			func(bet *domain.Bet) error {
+				if exclusion.Covers(bet.Timestamp) && !reported[bet.BetID] {
+					reported[bet.BetID] = true
+					report.Bets = append(report.Bets, domain.ExcludedBet{ExclusionID: exclusion.ID, Bet: bet})
+				}
+
+				return nil
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	slices.SortFunc(report.Bets, func(a, b domain.ExcludedBet) int {
+		return cmp.Or(a.Bet.Timestamp.Compare(b.Bet.Timestamp), cmp.Compare(a.Bet.BetID, b.Bet.BetID))
+	})
+
+	return report, nil
+}
+
+// screenExclusions finds a violation for every bet placed by a user during one of their self-exclusions.
+// It returns the bets to store along with those it turned down, which are the bets of self-excluded users
+// when limits.exclusion_mode is reject and none otherwise, and the violations to record once the bets are stored.
+func (u *UsecaseMayBets) screenExclusions(
+	ctx context.Context, bets []*domain.Bet,
+) ([]*domain.Bet, []domain.RejectedBet, []domain.ExclusionViolation, error) {
+	if len(bets) == 0 {
+		return bets, []domain.RejectedBet{}, nil, nil
+	}
+
+	exclusions, err := u.Infrastructure.Database.ListSelfExclusions(ctx, betUserIDs(bets))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if len(exclusions) == 0 {
+		return bets, []domain.RejectedBet{}, nil, nil
+	}
+
+	mode := u.LimitsConfig.ExclusionMode
+
+	accepted, rejected := make([]*domain.Bet, 0, len(bets)), []domain.RejectedBet{}
+
+	var violations []domain.ExclusionViolation
+
+	now := time.Now()
+
+	for _, bet := range bets {
+		// bets that do not say when they were placed are taken to be placed as they are received
+		placedAt := bet.Timestamp
+		if placedAt.IsZero() {
+			placedAt = now
+		}
+
+		exclusion, excluded := domain.CoveringExclusion(exclusions, bet.UserID, placedAt)
+		if !excluded {
+			accepted = append(accepted, bet)
+			continue
+		}
+
+		// bets that do not name a currency are stored in the default one
+		violations = append(violations, domain.ExclusionViolation{
+			ExclusionID: exclusion.ID,
+			UserID:      bet.UserID,
+			BetID:       bet.BetID,
+			Amount:      bet.Amount,
+			Currency:    cmp.Or(bet.Currency, u.Exchange.Default),
+			PlacedAt:    placedAt,
+			Action:      mode,
+		})
+
+		if mode == enums.Reject {
+			rejected = append(rejected, domain.RejectedBet{BetID: bet.BetID, UserID: bet.UserID, Reason: exclusion.Describe()})
+			continue
+		}
+
+		accepted = append(accepted, bet)
+	}
+
+	if len(violations) > 0 {
+		slog.WarnContext(ctx, "received bets from self-excluded users", "bets", len(bets), "violations", len(violations), "mode", mode)
+	}
+
+	return accepted, rejected, violations, nil
+}
+
+// recordExclusionViolations records the violations found by screenExclusions once the bets were stored.
+// Violations already on record for the same bet and exclusion are skipped, so that bets received again are reported once.
+func (u *UsecaseMayBets) recordExclusionViolations(ctx context.Context, violations []domain.ExclusionViolation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return u.Infrastructure.Database.RecordExclusionViolations(ctx, violations)
+}
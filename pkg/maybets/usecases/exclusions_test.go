@@ -0,0 +1,191 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/config"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+)
+
+// testExclusions excludes u1 from an hour ago on, and excluded u2 for a day that ended a week ago
+func testExclusions() []domain.Exclusion {
+	now := time.Now()
+	ended := now.Add(-7 * 24 * time.Hour)
+
+	return []domain.Exclusion{
+		{ID: "x1", UserID: "u1", StartsAt: now.Add(-time.Hour), Reason: "cooling off"},
+		{ID: "x2", UserID: "u2", StartsAt: ended.Add(-24 * time.Hour), EndsAt: &ended, Reason: "cooling off"},
+	}
+}
+
+// violationActions returns the bet and action of every violation, in the order they were recorded
+func violationActions(violations []domain.ExclusionViolation) []string {
+	actions := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		actions = append(actions, violation.BetID+":"+violation.Action.String())
+	}
+
+	return actions
+}
+
+func TestUsecaseMayBets_StoreBets(t *testing.T) {
+	bets := []*domain.Bet{testBet("b1", "u1", 10, enums.Pending), testBet("b2", "u2", 10, enums.Pending)}
+
+	tests := []struct {
+		name string
+		mode enums.ExclusionMode
+		db   *fakeDatabase
+		// deliveries is how many times the bets are received, once when zero
+		deliveries     int
+		wantStored     []string
+		wantViolations []string
+		wantErr        bool
+	}{
+		{
+			name:           "success: bets of excluded users turned down in reject mode",
+			mode:           enums.Reject,
+			db:             &fakeDatabase{exclusions: testExclusions()},
+			wantStored:     []string{"b2"},
+			wantViolations: []string{"b1:reject"},
+		},
+		{
+			name:           "success: bets of excluded users stored and flagged in flag mode",
+			mode:           enums.Flag,
+			db:             &fakeDatabase{exclusions: testExclusions()},
+			wantStored:     []string{"b1", "b2"},
+			wantViolations: []string{"b1:flag"},
+		},
+		{
+			name:           "success: bets turned down again reported once",
+			mode:           enums.Reject,
+			db:             &fakeDatabase{exclusions: testExclusions()},
+			deliveries:     2,
+			wantStored:     []string{"b2", "b2"},
+			wantViolations: []string{"b1:reject"},
+		},
+		{
+			name:           "success: bets flagged again reported once",
+			mode:           enums.Flag,
+			db:             &fakeDatabase{exclusions: testExclusions()},
+			deliveries:     2,
+			wantStored:     []string{"b1", "b2", "b1", "b2"},
+			wantViolations: []string{"b1:flag"},
+		},
+		{
+			name:           "success: no exclusions",
+			mode:           enums.Reject,
+			db:             &fakeDatabase{},
+			wantStored:     []string{"b1", "b2"},
+			wantViolations: []string{},
+		},
+		{
+			name:           "sad: unable to list the exclusions",
+			mode:           enums.Reject,
+			db:             &fakeDatabase{failExclusions: true},
+			wantStored:     []string{},
+			wantViolations: []string{},
+			wantErr:        true,
+		},
+		{
+			name:           "sad: no violations recorded for bets that could not be stored",
+			mode:           enums.Flag,
+			db:             &fakeDatabase{exclusions: testExclusions(), failStore: true},
+			wantStored:     []string{},
+			wantViolations: []string{},
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := newTestUsecase(t, tt.db, config.LimitsConfig{ExclusionMode: tt.mode}, config.AMLConfig{})
+
+			for range max(tt.deliveries, 1) {
+				if err := usecase.StoreBets(context.Background(), bets); (err != nil) != tt.wantErr {
+					t.Fatalf("UsecaseMayBets.StoreBets() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+
+			if got := betIDs(tt.db.stored); fmt.Sprint(got) != fmt.Sprint(tt.wantStored) {
+				t.Errorf("UsecaseMayBets.StoreBets() stored %v, want %v", got, tt.wantStored)
+			}
+
+			if got := violationActions(tt.db.violations); fmt.Sprint(got) != fmt.Sprint(tt.wantViolations) {
+				t.Errorf("UsecaseMayBets.StoreBets() recorded %v, want %v", got, tt.wantViolations)
+			}
+		})
+	}
+}
+
+func TestUsecaseMayBets_AcceptBetsFromExcludedUsers(t *testing.T) {
+	// u1 is excluded and already reached their bet cap for the day
+	capped := config.LimitsConfig{Day: config.PeriodLimitsConfig{BetCap: 1}, Reject: true}
+	totals := []domain.LimitTotals{{UserID: "u1", Period: enums.Day, Bets: 1}}
+
+	bets := []*domain.Bet{testBet("b1", "u1", 10, enums.Pending), testBet("b2", "u2", 10, enums.Pending)}
+
+	tests := []struct {
+		name           string
+		mode           enums.ExclusionMode
+		wantStored     []string
+		wantRejected   []string
+		wantViolations []string
+	}{
+		{
+			name:           "success: bets of excluded users turned down before the caps are counted",
+			mode:           enums.Reject,
+			wantStored:     []string{"b2"},
+			wantRejected:   []string{"b1"},
+			wantViolations: []string{"b1:reject"},
+		},
+		{
+			name:         "success: violations only flagged for the bets that pass the caps",
+			mode:         enums.Flag,
+			wantStored:   []string{"b2"},
+			wantRejected: []string{"b1"},
+			// b1 is turned down over the bet cap and never stored, so it is not a flagged violation
+			wantViolations: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &fakeDatabase{exclusions: testExclusions(), totals: totals}
+
+			limits := capped
+			limits.ExclusionMode = tt.mode
+
+			usecase := newTestUsecase(t, db, limits, config.AMLConfig{})
+
+			rejected, err := usecase.AcceptBets(context.Background(), bets)
+			if err != nil {
+				t.Fatalf("UsecaseMayBets.AcceptBets() error = %v", err)
+			}
+
+			rejectedIDs := []string{}
+			for _, bet := range rejected {
+				rejectedIDs = append(rejectedIDs, bet.BetID)
+			}
+
+			if fmt.Sprint(rejectedIDs) != fmt.Sprint(tt.wantRejected) {
+				t.Errorf("UsecaseMayBets.AcceptBets() rejected = %v, want %v", rejectedIDs, tt.wantRejected)
+			}
+
+			if got := betIDs(db.stored); fmt.Sprint(got) != fmt.Sprint(tt.wantStored) {
+				t.Errorf("UsecaseMayBets.AcceptBets() stored %v, want %v", got, tt.wantStored)
+			}
+
+			if got := violationActions(db.violations); fmt.Sprint(got) != fmt.Sprint(tt.wantViolations) {
+				t.Errorf("UsecaseMayBets.AcceptBets() recorded %v, want %v", got, tt.wantViolations)
+			}
+
+			if db.exclusionCalls != 1 {
+				t.Errorf("UsecaseMayBets.AcceptBets() looked the exclusions up %d times, want once", db.exclusionCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+)
+
+// SaveUserLimits sets the caps a user is held to over a period, replacing those they had over it.
+// Caps tighter than the defaults every user is held to take their place.
+func (u *UsecaseMayBets) SaveUserLimits(ctx context.Context, limits domain.Limits) (*domain.Limits, error) {
+	ctx, span := tracer.Start(ctx, "SaveUserLimits")
+	defer span.End()
+
+	if err := limits.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := u.Infrastructure.Database.SaveUserLimits(ctx, &limits); err != nil {
+		return nil, err
+	}
+
+	return &limits, nil
+}
+
+// GetLimitStanding works out where a user stands against every responsible gambling limit they are held to
+func (u *UsecaseMayBets) GetLimitStanding(ctx context.Context, userID string) (*domain.LimitStanding, error) {
+	ctx, span := tracer.Start(ctx, "GetLimitStanding")
+	defer span.End()
+
+	if userID == "" {
+		return nil, errors.New("user_id: must not be empty")
+	}
+
+	limits, err := u.Infrastructure.Database.ListUserLimits(ctx, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+
+	trackers, err := u.limitTrackers(ctx, []string{userID}, limits, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	standing := trackers[userID].Standing()
+
+	return &standing, nil
+}
+
+// screenLimits turns down the bets that would take their owner over a responsible gambling cap,
+// counting the bets accepted before them
+func (u *UsecaseMayBets) screenLimits(ctx context.Context, bets []*domain.Bet) ([]*domain.Bet, []domain.RejectedBet, error) {
+	userIDs := betUserIDs(bets)
+
+	limits, err := u.Infrastructure.Database.ListUserLimits(ctx, userIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	trackers, err := u.limitTrackers(ctx, userIDs, limits, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	accepted, rejected := make([]*domain.Bet, 0, len(bets)), []domain.RejectedBet{}
+
+	for _, bet := range bets {
+		tracker := trackers[bet.UserID]
+
+		if err := tracker.Check(bet); err != nil {
+			rejected = append(rejected, domain.RejectedBet{BetID: bet.BetID, UserID: bet.UserID, Reason: err.Error()})
+			continue
+		}
+
+		if err := tracker.Add(bet); err != nil {
+			return nil, nil, err
+		}
+
+		accepted = append(accepted, bet)
+	}
+
+	if len(rejected) > 0 {
+		slog.InfoContext(ctx, "rejected bets over responsible gambling caps", "bets", len(bets), "rejected", len(rejected))
+	}
+
+	return accepted, rejected, nil
+}
+
+// limitAlerts returns an alert for every responsible gambling limit the users stand in breach of.
+// Failures are logged since the bets are already stored.
+func (u *UsecaseMayBets) limitAlerts(ctx context.Context, userIDs []string) []domain.Alert {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	limits, err := u.Infrastructure.Database.ListUserLimits(ctx, userIDs)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to check responsible gambling limits", "users", len(userIDs), "error", err)
+		return nil
+	}
+
+	// without defaults, only the users with limits of their own are watched
+	if !u.LimitsConfig.Policy().Enabled() {
+		watched := map[string]bool{}
+		for _, limit := range limits {
+			watched[limit.UserID] = true
+		}
+
+		userIDs = slices.DeleteFunc(slices.Clone(userIDs), func(userID string) bool {
+			return !watched[userID]
+		})
+	}
+
+	trackers, err := u.limitTrackers(ctx, userIDs, limits, time.Now())
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to check responsible gambling limits", "users", len(userIDs), "error", err)
+		return nil
+	}
+
+	var alerts []domain.Alert
+
+	for _, userID := range userIDs {
+		for _, status := range trackers[userID].Standing().Limits {
+			if !status.Breached {
+				continue
+			}
+
+			alerts = append(alerts, domain.Alert{
+				Event:     enums.LimitBreached,
+				UserID:    userID,
+				Limit:     status.Limit,
+				Period:    status.Period,
+				Value:     status.Value,
+				Threshold: status.Cap,
+			})
+		}
+	}
+
+	return alerts
+}
+
+// limitTrackers returns a tracker for each of the users, held to the given limits of theirs, counting the bets they
+// placed over every limit period before now in a single grouped query.
+// Bets are only read one by one when the policy watches sessions or stake trends: the latest StakeTrendBets twice over
+// and those placed over the session length and two session gaps before now. A longer session is counted back no
+// further, which is enough to tell that it breached the session length.
+func (u *UsecaseMayBets) limitTrackers(
+	ctx context.Context, userIDs []string, limits []domain.Limits, now time.Time,
+) (map[string]*domain.LimitTracker, error) {
+	userLimits := map[string][]domain.Limits{}
+	for _, limit := range limits {
+		userLimits[limit.UserID] = append(userLimits[limit.UserID], limit)
+	}
+
+	policy := u.LimitsConfig.Policy()
+
+	trackers := make(map[string]*domain.LimitTracker, len(userIDs))
+	for _, userID := range userIDs {
+		trackers[userID] = domain.NewLimitTracker(userID, policy, userLimits[userID], u.Exchange, now)
+	}
+
+	if len(userIDs) == 0 {
+		return trackers, nil
+	}
+
+	totals, err := u.Infrastructure.Database.GetLimitTotals(ctx, userIDs, now)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, total := range totals {
+		if tracker, ok := trackers[total.UserID]; ok {
+			tracker.Count(total)
+		}
+	}
+
+	var since *time.Time
+
+	if policy.SessionLength > 0 {
+		from := now.Add(-policy.SessionLength - 2*policy.SessionGap)
+		since = &from
+	}
+
+	latest := 0
+	if policy.StakeTrendFactor > 0 {
+		latest = 2 * policy.StakeTrendBets
+	}
+
+	if since == nil && latest == 0 {
+		return trackers, nil
+	}
+
+	bets, err := u.Infrastructure.Database.GetLimitBets(ctx, userIDs, now.Add(-enums.Month.Duration()), since, latest)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range bets {
+		if tracker, ok := trackers[bets[i].UserID]; ok {
+			if err := tracker.Follow(&bets[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return trackers, nil
+}
+
+// betUserIDs returns the owners of the bets, each once, in the order they first appear
+func betUserIDs(bets []*domain.Bet) []string {
+	seen := map[string]bool{}
+
+	var userIDs []string
+
+	for _, bet := range bets {
+		if !seen[bet.UserID] {
+			seen[bet.UserID] = true
+			userIDs = append(userIDs, bet.UserID)
+		}
+	}
+
+	return userIDs
+}
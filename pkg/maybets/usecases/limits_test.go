@@ -0,0 +1,216 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/config"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+)
+
+func testBet(betID, userID string, amount float64, outcome enums.Outcome) *domain.Bet {
+	return &domain.Bet{
+		BetID: betID, UserID: userID, Amount: domain.MoneyFromFloat(amount), Currency: "USD", Odds: 2, Outcome: outcome,
+		Timestamp: time.Now().Add(-time.Minute),
+	}
+}
+
+// betIDs returns the IDs of the bets, in order
+func betIDs(bets []*domain.Bet) []string {
+	ids := make([]string, 0, len(bets))
+	for _, bet := range bets {
+		ids = append(ids, bet.BetID)
+	}
+
+	return ids
+}
+
+func TestUsecaseMayBets_AcceptBets(t *testing.T) {
+	dayStakeCap := config.LimitsConfig{Day: config.PeriodLimitsConfig{StakeCap: 50}, ExclusionMode: enums.Reject}
+
+	// u1 staked 30 USD today before the batch
+	staked := []domain.LimitTotals{{UserID: "u1", Period: enums.Day, Stake: domain.MoneyFromFloat(30), Bets: 1}}
+
+	tests := []struct {
+		name           string
+		limits         config.LimitsConfig
+		db             *fakeDatabase
+		bets           []*domain.Bet
+		wantStored     []string
+		wantRejected   []string
+		wantAlerts     []string
+		wantLimitCalls int
+		wantErr        bool
+	}{
+		{
+			name:   "success: bets over a cap turned down, counting the bets accepted before them",
+			limits: config.LimitsConfig{Day: dayStakeCap.Day, ExclusionMode: enums.Reject, Reject: true},
+			db:     &fakeDatabase{totals: staked},
+			bets: []*domain.Bet{
+				testBet("b1", "u1", 10, enums.Pending), testBet("b2", "u1", 20, enums.Pending), testBet("b3", "u2", 20, enums.Pending),
+			},
+			wantStored:   []string{"b1", "b3"},
+			wantRejected: []string{"b2"},
+			wantAlerts:   []string{},
+			// screening and the alerts raised once the bets are stored each count the totals of the batch once
+			wantLimitCalls: 2,
+		},
+		{
+			name:   "success: user held to a tighter cap of their own",
+			limits: config.LimitsConfig{Day: dayStakeCap.Day, ExclusionMode: enums.Reject, Reject: true},
+			db: &fakeDatabase{
+				totals: staked,
+				limits: []domain.Limits{{UserID: "u1", Period: enums.Day, BetCap: 1}},
+			},
+			bets:         []*domain.Bet{testBet("b1", "u1", 10, enums.Pending), testBet("b2", "u2", 10, enums.Pending)},
+			wantStored:   []string{"b2"},
+			wantRejected: []string{"b1"},
+			// only the owners of the stored bets are checked for breaches
+			wantAlerts:     []string{},
+			wantLimitCalls: 2,
+		},
+		{
+			name:           "success: bets over a cap stored and alerted on without reject",
+			limits:         dayStakeCap,
+			db:             &fakeDatabase{totals: staked},
+			bets:           []*domain.Bet{testBet("b1", "u1", 10, enums.Pending), testBet("b2", "u1", 20, enums.Pending)},
+			wantStored:     []string{"b1", "b2"},
+			wantRejected:   []string{},
+			wantAlerts:     []string{"user.limit_breached:u1"},
+			wantLimitCalls: 1,
+		},
+		{
+			name:           "success: nothing counted without limits",
+			limits:         config.LimitsConfig{ExclusionMode: enums.Reject, Reject: true},
+			db:             &fakeDatabase{totals: staked},
+			bets:           []*domain.Bet{testBet("b1", "u1", 10, enums.Pending)},
+			wantStored:     []string{"b1"},
+			wantRejected:   []string{},
+			wantAlerts:     []string{},
+			wantLimitCalls: 1,
+		},
+		{
+			name:           "sad: unable to list the limits",
+			limits:         config.LimitsConfig{Day: dayStakeCap.Day, ExclusionMode: enums.Reject, Reject: true},
+			db:             &fakeDatabase{failLimits: true},
+			bets:           []*domain.Bet{testBet("b1", "u1", 10, enums.Pending)},
+			wantStored:     []string{},
+			wantAlerts:     []string{},
+			wantLimitCalls: 0,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := newTestUsecase(t, tt.db, tt.limits, config.AMLConfig{})
+
+			rejected, err := usecase.AcceptBets(context.Background(), tt.bets)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UsecaseMayBets.AcceptBets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			rejectedIDs := []string{}
+			for _, bet := range rejected {
+				rejectedIDs = append(rejectedIDs, bet.BetID)
+			}
+
+			if !tt.wantErr && fmt.Sprint(rejectedIDs) != fmt.Sprint(tt.wantRejected) {
+				t.Errorf("UsecaseMayBets.AcceptBets() rejected = %v, want %v", rejectedIDs, tt.wantRejected)
+			}
+
+			if got := betIDs(tt.db.stored); fmt.Sprint(got) != fmt.Sprint(tt.wantStored) {
+				t.Errorf("UsecaseMayBets.AcceptBets() stored %v, want %v", got, tt.wantStored)
+			}
+
+			if got := alertEvents(tt.db.alerts); fmt.Sprint(got) != fmt.Sprint(tt.wantAlerts) {
+				t.Errorf("UsecaseMayBets.AcceptBets() raised %v, want %v", got, tt.wantAlerts)
+			}
+
+			if tt.db.limitCalls != tt.wantLimitCalls {
+				t.Errorf("UsecaseMayBets.AcceptBets() counted limit totals %d times, want %d", tt.db.limitCalls, tt.wantLimitCalls)
+			}
+		})
+	}
+}
+
+func TestUsecaseMayBets_SettleBet(t *testing.T) {
+	dayLossCap := config.LimitsConfig{Day: config.PeriodLimitsConfig{LossCap: 50}, ExclusionMode: enums.Reject}
+
+	// u1 lost 45 USD today before the settlement
+	lost := []domain.LimitTotals{{UserID: "u1", Period: enums.Day, Loss: domain.MoneyFromFloat(45), Bets: 1}}
+	pending := []domain.Bet{*testBet("b1", "u1", 10, enums.Pending)}
+
+	tests := []struct {
+		name        string
+		limits      config.LimitsConfig
+		lossLimit   float64
+		db          *fakeDatabase
+		settlements []domain.Settlement
+		wantAlerts  []string
+		wantErr     bool
+	}{
+		{
+			name:        "success: loss taking the user over a cap raises a breach alert",
+			limits:      dayLossCap,
+			db:          &fakeDatabase{bets: pending, totals: lost},
+			settlements: []domain.Settlement{{BetID: "b1", Outcome: enums.Lose, SettledBy: "trader"}},
+			wantAlerts:  []string{"user.limit_breached:u1"},
+		},
+		{
+			name:      "success: loss crossing the loss limit",
+			lossLimit: 50,
+			db: &fakeDatabase{
+				bets:   pending,
+				losses: []domain.User{{ID: "u1", TotalLosses: domain.MoneyFromFloat(55)}},
+			},
+			settlements: []domain.Settlement{{BetID: "b1", Outcome: enums.Lose, SettledBy: "trader"}},
+			wantAlerts:  []string{"user.loss_limit_crossed:u1"},
+		},
+		{
+			name:   "success: breach alerted on once a day",
+			limits: dayLossCap,
+			db:     &fakeDatabase{bets: pending, totals: lost},
+			settlements: []domain.Settlement{
+				{BetID: "b1", Outcome: enums.Lose, SettledBy: "trader"},
+				{BetID: "b1", Outcome: enums.Lose, SettledBy: "trader"},
+			},
+			wantAlerts: []string{"user.limit_breached:u1"},
+		},
+		{
+			name:        "success: win raises no alert",
+			limits:      dayLossCap,
+			db:          &fakeDatabase{bets: pending, totals: lost},
+			settlements: []domain.Settlement{{BetID: "b1", Outcome: enums.Win, SettledBy: "trader"}},
+			wantAlerts:  []string{},
+		},
+		{
+			name:        "fail: settlement that does not say who settled the bet",
+			limits:      dayLossCap,
+			db:          &fakeDatabase{bets: pending, totals: lost},
+			settlements: []domain.Settlement{{BetID: "b1", Outcome: enums.Lose}},
+			wantAlerts:  []string{},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := newTestUsecase(t, tt.db, tt.limits, config.AMLConfig{})
+			usecase.WebhookConfig.LossLimit = tt.lossLimit
+
+			for _, settlement := range tt.settlements {
+				if _, err := usecase.SettleBet(context.Background(), settlement); (err != nil) != tt.wantErr {
+					t.Fatalf("UsecaseMayBets.SettleBet() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			}
+
+			if got := alertEvents(tt.db.alerts); fmt.Sprint(got) != fmt.Sprint(tt.wantAlerts) {
+				t.Errorf("UsecaseMayBets.SettleBet() raised %v, want %v", got, tt.wantAlerts)
+			}
+		})
+	}
+}
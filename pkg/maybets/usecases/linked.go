@@ -0,0 +1,79 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+)
+
+// GetLinkedAccounts groups the users who bet the same selections at the same odds at the same moments over many events,
+// as ScanLinkedAccounts does. A filter without a start only reads the bets placed over the linked_accounts lookback
+// before its end, or before now.
+func (u *UsecaseMayBets) GetLinkedAccounts(
+	ctx context.Context, filter domain.BetFilter, rules domain.LinkRules,
+) (*domain.LinkedAccountsReport, error) {
+	ctx, span := tracer.Start(ctx, "GetLinkedAccounts")
+	defer span.End()
+
+	if filter.From == nil {
+		end := time.Now()
+		if filter.To != nil {
+			end = *filter.To
+		}
+
+		from := end.Add(-u.LinkedConfig.Lookback)
+		filter.From = &from
+	}
+
+	return u.ScanLinkedAccounts(ctx, filter, rules)
+}
+
+// ScanLinkedAccounts groups the users who bet the same selections at the same odds at the same moments over many events,
+// reading every bet placed over the period of the filter. The rules that are not given are taken from linked_accounts.
+// A filter naming a user only reports the groups of that user, though their bets are still compared with everyone's.
+func (u *UsecaseMayBets) ScanLinkedAccounts(
+	ctx context.Context, filter domain.BetFilter, rules domain.LinkRules,
+) (*domain.LinkedAccountsReport, error) {
+	ctx, span := tracer.Start(ctx, "ScanLinkedAccounts")
+	defer span.End()
+
+	rules.Window = cmp.Or(rules.Window, u.LinkedConfig.Window)
+	rules.MinEvents = cmp.Or(rules.MinEvents, u.LinkedConfig.MinEvents)
+
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+
+	detector := domain.NewLinkDetector(rules)
+
+	userID := filter.UserID
+	filter.UserID = ""
+
+	err := u.Infrastructure.Database.StreamBets(ctx, filter, func(bet *domain.Bet) error {
+		detector.Add(bet)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.LinkedAccountsReport{
+		From:      filter.From,
+		To:        filter.To,
+		Window:    rules.Window.String(),
+		MinEvents: rules.MinEvents,
+		Groups:    []domain.LinkedGroup{},
+	}
+
+	for _, group := range detector.Groups() {
+		if userID == "" || slices.Contains(group.Users, userID) {
+			report.Groups = append(report.Groups, group)
+		}
+	}
+
+	return report, nil
+}
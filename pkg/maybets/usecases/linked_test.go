@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/config"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+)
+
+func TestUsecaseMayBets_GetLinkedAccounts(t *testing.T) {
+	lookback := 7 * 24 * time.Hour
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		filter   domain.BetFilter
+		scan     bool
+		wantFrom func(now time.Time) *time.Time
+	}{
+		{
+			name:     "success: bets of the lookback before now read without a start",
+			filter:   domain.BetFilter{},
+			wantFrom: func(now time.Time) *time.Time { from := now.Add(-lookback); return &from },
+		},
+		{
+			name:     "success: bets of the lookback before the end read without a start",
+			filter:   domain.BetFilter{To: &to},
+			wantFrom: func(time.Time) *time.Time { from := to.Add(-lookback); return &from },
+		},
+		{
+			name:     "success: start of the filter kept",
+			filter:   domain.BetFilter{From: &from},
+			wantFrom: func(time.Time) *time.Time { return &from },
+		},
+		{
+			name:     "success: every bet read by the offline scan",
+			filter:   domain.BetFilter{},
+			scan:     true,
+			wantFrom: func(time.Time) *time.Time { return nil },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &fakeDatabase{}
+
+			usecase := newTestUsecase(t, db, config.LimitsConfig{}, config.AMLConfig{})
+			usecase.LinkedConfig = config.LinkedAccountsConfig{Window: 10 * time.Second, MinEvents: 3, Lookback: lookback}
+
+			get := usecase.GetLinkedAccounts
+			if tt.scan {
+				get = usecase.ScanLinkedAccounts
+			}
+
+			now := time.Now()
+
+			if _, err := get(context.Background(), tt.filter, domain.LinkRules{}); err != nil {
+				t.Fatalf("UsecaseMayBets.GetLinkedAccounts() error = %v", err)
+			}
+
+			if len(db.filters) != 1 {
+				t.Fatalf("UsecaseMayBets.GetLinkedAccounts() read the bets %d times, want once", len(db.filters))
+			}
+
+			got, want := db.filters[0].From, tt.wantFrom(now)
+			if (got == nil) != (want == nil) || (got != nil && got.Sub(*want).Abs() > time.Second) {
+				t.Errorf("UsecaseMayBets.GetLinkedAccounts() read the bets from %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/pubsub"
+)
+
+// afterStore follows up on a batch of bets once it is committed: the bets are published to the live feed and their alerts raised
+func (u *UsecaseMayBets) afterStore(ctx context.Context, bets []*domain.Bet) {
+	for _, bet := range bets {
+		u.Infrastructure.Live.Publish(domain.LiveEvent{Topic: enums.BetsTopic, UserID: bet.UserID, Data: bet})
+	}
+
+	u.raiseBetAlerts(ctx, bets)
+}
+
+// SubscribeLive subscribes to the live feed events matching the filter.
+// The subscription must be closed once the client goes away.
+func (u *UsecaseMayBets) SubscribeLive(filter domain.LiveFilter) *pubsub.Subscription {
+	return u.Infrastructure.Live.Subscribe(filter)
+}
+
+// RunLeaderboard publishes the top users to the live feed whenever they change until ctx is canceled.
+// The leaderboard is only checked while the live feed has clients.
+func (u *UsecaseMayBets) RunLeaderboard(ctx context.Context) {
+	ticker := time.NewTicker(u.LiveConfig.LeaderboardInterval)
+	defer ticker.Stop()
+
+	var published []domain.User
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if u.Infrastructure.Live.Subscribers() == 0 {
+				continue
+			}
+
+			users, err := u.GetTopFiveUsers(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to check the leaderboard", "error", err)
+				continue
+			}
+
+			if slices.Equal(users, published) {
+				continue
+			}
+
+			published = users
+
+			u.Infrastructure.Live.Publish(domain.LiveEvent{Topic: enums.LeaderboardTopic, Data: users})
+		}
+	}
+}
+
+// RunExposure publishes the exposure of the pending bets to the live feed whenever it changes until ctx is canceled.
+// The exposure is only worked out while the live feed has clients.
+func (u *UsecaseMayBets) RunExposure(ctx context.Context) {
+	ticker := time.NewTicker(u.LiveConfig.ExposureInterval)
+	defer ticker.Stop()
+
+	var published *domain.Exposure
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if u.Infrastructure.Live.Subscribers() == 0 {
+				continue
+			}
+
+			exposure, err := u.GetExposure(ctx, "")
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to check the exposure", "error", err)
+				continue
+			}
+
+			if published != nil && reflect.DeepEqual(exposure, *published) {
+				continue
+			}
+
+			published = &exposure
+
+			u.Infrastructure.Live.Publish(domain.LiveEvent{Topic: enums.ExposureTopic, Data: exposure})
+		}
+	}
+}
@@ -2,9 +2,13 @@ package usecases
 
 import (
 	"context"
-	"log"
-	"sync"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
 
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/codec"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
 	"go.opentelemetry.io/otel"
 )
@@ -13,7 +17,7 @@ var tracer = otel.Tracer("github.com/KathurimaKimathi/maybets/pkg/maybets/usecas
 
 // GetUserTotalBets fetches the total number of bets placed by a user.
 func (u *UsecaseMayBets) GetUserTotalBets(ctx context.Context, userID string) (*domain.User, error) {
-	_, span := tracer.Start(ctx, "GetUserTotalBets")
+	ctx, span := tracer.Start(ctx, "GetUserTotalBets")
 	defer span.End()
 
 	totalBets, err := u.Infrastructure.Database.GetTotalBets(ctx, userID)
@@ -29,7 +33,7 @@ func (u *UsecaseMayBets) GetUserTotalBets(ctx context.Context, userID string) (*
 
 // GetUserTotalWinnings calculates the total winnings of a user.
 func (u *UsecaseMayBets) GetUserTotalWinnings(ctx context.Context, userID string) (*domain.User, error) {
-	_, span := tracer.Start(ctx, "GetUserTotalWinnings")
+	ctx, span := tracer.Start(ctx, "GetUserTotalWinnings")
 	defer span.End()
 
 	totalWinnings, err := u.Infrastructure.Database.GetTotalWinnings(ctx, userID)
@@ -40,12 +44,13 @@ func (u *UsecaseMayBets) GetUserTotalWinnings(ctx context.Context, userID string
 	return &domain.User{
 		ID:            userID,
 		TotalWinnings: totalWinnings,
+		Currency:      u.Exchange.Reporting,
 	}, nil
 }
 
 // GetTopFiveUsers fetches the top 5 users with the highest betting volume.
 func (u *UsecaseMayBets) GetTopFiveUsers(ctx context.Context) ([]domain.User, error) {
-	_, span := tracer.Start(ctx, "GetTopFiveUsers")
+	ctx, span := tracer.Start(ctx, "GetTopFiveUsers")
 	defer span.End()
 
 	users, err := u.Infrastructure.Database.GetTopUsers(ctx, 5)
@@ -58,7 +63,7 @@ func (u *UsecaseMayBets) GetTopFiveUsers(ctx context.Context) ([]domain.User, er
 
 // GetAllAnomalousUsers fetches users with significantly higher betting activity than the average.
 func (u *UsecaseMayBets) GetAllAnomalousUsers(ctx context.Context) ([]domain.User, error) {
-	_, span := tracer.Start(ctx, "GetAllAnomalousUsers")
+	ctx, span := tracer.Start(ctx, "GetAllAnomalousUsers")
 	defer span.End()
 
 	users, err := u.Infrastructure.Database.GetAnomalousUsers(ctx)
@@ -69,25 +74,311 @@ func (u *UsecaseMayBets) GetAllAnomalousUsers(ctx context.Context) ([]domain.Use
 	return users, nil
 }
 
-// ProcessBets processes bets concurrently and in batches of 1000
-func (u *UsecaseMayBets) ProcessBets(ctx context.Context, bets []*domain.Bet) error {
-	var wg sync.WaitGroup
+// StoreBets stores a batch of bets received over the API or a streaming transport. The bets of self-excluded users are
+// flagged or turned down depending on limits.exclusion_mode.
+// Failures are reported so that the bets can be sent again. Delivery is at least once, so the bets that are already
+// stored are skipped rather than failing the batch.
+func (u *UsecaseMayBets) StoreBets(ctx context.Context, bets []*domain.Bet) error {
+	ctx, span := tracer.Start(ctx, "StoreBets")
+	defer span.End()
 
-	batchSize := 1000
+	bets, _, violations, err := u.screenExclusions(ctx, bets)
+	if err != nil {
+		return err
+	}
+
+	if err := u.storeBets(ctx, bets); err != nil {
+		return err
+	}
+
+	return u.recordExclusionViolations(ctx, violations)
+}
+
+// AcceptBets screens the bets sent over HTTP once, then stores those it accepts like StoreBets and returns the others.
+// The bets of self-excluded users are turned down first when limits.exclusion_mode is reject, then with limits.reject set
+// those that would take their owner over a responsible gambling cap, counting the bets accepted before them.
+// In flag mode self-exclusions are screened last, so that violations are only recorded for the bets that are stored.
+// The rejected bets are never nil.
+func (u *UsecaseMayBets) AcceptBets(ctx context.Context, bets []*domain.Bet) ([]domain.RejectedBet, error) {
+	ctx, span := tracer.Start(ctx, "AcceptBets")
+	defer span.End()
+
+	rejectExcluded := u.LimitsConfig.ExclusionMode == enums.Reject
+	rejected := []domain.RejectedBet{}
+
+	var (
+		violations []domain.ExclusionViolation
+		err        error
+	)
+
+	if rejectExcluded {
+		if bets, rejected, violations, err = u.screenExclusions(ctx, bets); err != nil {
+			return nil, err
+		}
+	}
+
+	if u.LimitsConfig.Reject && len(bets) > 0 {
+		var capped []domain.RejectedBet
+
+		if bets, capped, err = u.screenLimits(ctx, bets); err != nil {
+			return nil, err
+		}
+
+		rejected = append(rejected, capped...)
+	}
+
+	if !rejectExcluded {
+		if bets, _, violations, err = u.screenExclusions(ctx, bets); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := u.storeBets(ctx, bets); err != nil {
+		return nil, err
+	}
+
+	if err := u.recordExclusionViolations(ctx, violations); err != nil {
+		return nil, err
+	}
+
+	return rejected, nil
+}
 
-	for i := 0; i < len(bets); i += batchSize {
-		wg.Add(1)
+// storeBets stores bets that were already screened, skipping those already stored, and hands them to afterStore
+func (u *UsecaseMayBets) storeBets(ctx context.Context, bets []*domain.Bet) error {
+	if len(bets) == 0 {
+		return nil
+	}
+
+	stored, err := u.Infrastructure.Database.StoreNewBets(ctx, bets)
+	if err != nil {
+		return err
+	}
+
+	if skipped := int64(len(bets)) - stored; skipped > 0 {
+		slog.InfoContext(ctx, "skipped bets that were already stored", "skipped", skipped)
+	}
+
+	u.afterStore(ctx, bets)
 
-		go func(batch []*domain.Bet) {
-			defer wg.Done()
+	return nil
+}
+
+// IngestBets reads every bet from the reader and stores them in batches of 1000, screened for self-excluded users
+// like StoreBets. It returns the number of bets stored, which on failure covers the batches written before the error.
+func (u *UsecaseMayBets) IngestBets(ctx context.Context, reader codec.BetReader) (int64, error) {
+	ctx, span := tracer.Start(ctx, "IngestBets")
+	defer span.End()
 
+	return ingestBatches(ctx, reader, func(batch []*domain.Bet, _ codec.Position) (int, error) {
+		batch, _, violations, err := u.screenExclusions(ctx, batch)
+		if err != nil {
+			return 0, err
+		}
+
+		if len(batch) > 0 {
 			if err := u.Infrastructure.Database.StoreBetData(ctx, batch); err != nil {
-				log.Printf("Error processing batch: %v", err)
+				return 0, err
+			}
+
+			u.afterStore(ctx, batch)
+		}
+
+		return len(batch), u.recordExclusionViolations(ctx, violations)
+	})
+}
+
+// StartIngestJob returns the job used to import a file described by source.
+// With resume set, the job that got furthest importing the same file is reused so that the import continues from its checkpoint.
+// A completed job is returned unchanged and the caller should not import the file again.
+func (u *UsecaseMayBets) StartIngestJob(ctx context.Context, source domain.IngestJob, resume bool) (*domain.IngestJob, error) {
+	ctx, span := tracer.Start(ctx, "StartIngestJob")
+	defer span.End()
+
+	if resume {
+		job, err := u.Infrastructure.Database.FindIngestJob(ctx, source.Hash, source.Size)
+		if err != nil {
+			return nil, err
+		}
+
+		if job != nil {
+			if job.Status == enums.IngestCompleted {
+				return job, nil
+			}
+
+			job.Path = source.Path
+			job.Status = enums.IngestRunning
+			job.Error = ""
+
+			if err := u.Infrastructure.Database.UpdateIngestJob(ctx, job); err != nil {
+				return nil, err
 			}
-		}(bets[i:min(i+batchSize, len(bets))])
+
+			slog.InfoContext(ctx, "resuming ingest job", "job_id", job.ID, "path", job.Path, "offset", job.Offset, "records", job.Records)
+
+			return job, nil
+		}
 	}
 
-	wg.Wait()
+	job := &domain.IngestJob{
+		Path:   source.Path,
+		Size:   source.Size,
+		Hash:   source.Hash,
+		Format: source.Format,
+		Status: enums.IngestRunning,
+	}
 
-	return nil
+	if err := u.Infrastructure.Database.CreateIngestJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// RunIngestJob stores the bets read from the reader in batches of 1000, advancing the job checkpoint with every batch.
+// The bets are screened for self-excluded users like StoreBets.
+// The reader must start at the job checkpoint. The job is marked completed or failed when the import ends.
+// It returns the number of bets stored by this run.
+func (u *UsecaseMayBets) RunIngestJob(ctx context.Context, job *domain.IngestJob, reader codec.BetReader) (int64, error) {
+	ctx, span := tracer.Start(ctx, "RunIngestJob")
+	defer span.End()
+
+	stored, err := ingestBatches(ctx, reader, func(batch []*domain.Bet, position codec.Position) (int, error) {
+		batch, _, violations, err := u.screenExclusions(ctx, batch)
+		if err != nil {
+			return 0, err
+		}
+
+		checkpoint := *job
+		checkpoint.Offset = position.Offset
+		checkpoint.Records = position.Records
+
+		if err := u.Infrastructure.Database.CommitIngestBatch(ctx, &checkpoint, batch, violations); err != nil {
+			return 0, err
+		}
+
+		job.Offset = checkpoint.Offset
+		job.Records = checkpoint.Records
+
+		u.afterStore(ctx, batch)
+
+		return len(batch), nil
+	})
+
+	// the outcome is recorded even when the import was canceled
+	ctx = context.WithoutCancel(ctx)
+
+	if err != nil {
+		job.Status = enums.IngestFailed
+		job.Error = err.Error()
+
+		return stored, errors.Join(err, u.Infrastructure.Database.UpdateIngestJob(ctx, job))
+	}
+
+	completedAt := time.Now()
+
+	job.Status = enums.IngestCompleted
+	job.CompletedAt = &completedAt
+
+	return stored, u.Infrastructure.Database.UpdateIngestJob(ctx, job)
+}
+
+// GetIngestJob fetches an ingest job by its ID
+func (u *UsecaseMayBets) GetIngestJob(ctx context.Context, id string) (*domain.IngestJob, error) {
+	ctx, span := tracer.Start(ctx, "GetIngestJob")
+	defer span.End()
+
+	return u.Infrastructure.Database.GetIngestJob(ctx, id)
+}
+
+// ListIngestJobs fetches the most recent ingest jobs, newest first
+func (u *UsecaseMayBets) ListIngestJobs(ctx context.Context, limit int) ([]domain.IngestJob, error) {
+	ctx, span := tracer.Start(ctx, "ListIngestJobs")
+	defer span.End()
+
+	return u.Infrastructure.Database.ListIngestJobs(ctx, limit)
+}
+
+// ingestBatches reads every bet from the reader and hands them to store in batches of 1000,
+// together with the reader position just after the last bet of the batch.
+// It returns the number of bets stored, adding up those store reports for every batch.
+func ingestBatches(
+	ctx context.Context,
+	reader codec.BetReader,
+	store func(batch []*domain.Bet, position codec.Position) (int, error),
+) (int64, error) {
+	batchSize := 1000
+
+	var (
+		stored   int64
+		position codec.Position
+	)
+
+	batch := make([]*domain.Bet, 0, batchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		count, err := store(batch, position)
+		stored += int64(count)
+
+		if err != nil {
+			return err
+		}
+
+		batch = make([]*domain.Bet, 0, batchSize)
+
+		return nil
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return stored, err
+		}
+
+		bet, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return stored, err
+		}
+
+		batch = append(batch, bet)
+		position = reader.Position()
+
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return stored, err
+			}
+		}
+	}
+
+	return stored, flush()
+}
+
+// ExportBets streams every bet matching the filter to the writer and returns the number of bets written
+func (u *UsecaseMayBets) ExportBets(ctx context.Context, filter domain.BetFilter, writer codec.BetWriter) (int64, error) {
+	ctx, span := tracer.Start(ctx, "ExportBets")
+	defer span.End()
+
+	var written int64
+
+	err := u.Infrastructure.Database.StreamBets(ctx, filter, func(bet *domain.Bet) error {
+		if err := writer.Write(bet); err != nil {
+			return err
+		}
+
+		written++
+
+		return nil
+	})
+	if err != nil {
+		return written, err
+	}
+
+	return written, writer.Flush()
 }
@@ -0,0 +1,126 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+)
+
+// SettleBet changes the state of a placed bet, for instance when its event is decided, it is voided or cashed out.
+// Settled bets can be settled again to correct them. The settled bet is published to the live feed,
+// and its owner is alerted when the loss it adds takes them over the loss limit or a responsible gambling limit.
+func (u *UsecaseMayBets) SettleBet(ctx context.Context, settlement domain.Settlement) (*domain.Bet, error) {
+	ctx, span := tracer.Start(ctx, "SettleBet")
+	defer span.End()
+
+	if settlement.SettledBy == "" {
+		return nil, errors.New("settled_by: must identify who settled the bet")
+	}
+
+	bet, err := u.Infrastructure.Database.GetBet(ctx, settlement.BetID)
+	if err != nil {
+		return nil, err
+	}
+
+	previousLoss := bet.Loss()
+
+	if err := bet.Settle(settlement); err != nil {
+		return nil, err
+	}
+
+	if err := u.Infrastructure.Database.SettleBet(ctx, bet, settlement.SettledBy); err != nil {
+		return nil, err
+	}
+
+	u.Infrastructure.Live.Publish(domain.LiveEvent{Topic: enums.BetsTopic, UserID: bet.UserID, Data: bet})
+
+	if loss := u.reportedLoss(ctx, bet, previousLoss); loss > 0 {
+		alerts := u.lossLimitAlerts(ctx, map[string]domain.Money{bet.UserID: loss})
+		alerts = append(alerts, u.limitAlerts(ctx, []string{bet.UserID})...)
+
+		// the bet is settled already, failing the settlement would only get it settled twice
+		if _, err := u.raiseAlerts(ctx, alerts); err != nil {
+			slog.ErrorContext(ctx, "failed to raise settlement alerts", "bet_id", bet.BetID, "error", err)
+		}
+	}
+
+	return bet, nil
+}
+
+// SettleSelection records a result submitted for a selection and settles every bet on it, in a single transaction.
+// Bets and legs still pending take the result. Submitting a new result for a selection resettles the bets
+// the previous one settled, leaving the bets settled by hand alone, and is kept to be audited.
+// The settled bets are published to the live feed along with the summary of the settlement,
+// and their owners are alerted when the losses it adds take them over the loss limit or a responsible gambling limit.
+func (u *UsecaseMayBets) SettleSelection(ctx context.Context, result domain.SelectionResult) (*domain.SelectionResult, error) {
+	ctx, span := tracer.Start(ctx, "SettleSelection")
+	defer span.End()
+
+	if err := result.Validate(); err != nil {
+		return nil, err
+	}
+
+	var settled []*domain.Bet
+
+	// losses holds the loss the settlement adds for each user in the reporting currency, which resettlements can lower
+	losses := map[string]domain.Money{}
+
+	err := u.Infrastructure.Database.SettleSelection(ctx, &result, func(bet *domain.Bet) (bool, error) {
+		previousLoss := bet.Loss()
+
+		changed, err := bet.ApplyResult(result.SelectionID, result.Result, result.PreviousResult)
+		if err != nil || !changed {
+			return false, err
+		}
+
+		settled = append(settled, bet)
+		losses[bet.UserID] += u.reportedLoss(ctx, bet, previousLoss)
+
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "settled selection",
+		"selection_id", result.SelectionID, "result", result.Result, "previous_result", result.PreviousResult,
+		"submitted_by", result.SubmittedBy, "bets", result.BetsSettled, "stake", result.Stake.String(), "payout", result.Payout.String(), "currency", result.Currency)
+
+	for _, bet := range settled {
+		u.Infrastructure.Live.Publish(domain.LiveEvent{Topic: enums.BetsTopic, UserID: bet.UserID, Data: bet})
+	}
+
+	u.Infrastructure.Live.Publish(domain.LiveEvent{Topic: enums.SettlementsTopic, Data: result})
+
+	for userID, loss := range losses {
+		if loss <= 0 {
+			delete(losses, userID)
+		}
+	}
+
+	userIDs := make([]string, 0, len(losses))
+	for userID := range losses {
+		userIDs = append(userIDs, userID)
+	}
+
+	alerts := u.lossLimitAlerts(ctx, losses)
+	alerts = append(alerts, u.limitAlerts(ctx, userIDs)...)
+
+	// the bets are settled already, failing the submission would only get them settled twice
+	if _, err := u.raiseAlerts(ctx, alerts); err != nil {
+		slog.ErrorContext(ctx, "failed to raise settlement alerts", "selection_id", result.SelectionID, "error", err)
+	}
+
+	return &result, nil
+}
+
+// ListSelectionResults fetches every result submitted for a selection, newest first
+func (u *UsecaseMayBets) ListSelectionResults(ctx context.Context, selectionID string) ([]domain.SelectionResult, error) {
+	ctx, span := tracer.Start(ctx, "ListSelectionResults")
+	defer span.End()
+
+	return u.Infrastructure.Database.ListSelectionResults(ctx, selectionID)
+}
@@ -1,19 +1,45 @@
 package usecases
 
 import (
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/config"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure"
 )
 
 // UsecaseMayBets represents an assemble of all use cases into a single object that can be instantiated anywhere
 type UsecaseMayBets struct {
 	Infrastructure infrastructure.Infrastructure
+	// WebhookConfig holds the alert thresholds and how webhooks are delivered
+	WebhookConfig config.WebhookConfig
+	// LiveConfig holds the live feed settings
+	LiveConfig config.LiveConfig
+	// LimitsConfig holds the responsible gambling limits every user is held to
+	LimitsConfig config.LimitsConfig
+	// AMLConfig holds the anti-money laundering rules bets are screened against
+	AMLConfig config.AMLConfig
+	// LinkedConfig holds the rules users are linked by when they bet alike
+	LinkedConfig config.LinkedAccountsConfig
+	// Exchange converts bet amounts into the reporting currency the alert thresholds are set in and exposure is reported in
+	Exchange *domain.Exchange
 }
 
 // NewUsecaseMayBetsImpl returns a new Maybets interactor
 func NewUsecaseMayBetsImpl(
 	infra infrastructure.Infrastructure,
+	webhookConfig config.WebhookConfig,
+	liveConfig config.LiveConfig,
+	limitsConfig config.LimitsConfig,
+	amlConfig config.AMLConfig,
+	linkedConfig config.LinkedAccountsConfig,
+	exchange *domain.Exchange,
 ) (*UsecaseMayBets, error) {
 	return &UsecaseMayBets{
 		Infrastructure: infra,
+		WebhookConfig:  webhookConfig,
+		LiveConfig:     liveConfig,
+		LimitsConfig:   limitsConfig,
+		AMLConfig:      amlConfig,
+		LinkedConfig:   linkedConfig,
+		Exchange:       exchange,
 	}, nil
 }
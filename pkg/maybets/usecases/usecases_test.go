@@ -0,0 +1,235 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/config"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/enums"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure"
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/infrastructure/pubsub"
+)
+
+// errTestDatabase is returned by fakeDatabase for the calls set to fail
+var errTestDatabase = errors.New("database is locked")
+
+// fakeDatabase keeps what the usecases read and write in memory. The methods it does not override panic when called.
+type fakeDatabase struct {
+	infrastructure.Database
+
+	bets       []domain.Bet
+	limits     []domain.Limits
+	totals     []domain.LimitTotals
+	exclusions []domain.Exclusion
+	losses     []domain.User
+
+	// failExclusions and failLimits fail listing the self-exclusions and the limits, failStore storing the bets
+	failExclusions bool
+	failLimits     bool
+	failStore      bool
+
+	stored     []*domain.Bet
+	settled    []*domain.Bet
+	violations []domain.ExclusionViolation
+	alerts     []domain.Alert
+	filters    []domain.BetFilter
+	limitCalls int
+	// exclusionCalls counts the self-exclusion lookups
+	exclusionCalls int
+}
+
+func (f *fakeDatabase) StoreNewBets(_ context.Context, bets []*domain.Bet) (int64, error) {
+	if f.failStore {
+		return 0, errTestDatabase
+	}
+
+	f.stored = append(f.stored, bets...)
+
+	return int64(len(bets)), nil
+}
+
+func (f *fakeDatabase) GetBet(_ context.Context, betID string) (*domain.Bet, error) {
+	for _, bet := range f.bets {
+		if bet.BetID == betID {
+			return &bet, nil
+		}
+	}
+
+	return nil, errors.New("bet not found")
+}
+
+func (f *fakeDatabase) SettleBet(_ context.Context, bet *domain.Bet, _ string) error {
+	f.settled = append(f.settled, bet)
+
+	return nil
+}
+
+// StreamBets streams the bets placed from the start of the filter, which is recorded
+func (f *fakeDatabase) StreamBets(_ context.Context, filter domain.BetFilter, fn func(bet *domain.Bet) error) error {
+	f.filters = append(f.filters, filter)
+
+	for _, bet := range f.bets {
+		if filter.From != nil && bet.Timestamp.Before(*filter.From) {
+			continue
+		}
+
+		if err := fn(&bet); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeDatabase) GetUserLosses(_ context.Context, _ []string) ([]domain.User, error) {
+	return f.losses, nil
+}
+
+func (f *fakeDatabase) ListUserLimits(_ context.Context, userIDs []string) ([]domain.Limits, error) {
+	if f.failLimits {
+		return nil, errTestDatabase
+	}
+
+	var limits []domain.Limits
+
+	for _, limit := range f.limits {
+		if slices.Contains(userIDs, limit.UserID) {
+			limits = append(limits, limit)
+		}
+	}
+
+	return limits, nil
+}
+
+// GetLimitTotals returns the totals of the users along with the bets stored or settled so far, counted in every period
+func (f *fakeDatabase) GetLimitTotals(_ context.Context, userIDs []string, _ time.Time) ([]domain.LimitTotals, error) {
+	f.limitCalls++
+
+	var totals []domain.LimitTotals
+
+	for _, total := range f.totals {
+		if slices.Contains(userIDs, total.UserID) {
+			totals = append(totals, total)
+		}
+	}
+
+	for _, bet := range slices.Concat(f.stored, f.settled) {
+		for _, total := range []domain.LimitTotals{
+			{UserID: bet.UserID, Period: enums.Day, Loss: bet.Loss(), Stake: bet.Amount, Bets: 1},
+			{UserID: bet.UserID, Period: enums.Week, Loss: bet.Loss(), Stake: bet.Amount, Bets: 1},
+			{UserID: bet.UserID, Period: enums.Month, Loss: bet.Loss(), Stake: bet.Amount, Bets: 1},
+		} {
+			if slices.Contains(userIDs, bet.UserID) {
+				totals = append(totals, total)
+			}
+		}
+	}
+
+	return totals, nil
+}
+
+func (f *fakeDatabase) GetLimitBets(_ context.Context, _ []string, _ time.Time, _ *time.Time, _ int) ([]domain.Bet, error) {
+	return nil, nil
+}
+
+func (f *fakeDatabase) ListSelfExclusions(_ context.Context, userIDs []string) ([]domain.Exclusion, error) {
+	f.exclusionCalls++
+
+	if f.failExclusions {
+		return nil, errTestDatabase
+	}
+
+	var exclusions []domain.Exclusion
+
+	for _, exclusion := range f.exclusions {
+		if slices.Contains(userIDs, exclusion.UserID) {
+			exclusions = append(exclusions, exclusion)
+		}
+	}
+
+	return exclusions, nil
+}
+
+// RecordExclusionViolations records the violations not recorded before for the same bet and exclusion,
+// like the exclusion_violations table
+func (f *fakeDatabase) RecordExclusionViolations(_ context.Context, violations []domain.ExclusionViolation) error {
+	for _, violation := range violations {
+		recorded := slices.ContainsFunc(f.violations, func(v domain.ExclusionViolation) bool {
+			return v.BetID == violation.BetID && v.ExclusionID == violation.ExclusionID
+		})
+		if !recorded {
+			f.violations = append(f.violations, violation)
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeDatabase) ListWebhookSubscriptions(_ context.Context) ([]domain.WebhookSubscription, error) {
+	return nil, nil
+}
+
+// RecordAlert records the alerts whose key was not recorded before, like the alerts table
+func (f *fakeDatabase) RecordAlert(_ context.Context, alert *domain.Alert, _ []domain.WebhookDelivery) (bool, error) {
+	for _, recorded := range f.alerts {
+		if recorded.Key() == alert.Key() {
+			return false, nil
+		}
+	}
+
+	recorded := *alert
+	recorded.CreatedAt = time.Now()
+
+	f.alerts = append(f.alerts, recorded)
+
+	return true, nil
+}
+
+func (f *fakeDatabase) ListUserAlertsSince(
+	_ context.Context, event enums.WebhookEvent, userIDs []string, since time.Time,
+) ([]domain.Alert, error) {
+	var alerts []domain.Alert
+
+	for _, alert := range f.alerts {
+		if alert.Event == event && slices.Contains(userIDs, alert.UserID) && !alert.CreatedAt.Before(since) {
+			alerts = append(alerts, alert)
+		}
+	}
+
+	return alerts, nil
+}
+
+// newTestUsecase returns the usecases on top of the database, reporting in USD
+func newTestUsecase(t *testing.T, db *fakeDatabase, limits config.LimitsConfig, aml config.AMLConfig) *UsecaseMayBets {
+	t.Helper()
+
+	exchange, err := domain.NewExchange("USD", "USD", nil)
+	if err != nil {
+		t.Fatalf("NewExchange() error = %v", err)
+	}
+
+	infra := infrastructure.NewInfrastructureInteractor(nil, db, nil, pubsub.NewBroker(1))
+
+	usecase, err := NewUsecaseMayBetsImpl(
+		*infra, config.WebhookConfig{}, config.LiveConfig{}, limits, aml, config.LinkedAccountsConfig{}, exchange,
+	)
+	if err != nil {
+		t.Fatalf("NewUsecaseMayBetsImpl() error = %v", err)
+	}
+
+	return usecase
+}
+
+// alertEvents returns the event and user of every alert, in the order they were raised
+func alertEvents(alerts []domain.Alert) []string {
+	events := make([]string, 0, len(alerts))
+	for _, alert := range alerts {
+		events = append(events, alert.Event.String()+":"+alert.UserID)
+	}
+
+	return events
+}
@@ -0,0 +1,214 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/domain"
+)
+
+// webhookBatchSize is the maximum number of deliveries attempted in one pass
+const webhookBatchSize = 100
+
+// CreateWebhookSubscription validates and stores a new webhook subscription.
+// A secret is generated when none is given. This is the only time the secret is returned.
+func (u *UsecaseMayBets) CreateWebhookSubscription(ctx context.Context, subscription domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	ctx, span := tracer.Start(ctx, "CreateWebhookSubscription")
+	defer span.End()
+
+	target, err := url.Parse(subscription.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, fmt.Errorf("invalid url %q: must be an absolute http or https URL", subscription.URL)
+	}
+
+	if len(subscription.Events) == 0 {
+		return nil, fmt.Errorf("events: must not be empty")
+	}
+
+	for _, event := range subscription.Events {
+		if !event.IsValid() {
+			return nil, fmt.Errorf("invalid event %q", event)
+		}
+	}
+
+	slices.Sort(subscription.Events)
+	subscription.Events = slices.Compact(subscription.Events)
+
+	if subscription.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+		}
+
+		subscription.Secret = hex.EncodeToString(secret)
+	}
+
+	if err := u.Infrastructure.Database.CreateWebhookSubscription(ctx, &subscription); err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+// GetWebhookSubscription fetches a webhook subscription by its ID, without its secret
+func (u *UsecaseMayBets) GetWebhookSubscription(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	ctx, span := tracer.Start(ctx, "GetWebhookSubscription")
+	defer span.End()
+
+	subscription, err := u.Infrastructure.Database.GetWebhookSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.Secret = ""
+
+	return subscription, nil
+}
+
+// ListWebhookSubscriptions fetches every webhook subscription, without their secrets
+func (u *UsecaseMayBets) ListWebhookSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	ctx, span := tracer.Start(ctx, "ListWebhookSubscriptions")
+	defer span.End()
+
+	subscriptions, err := u.Infrastructure.Database.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range subscriptions {
+		subscriptions[i].Secret = ""
+	}
+
+	return subscriptions, nil
+}
+
+// DeleteWebhookSubscription deletes a webhook subscription along with its pending deliveries
+func (u *UsecaseMayBets) DeleteWebhookSubscription(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "DeleteWebhookSubscription")
+	defer span.End()
+
+	return u.Infrastructure.Database.DeleteWebhookSubscription(ctx, id)
+}
+
+// ListWebhookDeadLetters fetches the most recent deliveries that exhausted their attempts, newest first
+func (u *UsecaseMayBets) ListWebhookDeadLetters(ctx context.Context, limit int) ([]domain.WebhookDeadLetter, error) {
+	ctx, span := tracer.Start(ctx, "ListWebhookDeadLetters")
+	defer span.End()
+
+	return u.Infrastructure.Database.ListWebhookDeadLetters(ctx, limit)
+}
+
+// DispatchWebhooks attempts the deliveries that are due concurrently.
+// A successful delivery is deleted, a failed one is retried with exponential backoff until it is dead lettered.
+// It returns the number of successful deliveries.
+func (u *UsecaseMayBets) DispatchWebhooks(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "DispatchWebhooks")
+	defer span.End()
+
+	deliveries, err := u.Infrastructure.Database.ListDueWebhookDeliveries(ctx, time.Now(), webhookBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		delivered int
+	)
+
+	for _, delivery := range deliveries {
+		wg.Add(1)
+
+		go func(delivery domain.WebhookDelivery) {
+			defer wg.Done()
+
+			ok, err := u.deliver(ctx, delivery)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to record webhook delivery", "delivery_id", delivery.ID, "error", err)
+			}
+
+			if ok {
+				mu.Lock()
+				delivered++
+				mu.Unlock()
+			}
+		}(delivery)
+	}
+
+	wg.Wait()
+
+	return delivered, nil
+}
+
+// deliver attempts a single delivery and records the outcome.
+// It reports whether the subscriber accepted the delivery.
+func (u *UsecaseMayBets) deliver(ctx context.Context, delivery domain.WebhookDelivery) (bool, error) {
+	status, sendErr := u.Infrastructure.Webhooks.Send(ctx, delivery)
+
+	// the outcome is recorded even when dispatching is canceled mid attempt
+	ctx = context.WithoutCancel(ctx)
+
+	if sendErr == nil {
+		return true, u.Infrastructure.Database.DeleteWebhookDelivery(ctx, delivery.ID)
+	}
+
+	delivery.Attempts++
+	delivery.LastStatus = status
+	delivery.LastError = sendErr.Error()
+
+	if delivery.Attempts >= u.WebhookConfig.MaxAttempts {
+		slog.WarnContext(ctx, "dead lettering webhook delivery",
+			"delivery_id", delivery.ID, "subscription_id", delivery.SubscriptionID, "attempts", delivery.Attempts, "error", sendErr)
+
+		return false, u.Infrastructure.Database.DeadLetterWebhookDelivery(ctx, &delivery)
+	}
+
+	delivery.NextAttemptAt = time.Now().Add(u.webhookBackoff(delivery.Attempts))
+
+	slog.InfoContext(ctx, "webhook delivery failed",
+		"delivery_id", delivery.ID, "attempts", delivery.Attempts, "next_attempt_at", delivery.NextAttemptAt, "error", sendErr)
+
+	return false, u.Infrastructure.Database.UpdateWebhookDelivery(ctx, &delivery)
+}
+
+// webhookBackoff returns the wait before the next attempt of a delivery that failed attempts times
+func (u *UsecaseMayBets) webhookBackoff(attempts int) time.Duration {
+	backoff := u.WebhookConfig.InitialBackoff
+
+	for i := 1; i < attempts && backoff < u.WebhookConfig.MaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, u.WebhookConfig.MaxBackoff)
+}
+
+// RunWebhooks looks for anomalous users and dispatches the deliveries that are due on their intervals until ctx is canceled
+func (u *UsecaseMayBets) RunWebhooks(ctx context.Context) {
+	anomalies := time.NewTicker(u.WebhookConfig.AnomalyInterval)
+	defer anomalies.Stop()
+
+	deliveries := time.NewTicker(u.WebhookConfig.PollInterval)
+	defer deliveries.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-anomalies.C:
+			if _, err := u.DetectAnomalies(ctx); err != nil {
+				slog.ErrorContext(ctx, "failed to detect anomalies", "error", err)
+			}
+		case <-deliveries.C:
+			if _, err := u.DispatchWebhooks(ctx); err != nil {
+				slog.ErrorContext(ctx, "failed to dispatch webhooks", "error", err)
+			}
+		}
+	}
+}
@@ -2,23 +2,38 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
 
+	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/config"
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/application/helpers"
 	"github.com/KathurimaKimathi/maybets/pkg/maybets/presentation"
 )
 
-const (
-	JaegerCodllectorEndpoint = "JAEGER_URL"
-)
-
 // StartApplication is used to start the application server
-func StartApplication(ctx context.Context) error {
-	port, err := helpers.ConvertPortToInt()
+func StartApplication(ctx context.Context) (err error) {
+	cfg, err := config.Load(os.Getenv("CONFIG_FILE"))
 	if err != nil {
 		return err
 	}
 
-	return presentation.StartServer(ctx, port)
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration:\n%w", err)
+	}
+
+	helpers.SetupLogger(cfg.Level())
+
+	otelShutdown, err := helpers.SetupOTelSDK(ctx, cfg.TracingConfig())
+	if err != nil {
+		return fmt.Errorf("failed to set up tracing: %w", err)
+	}
+
+	defer func() {
+		err = errors.Join(err, otelShutdown(ctx))
+	}()
+
+	return presentation.StartServer(ctx, cfg)
 }
 
 func main() {